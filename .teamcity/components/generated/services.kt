@@ -7,6 +7,7 @@ var services = mapOf(
         "approleassignments" to "App Role Assignments",
         "applications" to "Applications",
         "conditionalaccess" to "Conditional Access",
+        "customsecurityattributes" to "Custom Security Attributes",
         "directoryobjects" to "Directory Objects",
         "directoryroles" to "Directory Roles",
         "domains" to "Domains",
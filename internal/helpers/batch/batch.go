@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client/msgraph"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/rawrequest"
+)
+
+// MaxRequests is the maximum number of requests that Microsoft Graph accepts in a single JSON batch
+const MaxRequests = 20
+
+// maxAttempts is the number of times a throttled or unavailable request will be attempted before giving up
+const maxAttempts = 5
+
+// Request is an individual request to be sent as part of a JSON batch. The Url must be relative to the API version,
+// e.g. `/groups/00000000-0000-0000-0000-000000000000`.
+type Request struct {
+	Id      string            `json:"id"`
+	Method  string            `json:"method"`
+	Url     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    interface{}       `json:"body,omitempty"`
+}
+
+// Response is the response to an individual Request, correlated by its Id
+type Response struct {
+	Id      string            `json:"id"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// Success returns true when the response has a 2xx status code
+func (r Response) Success() bool {
+	return r.Status >= 200 && r.Status < 300
+}
+
+// Error returns the error returned by the API for this response, or nil when the response was successful
+func (r Response) Error() error {
+	if r.Success() {
+		return nil
+	}
+
+	var body struct {
+		Error *odata.Error `json:"error"`
+	}
+	if len(r.Body) > 0 {
+		if err := json.Unmarshal(r.Body, &body); err == nil && body.Error != nil {
+			return fmt.Errorf("unexpected status %d: %s", r.Status, body.Error.String())
+		}
+	}
+
+	return fmt.Errorf("unexpected status %d", r.Status)
+}
+
+// Unmarshal decodes the body of the response into the provided model
+func (r Response) Unmarshal(model interface{}) error {
+	if len(r.Body) == 0 {
+		return errors.New("response body was empty")
+	}
+	return json.Unmarshal(r.Body, model)
+}
+
+type batchRequest struct {
+	Requests []Request `json:"requests"`
+}
+
+type batchResponse struct {
+	Responses []Response `json:"responses"`
+}
+
+// Execute sends the provided requests to the Microsoft Graph `$batch` endpoint, splitting them into as many batches as
+// required. Throttled or temporarily unavailable requests are retried individually, honouring any `Retry-After` header.
+// Responses are returned keyed by request ID. An error is only returned when a batch could not be submitted; failures
+// of individual requests are indicated by their respective responses.
+func Execute(ctx context.Context, c *msgraph.Client, requests []Request) (map[string]Response, error) {
+	result := make(map[string]Response, len(requests))
+	seen := make(map[string]struct{}, len(requests))
+	for _, r := range requests {
+		if r.Id == "" {
+			return nil, errors.New("batch request IDs cannot be empty")
+		}
+		if _, ok := seen[r.Id]; ok {
+			return nil, fmt.Errorf("duplicate batch request ID %q", r.Id)
+		}
+		seen[r.Id] = struct{}{}
+	}
+
+	pending := requests
+	for attempt := 1; len(pending) > 0; attempt++ {
+		retries := make([]Request, 0)
+		retryAfter := 0
+
+		for _, chunk := range Chunk(pending, MaxRequests) {
+			responses, err := submit(ctx, c, chunk)
+			if err != nil {
+				return nil, err
+			}
+
+			requestsById := make(map[string]Request, len(chunk))
+			for _, r := range chunk {
+				requestsById[r.Id] = r
+			}
+
+			for _, resp := range responses {
+				if (resp.Status == http.StatusTooManyRequests || resp.Status == http.StatusServiceUnavailable) && attempt < maxAttempts {
+					if r, ok := requestsById[resp.Id]; ok {
+						retries = append(retries, r)
+						if v, err := strconv.Atoi(resp.Headers["Retry-After"]); err == nil && v > retryAfter {
+							retryAfter = v
+						}
+						continue
+					}
+				}
+				result[resp.Id] = resp
+			}
+		}
+
+		if len(retries) > 0 {
+			if retryAfter == 0 {
+				retryAfter = attempt * 5
+			}
+			log.Printf("[DEBUG] Retrying %d throttled batch request(s) after %d seconds", len(retries), retryAfter)
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Duration(retryAfter) * time.Second):
+			}
+		}
+
+		pending = retries
+	}
+
+	return result, nil
+}
+
+func submit(ctx context.Context, c *msgraph.Client, requests []Request) ([]Response, error) {
+	for i, r := range requests {
+		if r.Body != nil {
+			if requests[i].Headers == nil {
+				requests[i].Headers = make(map[string]string)
+			}
+			if _, ok := requests[i].Headers["Content-Type"]; !ok {
+				requests[i].Headers["Content-Type"] = "application/json"
+			}
+		}
+	}
+
+	var model batchResponse
+	if _, err := rawrequest.Do(ctx, c, rawrequest.Options{
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodPost,
+		Path:       "/$batch",
+	}, batchRequest{Requests: requests}, &model); err != nil {
+		return nil, fmt.Errorf("batch request: %+v", err)
+	}
+
+	return model.Responses, nil
+}
+
+// Chunk splits the provided slice into consecutive slices of at most `size` elements
+func Chunk[T any](in []T, size int) [][]T {
+	out := make([][]T, 0, (len(in)+size-1)/size)
+	for size < len(in) {
+		in, out = in[size:], append(out, in[0:size:size])
+	}
+	if len(in) > 0 {
+		out = append(out, in)
+	}
+	return out
+}
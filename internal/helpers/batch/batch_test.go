@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package batch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChunk(t *testing.T) {
+	cases := []struct {
+		Input    []int
+		Size     int
+		Expected [][]int
+	}{
+		{
+			Input:    []int{},
+			Size:     20,
+			Expected: [][]int{},
+		},
+		{
+			Input:    []int{1, 2, 3},
+			Size:     20,
+			Expected: [][]int{{1, 2, 3}},
+		},
+		{
+			Input:    []int{1, 2, 3, 4},
+			Size:     2,
+			Expected: [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			Input:    []int{1, 2, 3, 4, 5},
+			Size:     2,
+			Expected: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+	}
+
+	for _, tc := range cases {
+		actual := Chunk(tc.Input, tc.Size)
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("Expected %v for input %v with size %d, got %v", tc.Expected, tc.Input, tc.Size, actual)
+		}
+	}
+}
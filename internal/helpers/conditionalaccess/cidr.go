@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// NormalizeCIDR returns the canonical form of an IPv4 or IPv6 CIDR range, so that equivalent ranges such as
+// `2001:DB8:0::/32` and `2001:db8::/32` can be compared. Any host bits are cleared, e.g. `10.0.0.1/8` becomes `10.0.0.0/8`.
+func NormalizeCIDR(input string) (string, error) {
+	_, ipNet, err := net.ParseCIDR(strings.TrimSpace(input))
+	if err != nil {
+		return "", fmt.Errorf("parsing CIDR range %q: %v", input, err)
+	}
+
+	return ipNet.String(), nil
+}
+
+// IsIPv6CIDR returns whether the provided CIDR range is an IPv6 range
+func IsIPv6CIDR(input string) bool {
+	ip, _, err := net.ParseCIDR(strings.TrimSpace(input))
+	return err == nil && ip.To4() == nil
+}
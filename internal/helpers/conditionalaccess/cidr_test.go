@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"testing"
+)
+
+func TestNormalizeCIDR(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected string
+		Error    bool
+	}{
+		{Input: "10.0.0.0/8", Expected: "10.0.0.0/8"},
+		{Input: "10.1.2.3/8", Expected: "10.0.0.0/8"},
+		{Input: " 192.168.1.0/24 ", Expected: "192.168.1.0/24"},
+		{Input: "2001:DB8:0:0::/32", Expected: "2001:db8::/32"},
+		{Input: "2001:0db8:0000:0000:0000:0000:0000:0001/128", Expected: "2001:db8::1/128"},
+		{Input: "::ffff:10.0.0.0/104", Expected: "10.0.0.0/8"},
+		{Input: "10.0.0.0", Error: true},
+		{Input: "2001:db8::/129", Error: true},
+	}
+
+	for _, tc := range cases {
+		actual, err := NormalizeCIDR(tc.Input)
+		if tc.Error {
+			if err == nil {
+				t.Fatalf("expected an error normalizing %q, got none", tc.Input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error normalizing %q: %v", tc.Input, err)
+		}
+		if actual != tc.Expected {
+			t.Fatalf("expected %q to be normalized to %q, got %q", tc.Input, tc.Expected, actual)
+		}
+	}
+}
+
+func TestIsIPv6CIDR(t *testing.T) {
+	if IsIPv6CIDR("10.0.0.0/8") {
+		t.Fatalf("expected 10.0.0.0/8 not to be an IPv6 range")
+	}
+	if !IsIPv6CIDR("2001:db8::/32") {
+		t.Fatalf("expected 2001:db8::/32 to be an IPv6 range")
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+)
+
+// unsatisfiableControls are the built-in grant controls that a principal cannot be relied upon to satisfy when signing
+// in from an arbitrary device or client, such as a break-glass account or the principal used to run Terraform
+var unsatisfiableControls = []string{
+	string(stable.ConditionalAccessGrantControl_Block),
+	string(stable.ConditionalAccessGrantControl_CompliantDevice),
+	string(stable.ConditionalAccessGrantControl_DomainJoinedDevice),
+	string(stable.ConditionalAccessGrantControl_ApprovedApplication),
+	string(stable.ConditionalAccessGrantControl_CompliantApplication),
+}
+
+// CheckLockout determines whether an enabled policy would prevent the principal with the specified object ID from
+// signing in. A user is only checked against the users condition, and a service principal is only checked against the
+// client applications condition. Group and role memberships are not resolved, so a user is considered to be targeted by
+// any policy that includes groups or roles, unless the user is explicitly excluded. When the policy would lock out the
+// principal, a description of the offending condition is returned.
+func CheckLockout(policy stable.ConditionalAccessPolicy, principalId string, servicePrincipal bool) (bool, string) {
+	if pointer.From(policy.State) != stable.ConditionalAccessPolicyState_Enabled || policy.Conditions == nil {
+		return false, ""
+	}
+
+	controls, ok := unsatisfiableGrantControls(policy.GrantControls)
+	if !ok {
+		return false, ""
+	}
+
+	targeted := make([]string, 0)
+	if servicePrincipal {
+		if clientApplications := policy.Conditions.ClientApplications; clientApplications != nil {
+			if ok, _ := servicePrincipalIncluded(clientApplications, principalId); ok {
+				targeted = append(targeted, fmt.Sprintf("`included_service_principals` contains %q", matching(pointer.From(clientApplications.IncludeServicePrincipals), "ServicePrincipalsInMyTenant", principalId)))
+			}
+		}
+	} else if users := policy.Conditions.Users; users != nil && !containsAny(pointer.From(users.ExcludeUsers), principalId) {
+		if containsAny(pointer.From(users.IncludeUsers), "All", principalId) {
+			targeted = append(targeted, fmt.Sprintf("`included_users` contains %q", matching(pointer.From(users.IncludeUsers), "All", principalId)))
+		}
+		if len(pointer.From(users.IncludeGroups)) > 0 {
+			targeted = append(targeted, "`included_groups` is set")
+		}
+		if len(pointer.From(users.IncludeRoles)) > 0 {
+			targeted = append(targeted, "`included_roles` is set")
+		}
+	}
+
+	if len(targeted) == 0 {
+		return false, ""
+	}
+
+	return true, fmt.Sprintf("%s and the principal is not excluded, with grant controls that cannot be satisfied (%s)", strings.Join(targeted, ", "), controls)
+}
+
+// unsatisfiableGrantControls returns a description of the grant controls, and whether they cannot be satisfied. With
+// the AND operator, any unsatisfiable control is sufficient, whereas with the OR operator, every control must be
+// unsatisfiable. The `block` control can never be satisfied regardless of the operator.
+func unsatisfiableGrantControls(in *stable.ConditionalAccessGrantControls) (string, bool) {
+	if in == nil {
+		return "", false
+	}
+
+	builtInControls := make([]string, 0)
+	for _, v := range pointer.From(in.BuiltInControls) {
+		builtInControls = append(builtInControls, string(v))
+	}
+
+	if containsAny(builtInControls, string(stable.ConditionalAccessGrantControl_Block)) {
+		return fmt.Sprintf("`built_in_controls` contains %q", stable.ConditionalAccessGrantControl_Block), true
+	}
+
+	unsatisfiable := make([]string, 0)
+	for _, v := range builtInControls {
+		if containsAny(unsatisfiableControls, v) {
+			unsatisfiable = append(unsatisfiable, fmt.Sprintf("%q", v))
+		}
+	}
+	if len(unsatisfiable) == 0 {
+		return "", false
+	}
+
+	if !strings.EqualFold(in.Operator.GetOrZero(), "AND") {
+		otherControls := len(builtInControls) - len(unsatisfiable) + len(pointer.From(in.CustomAuthenticationFactors)) + len(pointer.From(in.TermsOfUse))
+		if in.AuthenticationStrength != nil {
+			otherControls++
+		}
+		if otherControls > 0 {
+			return "", false
+		}
+	}
+
+	return fmt.Sprintf("`built_in_controls` contains %s", strings.Join(unsatisfiable, ", ")), true
+}
+
+// matching returns the first item in the list that matches any of the values, ignoring case
+func matching(list []string, values ...string) string {
+	for _, item := range list {
+		if containsAny([]string{item}, values...) {
+			return item
+		}
+	}
+	return ""
+}
@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+)
+
+func testGrantControls(operator string, controls ...stable.ConditionalAccessGrantControl) *stable.ConditionalAccessGrantControls {
+	return &stable.ConditionalAccessGrantControls{
+		BuiltInControls: pointer.To(controls),
+		Operator:        nullable.Value(operator),
+	}
+}
+
+func TestCheckLockout(t *testing.T) {
+	cases := []struct {
+		TestName         string
+		Policy           func() stable.ConditionalAccessPolicy
+		ServicePrincipal bool
+		Lockout          bool
+		Reason           string
+	}{
+		{
+			TestName: "BlockAllUsers",
+			Policy: func() stable.ConditionalAccessPolicy {
+				p := testPolicy(testConditions())
+				p.GrantControls = testGrantControls("OR", stable.ConditionalAccessGrantControl_Block)
+				return p
+			},
+			Lockout: true,
+			Reason:  "`included_users` contains \"All\"",
+		},
+		{
+			TestName: "BlockAllUsersExcluded",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Users.ExcludeUsers = pointer.To([]string{testUserId})
+				p := testPolicy(c)
+				p.GrantControls = testGrantControls("OR", stable.ConditionalAccessGrantControl_Block)
+				return p
+			},
+		},
+		{
+			TestName: "BlockReportOnly",
+			Policy: func() stable.ConditionalAccessPolicy {
+				p := testPolicy(testConditions())
+				p.State = pointer.To(stable.ConditionalAccessPolicyState_EnabledForReportingButNotEnforced)
+				p.GrantControls = testGrantControls("OR", stable.ConditionalAccessGrantControl_Block)
+				return p
+			},
+		},
+		{
+			TestName: "BlockIncludedGroup",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Users = &stable.ConditionalAccessUsers{IncludeGroups: pointer.To([]string{testGroupId})}
+				p := testPolicy(c)
+				p.GrantControls = testGrantControls("OR", stable.ConditionalAccessGrantControl_Block)
+				return p
+			},
+			Lockout: true,
+			Reason:  "`included_groups` is set",
+		},
+		{
+			TestName: "BlockOtherUser",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Users = &stable.ConditionalAccessUsers{IncludeUsers: pointer.To([]string{testServicePrincipalId})}
+				p := testPolicy(c)
+				p.GrantControls = testGrantControls("OR", stable.ConditionalAccessGrantControl_Block)
+				return p
+			},
+		},
+		{
+			TestName: "BlockWorkloadIdentities",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Users = &stable.ConditionalAccessUsers{IncludeUsers: pointer.To([]string{"None"})}
+				c.ClientApplications = &stable.ConditionalAccessClientApplications{
+					IncludeServicePrincipals: pointer.To([]string{"ServicePrincipalsInMyTenant"}),
+				}
+				p := testPolicy(c)
+				p.GrantControls = testGrantControls("OR", stable.ConditionalAccessGrantControl_Block)
+				return p
+			},
+			ServicePrincipal: true,
+			Lockout:          true,
+			Reason:           "`included_service_principals` contains \"ServicePrincipalsInMyTenant\"",
+		},
+		{
+			TestName: "BlockWorkloadIdentitiesUser",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Users = &stable.ConditionalAccessUsers{IncludeUsers: pointer.To([]string{"None"})}
+				c.ClientApplications = &stable.ConditionalAccessClientApplications{
+					IncludeServicePrincipals: pointer.To([]string{"ServicePrincipalsInMyTenant"}),
+				}
+				p := testPolicy(c)
+				p.GrantControls = testGrantControls("OR", stable.ConditionalAccessGrantControl_Block)
+				return p
+			},
+		},
+		{
+			TestName: "BlockAllUsersServicePrincipal",
+			Policy: func() stable.ConditionalAccessPolicy {
+				p := testPolicy(testConditions())
+				p.GrantControls = testGrantControls("OR", stable.ConditionalAccessGrantControl_Block)
+				return p
+			},
+			ServicePrincipal: true,
+		},
+		{
+			TestName: "CompliantDeviceOrMfa",
+			Policy: func() stable.ConditionalAccessPolicy {
+				p := testPolicy(testConditions())
+				p.GrantControls = testGrantControls("OR", stable.ConditionalAccessGrantControl_CompliantDevice, stable.ConditionalAccessGrantControl_Mfa)
+				return p
+			},
+		},
+		{
+			TestName: "CompliantDeviceAndMfa",
+			Policy: func() stable.ConditionalAccessPolicy {
+				p := testPolicy(testConditions())
+				p.GrantControls = testGrantControls("AND", stable.ConditionalAccessGrantControl_CompliantDevice, stable.ConditionalAccessGrantControl_Mfa)
+				return p
+			},
+			Lockout: true,
+			Reason:  "`built_in_controls` contains \"compliantDevice\"",
+		},
+		{
+			TestName: "CompliantDeviceOrTermsOfUse",
+			Policy: func() stable.ConditionalAccessPolicy {
+				p := testPolicy(testConditions())
+				p.GrantControls = testGrantControls("OR", stable.ConditionalAccessGrantControl_CompliantDevice)
+				p.GrantControls.TermsOfUse = pointer.To([]string{"d5b1b4b4-1b1a-4c4e-8f4a-1e5d6c7b8a9f"})
+				return p
+			},
+		},
+		{
+			TestName: "SessionControlsOnly",
+			Policy: func() stable.ConditionalAccessPolicy {
+				return testPolicy(testConditions())
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			principalId := testUserId
+			if tc.ServicePrincipal {
+				principalId = testServicePrincipalId
+			}
+
+			lockout, reason := CheckLockout(tc.Policy(), principalId, tc.ServicePrincipal)
+			if lockout != tc.Lockout {
+				t.Fatalf("expected lockout to be %t, got %t (reason: %q)", tc.Lockout, lockout, reason)
+			}
+			if !strings.Contains(reason, tc.Reason) {
+				t.Fatalf("expected reason to contain %q, got %q", tc.Reason, reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,399 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+// SignIn describes a sign-in scenario which can be evaluated against conditional access policies.
+// Either UserId or ServicePrincipalId should be set, and either ApplicationId or UserAction should be set.
+type SignIn struct {
+	UserId                  string
+	GroupIds                []string
+	RoleIds                 []string
+	GuestOrExternalUserType string
+	ExternalTenantId        string
+
+	ServicePrincipalId string
+
+	ApplicationId string
+	UserAction    string
+
+	ClientAppType string
+	Platform      string
+
+	// NamedLocationIds contains the IDs of the named locations from which the sign-in originates
+	NamedLocationIds []string
+	TrustedLocation  bool
+
+	SignInRiskLevel           string
+	UserRiskLevel             string
+	ServicePrincipalRiskLevel string
+	InsiderRiskLevel          string
+
+	// DeviceAttributes contains the properties of the device used to sign in, without the `device.` prefix
+	DeviceAttributes map[string]string
+}
+
+// EvaluationResult describes whether a conditional access policy applies to a sign-in
+type EvaluationResult string
+
+const (
+	EvaluationResultApplies       EvaluationResult = "applies"
+	EvaluationResultDoesNotApply  EvaluationResult = "doesNotApply"
+	EvaluationResultIndeterminate EvaluationResult = "indeterminate"
+)
+
+// Evaluate determines whether a conditional access policy applies to a sign-in. When the policy does not apply, a reason
+// is returned describing the first condition that was not satisfied. When all other conditions are satisfied but the
+// policy cannot be evaluated locally, e.g. due to a device filter rule that cannot be parsed, the result is
+// indeterminate, with a reason describing the problem.
+func Evaluate(policy stable.ConditionalAccessPolicy, signIn SignIn) (EvaluationResult, string) {
+	if pointer.From(policy.State) == stable.ConditionalAccessPolicyState_Disabled {
+		return EvaluationResultDoesNotApply, "policy is disabled"
+	}
+
+	conditions := policy.Conditions
+	if conditions == nil {
+		return EvaluationResultDoesNotApply, "policy has no conditions"
+	}
+
+	if signIn.ServicePrincipalId != "" {
+		if ok, reason := servicePrincipalIncluded(conditions.ClientApplications, signIn.ServicePrincipalId); !ok {
+			return EvaluationResultDoesNotApply, reason
+		}
+	} else if ok, reason := userIncluded(conditions, signIn); !ok {
+		return EvaluationResultDoesNotApply, reason
+	}
+
+	if ok, reason := applicationIncluded(conditions.Applications, signIn); !ok {
+		return EvaluationResultDoesNotApply, reason
+	}
+
+	if len(conditions.ClientAppTypes) > 0 {
+		clientAppTypes := make([]string, 0)
+		for _, v := range conditions.ClientAppTypes {
+			clientAppTypes = append(clientAppTypes, string(v))
+		}
+		if !containsAny(clientAppTypes, string(stable.ConditionalAccessClientApp_All), signIn.ClientAppType) {
+			return EvaluationResultDoesNotApply, fmt.Sprintf("client app type %q is not included", signIn.ClientAppType)
+		}
+	}
+
+	if platforms := conditions.Platforms; platforms != nil && len(pointer.From(platforms.IncludePlatforms)) > 0 {
+		includePlatforms := make([]string, 0)
+		for _, v := range pointer.From(platforms.IncludePlatforms) {
+			includePlatforms = append(includePlatforms, string(v))
+		}
+		excludePlatforms := make([]string, 0)
+		for _, v := range pointer.From(platforms.ExcludePlatforms) {
+			excludePlatforms = append(excludePlatforms, string(v))
+		}
+
+		if !containsAny(includePlatforms, string(stable.ConditionalAccessDevicePlatform_All), signIn.Platform) {
+			return EvaluationResultDoesNotApply, fmt.Sprintf("platform %q is not included", signIn.Platform)
+		}
+		if containsAny(excludePlatforms, signIn.Platform) {
+			return EvaluationResultDoesNotApply, fmt.Sprintf("platform %q is excluded", signIn.Platform)
+		}
+	}
+
+	if locations := conditions.Locations; locations != nil && len(pointer.From(locations.IncludeLocations)) > 0 {
+		if !locationMatches(pointer.From(locations.IncludeLocations), signIn) {
+			return EvaluationResultDoesNotApply, "location is not included"
+		}
+		if locationMatches(pointer.From(locations.ExcludeLocations), signIn) {
+			return EvaluationResultDoesNotApply, "location is excluded"
+		}
+	}
+
+	if !riskLevelIncluded(conditions.SignInRiskLevels, signIn.SignInRiskLevel) {
+		return EvaluationResultDoesNotApply, "sign-in risk level is not included"
+	}
+	if !riskLevelIncluded(conditions.UserRiskLevels, signIn.UserRiskLevel) {
+		return EvaluationResultDoesNotApply, "user risk level is not included"
+	}
+	if !riskLevelIncluded(pointer.From(conditions.ServicePrincipalRiskLevels), signIn.ServicePrincipalRiskLevel) {
+		return EvaluationResultDoesNotApply, "service principal risk level is not included"
+	}
+
+	if insiderRiskLevels := string(pointer.From(conditions.InsiderRiskLevels)); insiderRiskLevels != "" {
+		if !containsAny(strings.Split(insiderRiskLevels, ","), signIn.InsiderRiskLevel) {
+			return EvaluationResultDoesNotApply, "insider risk level is not included"
+		}
+	}
+
+	if devices := conditions.Devices; devices != nil && devices.DeviceFilter != nil && pointer.From(devices.DeviceFilter.Rule) != "" {
+		rule, err := validation.ParseDeviceFilterRule(*devices.DeviceFilter.Rule)
+		if err != nil {
+			return EvaluationResultIndeterminate, fmt.Sprintf("device filter rule could not be parsed: %v", err)
+		}
+
+		matches, err := rule.Evaluate(validation.DynamicMembershipRuleSubject{Attributes: signIn.DeviceAttributes})
+		if err != nil {
+			return EvaluationResultIndeterminate, fmt.Sprintf("device filter rule could not be evaluated: %v", err)
+		}
+
+		if pointer.From(devices.DeviceFilter.Mode) == stable.FilterMode_Exclude {
+			if matches {
+				return EvaluationResultDoesNotApply, "device is excluded by filter"
+			}
+		} else if !matches {
+			return EvaluationResultDoesNotApply, "device is not included by filter"
+		}
+	}
+
+	return EvaluationResultApplies, ""
+}
+
+func userIncluded(conditions *stable.ConditionalAccessConditionSet, signIn SignIn) (bool, string) {
+	if clientApplications := conditions.ClientApplications; clientApplications != nil && len(pointer.From(clientApplications.IncludeServicePrincipals)) > 0 {
+		return false, "policy applies to workload identities"
+	}
+
+	users := conditions.Users
+	if users == nil {
+		return false, "policy does not apply to users"
+	}
+
+	guest := ""
+	if signIn.GuestOrExternalUserType != "" {
+		guest = "GuestsOrExternalUsers"
+	}
+
+	included := containsAny(pointer.From(users.IncludeUsers), "All", signIn.UserId, guest) ||
+		containsAny(pointer.From(users.IncludeGroups), signIn.GroupIds...) ||
+		containsAny(pointer.From(users.IncludeRoles), signIn.RoleIds...) ||
+		guestOrExternalUserMatches(users.IncludeGuestsOrExternalUsers, signIn)
+	if !included {
+		return false, "user is not included"
+	}
+
+	excluded := containsAny(pointer.From(users.ExcludeUsers), signIn.UserId, guest) ||
+		containsAny(pointer.From(users.ExcludeGroups), signIn.GroupIds...) ||
+		containsAny(pointer.From(users.ExcludeRoles), signIn.RoleIds...) ||
+		guestOrExternalUserMatches(users.ExcludeGuestsOrExternalUsers, signIn)
+	if excluded {
+		return false, "user is excluded"
+	}
+
+	return true, ""
+}
+
+func guestOrExternalUserMatches(in *stable.ConditionalAccessGuestsOrExternalUsers, signIn SignIn) bool {
+	if in == nil || signIn.GuestOrExternalUserType == "" {
+		return false
+	}
+
+	userTypes := strings.Split(string(pointer.From(in.GuestOrExternalUserTypes)), ",")
+	if !containsAny(userTypes, signIn.GuestOrExternalUserType) {
+		return false
+	}
+
+	if in.ExternalTenants != nil {
+		externalTenants := in.ExternalTenants.ConditionalAccessExternalTenants()
+		if pointer.From(externalTenants.MembershipKind) == stable.ConditionalAccessExternalTenantsMembershipKind_Enumerated {
+			return containsAny(pointer.From(externalTenants.Members), signIn.ExternalTenantId)
+		}
+	}
+
+	return true
+}
+
+func servicePrincipalIncluded(in *stable.ConditionalAccessClientApplications, servicePrincipalId string) (bool, string) {
+	if in == nil || len(pointer.From(in.IncludeServicePrincipals)) == 0 {
+		return false, "policy does not apply to workload identities"
+	}
+
+	if !containsAny(pointer.From(in.IncludeServicePrincipals), "ServicePrincipalsInMyTenant", servicePrincipalId) {
+		return false, "service principal is not included"
+	}
+	if containsAny(pointer.From(in.ExcludeServicePrincipals), servicePrincipalId) {
+		return false, "service principal is excluded"
+	}
+
+	return true, ""
+}
+
+func applicationIncluded(in stable.ConditionalAccessApplications, signIn SignIn) (bool, string) {
+	if signIn.UserAction != "" {
+		if !containsAny(pointer.From(in.IncludeUserActions), signIn.UserAction) {
+			return false, fmt.Sprintf("user action %q is not included", signIn.UserAction)
+		}
+		return true, ""
+	}
+
+	if !containsAny(pointer.From(in.IncludeApplications), "All", signIn.ApplicationId) {
+		return false, "application is not included"
+	}
+	if containsAny(pointer.From(in.ExcludeApplications), signIn.ApplicationId) {
+		return false, "application is excluded"
+	}
+
+	return true, ""
+}
+
+func locationMatches(locations []string, signIn SignIn) bool {
+	for _, location := range locations {
+		switch {
+		case strings.EqualFold(location, "All"):
+			return true
+		case strings.EqualFold(location, "AllTrusted"):
+			if signIn.TrustedLocation {
+				return true
+			}
+		default:
+			for _, id := range signIn.NamedLocationIds {
+				if strings.EqualFold(namedLocationId(location), namedLocationId(id)) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// namedLocationId returns the object ID of a named location, which may be given either as a bare object ID or as a
+// resource ID, as exported by the `azuread_named_location` resource
+func namedLocationId(in string) string {
+	if id, err := stable.ParseIdentityConditionalAccessNamedLocationID(in); err == nil {
+		return id.NamedLocationId
+	}
+	return in
+}
+
+func riskLevelIncluded(levels []stable.RiskLevel, level string) bool {
+	if len(levels) == 0 {
+		return true
+	}
+	if level == "" {
+		level = string(stable.RiskLevel_None)
+	}
+	for _, v := range levels {
+		if strings.EqualFold(string(v), level) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny returns true when any of the non-empty values is found in the list, ignoring case
+func containsAny(list []string, values ...string) bool {
+	for _, item := range list {
+		for _, v := range values {
+			if v != "" && strings.EqualFold(strings.TrimSpace(item), v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MatchNamedLocations determines which of the provided named locations match the IP address and/or
+// country of a sign-in, and whether any of the matching locations is trusted
+func MatchNamedLocations(namedLocations []stable.NamedLocation, ipAddress, countryCode string) ([]string, bool) {
+	ids := make([]string, 0)
+	trusted := false
+
+	ip := net.ParseIP(ipAddress)
+
+	for _, item := range namedLocations {
+		switch namedLocation := item.(type) {
+		case stable.IPNamedLocation:
+			if ip == nil {
+				continue
+			}
+			for _, ipRange := range namedLocation.IPRanges {
+				var cidr string
+				switch r := ipRange.(type) {
+				case stable.IPv4CIDRRange:
+					cidr = pointer.From(r.CIDRAddress)
+				case stable.IPv6CIDRRange:
+					cidr = pointer.From(r.CIDRAddress)
+				}
+				if _, network, err := net.ParseCIDR(cidr); err == nil && network.Contains(ip) {
+					ids = append(ids, pointer.From(namedLocation.Id))
+					if pointer.From(namedLocation.IsTrusted) {
+						trusted = true
+					}
+					break
+				}
+			}
+
+		case stable.CountryNamedLocation:
+			if countryCode == "" {
+				continue
+			}
+			if containsAny(namedLocation.CountriesAndRegions, countryCode) {
+				ids = append(ids, pointer.From(namedLocation.Id))
+			}
+		}
+	}
+
+	return ids, trusted
+}
+
+// CombineSessionControls combines the session controls of the policies that apply to a sign-in. Where policies conflict,
+// the most restrictive setting is used, e.g. the shortest sign-in frequency.
+func CombineSessionControls(in []*stable.ConditionalAccessSessionControls) *stable.ConditionalAccessSessionControls {
+	var result *stable.ConditionalAccessSessionControls
+	var signInFrequencyHours int64
+
+	for _, sessionControls := range in {
+		if sessionControls == nil {
+			continue
+		}
+		if result == nil {
+			result = &stable.ConditionalAccessSessionControls{}
+		}
+
+		if v := sessionControls.ApplicationEnforcedRestrictions; v != nil && v.IsEnabled.GetOrZero() {
+			result.ApplicationEnforcedRestrictions = v
+		}
+
+		if v := sessionControls.CloudAppSecurity; v != nil && result.CloudAppSecurity == nil {
+			result.CloudAppSecurity = v
+		}
+
+		if sessionControls.DisableResilienceDefaults.GetOrZero() {
+			result.DisableResilienceDefaults = sessionControls.DisableResilienceDefaults
+		}
+
+		if v := sessionControls.PersistentBrowser; v != nil {
+			if result.PersistentBrowser == nil || pointer.From(v.Mode) == stable.PersistentBrowserSessionMode_Never {
+				result.PersistentBrowser = v
+			}
+		}
+
+		if v := sessionControls.SignInFrequency; v != nil {
+			if pointer.From(v.FrequencyInterval) == stable.SignInFrequencyInterval_EveryTime {
+				result.SignInFrequency = v
+				signInFrequencyHours = 0
+				continue
+			}
+			if result.SignInFrequency != nil && pointer.From(result.SignInFrequency.FrequencyInterval) == stable.SignInFrequencyInterval_EveryTime {
+				continue
+			}
+
+			hours := v.Value.GetOrZero()
+			if pointer.From(v.Type) == stable.SigninFrequencyType_Days {
+				hours *= 24
+			}
+			if hours > 0 && (signInFrequencyHours == 0 || hours < signInFrequencyHours) {
+				result.SignInFrequency = v
+				signInFrequencyHours = hours
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,532 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+)
+
+const (
+	testUserId             = "62e19b97-8b3d-4d4a-a106-4ce66896a863"
+	testGroupId            = "3b4a6d47-48a9-4d0e-9a2f-2b35b5f3d5a1"
+	testServicePrincipalId = "b8c1f2a3-1d4e-4f5a-9b6c-7d8e9f0a1b2c"
+	testApplicationId      = "00000003-0000-0ff1-ce00-000000000000"
+	testNamedLocationId    = "f4d2a7c9-5b3e-4d6f-8a1b-2c3d4e5f6a7b"
+	testExternalTenantId   = "9d8c7b6a-5f4e-4d3c-2b1a-0f9e8d7c6b5a"
+)
+
+func testPolicy(conditions stable.ConditionalAccessConditionSet) stable.ConditionalAccessPolicy {
+	return stable.ConditionalAccessPolicy{
+		DisplayName: pointer.To("test"),
+		State:       pointer.To(stable.ConditionalAccessPolicyState_Enabled),
+		Conditions:  &conditions,
+	}
+}
+
+func testConditions() stable.ConditionalAccessConditionSet {
+	return stable.ConditionalAccessConditionSet{
+		Applications: stable.ConditionalAccessApplications{
+			IncludeApplications: pointer.To([]string{"All"}),
+		},
+		ClientAppTypes: []stable.ConditionalAccessClientApp{stable.ConditionalAccessClientApp_All},
+		Users: &stable.ConditionalAccessUsers{
+			IncludeUsers: pointer.To([]string{"All"}),
+		},
+	}
+}
+
+func testSignIn() SignIn {
+	return SignIn{
+		UserId:        testUserId,
+		GroupIds:      []string{testGroupId},
+		ApplicationId: testApplicationId,
+		ClientAppType: string(stable.ConditionalAccessClientApp_Browser),
+		Platform:      string(stable.ConditionalAccessDevicePlatform_Windows),
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	cases := []struct {
+		TestName      string
+		Policy        func() stable.ConditionalAccessPolicy
+		SignIn        func() SignIn
+		Applies       bool
+		Indeterminate bool
+	}{
+		{
+			TestName: "AllUsersAllApplications",
+			Policy:   func() stable.ConditionalAccessPolicy { return testPolicy(testConditions()) },
+			SignIn:   testSignIn,
+			Applies:  true,
+		},
+		{
+			TestName: "Disabled",
+			Policy: func() stable.ConditionalAccessPolicy {
+				p := testPolicy(testConditions())
+				p.State = pointer.To(stable.ConditionalAccessPolicyState_Disabled)
+				return p
+			},
+			SignIn:  testSignIn,
+			Applies: false,
+		},
+		{
+			TestName: "ExcludedGroup",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Users.ExcludeGroups = pointer.To([]string{testGroupId})
+				return testPolicy(c)
+			},
+			SignIn:  testSignIn,
+			Applies: false,
+		},
+		{
+			TestName: "IncludedGroup",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Users.IncludeUsers = nil
+				c.Users.IncludeGroups = pointer.To([]string{testGroupId})
+				return testPolicy(c)
+			},
+			SignIn:  testSignIn,
+			Applies: true,
+		},
+		{
+			TestName: "ExcludedApplication",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Applications.ExcludeApplications = pointer.To([]string{testApplicationId})
+				return testPolicy(c)
+			},
+			SignIn:  testSignIn,
+			Applies: false,
+		},
+		{
+			TestName: "UserActionNotIncluded",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Applications = stable.ConditionalAccessApplications{
+					IncludeUserActions: pointer.To([]string{"urn:user:registerdevice"}),
+				}
+				return testPolicy(c)
+			},
+			SignIn:  testSignIn,
+			Applies: false,
+		},
+		{
+			TestName: "UserAction",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Applications = stable.ConditionalAccessApplications{
+					IncludeUserActions: pointer.To([]string{"urn:user:registersecurityinfo"}),
+				}
+				return testPolicy(c)
+			},
+			SignIn: func() SignIn {
+				s := testSignIn()
+				s.ApplicationId = ""
+				s.UserAction = "urn:user:registersecurityinfo"
+				return s
+			},
+			Applies: true,
+		},
+		{
+			TestName: "ClientAppTypeNotIncluded",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.ClientAppTypes = []stable.ConditionalAccessClientApp{stable.ConditionalAccessClientApp_ExchangeActiveSync, stable.ConditionalAccessClientApp_Other}
+				return testPolicy(c)
+			},
+			SignIn:  testSignIn,
+			Applies: false,
+		},
+		{
+			TestName: "PlatformExcluded",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Platforms = &stable.ConditionalAccessPlatforms{
+					IncludePlatforms: pointer.To([]stable.ConditionalAccessDevicePlatform{stable.ConditionalAccessDevicePlatform_All}),
+					ExcludePlatforms: pointer.To([]stable.ConditionalAccessDevicePlatform{stable.ConditionalAccessDevicePlatform_Windows}),
+				}
+				return testPolicy(c)
+			},
+			SignIn:  testSignIn,
+			Applies: false,
+		},
+		{
+			TestName: "UnknownPlatform",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Platforms = &stable.ConditionalAccessPlatforms{
+					IncludePlatforms: pointer.To([]stable.ConditionalAccessDevicePlatform{stable.ConditionalAccessDevicePlatform_Android}),
+				}
+				return testPolicy(c)
+			},
+			SignIn: func() SignIn {
+				s := testSignIn()
+				s.Platform = ""
+				return s
+			},
+			Applies: false,
+		},
+		{
+			TestName: "TrustedLocationExcluded",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Locations = &stable.ConditionalAccessLocations{
+					IncludeLocations: pointer.To([]string{"All"}),
+					ExcludeLocations: pointer.To([]string{"AllTrusted"}),
+				}
+				return testPolicy(c)
+			},
+			SignIn: func() SignIn {
+				s := testSignIn()
+				s.TrustedLocation = true
+				return s
+			},
+			Applies: false,
+		},
+		{
+			TestName: "NamedLocationResourceId",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Locations = &stable.ConditionalAccessLocations{
+					IncludeLocations: pointer.To([]string{testNamedLocationId}),
+				}
+				return testPolicy(c)
+			},
+			SignIn: func() SignIn {
+				s := testSignIn()
+				s.NamedLocationIds = []string{stable.NewIdentityConditionalAccessNamedLocationID(testNamedLocationId).ID()}
+				return s
+			},
+			Applies: true,
+		},
+		{
+			TestName: "SignInRiskNotIncluded",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.SignInRiskLevels = []stable.RiskLevel{stable.RiskLevel_High, stable.RiskLevel_Medium}
+				return testPolicy(c)
+			},
+			SignIn:  testSignIn,
+			Applies: false,
+		},
+		{
+			TestName: "SignInRiskIncluded",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.SignInRiskLevels = []stable.RiskLevel{stable.RiskLevel_High, stable.RiskLevel_Medium}
+				return testPolicy(c)
+			},
+			SignIn: func() SignIn {
+				s := testSignIn()
+				s.SignInRiskLevel = "high"
+				return s
+			},
+			Applies: true,
+		},
+		{
+			TestName: "InsiderRisk",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.InsiderRiskLevels = pointer.To(stable.ConditionalAccessInsiderRiskLevels("moderate,elevated"))
+				return testPolicy(c)
+			},
+			SignIn: func() SignIn {
+				s := testSignIn()
+				s.InsiderRiskLevel = "elevated"
+				return s
+			},
+			Applies: true,
+		},
+		{
+			TestName: "DeviceFilterExclude",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Devices = &stable.ConditionalAccessDevices{
+					DeviceFilter: &stable.ConditionalAccessFilter{
+						Mode: pointer.To(stable.FilterMode_Exclude),
+						Rule: pointer.To(`device.isCompliant -eq true -and device.trustType -eq "AzureAD"`),
+					},
+				}
+				return testPolicy(c)
+			},
+			SignIn: func() SignIn {
+				s := testSignIn()
+				s.DeviceAttributes = map[string]string{"isCompliant": "true", "trustType": "AzureAD"}
+				return s
+			},
+			Applies: false,
+		},
+		{
+			TestName: "DeviceFilterInclude",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Devices = &stable.ConditionalAccessDevices{
+					DeviceFilter: &stable.ConditionalAccessFilter{
+						Mode: pointer.To(stable.FilterMode_Include),
+						Rule: pointer.To(`device.model -startsWith "Surface"`),
+					},
+				}
+				return testPolicy(c)
+			},
+			SignIn: func() SignIn {
+				s := testSignIn()
+				s.DeviceAttributes = map[string]string{"model": "Surface Pro"}
+				return s
+			},
+			Applies: true,
+		},
+		{
+			TestName: "DeviceFilterInvalid",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Devices = &stable.ConditionalAccessDevices{
+					DeviceFilter: &stable.ConditionalAccessFilter{
+						Mode: pointer.To(stable.FilterMode_Include),
+						Rule: pointer.To(`user.department -eq "Sales"`),
+					},
+				}
+				return testPolicy(c)
+			},
+			SignIn:        testSignIn,
+			Indeterminate: true,
+		},
+		{
+			TestName: "DeviceFilterCannotBeEvaluated",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Devices = &stable.ConditionalAccessDevices{
+					DeviceFilter: &stable.ConditionalAccessFilter{
+						Mode: pointer.To(stable.FilterMode_Exclude),
+						Rule: pointer.To(`device.displayName -match "^(?!SAW-)"`),
+					},
+				}
+				return testPolicy(c)
+			},
+			SignIn: func() SignIn {
+				s := testSignIn()
+				s.DeviceAttributes = map[string]string{"displayName": "SAW-001"}
+				return s
+			},
+			Indeterminate: true,
+		},
+		{
+			TestName: "DeviceFilterInvalidOtherConditionsNotSatisfied",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Users = &stable.ConditionalAccessUsers{IncludeUsers: pointer.To([]string{"None"})}
+				c.Devices = &stable.ConditionalAccessDevices{
+					DeviceFilter: &stable.ConditionalAccessFilter{
+						Mode: pointer.To(stable.FilterMode_Include),
+						Rule: pointer.To(`user.department -eq "Sales"`),
+					},
+				}
+				return testPolicy(c)
+			},
+			SignIn:  testSignIn,
+			Applies: false,
+		},
+		{
+			TestName: "GuestFromEnumeratedTenant",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Users = &stable.ConditionalAccessUsers{
+					IncludeGuestsOrExternalUsers: &stable.ConditionalAccessGuestsOrExternalUsers{
+						GuestOrExternalUserTypes: pointer.To(stable.ConditionalAccessGuestOrExternalUserTypes("b2bCollaborationGuest,serviceProvider")),
+						ExternalTenants: stable.ConditionalAccessEnumeratedExternalTenants{
+							MembershipKind: pointer.To(stable.ConditionalAccessExternalTenantsMembershipKind_Enumerated),
+							Members:        pointer.To([]string{testExternalTenantId}),
+						},
+					},
+				}
+				return testPolicy(c)
+			},
+			SignIn: func() SignIn {
+				s := testSignIn()
+				s.GuestOrExternalUserType = "b2bCollaborationGuest"
+				s.ExternalTenantId = testExternalTenantId
+				return s
+			},
+			Applies: true,
+		},
+		{
+			TestName: "MemberNotIncludedByGuestPolicy",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Users = &stable.ConditionalAccessUsers{
+					IncludeUsers: pointer.To([]string{"GuestsOrExternalUsers"}),
+				}
+				return testPolicy(c)
+			},
+			SignIn:  testSignIn,
+			Applies: false,
+		},
+		{
+			TestName: "WorkloadIdentity",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.Users = &stable.ConditionalAccessUsers{IncludeUsers: pointer.To([]string{"None"})}
+				c.ClientApplications = &stable.ConditionalAccessClientApplications{
+					IncludeServicePrincipals: pointer.To([]string{"ServicePrincipalsInMyTenant"}),
+				}
+				c.ServicePrincipalRiskLevels = pointer.To([]stable.RiskLevel{stable.RiskLevel_High})
+				return testPolicy(c)
+			},
+			SignIn: func() SignIn {
+				return SignIn{
+					ServicePrincipalId:        testServicePrincipalId,
+					ApplicationId:             testApplicationId,
+					ServicePrincipalRiskLevel: "high",
+				}
+			},
+			Applies: true,
+		},
+		{
+			TestName: "WorkloadIdentityPolicyForUser",
+			Policy: func() stable.ConditionalAccessPolicy {
+				c := testConditions()
+				c.ClientApplications = &stable.ConditionalAccessClientApplications{
+					IncludeServicePrincipals: pointer.To([]string{"ServicePrincipalsInMyTenant"}),
+				}
+				return testPolicy(c)
+			},
+			SignIn:  testSignIn,
+			Applies: false,
+		},
+		{
+			TestName: "UserPolicyForWorkloadIdentity",
+			Policy:   func() stable.ConditionalAccessPolicy { return testPolicy(testConditions()) },
+			SignIn: func() SignIn {
+				return SignIn{
+					ServicePrincipalId: testServicePrincipalId,
+					ApplicationId:      testApplicationId,
+				}
+			},
+			Applies: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			expected := EvaluationResultDoesNotApply
+			if tc.Indeterminate {
+				expected = EvaluationResultIndeterminate
+			} else if tc.Applies {
+				expected = EvaluationResultApplies
+			}
+
+			result, reason := Evaluate(tc.Policy(), tc.SignIn())
+			if result != expected {
+				t.Fatalf("Expected result %q, got %q (reason: %q)", expected, result, reason)
+			}
+			if result != EvaluationResultApplies && reason == "" {
+				t.Fatalf("Expected a reason when policy does not apply or is indeterminate")
+			}
+		})
+	}
+}
+
+func TestMatchNamedLocations(t *testing.T) {
+	namedLocations := []stable.NamedLocation{
+		stable.IPNamedLocation{
+			Id:        pointer.To("ip-trusted"),
+			IsTrusted: pointer.To(true),
+			IPRanges: []stable.IPRange{
+				stable.IPv4CIDRRange{CIDRAddress: pointer.To("10.0.0.0/8")},
+				stable.IPv6CIDRRange{CIDRAddress: pointer.To("2001:db8::/32")},
+			},
+		},
+		stable.IPNamedLocation{
+			Id: pointer.To("ip-untrusted"),
+			IPRanges: []stable.IPRange{
+				stable.IPv4CIDRRange{CIDRAddress: pointer.To("192.0.2.0/24")},
+			},
+		},
+		stable.CountryNamedLocation{
+			Id:                  pointer.To("country"),
+			CountriesAndRegions: []string{"GB", "FR"},
+		},
+	}
+
+	cases := []struct {
+		IpAddress   string
+		CountryCode string
+		Expected    []string
+		Trusted     bool
+	}{
+		{IpAddress: "10.1.2.3", Expected: []string{"ip-trusted"}, Trusted: true},
+		{IpAddress: "2001:db8::1", Expected: []string{"ip-trusted"}, Trusted: true},
+		{IpAddress: "192.0.2.10", CountryCode: "gb", Expected: []string{"ip-untrusted", "country"}},
+		{IpAddress: "198.51.100.1", CountryCode: "US", Expected: []string{}},
+	}
+
+	for _, tc := range cases {
+		ids, trusted := MatchNamedLocations(namedLocations, tc.IpAddress, tc.CountryCode)
+		if trusted != tc.Trusted {
+			t.Fatalf("Expected trusted to be %t for %q, got %t", tc.Trusted, tc.IpAddress, trusted)
+		}
+		if len(ids) != len(tc.Expected) {
+			t.Fatalf("Expected %v for %q/%q, got %v", tc.Expected, tc.IpAddress, tc.CountryCode, ids)
+		}
+		for i := range ids {
+			if ids[i] != tc.Expected[i] {
+				t.Fatalf("Expected %v for %q/%q, got %v", tc.Expected, tc.IpAddress, tc.CountryCode, ids)
+			}
+		}
+	}
+}
+
+func TestCombineSessionControls(t *testing.T) {
+	if result := CombineSessionControls(nil); result != nil {
+		t.Fatalf("Expected nil result for no session controls, got %+v", result)
+	}
+
+	result := CombineSessionControls([]*stable.ConditionalAccessSessionControls{
+		{
+			PersistentBrowser: &stable.PersistentBrowserSessionControl{
+				IsEnabled: nullable.Value(true),
+				Mode:      pointer.To(stable.PersistentBrowserSessionMode_Always),
+			},
+			SignInFrequency: &stable.SignInFrequencySessionControl{
+				IsEnabled: nullable.Value(true),
+				Type:      pointer.To(stable.SigninFrequencyType_Days),
+				Value:     nullable.Value(int64(1)),
+			},
+		},
+		nil,
+		{
+			PersistentBrowser: &stable.PersistentBrowserSessionControl{
+				IsEnabled: nullable.Value(true),
+				Mode:      pointer.To(stable.PersistentBrowserSessionMode_Never),
+			},
+			SignInFrequency: &stable.SignInFrequencySessionControl{
+				IsEnabled: nullable.Value(true),
+				Type:      pointer.To(stable.SigninFrequencyType_Hours),
+				Value:     nullable.Value(int64(12)),
+			},
+		},
+		{
+			SignInFrequency: &stable.SignInFrequencySessionControl{
+				IsEnabled: nullable.Value(true),
+				Type:      pointer.To(stable.SigninFrequencyType_Hours),
+				Value:     nullable.Value(int64(20)),
+			},
+		},
+	})
+
+	if result == nil {
+		t.Fatalf("Expected combined session controls, got nil")
+	}
+	if mode := pointer.From(result.PersistentBrowser.Mode); mode != stable.PersistentBrowserSessionMode_Never {
+		t.Fatalf("Expected persistent browser mode %q, got %q", stable.PersistentBrowserSessionMode_Never, mode)
+	}
+	if v := result.SignInFrequency.Value.GetOrZero(); v != 12 || pointer.From(result.SignInFrequency.Type) != stable.SigninFrequencyType_Hours {
+		t.Fatalf("Expected sign-in frequency of 12 hours, got %d %s", v, pointer.From(result.SignInFrequency.Type))
+	}
+}
@@ -9,3 +9,14 @@ const (
 )
 
 var PossibleValuesForKeyCredentialUsage = []string{KeyCredentialUsageSign, KeyCredentialUsageVerify}
+
+const (
+	CertificateKeyAlgorithmECDSA = "ECDSA"
+	CertificateKeyAlgorithmRSA   = "RSA"
+)
+
+var PossibleValuesForCertificateKeyAlgorithm = []string{CertificateKeyAlgorithmECDSA, CertificateKeyAlgorithmRSA}
+
+var PossibleValuesForCertificateECDSACurve = []string{"P256", "P384", "P521"}
+
+var PossibleValuesForCertificateRSABits = []int{2048, 3072, 4096}
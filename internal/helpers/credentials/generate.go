@@ -0,0 +1,303 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package credentials
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+type GenerateCertificateOptions struct {
+	CommonName     string
+	KeyAlgorithm   string
+	RSABits        int
+	ECDSACurve     string
+	NotBefore      time.Time
+	NotAfter       time.Time
+	PKCS12Password string
+}
+
+type GeneratedCertificate struct {
+	CertificatePem string
+	PrivateKeyPem  string
+	PKCS12Base64   string
+}
+
+// GenerateCertificateSchema returns the schema for the `generate` block of certificate resources, which generates a
+// self-signed certificate locally instead of requiring the certificate data to be supplied.
+func GenerateCertificateSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Description:  "Generate a key pair and self-signed certificate locally, instead of supplying the certificate data",
+		Type:         pluginsdk.TypeList,
+		Optional:     true,
+		ForceNew:     true,
+		MaxItems:     1,
+		ExactlyOneOf: []string{"generate", "value"},
+
+		// Generated certificates are always PEM encoded
+		ConflictsWith: []string{"encoding"},
+
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"common_name": {
+					Description:  "The common name for the subject of the generated certificate",
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"key_algorithm": {
+					Description:  "The algorithm of the generated key pair",
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ForceNew:     true,
+					Default:      CertificateKeyAlgorithmRSA,
+					ValidateFunc: validation.StringInSlice(PossibleValuesForCertificateKeyAlgorithm, false),
+				},
+
+				"rsa_bits": {
+					Description:  "The size of the generated RSA key in bits",
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					ForceNew:     true,
+					Default:      2048,
+					ValidateFunc: validation.IntInSlice(PossibleValuesForCertificateRSABits),
+				},
+
+				"ecdsa_curve": {
+					Description:  "The elliptic curve of the generated ECDSA key",
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ForceNew:     true,
+					Default:      "P256",
+					ValidateFunc: validation.StringInSlice(PossibleValuesForCertificateECDSACurve, false),
+				},
+
+				"validity_in_days": {
+					Description:  "The number of days for which the generated certificate is valid, when `end_date` is not specified",
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					ForceNew:     true,
+					Default:      365,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+
+				"renew_before_days": {
+					Description:  "The number of days before the end date of the generated certificate at which it should be replaced, when `end_date` is not specified",
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					ForceNew:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+
+				"pkcs12_password": {
+					Description: "The password used to protect the generated PKCS#12 bundle",
+					Type:        pluginsdk.TypeString,
+					Optional:    true,
+					ForceNew:    true,
+					Sensitive:   true,
+				},
+			},
+		},
+	}
+}
+
+// GenerateCertificate creates a new key pair and a self-signed X.509 certificate for the public key, returning the
+// PEM encoded certificate and private key, along with a base64 encoded PKCS#12 bundle containing both.
+func GenerateCertificate(opts GenerateCertificateOptions) (*GeneratedCertificate, error) {
+	var privateKey crypto.Signer
+	keyUsage := x509.KeyUsageDigitalSignature
+
+	switch opts.KeyAlgorithm {
+	case CertificateKeyAlgorithmRSA:
+		key, err := rsa.GenerateKey(rand.Reader, opts.RSABits)
+		if err != nil {
+			return nil, fmt.Errorf("generating RSA key: %+v", err)
+		}
+		privateKey = key
+		keyUsage |= x509.KeyUsageKeyEncipherment
+
+	case CertificateKeyAlgorithmECDSA:
+		var curve elliptic.Curve
+		switch opts.ECDSACurve {
+		case "P256":
+			curve = elliptic.P256()
+		case "P384":
+			curve = elliptic.P384()
+		case "P521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA curve %q", opts.ECDSACurve)
+		}
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generating ECDSA key: %+v", err)
+		}
+		privateKey = key
+
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %q", opts.KeyAlgorithm)
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("generating serial number: %+v", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: opts.CommonName},
+		NotBefore:             opts.NotBefore,
+		NotAfter:              opts.NotAfter,
+		KeyUsage:              keyUsage,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, privateKey.Public(), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("creating certificate: %+v", err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("parsing generated certificate: %+v", err)
+	}
+
+	keyDer, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling private key: %+v", err)
+	}
+
+	pfx, err := pkcs12.Modern.Encode(privateKey, certificate, nil, opts.PKCS12Password)
+	if err != nil {
+		return nil, fmt.Errorf("encoding PKCS#12 bundle: %+v", err)
+	}
+
+	return &GeneratedCertificate{
+		CertificatePem: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		PrivateKeyPem:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})),
+		PKCS12Base64:   base64.StdEncoding.EncodeToString(pfx),
+	}, nil
+}
+
+// GenerateCertificateForResource generates a certificate according to the `generate` block of a certificate resource,
+// and populates the `value`, `encoding`, `start_date`, `end_date` and `type` attributes so that the public part can be uploaded
+// using KeyCredentialForResource.
+func GenerateCertificateForResource(d *pluginsdk.ResourceData) (*GeneratedCertificate, error) {
+	raw := d.Get("generate").([]interface{})
+	if len(raw) == 0 || raw[0] == nil {
+		return nil, nil
+	}
+	generate := raw[0].(map[string]interface{})
+
+	notBefore := time.Now().UTC().Truncate(time.Second)
+	if v, ok := d.GetOk("start_date"); ok {
+		startDate, err := time.Parse(time.RFC3339, v.(string))
+		if err != nil {
+			return nil, CredentialError{str: fmt.Sprintf("Unable to parse the provided start date %q: %+v", v, err), attr: "start_date"}
+		}
+		notBefore = startDate
+	}
+
+	notAfter := notBefore.AddDate(0, 0, generate["validity_in_days"].(int))
+	if v, ok := d.GetOk("end_date"); ok && v.(string) != "" {
+		endDate, err := time.Parse(time.RFC3339, v.(string))
+		if err != nil {
+			return nil, CredentialError{str: fmt.Sprintf("Unable to parse the provided end date %q: %+v", v, err), attr: "end_date"}
+		}
+		notAfter = endDate
+	} else if v, ok := d.GetOk("end_date_relative"); ok && v.(string) != "" {
+		duration, err := time.ParseDuration(v.(string))
+		if err != nil {
+			return nil, CredentialError{str: fmt.Sprintf("Unable to parse `end_date_relative` (%q) as a duration", v), attr: "end_date_relative"}
+		}
+		notAfter = notBefore.Add(duration)
+	}
+
+	if !notAfter.After(notBefore) {
+		return nil, CredentialError{str: "The end date of a generated certificate must be after its start date", attr: "end_date"}
+	}
+
+	certificate, err := GenerateCertificate(GenerateCertificateOptions{
+		CommonName:     generate["common_name"].(string),
+		KeyAlgorithm:   generate["key_algorithm"].(string),
+		RSABits:        generate["rsa_bits"].(int),
+		ECDSACurve:     generate["ecdsa_curve"].(string),
+		NotBefore:      notBefore,
+		NotAfter:       notAfter,
+		PKCS12Password: generate["pkcs12_password"].(string),
+	})
+	if err != nil {
+		return nil, CredentialError{str: fmt.Sprintf("Generating certificate: %+v", err), attr: "generate"}
+	}
+
+	values := map[string]string{
+		"encoding":   "pem",
+		"end_date":   notAfter.Format(time.RFC3339),
+		"start_date": notBefore.Format(time.RFC3339),
+		"value":      certificate.CertificatePem,
+	}
+	if d.Get("type").(string) == "" {
+		values["type"] = "AsymmetricX509Cert"
+	}
+
+	for k, v := range values {
+		if err = d.Set(k, v); err != nil {
+			return nil, fmt.Errorf("setting %q: %+v", k, err)
+		}
+	}
+
+	return certificate, nil
+}
+
+// GeneratedCertificateRenewalCustomizeDiff forces replacement of a generated certificate once it is due for renewal,
+// i.e. when `renew_before_days` days or fewer remain until its end date. Renewal only applies when the end date is
+// computed from the `generate` block, since a fixed `end_date` would produce the same expiry again.
+func GeneratedCertificateRenewalCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+	raw := diff.Get("generate").([]interface{})
+	if len(raw) == 0 || raw[0] == nil {
+		return nil
+	}
+	generate := raw[0].(map[string]interface{})
+
+	if validity, renewBefore := generate["validity_in_days"].(int), generate["renew_before_days"].(int); renewBefore >= validity {
+		return fmt.Errorf("`generate.0.renew_before_days` (%d) must be less than `generate.0.validity_in_days` (%d)", renewBefore, validity)
+	}
+
+	if diff.Id() == "" || !diff.GetRawConfig().GetAttr("end_date").IsNull() {
+		return nil
+	}
+
+	endDate, err := time.Parse(time.RFC3339, diff.Get("end_date").(string))
+	if err != nil {
+		return nil
+	}
+
+	if !time.Now().Before(endDate.AddDate(0, 0, -generate["renew_before_days"].(int))) {
+		if err = diff.SetNew("ready_for_renewal", true); err != nil {
+			return err
+		}
+		return diff.ForceNew("ready_for_renewal")
+	}
+
+	return nil
+}
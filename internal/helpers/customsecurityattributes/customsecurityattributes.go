@@ -0,0 +1,332 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package customsecurityattributes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client/msgraph"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/rawrequest"
+)
+
+const (
+	TypeBoolean = "Boolean"
+	TypeInteger = "Integer"
+	TypeString  = "String"
+)
+
+var PossibleValuesForType = []string{TypeBoolean, TypeInteger, TypeString}
+
+const (
+	odataTypeCollectionInt32              = "#Collection(Int32)"
+	odataTypeCollectionString             = "#Collection(String)"
+	odataTypeCustomSecurityAttributeValue = "#Microsoft.DirectoryServices.CustomSecurityAttributeValue"
+	odataTypeInt32                        = "#Int32"
+	odataTypeAnnotationSuffix             = "@odata.type"
+	customSecurityAttributesPropertyName  = "customSecurityAttributes"
+)
+
+// ErrAccessDenied is returned by Get when the caller is not permitted to read custom security attributes. Reading
+// custom security attributes requires additional permissions that are not granted by any directory role by default.
+var ErrAccessDenied = errors.New("insufficient privileges to read custom security attributes")
+
+// Values holds the custom security attributes assigned to a directory object, keyed by attribute set and then by
+// attribute name. Each attribute set also contains any OData type annotations returned by the API, which are needed
+// to distinguish single-valued integers and collections from other values.
+type Values map[string]map[string]json.RawMessage
+
+// Get retrieves the custom security attributes assigned to the directory object at the specified path, e.g.
+// `/users/00000000-0000-0000-0000-000000000000`. The SDK models do not expose attribute values, so the request is made
+// directly.
+func Get(ctx context.Context, c *msgraph.Client, path string) (Values, error) {
+	var model map[string]json.RawMessage
+	resp, err := rawrequest.Do(ctx, c, rawrequest.Options{
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       path,
+		Select:     []string{customSecurityAttributesPropertyName},
+	}, nil, &model)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusForbidden {
+			return nil, fmt.Errorf("%w: %v", ErrAccessDenied, err)
+		}
+		return nil, err
+	}
+
+	result := make(Values)
+	if raw, ok := model[customSecurityAttributesPropertyName]; ok && len(raw) > 0 && string(raw) != "null" {
+		sets := make(map[string]json.RawMessage)
+		if err = json.Unmarshal(raw, &sets); err != nil {
+			return nil, fmt.Errorf("unmarshaling custom security attributes: %+v", err)
+		}
+
+		for setName, rawSet := range sets {
+			if strings.HasPrefix(setName, "@") {
+				continue
+			}
+			attributes := make(map[string]json.RawMessage)
+			if err = json.Unmarshal(rawSet, &attributes); err != nil {
+				return nil, fmt.Errorf("unmarshaling attribute set %q: %+v", setName, err)
+			}
+			result[setName] = attributes
+		}
+	}
+
+	return result, nil
+}
+
+// Update assigns the provided custom security attributes to the directory object at the specified path. The payload
+// should be built using Expand, so that removed attributes are explicitly unset.
+func Update(ctx context.Context, c *msgraph.Client, path string, payload map[string]interface{}) error {
+	if len(payload) == 0 {
+		return nil
+	}
+
+	if _, err := rawrequest.Do(ctx, c, rawrequest.Options{
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+			http.StatusNoContent,
+		},
+		HttpMethod: http.MethodPatch,
+		Path:       path,
+	}, map[string]interface{}{customSecurityAttributesPropertyName: payload}, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Expand builds a payload for Update from the `custom_security_attributes` schema. Any attributes in `previous` that
+// are no longer configured are explicitly unset, whilst attributes that were not previously managed are left
+// unchanged. Each value is annotated with its OData type so that integers and collections are stored with the correct
+// type.
+func Expand(in []interface{}, previous []interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+
+	setFor := func(setName string) map[string]interface{} {
+		// Attribute set names are case-insensitive, so match existing keys to avoid duplicates
+		for existingSetName, v := range result {
+			if strings.EqualFold(existingSetName, setName) {
+				return v.(map[string]interface{})
+			}
+		}
+		set := map[string]interface{}{
+			"@odata.type": odataTypeCustomSecurityAttributeValue,
+		}
+		result[setName] = set
+		return set
+	}
+
+	configured := make(map[string]struct{})
+
+	for _, raw := range in {
+		if raw == nil {
+			continue
+		}
+		attr := raw.(map[string]interface{})
+
+		setName := attr["attribute_set"].(string)
+		name := attr["name"].(string)
+		attrType := attr["type"].(string)
+		value := attr["value"].(string)
+		values := make([]string, 0)
+		for _, v := range attr["values"].([]interface{}) {
+			if v != nil {
+				values = append(values, v.(string))
+			}
+		}
+
+		key := strings.ToLower(setName + "/" + name)
+		if _, ok := configured[key]; ok {
+			return nil, fmt.Errorf("custom security attribute %q in attribute set %q was specified more than once", name, setName)
+		}
+		configured[key] = struct{}{}
+
+		if (value == "") == (len(values) == 0) {
+			return nil, fmt.Errorf("exactly one of `value` or `values` must be specified for custom security attribute %q in attribute set %q", name, setName)
+		}
+
+		set := setFor(setName)
+
+		switch attrType {
+		case TypeBoolean:
+			if len(values) > 0 {
+				return nil, fmt.Errorf("custom security attribute %q in attribute set %q has type %q and cannot have multiple `values`", name, setName, TypeBoolean)
+			}
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("value %q for custom security attribute %q in attribute set %q is not a valid boolean", value, name, setName)
+			}
+			set[name] = b
+
+		case TypeInteger:
+			if len(values) > 0 {
+				ints := make([]int32, 0, len(values))
+				for _, v := range values {
+					i, err := strconv.ParseInt(v, 10, 32)
+					if err != nil {
+						return nil, fmt.Errorf("value %q for custom security attribute %q in attribute set %q is not a valid 32-bit integer", v, name, setName)
+					}
+					ints = append(ints, int32(i))
+				}
+				set[name+odataTypeAnnotationSuffix] = odataTypeCollectionInt32
+				set[name] = ints
+			} else {
+				i, err := strconv.ParseInt(value, 10, 32)
+				if err != nil {
+					return nil, fmt.Errorf("value %q for custom security attribute %q in attribute set %q is not a valid 32-bit integer", value, name, setName)
+				}
+				set[name+odataTypeAnnotationSuffix] = odataTypeInt32
+				set[name] = int32(i)
+			}
+
+		case TypeString:
+			if len(values) > 0 {
+				set[name+odataTypeAnnotationSuffix] = odataTypeCollectionString
+				set[name] = values
+			} else {
+				set[name] = value
+			}
+
+		default:
+			return nil, fmt.Errorf("unsupported type %q for custom security attribute %q in attribute set %q", attrType, name, setName)
+		}
+	}
+
+	// Unset any previously managed attributes that are no longer configured
+	for _, raw := range previous {
+		if raw == nil {
+			continue
+		}
+		attr := raw.(map[string]interface{})
+
+		setName := attr["attribute_set"].(string)
+		name := attr["name"].(string)
+		if _, ok := configured[strings.ToLower(setName+"/"+name)]; ok {
+			continue
+		}
+		setFor(setName)[name] = nil
+	}
+
+	return result, nil
+}
+
+// Flatten converts custom security attribute values returned by Get into the `custom_security_attributes` schema. Only
+// attributes present in `configured` are returned, so that attributes assigned outside of Terraform do not cause a diff.
+func Flatten(in Values, configured []interface{}) ([]interface{}, error) {
+	result := make([]interface{}, 0)
+
+	managed := make(map[string]struct{})
+	for _, raw := range configured {
+		if raw == nil {
+			continue
+		}
+		attr := raw.(map[string]interface{})
+		managed[strings.ToLower(attr["attribute_set"].(string)+"/"+attr["name"].(string))] = struct{}{}
+	}
+
+	setNames := make([]string, 0, len(in))
+	for setName := range in {
+		setNames = append(setNames, setName)
+	}
+	sort.Strings(setNames)
+
+	for _, setName := range setNames {
+		attributes := in[setName]
+
+		names := make([]string, 0, len(attributes))
+		for name := range attributes {
+			if _, ok := managed[strings.ToLower(setName+"/"+name)]; ok && !strings.Contains(name, "@") {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			odataType := ""
+			if raw, ok := attributes[name+odataTypeAnnotationSuffix]; ok {
+				if err := json.Unmarshal(raw, &odataType); err != nil {
+					return nil, fmt.Errorf("unmarshaling OData type for custom security attribute %q in attribute set %q: %+v", name, setName, err)
+				}
+				odataType = "#" + strings.TrimPrefix(odataType, "#")
+			}
+
+			attrType, value, values, err := flattenValue(attributes[name], odataType)
+			if err != nil {
+				return nil, fmt.Errorf("custom security attribute %q in attribute set %q: %+v", name, setName, err)
+			}
+			if attrType == "" {
+				// Unset attributes can be returned as null
+				continue
+			}
+
+			result = append(result, map[string]interface{}{
+				"attribute_set": setName,
+				"name":          name,
+				"type":          attrType,
+				"value":         value,
+				"values":        values,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func flattenValue(raw json.RawMessage, odataType string) (attrType string, value string, values []string, err error) {
+	var decoded interface{}
+	decoder := json.NewDecoder(strings.NewReader(string(raw)))
+	decoder.UseNumber()
+	if err = decoder.Decode(&decoded); err != nil {
+		return "", "", nil, fmt.Errorf("unmarshaling value: %+v", err)
+	}
+
+	values = make([]string, 0)
+
+	switch v := decoded.(type) {
+	case nil:
+		return "", "", nil, nil
+
+	case bool:
+		return TypeBoolean, strconv.FormatBool(v), values, nil
+
+	case json.Number:
+		return TypeInteger, v.String(), values, nil
+
+	case string:
+		if strings.EqualFold(odataType, odataTypeInt32) {
+			return TypeInteger, v, values, nil
+		}
+		return TypeString, v, values, nil
+
+	case []interface{}:
+		attrType = TypeString
+		if strings.EqualFold(odataType, odataTypeCollectionInt32) {
+			attrType = TypeInteger
+		}
+		for _, item := range v {
+			switch i := item.(type) {
+			case json.Number:
+				attrType = TypeInteger
+				values = append(values, i.String())
+			case string:
+				values = append(values, i)
+			default:
+				return "", "", nil, fmt.Errorf("unsupported collection item %v", item)
+			}
+		}
+		return attrType, "", values, nil
+	}
+
+	return "", "", nil, fmt.Errorf("unsupported value %s", string(raw))
+}
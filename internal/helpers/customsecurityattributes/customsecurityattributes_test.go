@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package customsecurityattributes
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestExpandFlattenRoundTrip(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{"attribute_set": "Engineering", "name": "Certified", "type": TypeBoolean, "value": "true", "values": []interface{}{}},
+		map[string]interface{}{"attribute_set": "Engineering", "name": "CostCenter", "type": TypeInteger, "value": "", "values": []interface{}{"1001", "1002"}},
+		map[string]interface{}{"attribute_set": "Engineering", "name": "Level", "type": TypeInteger, "value": "5", "values": []interface{}{}},
+		map[string]interface{}{"attribute_set": "Engineering", "name": "Project", "type": TypeString, "value": "", "values": []interface{}{"Baker", "Cascade"}},
+		map[string]interface{}{"attribute_set": "Marketing", "name": "Campaign", "type": TypeString, "value": "Spring", "values": []interface{}{}},
+	}
+
+	payload, err := Expand(in, nil)
+	if err != nil {
+		t.Fatalf("expanding: %+v", err)
+	}
+
+	engineering := payload["Engineering"].(map[string]interface{})
+	expectedAnnotations := map[string]string{
+		"@odata.type":           odataTypeCustomSecurityAttributeValue,
+		"CostCenter@odata.type": odataTypeCollectionInt32,
+		"Level@odata.type":      odataTypeInt32,
+		"Project@odata.type":    odataTypeCollectionString,
+	}
+	for k, v := range expectedAnnotations {
+		if engineering[k] != v {
+			t.Fatalf("expected %q to be %q, got %v", k, v, engineering[k])
+		}
+	}
+	if _, ok := engineering["Certified@odata.type"]; ok {
+		t.Fatalf("expected no OData type annotation for boolean attribute")
+	}
+
+	// Simulate the API response by marshaling the payload
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshaling: %+v", err)
+	}
+	sets := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(encoded, &sets); err != nil {
+		t.Fatalf("unmarshaling: %+v", err)
+	}
+	values := make(Values)
+	for setName, raw := range sets {
+		attributes := make(map[string]json.RawMessage)
+		if err = json.Unmarshal(raw, &attributes); err != nil {
+			t.Fatalf("unmarshaling attribute set: %+v", err)
+		}
+		values[setName] = attributes
+	}
+
+	out, err := Flatten(values, in)
+	if err != nil {
+		t.Fatalf("flattening: %+v", err)
+	}
+
+	expected := make([]interface{}, 0, len(in))
+	for _, raw := range in {
+		attr := raw.(map[string]interface{})
+		values := make([]string, 0)
+		for _, v := range attr["values"].([]interface{}) {
+			values = append(values, v.(string))
+		}
+		expected = append(expected, map[string]interface{}{
+			"attribute_set": attr["attribute_set"],
+			"name":          attr["name"],
+			"type":          attr["type"],
+			"value":         attr["value"],
+			"values":        values,
+		})
+	}
+
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("round trip mismatch\nexpected: %+v\nactual:   %+v", expected, out)
+	}
+}
+
+func TestExpandRemovesPreviouslyManagedAttributes(t *testing.T) {
+	previous := []interface{}{
+		map[string]interface{}{"attribute_set": "Engineering", "name": "Project", "type": TypeString, "value": "", "values": []interface{}{"Baker"}},
+		map[string]interface{}{"attribute_set": "Engineering", "name": "Level", "type": TypeInteger, "value": "5", "values": []interface{}{}},
+	}
+
+	in := []interface{}{
+		map[string]interface{}{"attribute_set": "engineering", "name": "level", "type": TypeInteger, "value": "6", "values": []interface{}{}},
+	}
+
+	payload, err := Expand(in, previous)
+	if err != nil {
+		t.Fatalf("expanding: %+v", err)
+	}
+
+	if len(payload) != 1 {
+		t.Fatalf("expected attribute set names to be matched case-insensitively, got %+v", payload)
+	}
+
+	engineering := payload["engineering"].(map[string]interface{})
+	if v, ok := engineering["Project"]; !ok || v != nil {
+		t.Fatalf("expected Project to be explicitly unset, got %v", v)
+	}
+	if v := engineering["level"]; v != int32(6) {
+		t.Fatalf("expected level to be updated, got %v", v)
+	}
+	if len(engineering) != 4 {
+		t.Fatalf("expected only managed attributes in the payload, got %+v", engineering)
+	}
+}
+
+func TestFlattenIgnoresUnmanagedAttributes(t *testing.T) {
+	values := Values{
+		"Engineering": {
+			"@odata.type":        json.RawMessage(`"#Microsoft.DirectoryServices.CustomSecurityAttributeValue"`),
+			"Project@odata.type": json.RawMessage(`"#Collection(String)"`),
+			"Project":            json.RawMessage(`["Baker"]`),
+			"Level":              json.RawMessage(`5`),
+		},
+		"Marketing": {
+			"Campaign": json.RawMessage(`"Spring"`),
+		},
+	}
+
+	configured := []interface{}{
+		map[string]interface{}{"attribute_set": "engineering", "name": "level", "type": TypeInteger, "value": "5", "values": []interface{}{}},
+	}
+
+	out, err := Flatten(values, configured)
+	if err != nil {
+		t.Fatalf("flattening: %+v", err)
+	}
+
+	if len(out) != 1 || out[0].(map[string]interface{})["name"] != "Level" {
+		t.Fatalf("expected only the managed attribute to be flattened, got %+v", out)
+	}
+}
+
+func TestExpandInvalid(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		"NeitherValueNorValues":  {"attribute_set": "A", "name": "B", "type": TypeString, "value": "", "values": []interface{}{}},
+		"BothValueAndValues":     {"attribute_set": "A", "name": "B", "type": TypeString, "value": "x", "values": []interface{}{"y"}},
+		"InvalidBoolean":         {"attribute_set": "A", "name": "B", "type": TypeBoolean, "value": "yes", "values": []interface{}{}},
+		"BooleanCollection":      {"attribute_set": "A", "name": "B", "type": TypeBoolean, "value": "", "values": []interface{}{"true"}},
+		"InvalidInteger":         {"attribute_set": "A", "name": "B", "type": TypeInteger, "value": "1.5", "values": []interface{}{}},
+		"IntegerOutOfRange":      {"attribute_set": "A", "name": "B", "type": TypeInteger, "value": "4294967296", "values": []interface{}{}},
+		"InvalidIntegerInValues": {"attribute_set": "A", "name": "B", "type": TypeInteger, "value": "", "values": []interface{}{"1", "two"}},
+	}
+
+	for name, attr := range cases {
+		if _, err := Expand([]interface{}{attr}, nil); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+}
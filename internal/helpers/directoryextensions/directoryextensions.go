@@ -0,0 +1,426 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package directoryextensions
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directoryobjects/stable/directoryobject"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/msgraph"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/rawrequest"
+)
+
+const (
+	DataTypeBinary       = "Binary"
+	DataTypeBoolean      = "Boolean"
+	DataTypeDateTime     = "DateTime"
+	DataTypeInteger      = "Integer"
+	DataTypeLargeInteger = "LargeInteger"
+	DataTypeString       = "String"
+)
+
+var PossibleValuesForDataType = []string{
+	DataTypeBinary,
+	DataTypeBoolean,
+	DataTypeDateTime,
+	DataTypeInteger,
+	DataTypeLargeInteger,
+	DataTypeString,
+}
+
+const (
+	TargetObjectAdministrativeUnit = "AdministrativeUnit"
+	TargetObjectApplication        = "Application"
+	TargetObjectDevice             = "Device"
+	TargetObjectGroup              = "Group"
+	TargetObjectOrganization       = "Organization"
+	TargetObjectUser               = "User"
+)
+
+var PossibleValuesForTargetObject = []string{
+	TargetObjectAdministrativeUnit,
+	TargetObjectApplication,
+	TargetObjectDevice,
+	TargetObjectGroup,
+	TargetObjectOrganization,
+	TargetObjectUser,
+}
+
+// selectBatchSize limits the number of extension properties requested with each $select, to avoid overly long URLs
+const selectBatchSize = 20
+
+// NameRegex matches the full name of a directory extension property, which has the format `extension_{appId}_{name}`,
+// where `appId` is the client ID of the owning application with hyphens removed.
+var NameRegex = regexp.MustCompile(`^extension_([0-9a-fA-F]{32})_([A-Za-z0-9_]+)$`)
+
+// Name returns the full name of a directory extension property, given the client ID of the owning application and the
+// short name of the extension property.
+func Name(clientId, name string) string {
+	return fmt.Sprintf("extension_%s_%s", strings.ToLower(strings.ReplaceAll(clientId, "-", "")), name)
+}
+
+// OwnerId returns the client ID of the application owning the named directory extension property, with hyphens
+// removed and in lower case, for comparison purposes.
+func OwnerId(name string) (string, bool) {
+	m := NameRegex.FindStringSubmatch(name)
+	if m == nil {
+		return "", false
+	}
+	return strings.ToLower(m[1]), true
+}
+
+// Property describes a directory extension property registered in the tenant.
+type Property struct {
+	Name          string
+	DataType      string
+	IsMultiValued bool
+}
+
+// Properties holds a collection of directory extension properties, keyed by the lower-cased extension name.
+type Properties map[string]Property
+
+// Lookup returns the extension property with the specified name, which is matched case-insensitively.
+func (p Properties) Lookup(name string) (Property, bool) {
+	property, ok := p[strings.ToLower(name)]
+	return property, ok
+}
+
+// OwnedBy returns only the extension properties owned by any of the applications whose extension properties are
+// referenced in the provided map. This ensures that extension properties belonging to unrelated applications are
+// not considered when computing changes.
+func (p Properties) OwnedBy(values map[string]interface{}) Properties {
+	owners := make(map[string]struct{})
+	for name := range values {
+		if owner, ok := OwnerId(name); ok {
+			owners[owner] = struct{}{}
+		}
+	}
+
+	result := make(Properties)
+	for key, property := range p {
+		if owner, ok := OwnerId(property.Name); ok {
+			if _, ok = owners[owner]; ok {
+				result[key] = property
+			}
+		}
+	}
+
+	return result
+}
+
+// ListAvailable retrieves the directory extension properties registered in the tenant which can be applied to the
+// specified type of object, e.g. `User` or `Group`.
+func ListAvailable(ctx context.Context, c *directoryobject.DirectoryObjectClient, targetObject string) (Properties, error) {
+	resp, err := c.ListGetsAvailableExtensionPropertiesComplete(ctx, directoryobject.ListGetsAvailableExtensionPropertiesRequest{}, directoryobject.DefaultListGetsAvailableExtensionPropertiesOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("listing available extension properties: %+v", err)
+	}
+
+	result := make(Properties)
+	for _, extensionProperty := range resp.Items {
+		if extensionProperty.Name == nil || extensionProperty.TargetObjects == nil {
+			continue
+		}
+
+		applicable := false
+		for _, t := range *extensionProperty.TargetObjects {
+			if strings.EqualFold(t, targetObject) {
+				applicable = true
+				break
+			}
+		}
+		if !applicable {
+			continue
+		}
+
+		property := Property{
+			Name: *extensionProperty.Name,
+		}
+		if extensionProperty.DataType != nil {
+			property.DataType = *extensionProperty.DataType
+		}
+		if extensionProperty.IsMultiValued != nil {
+			property.IsMultiValued = *extensionProperty.IsMultiValued
+		}
+
+		result[strings.ToLower(property.Name)] = property
+	}
+
+	return result, nil
+}
+
+// ListOwned retrieves the directory extension properties which can be applied to the specified type of object, and
+// which are owned by any of the applications whose extension properties are referenced in the provided maps. No
+// request is made when no extension properties are referenced.
+func ListOwned(ctx context.Context, c *directoryobject.DirectoryObjectClient, targetObject string, values ...map[string]interface{}) (Properties, error) {
+	referenced := make(map[string]interface{})
+	for _, v := range values {
+		for name, value := range v {
+			referenced[name] = value
+		}
+	}
+	if len(referenced) == 0 {
+		return Properties{}, nil
+	}
+
+	available, err := ListAvailable(ctx, c, targetObject)
+	if err != nil {
+		return nil, err
+	}
+
+	return available.OwnedBy(referenced), nil
+}
+
+// Get retrieves the values of the specified extension properties for the directory object at the specified path, e.g.
+// `/users/00000000-0000-0000-0000-000000000000`. Extension properties are only returned when explicitly selected, and
+// the SDK models do not expose them, so the requests are made directly. Extension properties without a value are
+// omitted from the result.
+func Get(ctx context.Context, c *msgraph.Client, path string, properties Properties) (map[string]json.RawMessage, error) {
+	names := make([]string, 0, len(properties))
+	for _, property := range properties {
+		names = append(names, property.Name)
+	}
+	sort.Strings(names)
+
+	result := make(map[string]json.RawMessage)
+
+	for start := 0; start < len(names); start += selectBatchSize {
+		end := start + selectBatchSize
+		if end > len(names) {
+			end = len(names)
+		}
+
+		var model map[string]json.RawMessage
+		if _, err := rawrequest.Do(ctx, c, rawrequest.Options{
+			ExpectedStatusCodes: []int{
+				http.StatusOK,
+			},
+			HttpMethod: http.MethodGet,
+			Path:       path,
+			Select:     append([]string{"id"}, names[start:end]...),
+		}, nil, &model); err != nil {
+			return nil, err
+		}
+
+		for key, value := range model {
+			property, ok := properties.Lookup(key)
+			if !ok || len(value) == 0 || string(value) == "null" {
+				continue
+			}
+			result[property.Name] = value
+		}
+	}
+
+	return result, nil
+}
+
+// Update sets the provided extension property values on the directory object at the specified path. The payload should
+// be built using Expand, so that removed values are explicitly unset.
+func Update(ctx context.Context, c *msgraph.Client, path string, payload map[string]interface{}) error {
+	if len(payload) == 0 {
+		return nil
+	}
+
+	_, err := rawrequest.Do(ctx, c, rawrequest.Options{
+		ExpectedStatusCodes: []int{
+			http.StatusNoContent,
+		},
+		HttpMethod: http.MethodPatch,
+		Path:       path,
+	}, payload, nil)
+
+	return err
+}
+
+// Expand builds a request payload from the configured extension attribute values, converting each value to the data
+// type declared by the corresponding extension property. Values for multi-valued extension properties must be
+// specified as a JSON array. Any extension attributes present in `previous` but not in `in` are explicitly unset.
+func Expand(in, previous map[string]interface{}, properties Properties) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+
+	for name, raw := range in {
+		property, ok := properties.Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("extension property %q was not found, or is not applicable to this type of object", name)
+		}
+
+		value, err := expandValue(property, raw.(string))
+		if err != nil {
+			return nil, fmt.Errorf("extension attribute %q: %v", name, err)
+		}
+
+		result[property.Name] = value
+	}
+
+	for name := range previous {
+		property, ok := properties.Lookup(name)
+		if !ok {
+			// The extension property has been removed from its application, so there is no value to unset
+			continue
+		}
+		if _, ok = result[property.Name]; !ok {
+			result[property.Name] = nil
+		}
+	}
+
+	return result, nil
+}
+
+// Flatten converts extension attribute values returned by the API into strings suitable for a map attribute. When an
+// extension attribute is also present in `configured`, the configured key is used so that differences in the casing of
+// extension names do not cause a diff.
+func Flatten(values map[string]json.RawMessage, properties Properties, configured map[string]interface{}) (map[string]string, error) {
+	keys := make(map[string]string)
+	for name := range configured {
+		keys[strings.ToLower(name)] = name
+	}
+
+	result := make(map[string]string)
+	for name, raw := range values {
+		property, ok := properties.Lookup(name)
+		if !ok {
+			continue
+		}
+
+		value, err := flattenValue(property, raw)
+		if err != nil {
+			return nil, fmt.Errorf("extension attribute %q: %v", name, err)
+		}
+
+		key := property.Name
+		if configuredKey, ok := keys[strings.ToLower(name)]; ok {
+			key = configuredKey
+		}
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+func expandValue(property Property, in string) (interface{}, error) {
+	if !property.IsMultiValued {
+		return expandScalar(property.DataType, in)
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(in), &items); err != nil {
+		return nil, fmt.Errorf("extension property is multi-valued, so the value must be a JSON array (e.g. using the `jsonencode()` function): %v", err)
+	}
+
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		var s string
+		if err := json.Unmarshal(item, &s); err != nil {
+			// Allow unquoted booleans and numbers in the array
+			s = string(item)
+		}
+		v, err := expandScalar(property.DataType, s)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+
+	return result, nil
+}
+
+func expandScalar(dataType, in string) (interface{}, error) {
+	switch dataType {
+	case DataTypeBoolean:
+		v, err := strconv.ParseBool(in)
+		if err != nil {
+			return nil, fmt.Errorf("expected a boolean value, got %q", in)
+		}
+		return v, nil
+
+	case DataTypeInteger:
+		v, err := strconv.ParseInt(in, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("expected a 32-bit integer value, got %q", in)
+		}
+		return int32(v), nil
+
+	case DataTypeLargeInteger:
+		v, err := strconv.ParseInt(in, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a 64-bit integer value, got %q", in)
+		}
+		return v, nil
+
+	case DataTypeDateTime:
+		if _, err := time.Parse(time.RFC3339, in); err != nil {
+			return nil, fmt.Errorf("expected an RFC3339 date, got %q", in)
+		}
+		return in, nil
+
+	case DataTypeBinary:
+		if _, err := base64.StdEncoding.DecodeString(in); err != nil {
+			return nil, fmt.Errorf("expected a base64-encoded value, got %q", in)
+		}
+		return in, nil
+	}
+
+	return in, nil
+}
+
+func flattenValue(property Property, raw json.RawMessage) (string, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return "", fmt.Errorf("unmarshaling collection: %v", err)
+		}
+
+		result := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			s, err := flattenScalar(item)
+			if err != nil {
+				return "", err
+			}
+			v, err := expandScalar(property.DataType, s)
+			if err != nil {
+				return "", err
+			}
+			result = append(result, v)
+		}
+
+		out, err := json.Marshal(result)
+		if err != nil {
+			return "", fmt.Errorf("marshaling collection: %v", err)
+		}
+		return string(out), nil
+	}
+
+	return flattenScalar(trimmed)
+}
+
+func flattenScalar(raw json.RawMessage) (string, error) {
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return "", fmt.Errorf("unmarshaling value: %v", err)
+	}
+
+	switch value := v.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case json.Number:
+		return value.String(), nil
+	}
+
+	return "", fmt.Errorf("unexpected value %s", string(raw))
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package directoryextensions
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	testOwner = "9d98ed114c4840d298fad781915f27e4"
+	testOther = "b7b1c57b532f40b8b5ed4b7a7ba67401"
+)
+
+func testProperties() Properties {
+	properties := Properties{}
+	for _, p := range []Property{
+		{Name: "extension_" + testOwner + "_costCenter", DataType: DataTypeString},
+		{Name: "extension_" + testOwner + "_isContractor", DataType: DataTypeBoolean},
+		{Name: "extension_" + testOwner + "_level", DataType: DataTypeInteger},
+		{Name: "extension_" + testOwner + "_badgeNumber", DataType: DataTypeLargeInteger},
+		{Name: "extension_" + testOwner + "_projects", DataType: DataTypeString, IsMultiValued: true},
+		{Name: "extension_" + testOther + "_unrelated", DataType: DataTypeString},
+	} {
+		properties[strings.ToLower(p.Name)] = p
+	}
+	return properties
+}
+
+func TestName(t *testing.T) {
+	name := Name("9D98ED11-4C48-40D2-98FA-D781915F27E4", "costCenter")
+	if expected := "extension_" + testOwner + "_costCenter"; name != expected {
+		t.Fatalf("expected %q, got %q", expected, name)
+	}
+
+	owner, ok := OwnerId(name)
+	if !ok || owner != testOwner {
+		t.Fatalf("expected owner %q, got %q", testOwner, owner)
+	}
+
+	if _, ok = OwnerId("extensionAttribute1"); ok {
+		t.Fatalf("expected extensionAttribute1 not to be parsed as a directory extension")
+	}
+}
+
+func TestOwnedBy(t *testing.T) {
+	properties := testProperties().OwnedBy(map[string]interface{}{
+		"extension_" + testOwner + "_costCenter": "1234",
+	})
+
+	if len(properties) != 5 {
+		t.Fatalf("expected 5 properties, got %d", len(properties))
+	}
+	if _, ok := properties.Lookup("extension_" + testOther + "_unrelated"); ok {
+		t.Fatalf("expected extension property owned by another application to be excluded")
+	}
+}
+
+func TestExpandFlatten(t *testing.T) {
+	properties := testProperties()
+
+	in := map[string]interface{}{
+		"extension_" + testOwner + "_costCenter":   "1234",
+		"extension_" + testOwner + "_isContractor": "true",
+		"extension_" + testOwner + "_level":        "5",
+		"extension_" + testOwner + "_badgeNumber":  "9007199254740993",
+		"extension_" + testOwner + "_projects":     `["Alpine","Baker"]`,
+	}
+	previous := map[string]interface{}{
+		"extension_" + testOwner + "_costCenter": "1000",
+		"extension_" + testOther + "_removed":    "foo",
+		"EXTENSION_" + testOwner + "_LEVEL":      "4",
+	}
+
+	payload, err := Expand(in, previous, properties)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"extension_" + testOwner + "_costCenter":   "1234",
+		"extension_" + testOwner + "_isContractor": true,
+		"extension_" + testOwner + "_level":        int32(5),
+		"extension_" + testOwner + "_badgeNumber":  int64(9007199254740993),
+		"extension_" + testOwner + "_projects":     []interface{}{"Alpine", "Baker"},
+	}
+	if !reflect.DeepEqual(payload, expected) {
+		t.Fatalf("unexpected payload:\n%#v\nexpected:\n%#v", payload, expected)
+	}
+
+	// Round trip the payload as though it had been returned by the API
+	raw := make(map[string]json.RawMessage)
+	for k, v := range payload {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("marshaling: %v", err)
+		}
+		raw[k] = b
+	}
+
+	flattened, err := Flatten(raw, properties, in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for k, v := range in {
+		if flattened[k] != v {
+			t.Fatalf("expected %q to be %q, got %q", k, v, flattened[k])
+		}
+	}
+}
+
+func TestExpandRemoval(t *testing.T) {
+	previous := map[string]interface{}{
+		"extension_" + testOwner + "_costCenter": "1000",
+	}
+
+	payload, err := Expand(map[string]interface{}{}, previous, testProperties())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v, ok := payload["extension_"+testOwner+"_costCenter"]; !ok || v != nil {
+		t.Fatalf("expected removed extension attribute to be unset, got %#v", payload)
+	}
+}
+
+func TestExpandInvalid(t *testing.T) {
+	cases := map[string]string{
+		"extension_" + testOwner + "_isContractor": "maybe",
+		"extension_" + testOwner + "_level":        "3000000000",
+		"extension_" + testOwner + "_projects":     "Alpine",
+		"extension_" + testOwner + "_missing":      "foo",
+	}
+
+	for name, value := range cases {
+		if _, err := Expand(map[string]interface{}{name: value}, nil, testProperties()); err == nil {
+			t.Fatalf("expected an error for %q = %q", name, value)
+		}
+	}
+}
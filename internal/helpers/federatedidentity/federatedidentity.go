@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package federatedidentity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client/msgraph"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/rawrequest"
+)
+
+// Credential is a federated identity credential, including the `claimsMatchingExpression` property which is not yet
+// modelled by the SDK. Flexible federated identity credentials specify a claims matching expression in place of a
+// subject, and are only supported by the beta API, so the client used with this model must target the beta API.
+type Credential struct {
+	Id                       *string                           `json:"id,omitempty"`
+	Audiences                []string                          `json:"audiences"`
+	ClaimsMatchingExpression nullable.Type[MatchingExpression] `json:"claimsMatchingExpression,omitempty"`
+	Description              nullable.Type[string]             `json:"description,omitempty"`
+	Issuer                   string                            `json:"issuer"`
+	Name                     string                            `json:"name"`
+	Subject                  nullable.Type[string]             `json:"subject,omitempty"`
+}
+
+// MatchingExpression is an expression that is evaluated against the claims of an incoming token
+type MatchingExpression struct {
+	LanguageVersion int64  `json:"languageVersion"`
+	Value           string `json:"value"`
+}
+
+// Create adds a federated identity credential to the object with the provided resource ID, e.g.
+// `/applications/00000000-0000-0000-0000-000000000000`, returning the newly created credential.
+func Create(ctx context.Context, c *msgraph.Client, parentId string, input Credential) (*Credential, *http.Response, error) {
+	var model Credential
+	resp, err := rawrequest.Do(ctx, c, rawrequest.Options{
+		ExpectedStatusCodes: []int{
+			http.StatusCreated,
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodPost,
+		Path:       fmt.Sprintf("%s/federatedIdentityCredentials", parentId),
+	}, input, &model)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &model, resp, nil
+}
+
+// Get retrieves the federated identity credential with the provided resource ID, e.g.
+// `/applications/00000000-0000-0000-0000-000000000000/federatedIdentityCredentials/11111111-1111-1111-1111-111111111111`
+func Get(ctx context.Context, c *msgraph.Client, id string) (*Credential, *http.Response, error) {
+	var model Credential
+	resp, err := rawrequest.Do(ctx, c, rawrequest.Options{
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       id,
+	}, nil, &model)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &model, resp, nil
+}
+
+// Update updates the federated identity credential with the provided resource ID
+func Update(ctx context.Context, c *msgraph.Client, id string, input Credential) (*http.Response, error) {
+	return rawrequest.Do(ctx, c, rawrequest.Options{
+		ExpectedStatusCodes: []int{
+			http.StatusNoContent,
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodPatch,
+		Path:       id,
+	}, input, nil)
+}
+
+// ExpandClaimsMatchingExpression expands a `claims_matching_expression` block, returning a null value when the block
+// is not specified so that any existing expression is removed
+func ExpandClaimsMatchingExpression(input []interface{}) nullable.Type[MatchingExpression] {
+	if len(input) == 0 || input[0] == nil {
+		var result nullable.Type[MatchingExpression]
+		result.SetNull()
+		return result
+	}
+
+	in := input[0].(map[string]interface{})
+
+	return nullable.Value(MatchingExpression{
+		LanguageVersion: int64(in["language_version"].(int)),
+		Value:           in["value"].(string),
+	})
+}
+
+// FlattenClaimsMatchingExpression flattens a claims matching expression into a `claims_matching_expression` block
+func FlattenClaimsMatchingExpression(input nullable.Type[MatchingExpression]) []interface{} {
+	if !input.IsSet() || input.IsNull() {
+		return []interface{}{}
+	}
+	expression := input.Get()
+
+	return []interface{}{
+		map[string]interface{}{
+			"language_version": int(expression.LanguageVersion),
+			"value":            expression.Value,
+		},
+	}
+}
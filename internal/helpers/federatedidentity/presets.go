@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package federatedidentity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultAudience is the audience recommended for federated identity credentials in the public cloud
+const DefaultAudience = "api://AzureADTokenExchange"
+
+const (
+	GitHubIssuer                 = "https://token.actions.githubusercontent.com"
+	AzureDevOpsIssuerFormat      = "https://vstoken.dev.azure.com/%s"
+	TerraformCloudDefaultHost    = "app.terraform.io"
+	TerraformCloudDefaultProject = "Default Project"
+)
+
+const (
+	TerraformCloudRunPhaseApply = "apply"
+	TerraformCloudRunPhasePlan  = "plan"
+)
+
+func PossibleValuesForTerraformCloudRunPhase() []string {
+	return []string{TerraformCloudRunPhaseApply, TerraformCloudRunPhasePlan}
+}
+
+// Preset holds the issuer, subject and audience that a workload on a well-known platform presents in its tokens
+type Preset struct {
+	Audience string
+	Issuer   string
+	Subject  string
+}
+
+// GitHubOptions describes a GitHub Actions workflow. Exactly one of Environment, Branch, Tag or PullRequest should
+// be specified.
+type GitHubOptions struct {
+	// EnterpriseSlug is only specified when the enterprise uses a customized issuer URL
+	EnterpriseSlug string
+
+	// Repository is in the format `{organization}/{repository}`
+	Repository string
+
+	Branch      string
+	Environment string
+	PullRequest bool
+	Tag         string
+}
+
+// GitHub returns the token details for a GitHub Actions workflow
+func GitHub(o GitHubOptions) (*Preset, error) {
+	if o.Repository == "" {
+		return nil, errors.New("repository must be specified")
+	}
+
+	issuer := GitHubIssuer
+	if o.EnterpriseSlug != "" {
+		issuer = fmt.Sprintf("%s/%s", GitHubIssuer, o.EnterpriseSlug)
+	}
+
+	entity := ""
+	count := 0
+	if o.Environment != "" {
+		entity = fmt.Sprintf("environment:%s", o.Environment)
+		count++
+	}
+	if o.Branch != "" {
+		entity = fmt.Sprintf("ref:refs/heads/%s", o.Branch)
+		count++
+	}
+	if o.Tag != "" {
+		entity = fmt.Sprintf("ref:refs/tags/%s", o.Tag)
+		count++
+	}
+	if o.PullRequest {
+		entity = "pull_request"
+		count++
+	}
+	if count != 1 {
+		return nil, errors.New("exactly one of environment, branch, tag or pull_request must be specified")
+	}
+
+	return &Preset{
+		Audience: DefaultAudience,
+		Issuer:   issuer,
+		Subject:  fmt.Sprintf("repo:%s:%s", o.Repository, entity),
+	}, nil
+}
+
+// AzureDevOpsOptions describes an Azure DevOps service connection
+type AzureDevOpsOptions struct {
+	OrganizationId        string
+	OrganizationName      string
+	ProjectName           string
+	ServiceConnectionName string
+}
+
+// AzureDevOps returns the token details for an Azure DevOps service connection using workload identity federation
+func AzureDevOps(o AzureDevOpsOptions) (*Preset, error) {
+	if o.OrganizationId == "" || o.OrganizationName == "" || o.ProjectName == "" || o.ServiceConnectionName == "" {
+		return nil, errors.New("organization_id, organization_name, project_name and service_connection_name must all be specified")
+	}
+
+	return &Preset{
+		Audience: DefaultAudience,
+		Issuer:   fmt.Sprintf(AzureDevOpsIssuerFormat, o.OrganizationId),
+		Subject:  fmt.Sprintf("sc://%s/%s/%s", o.OrganizationName, o.ProjectName, o.ServiceConnectionName),
+	}, nil
+}
+
+// KubernetesOptions describes a Kubernetes service account
+type KubernetesOptions struct {
+	IssuerUrl          string
+	Namespace          string
+	ServiceAccountName string
+}
+
+// Kubernetes returns the token details for a Kubernetes service account using workload identity
+func Kubernetes(o KubernetesOptions) (*Preset, error) {
+	if o.IssuerUrl == "" || o.Namespace == "" || o.ServiceAccountName == "" {
+		return nil, errors.New("issuer_url, namespace and service_account_name must all be specified")
+	}
+
+	return &Preset{
+		Audience: DefaultAudience,
+		Issuer:   o.IssuerUrl,
+		Subject:  fmt.Sprintf("system:serviceaccount:%s:%s", o.Namespace, o.ServiceAccountName),
+	}, nil
+}
+
+// TerraformCloudOptions describes a run phase of an HCP Terraform or Terraform Enterprise workspace
+type TerraformCloudOptions struct {
+	Hostname     string
+	Organization string
+	Project      string
+	Workspace    string
+	RunPhase     string
+}
+
+// TerraformCloud returns the token details for a run phase of an HCP Terraform or Terraform Enterprise workspace using
+// dynamic provider credentials
+func TerraformCloud(o TerraformCloudOptions) (*Preset, error) {
+	if o.Organization == "" || o.Workspace == "" || o.RunPhase == "" {
+		return nil, errors.New("organization, workspace and run_phase must all be specified")
+	}
+
+	hostname := strings.TrimSuffix(strings.TrimPrefix(o.Hostname, "https://"), "/")
+	if hostname == "" {
+		hostname = TerraformCloudDefaultHost
+	}
+
+	project := o.Project
+	if project == "" {
+		project = TerraformCloudDefaultProject
+	}
+
+	return &Preset{
+		Audience: DefaultAudience,
+		Issuer:   fmt.Sprintf("https://%s", hostname),
+		Subject:  fmt.Sprintf("organization:%s:project:%s:workspace:%s:run_phase:%s", o.Organization, project, o.Workspace, o.RunPhase),
+	}, nil
+}
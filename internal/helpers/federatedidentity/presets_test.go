@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package federatedidentity
+
+import (
+	"testing"
+)
+
+func TestGitHub(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Options  GitHubOptions
+		Expected *Preset
+	}{
+		{
+			Name:    "Environment",
+			Options: GitHubOptions{Repository: "my-org/my-repo", Environment: "prod"},
+			Expected: &Preset{
+				Audience: DefaultAudience,
+				Issuer:   "https://token.actions.githubusercontent.com",
+				Subject:  "repo:my-org/my-repo:environment:prod",
+			},
+		},
+		{
+			Name:    "Branch",
+			Options: GitHubOptions{Repository: "my-org/my-repo", Branch: "main"},
+			Expected: &Preset{
+				Audience: DefaultAudience,
+				Issuer:   "https://token.actions.githubusercontent.com",
+				Subject:  "repo:my-org/my-repo:ref:refs/heads/main",
+			},
+		},
+		{
+			Name:    "Tag",
+			Options: GitHubOptions{Repository: "my-org/my-repo", Tag: "v1.0.0"},
+			Expected: &Preset{
+				Audience: DefaultAudience,
+				Issuer:   "https://token.actions.githubusercontent.com",
+				Subject:  "repo:my-org/my-repo:ref:refs/tags/v1.0.0",
+			},
+		},
+		{
+			Name:    "PullRequestWithEnterpriseSlug",
+			Options: GitHubOptions{Repository: "my-org/my-repo", PullRequest: true, EnterpriseSlug: "my-enterprise"},
+			Expected: &Preset{
+				Audience: DefaultAudience,
+				Issuer:   "https://token.actions.githubusercontent.com/my-enterprise",
+				Subject:  "repo:my-org/my-repo:pull_request",
+			},
+		},
+		{
+			Name:    "NoEntity",
+			Options: GitHubOptions{Repository: "my-org/my-repo"},
+		},
+		{
+			Name:    "MultipleEntities",
+			Options: GitHubOptions{Repository: "my-org/my-repo", Environment: "prod", Branch: "main"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := GitHub(tc.Options)
+			comparePreset(t, result, err, tc.Expected)
+		})
+	}
+}
+
+func TestAzureDevOps(t *testing.T) {
+	result, err := AzureDevOps(AzureDevOpsOptions{
+		OrganizationId:        "00000000-0000-0000-0000-000000000000",
+		OrganizationName:      "my-org",
+		ProjectName:           "my-project",
+		ServiceConnectionName: "my-connection",
+	})
+	comparePreset(t, result, err, &Preset{
+		Audience: DefaultAudience,
+		Issuer:   "https://vstoken.dev.azure.com/00000000-0000-0000-0000-000000000000",
+		Subject:  "sc://my-org/my-project/my-connection",
+	})
+}
+
+func TestKubernetes(t *testing.T) {
+	result, err := Kubernetes(KubernetesOptions{
+		IssuerUrl:          "https://oidc.prod-aks.azure.com/00000000-0000-0000-0000-000000000000/",
+		Namespace:          "my-namespace",
+		ServiceAccountName: "my-service-account",
+	})
+	comparePreset(t, result, err, &Preset{
+		Audience: DefaultAudience,
+		Issuer:   "https://oidc.prod-aks.azure.com/00000000-0000-0000-0000-000000000000/",
+		Subject:  "system:serviceaccount:my-namespace:my-service-account",
+	})
+}
+
+func TestTerraformCloud(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Options  TerraformCloudOptions
+		Expected *Preset
+	}{
+		{
+			Name:    "Defaults",
+			Options: TerraformCloudOptions{Organization: "my-org", Workspace: "my-workspace", RunPhase: "plan"},
+			Expected: &Preset{
+				Audience: DefaultAudience,
+				Issuer:   "https://app.terraform.io",
+				Subject:  "organization:my-org:project:Default Project:workspace:my-workspace:run_phase:plan",
+			},
+		},
+		{
+			Name:    "Enterprise",
+			Options: TerraformCloudOptions{Hostname: "https://tfe.example.com/", Organization: "my-org", Project: "my-project", Workspace: "my-workspace", RunPhase: "apply"},
+			Expected: &Preset{
+				Audience: DefaultAudience,
+				Issuer:   "https://tfe.example.com",
+				Subject:  "organization:my-org:project:my-project:workspace:my-workspace:run_phase:apply",
+			},
+		},
+		{
+			Name:    "MissingWorkspace",
+			Options: TerraformCloudOptions{Organization: "my-org", RunPhase: "apply"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := TerraformCloud(tc.Options)
+			comparePreset(t, result, err, tc.Expected)
+		})
+	}
+}
+
+func comparePreset(t *testing.T, result *Preset, err error, expected *Preset) {
+	if expected == nil {
+		if err == nil {
+			t.Fatalf("expected an error, got none")
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *result != *expected {
+		t.Fatalf("expected %+v, got %+v", *expected, *result)
+	}
+}
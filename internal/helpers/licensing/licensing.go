@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package licensing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/msgraph"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/rawrequest"
+)
+
+// Values for the `state` property of a licenseProcessingState, which indicates the progress of group-based licensing
+const (
+	ProcessingStateQueued     = "QueuedForProcessing"
+	ProcessingStateInProgress = "ProcessingInProgress"
+	ProcessingStateComplete   = "ProcessingComplete"
+)
+
+// Values for the `state` property of a licenseAssignmentState
+const (
+	AssignmentStateActive          = "Active"
+	AssignmentStateActiveWithError = "ActiveWithError"
+	AssignmentStateDisabled        = "Disabled"
+	AssignmentStateError           = "Error"
+)
+
+// Values for the `error` property of a licenseAssignmentState
+const (
+	AssignmentErrorCountViolation                     = "CountViolation"
+	AssignmentErrorDependencyViolation                = "DependencyViolation"
+	AssignmentErrorMutuallyExclusiveViolation         = "MutuallyExclusiveViolation"
+	AssignmentErrorNone                               = "None"
+	AssignmentErrorOther                              = "Other"
+	AssignmentErrorProhibitedInUsageLocationViolation = "ProhibitedInUsageLocationViolation"
+	AssignmentErrorUniquenessViolation                = "UniquenessViolation"
+)
+
+var assignmentErrorDescriptions = map[string]string{
+	AssignmentErrorCountViolation:                     "there are not enough available licenses for this SKU",
+	AssignmentErrorDependencyViolation:                "one or more service plans depend on another service plan that is not enabled",
+	AssignmentErrorMutuallyExclusiveViolation:         "the SKU contains a service plan that conflicts with a service plan already assigned to the user",
+	AssignmentErrorProhibitedInUsageLocationViolation: "the SKU is not available in the user's usage location, or the user does not have a usage location",
+	AssignmentErrorUniquenessViolation:                "a proxy address of the user is already in use by another object",
+}
+
+// HasError returns whether the provided license assignment state reports a processing error
+func HasError(state stable.LicenseAssignmentState) bool {
+	if e := state.Error.GetOrZero(); e != "" && e != AssignmentErrorNone {
+		return true
+	}
+	s := state.State.GetOrZero()
+	return s == AssignmentStateError || s == AssignmentStateActiveWithError
+}
+
+// DescribeError returns a human-readable explanation for a license assignment error code
+func DescribeError(code string) string {
+	if description, ok := assignmentErrorDescriptions[code]; ok {
+		return fmt.Sprintf("%s (%s)", code, description)
+	}
+	return code
+}
+
+// FindAssignmentState returns the license assignment state for the specified SKU, which is either assigned directly
+// when `assignedByGroup` is empty, or inherited from the group with the specified object ID
+func FindAssignmentState(states *[]stable.LicenseAssignmentState, skuId, assignedByGroup string) *stable.LicenseAssignmentState {
+	if states == nil {
+		return nil
+	}
+	for _, state := range *states {
+		if !strings.EqualFold(state.SkuId.GetOrZero(), skuId) {
+			continue
+		}
+		if !strings.EqualFold(state.AssignedByGroup.GetOrZero(), assignedByGroup) {
+			continue
+		}
+		s := state
+		return &s
+	}
+	return nil
+}
+
+// FindAssignedLicense returns the assigned license for the specified SKU from a list of assigned licenses
+func FindAssignedLicense(licenses *[]stable.AssignedLicense, skuId string) *stable.AssignedLicense {
+	if licenses == nil {
+		return nil
+	}
+	for _, license := range *licenses {
+		if strings.EqualFold(license.SkuId.GetOrZero(), skuId) {
+			l := license
+			return &l
+		}
+	}
+	return nil
+}
+
+// DisabledPlansMatch returns whether two sets of disabled service plan IDs are equivalent
+func DisabledPlansMatch(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	normalize := func(in []string) []string {
+		out := make([]string, 0, len(in))
+		for _, v := range in {
+			out = append(out, strings.ToLower(v))
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	x, y := normalize(a), normalize(b)
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ListSubscribedSkus retrieves all commercial subscriptions that the tenant has acquired
+func ListSubscribedSkus(ctx context.Context, c *msgraph.Client) ([]stable.SubscribedSku, error) {
+	var model struct {
+		Value []stable.SubscribedSku `json:"value"`
+	}
+	if _, err := rawrequest.Do(ctx, c, rawrequest.Options{
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       "/subscribedSkus",
+	}, nil, &model); err != nil {
+		return nil, err
+	}
+
+	return model.Value, nil
+}
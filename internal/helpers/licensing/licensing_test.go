@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package licensing
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+)
+
+const (
+	testSkuId   = "6fd2c87f-b296-42f0-b197-1e91e994b900"
+	testGroupId = "3b4a6d47-48a9-4d0e-9a2f-2b35b5f3d5a1"
+)
+
+func TestFindAssignmentState(t *testing.T) {
+	states := &[]stable.LicenseAssignmentState{
+		{
+			SkuId:           nullable.Value(testSkuId),
+			AssignedByGroup: nullable.Value(testGroupId),
+			State:           nullable.Value(AssignmentStateError),
+			Error:           nullable.Value(AssignmentErrorCountViolation),
+		},
+		{
+			SkuId: nullable.Value("6FD2C87F-B296-42F0-B197-1E91E994B900"),
+			State: nullable.Value(AssignmentStateActive),
+			Error: nullable.Value(AssignmentErrorNone),
+		},
+	}
+
+	direct := FindAssignmentState(states, testSkuId, "")
+	if direct == nil {
+		t.Fatalf("expected to find a direct assignment")
+	}
+	if HasError(*direct) {
+		t.Fatalf("expected direct assignment not to have errors")
+	}
+
+	inherited := FindAssignmentState(states, testSkuId, testGroupId)
+	if inherited == nil {
+		t.Fatalf("expected to find an inherited assignment")
+	}
+	if !HasError(*inherited) {
+		t.Fatalf("expected inherited assignment to have errors")
+	}
+
+	if FindAssignmentState(states, "c42b9cae-ea4f-4ab7-9717-81576235ccac", "") != nil {
+		t.Fatalf("expected not to find an assignment for an unassigned SKU")
+	}
+}
+
+func TestDisabledPlansMatch(t *testing.T) {
+	cases := []struct {
+		A, B     []string
+		Expected bool
+	}{
+		{A: nil, B: []string{}, Expected: true},
+		{A: []string{"a", "b"}, B: []string{"B", "A"}, Expected: true},
+		{A: []string{"a"}, B: []string{"a", "b"}, Expected: false},
+		{A: []string{"a", "c"}, B: []string{"a", "b"}, Expected: false},
+	}
+
+	for _, tc := range cases {
+		if actual := DisabledPlansMatch(tc.A, tc.B); actual != tc.Expected {
+			t.Fatalf("expected DisabledPlansMatch(%v, %v) to be %t, got %t", tc.A, tc.B, tc.Expected, actual)
+		}
+	}
+}
+
+func TestDescribeError(t *testing.T) {
+	if actual := DescribeError(AssignmentErrorOther); actual != AssignmentErrorOther {
+		t.Fatalf("expected %q, got %q", AssignmentErrorOther, actual)
+	}
+	if actual := DescribeError(AssignmentErrorCountViolation); actual == AssignmentErrorCountViolation {
+		t.Fatalf("expected a description for %q", AssignmentErrorCountViolation)
+	}
+}
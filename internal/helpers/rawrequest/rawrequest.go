@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rawrequest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/msgraph"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// Options describes a request made directly against the Microsoft Graph API, for operations and properties that are
+// not exposed by the SDK
+type Options struct {
+	// ExpectedStatusCodes are the HTTP status codes that indicate a successful response
+	ExpectedStatusCodes []int
+
+	// HttpMethod is the HTTP method for the request
+	HttpMethod string
+
+	// Path is the path of the request, relative to the API version of the client, e.g. `/users/00000000-0000-0000-0000-000000000000`
+	Path string
+
+	// Select optionally limits the properties returned in the response
+	Select []string
+}
+
+// requestOptions implements client.Options for a request without additional headers or query parameters
+type requestOptions struct {
+	selectFields []string
+}
+
+func (o requestOptions) ToHeaders() *client.Headers {
+	return &client.Headers{}
+}
+
+func (o requestOptions) ToOData() *odata.Query {
+	return &odata.Query{
+		Select: o.selectFields,
+	}
+}
+
+func (o requestOptions) ToQuery() *client.QueryParams {
+	return &client.QueryParams{}
+}
+
+// Do builds and executes a request. When `input` is not nil, it is marshaled as the request body, and when `output` is
+// not nil, the response body is unmarshaled into it. The HTTP response is returned whenever one was received, so that
+// callers can inspect the status code of a failed request.
+func Do(ctx context.Context, c *msgraph.Client, opts Options, input, output interface{}) (*http.Response, error) {
+	req, err := c.NewRequest(ctx, client.RequestOptions{
+		ContentType:         "application/json; charset=utf-8",
+		ExpectedStatusCodes: opts.ExpectedStatusCodes,
+		HttpMethod:          opts.HttpMethod,
+		OptionsObject:       requestOptions{selectFields: opts.Select},
+		Path:                opts.Path,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building request: %+v", err)
+	}
+
+	if input != nil {
+		if err = req.Marshal(input); err != nil {
+			return nil, fmt.Errorf("marshaling request: %+v", err)
+		}
+	}
+
+	resp, err := req.Execute(ctx)
+	var httpResp *http.Response
+	if resp != nil {
+		httpResp = resp.Response
+	}
+	if err != nil {
+		return httpResp, fmt.Errorf("executing request: %+v", err)
+	}
+
+	if output != nil {
+		if err = resp.Unmarshal(output); err != nil {
+			return httpResp, fmt.Errorf("unmarshaling response: %+v", err)
+		}
+	}
+
+	return httpResp, nil
+}
@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// ClaimsMatchingExpressionLanguageVersion is the only supported version of the claims matching expression language
+// used by flexible federated identity credentials
+const ClaimsMatchingExpressionLanguageVersion = 1
+
+type claimsMatchingTokenKind int
+
+const (
+	claimsMatchingTokenEOF claimsMatchingTokenKind = iota
+	claimsMatchingTokenIdentifier
+	claimsMatchingTokenString
+	claimsMatchingTokenOpenBracket
+	claimsMatchingTokenCloseBracket
+)
+
+type claimsMatchingToken struct {
+	Kind     claimsMatchingTokenKind
+	Value    string
+	Position int
+}
+
+func (t claimsMatchingToken) describe() string {
+	switch t.Kind {
+	case claimsMatchingTokenEOF:
+		return "end of expression"
+	case claimsMatchingTokenString:
+		return fmt.Sprintf("string '%s'", t.Value)
+	}
+	return fmt.Sprintf("%q", t.Value)
+}
+
+func claimsMatchingTokenize(input string) ([]claimsMatchingToken, error) {
+	tokens := make([]claimsMatchingToken, 0)
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '[':
+			tokens = append(tokens, claimsMatchingToken{Kind: claimsMatchingTokenOpenBracket, Value: "[", Position: i})
+			i++
+
+		case r == ']':
+			tokens = append(tokens, claimsMatchingToken{Kind: claimsMatchingTokenCloseBracket, Value: "]", Position: i})
+			i++
+
+		case r == '\'':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != '\'' {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string starting at position %d", start)
+			}
+			tokens = append(tokens, claimsMatchingToken{Kind: claimsMatchingTokenString, Value: string(runes[start+1 : i]), Position: start})
+			i++
+
+		case r == '"':
+			return nil, fmt.Errorf("strings must be enclosed in single quotes, found double quote at position %d", i)
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, claimsMatchingToken{Kind: claimsMatchingTokenIdentifier, Value: string(runes[start:i]), Position: start})
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+		}
+	}
+
+	return append(tokens, claimsMatchingToken{Kind: claimsMatchingTokenEOF, Position: len(runes)}), nil
+}
+
+// ClaimsMatchingComparison is a single comparison of a token claim within a claims matching expression
+type ClaimsMatchingComparison struct {
+	Claim    string
+	Operator string
+	Value    string
+}
+
+// ParseClaimsMatchingExpression parses a claims matching expression for a flexible federated identity credential,
+// returning the comparisons it contains or an error describing the first problem found with the expression.
+// Expressions are made up of one or more comparisons joined with `and`, where each comparison is in the form
+// `claims['name'] eq 'value'` or `claims['name'] matches 'pattern'`, and patterns may contain `*` wildcards.
+func ParseClaimsMatchingExpression(input string) ([]ClaimsMatchingComparison, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, fmt.Errorf("expression cannot be empty")
+	}
+
+	tokens, err := claimsMatchingTokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	pos := 0
+	next := func() claimsMatchingToken {
+		t := tokens[pos]
+		if t.Kind != claimsMatchingTokenEOF {
+			pos++
+		}
+		return t
+	}
+	expect := func(kind claimsMatchingTokenKind, description string) (claimsMatchingToken, error) {
+		t := next()
+		if t.Kind != kind {
+			return t, fmt.Errorf("expected %s at position %d, found %s", description, t.Position, t.describe())
+		}
+		return t, nil
+	}
+
+	result := make([]ClaimsMatchingComparison, 0)
+	seen := make(map[string]bool)
+
+	for {
+		t, err := expect(claimsMatchingTokenIdentifier, "`claims`")
+		if err != nil {
+			return nil, err
+		}
+		if t.Value != "claims" {
+			return nil, fmt.Errorf("expected `claims` at position %d, found %s", t.Position, t.describe())
+		}
+
+		if _, err = expect(claimsMatchingTokenOpenBracket, "`[`"); err != nil {
+			return nil, err
+		}
+
+		claim, err := expect(claimsMatchingTokenString, "a quoted claim name")
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(claim.Value) == "" {
+			return nil, fmt.Errorf("claim name at position %d cannot be empty", claim.Position)
+		}
+
+		if _, err = expect(claimsMatchingTokenCloseBracket, "`]`"); err != nil {
+			return nil, err
+		}
+
+		operator, err := expect(claimsMatchingTokenIdentifier, "an operator")
+		if err != nil {
+			return nil, err
+		}
+		switch operator.Value {
+		case "eq", "matches":
+		default:
+			return nil, fmt.Errorf("unsupported operator %s at position %d, expected `eq` or `matches`", operator.describe(), operator.Position)
+		}
+
+		value, err := expect(claimsMatchingTokenString, "a quoted value")
+		if err != nil {
+			return nil, err
+		}
+		if value.Value == "" {
+			return nil, fmt.Errorf("value at position %d cannot be empty", value.Position)
+		}
+		if operator.Value == "eq" && strings.Contains(value.Value, "*") {
+			return nil, fmt.Errorf("wildcards are not supported with the `eq` operator at position %d, use `matches` instead", value.Position)
+		}
+
+		if seen[claim.Value] {
+			return nil, fmt.Errorf("claim '%s' is compared more than once", claim.Value)
+		}
+		seen[claim.Value] = true
+
+		result = append(result, ClaimsMatchingComparison{
+			Claim:    claim.Value,
+			Operator: operator.Value,
+			Value:    value.Value,
+		})
+
+		t = next()
+		if t.Kind == claimsMatchingTokenEOF {
+			break
+		}
+		if t.Kind != claimsMatchingTokenIdentifier || t.Value != "and" {
+			if t.Kind == claimsMatchingTokenIdentifier && t.Value == "or" {
+				return nil, fmt.Errorf("the `or` operator is not supported at position %d, create a separate credential instead", t.Position)
+			}
+			return nil, fmt.Errorf("expected `and` or end of expression at position %d, found %s", t.Position, t.describe())
+		}
+	}
+
+	return result, nil
+}
+
+// IsClaimsMatchingExpression validates the syntax of a claims matching expression for a flexible federated identity
+// credential
+func IsClaimsMatchingExpression(i interface{}, path cty.Path) (ret diag.Diagnostics) {
+	v, ok := i.(string)
+	if !ok {
+		ret = append(ret, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Expected a string value",
+			AttributePath: path,
+		})
+		return
+	}
+
+	if _, err := ParseClaimsMatchingExpression(v); err != nil {
+		ret = append(ret, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Invalid claims matching expression",
+			Detail:        fmt.Sprintf("Parsing %q: %v", v, err),
+			AttributePath: path,
+		})
+	}
+
+	return
+}
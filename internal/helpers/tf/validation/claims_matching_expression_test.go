@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestIsClaimsMatchingExpression(t *testing.T) {
+	cases := []struct {
+		Value    string
+		TestName string
+		ErrCount int
+	}{
+		{
+			Value:    `claims['sub'] matches 'repo:contoso/contoso-app:*'`,
+			TestName: "Matches",
+			ErrCount: 0,
+		},
+		{
+			Value:    `claims['sub'] eq 'repo:contoso/contoso-app:environment:prod'`,
+			TestName: "Equals",
+			ErrCount: 0,
+		},
+		{
+			Value:    `claims['sub'] matches 'repo:contoso/contoso-app:ref:refs/heads/*' and claims['job_workflow_ref'] eq 'contoso/workflows/.github/workflows/deploy.yml@refs/heads/main'`,
+			TestName: "And",
+			ErrCount: 0,
+		},
+		{
+			Value:    "  claims [ 'sub' ]   matches\n'system:serviceaccount:*:deployer'  ",
+			TestName: "Whitespace",
+			ErrCount: 0,
+		},
+		{
+			Value:    ``,
+			TestName: "Empty",
+			ErrCount: 1,
+		},
+		{
+			Value:    `claims["sub"] matches 'repo:contoso/*'`,
+			TestName: "DoubleQuotes",
+			ErrCount: 1,
+		},
+		{
+			Value:    `claims['sub'] matches 'repo:contoso/*`,
+			TestName: "Unterminated",
+			ErrCount: 1,
+		},
+		{
+			Value:    `claim['sub'] matches 'repo:contoso/*'`,
+			TestName: "WrongKeyword",
+			ErrCount: 1,
+		},
+		{
+			Value:    `claims['sub'] ne 'repo:contoso/app'`,
+			TestName: "UnsupportedOperator",
+			ErrCount: 1,
+		},
+		{
+			Value:    `claims['sub'] eq 'repo:contoso/*'`,
+			TestName: "WildcardWithEquals",
+			ErrCount: 1,
+		},
+		{
+			Value:    `claims['sub'] matches 'repo:contoso/a:*' or claims['sub'] matches 'repo:contoso/b:*'`,
+			TestName: "Or",
+			ErrCount: 1,
+		},
+		{
+			Value:    `claims['sub'] matches 'repo:contoso/a:*' and claims['sub'] matches 'repo:contoso/b:*'`,
+			TestName: "DuplicateClaim",
+			ErrCount: 1,
+		},
+		{
+			Value:    `claims[''] eq 'foo'`,
+			TestName: "EmptyClaim",
+			ErrCount: 1,
+		},
+		{
+			Value:    `claims['sub'] matches ''`,
+			TestName: "EmptyValue",
+			ErrCount: 1,
+		},
+		{
+			Value:    `claims['sub'] matches 'repo:contoso/*' and`,
+			TestName: "TrailingAnd",
+			ErrCount: 1,
+		},
+		{
+			Value:    `claims['sub'] matches 'repo:contoso/*' claims['aud'] eq 'api://AzureADTokenExchange'`,
+			TestName: "MissingAnd",
+			ErrCount: 1,
+		},
+		{
+			Value:    `(claims['sub'] matches 'repo:contoso/*')`,
+			TestName: "Parentheses",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			diags := IsClaimsMatchingExpression(tc.Value, cty.Path{})
+
+			if len(diags) != tc.ErrCount {
+				t.Fatalf("Expected %d errors for %q but got %d: %v", tc.ErrCount, tc.Value, len(diags), diags)
+			}
+		})
+	}
+}
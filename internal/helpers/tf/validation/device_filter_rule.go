@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// deviceFilterProperties contains the lower-cased device properties supported in device filters for conditional access
+// policies, which differ from those supported in dynamic membership rules
+var deviceFilterProperties = map[string]dynamicMembershipPropertyType{
+	"deviceid":               dynamicMembershipPropertyTypeString,
+	"deviceownership":        dynamicMembershipPropertyTypeString,
+	"displayname":            dynamicMembershipPropertyTypeString,
+	"enrollmentprofilename":  dynamicMembershipPropertyTypeString,
+	"iscompliant":            dynamicMembershipPropertyTypeBool,
+	"manufacturer":           dynamicMembershipPropertyTypeString,
+	"mdmappid":               dynamicMembershipPropertyTypeString,
+	"model":                  dynamicMembershipPropertyTypeString,
+	"operatingsystem":        dynamicMembershipPropertyTypeString,
+	"operatingsystemversion": dynamicMembershipPropertyTypeString,
+	"physicalids":            dynamicMembershipPropertyTypeStringCollection,
+	"profiletype":            dynamicMembershipPropertyTypeString,
+	"systemlabels":           dynamicMembershipPropertyTypeStringCollection,
+	"trusttype":              dynamicMembershipPropertyTypeString,
+}
+
+// ParseDeviceFilterRule parses the rule for a device filter in a conditional access policy, returning an error
+// describing the first problem found with the rule. Device filter rules share their syntax with dynamic membership rules,
+// so the result can be evaluated in the same way.
+func ParseDeviceFilterRule(input string) (*DynamicMembershipRule, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, fmt.Errorf("rule cannot be empty")
+	}
+
+	tokens, err := dynamicMembershipTokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dynamicMembershipParser{tokens: tokens, deviceFilter: true}
+	expression, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.Kind != dynamicMembershipTokenEOF {
+		return nil, p.errorf(t, "unexpected %s", t.describe())
+	}
+
+	return &DynamicMembershipRule{
+		ObjectType: "device",
+		expression: expression,
+	}, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"testing"
+)
+
+func TestParseDeviceFilterRule(t *testing.T) {
+	cases := []struct {
+		Value    string
+		TestName string
+		Error    bool
+	}{
+		{
+			Value:    `device.trustType -eq "ServerAD"`,
+			TestName: "TrustType",
+		},
+		{
+			Value:    `device.isCompliant -eq true -and device.operatingSystem -startsWith "Windows"`,
+			TestName: "CompliantAndOperatingSystem",
+		},
+		{
+			Value:    `device.extensionAttribute1 -eq "SAW"`,
+			TestName: "ExtensionAttribute",
+		},
+		{
+			Value:    `device.systemLabels -any (_ -eq "Kiosk")`,
+			TestName: "SystemLabels",
+		},
+		{
+			Value:    `device.systemLabels -contains "M365Managed" and device.trustType eq "AzureAD"`,
+			TestName: "SystemLabelsContainsHyphenless",
+		},
+		{
+			Value:    `device.deviceOSType -eq "Windows"`,
+			TestName: "DynamicMembershipOnlyProperty",
+			Error:    true,
+		},
+		{
+			Value:    `user.department -eq "Sales"`,
+			TestName: "UserProperty",
+			Error:    true,
+		},
+		{
+			Value:    `device.extension_0123456789abcdef0123456789abcdef_tier -eq "1"`,
+			TestName: "ExtensionProperty",
+			Error:    true,
+		},
+		{
+			Value:    "",
+			TestName: "Empty",
+			Error:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			_, err := ParseDeviceFilterRule(tc.Value)
+			if tc.Error && err == nil {
+				t.Fatalf("Expected an error parsing %q, got none", tc.Value)
+			}
+			if !tc.Error && err != nil {
+				t.Fatalf("Unexpected error parsing %q: %v", tc.Value, err)
+			}
+		})
+	}
+}
+
+func TestDeviceFilterRuleEvaluate(t *testing.T) {
+	device := DynamicMembershipRuleSubject{
+		Attributes: map[string]string{
+			"isCompliant":     "True",
+			"operatingSystem": "Windows",
+			"trustType":       "AzureAD",
+		},
+	}
+
+	cases := []struct {
+		Rule     string
+		Expected bool
+	}{
+		{Rule: `device.isCompliant -eq true`, Expected: true},
+		{Rule: `device.trustType -eq "ServerAD"`, Expected: false},
+		{Rule: `device.trustType -in ["AzureAD", "ServerAD"] -and device.operatingSystem -eq "windows"`, Expected: true},
+		{Rule: `device.model -eq null`, Expected: true},
+	}
+
+	for _, tc := range cases {
+		rule, err := ParseDeviceFilterRule(tc.Rule)
+		if err != nil {
+			t.Fatalf("parsing rule %q: %v", tc.Rule, err)
+		}
+
+		actual, err := rule.Evaluate(device)
+		if err != nil {
+			t.Fatalf("evaluating rule %q: %v", tc.Rule, err)
+		}
+		if actual != tc.Expected {
+			t.Fatalf("expected rule %q to evaluate to %t, got %t", tc.Rule, tc.Expected, actual)
+		}
+	}
+}
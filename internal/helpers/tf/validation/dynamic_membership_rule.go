@@ -0,0 +1,874 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// DynamicMembershipRuleMaxLength is the maximum length of a dynamic membership rule accepted by the API
+const DynamicMembershipRuleMaxLength = 3072
+
+type dynamicMembershipPropertyType int
+
+const (
+	dynamicMembershipPropertyTypeString dynamicMembershipPropertyType = iota
+	dynamicMembershipPropertyTypeBool
+	dynamicMembershipPropertyTypeDateTime
+	dynamicMembershipPropertyTypeStringCollection
+	dynamicMembershipPropertyTypeObjectCollection
+
+	// dynamicMembershipPropertyTypeUnknown is used for properties that are not known to the provider, which are passed
+	// through to the API without type checking
+	dynamicMembershipPropertyTypeUnknown
+)
+
+// dynamicMembershipObjectCollections maps multi-valued properties containing objects, to the name used to refer to
+// each element, and the element properties that can be referenced within an `-any` or `-all` expression. The Key is
+// the element property that is used to identify each element when evaluating rules locally.
+var dynamicMembershipObjectCollections = map[string]struct {
+	Element    string
+	Properties []string
+	Key        string
+}{
+	"assignedplans": {
+		Element:    "assignedplan",
+		Properties: []string{"capabilitystatus", "service", "serviceplanid"},
+		Key:        "serviceplanid",
+	},
+	"memberof": {
+		Element:    "group",
+		Properties: []string{"objectid"},
+		Key:        "objectid",
+	},
+}
+
+var dynamicMembershipUserProperties = map[string]dynamicMembershipPropertyType{
+	"accountenabled":               dynamicMembershipPropertyTypeBool,
+	"assignedplans":                dynamicMembershipPropertyTypeObjectCollection,
+	"city":                         dynamicMembershipPropertyTypeString,
+	"companyname":                  dynamicMembershipPropertyTypeString,
+	"country":                      dynamicMembershipPropertyTypeString,
+	"department":                   dynamicMembershipPropertyTypeString,
+	"dirsyncenabled":               dynamicMembershipPropertyTypeBool,
+	"displayname":                  dynamicMembershipPropertyTypeString,
+	"employeehiredate":             dynamicMembershipPropertyTypeDateTime,
+	"employeeid":                   dynamicMembershipPropertyTypeString,
+	"employeeorgdata.costcenter":   dynamicMembershipPropertyTypeString,
+	"employeeorgdata.division":     dynamicMembershipPropertyTypeString,
+	"employeetype":                 dynamicMembershipPropertyTypeString,
+	"facsimiletelephonenumber":     dynamicMembershipPropertyTypeString,
+	"givenname":                    dynamicMembershipPropertyTypeString,
+	"jobtitle":                     dynamicMembershipPropertyTypeString,
+	"mail":                         dynamicMembershipPropertyTypeString,
+	"mailnickname":                 dynamicMembershipPropertyTypeString,
+	"memberof":                     dynamicMembershipPropertyTypeObjectCollection,
+	"mobile":                       dynamicMembershipPropertyTypeString,
+	"objectid":                     dynamicMembershipPropertyTypeString,
+	"onpremisesdistinguishedname":  dynamicMembershipPropertyTypeString,
+	"onpremisessamaccountname":     dynamicMembershipPropertyTypeString,
+	"onpremisessecurityidentifier": dynamicMembershipPropertyTypeString,
+	"onpremisesuserprincipalname":  dynamicMembershipPropertyTypeString,
+	"othermails":                   dynamicMembershipPropertyTypeStringCollection,
+	"passwordpolicies":             dynamicMembershipPropertyTypeString,
+	"physicaldeliveryofficename":   dynamicMembershipPropertyTypeString,
+	"postalcode":                   dynamicMembershipPropertyTypeString,
+	"preferredlanguage":            dynamicMembershipPropertyTypeString,
+	"proxyaddresses":               dynamicMembershipPropertyTypeStringCollection,
+	"sipproxyaddress":              dynamicMembershipPropertyTypeString,
+	"state":                        dynamicMembershipPropertyTypeString,
+	"streetaddress":                dynamicMembershipPropertyTypeString,
+	"surname":                      dynamicMembershipPropertyTypeString,
+	"telephonenumber":              dynamicMembershipPropertyTypeString,
+	"usagelocation":                dynamicMembershipPropertyTypeString,
+	"userprincipalname":            dynamicMembershipPropertyTypeString,
+	"usertype":                     dynamicMembershipPropertyTypeString,
+}
+
+var dynamicMembershipDeviceProperties = map[string]dynamicMembershipPropertyType{
+	"accountenabled":        dynamicMembershipPropertyTypeBool,
+	"devicecategory":        dynamicMembershipPropertyTypeString,
+	"deviceid":              dynamicMembershipPropertyTypeString,
+	"devicemanagementappid": dynamicMembershipPropertyTypeString,
+	"devicemanufacturer":    dynamicMembershipPropertyTypeString,
+	"devicemodel":           dynamicMembershipPropertyTypeString,
+	"deviceostype":          dynamicMembershipPropertyTypeString,
+	"deviceosversion":       dynamicMembershipPropertyTypeString,
+	"deviceownership":       dynamicMembershipPropertyTypeString,
+	"devicephysicalids":     dynamicMembershipPropertyTypeStringCollection,
+	"devicetrusttype":       dynamicMembershipPropertyTypeString,
+	"displayname":           dynamicMembershipPropertyTypeString,
+	"enrollmentprofilename": dynamicMembershipPropertyTypeString,
+	"isrooted":              dynamicMembershipPropertyTypeBool,
+	"managementtype":        dynamicMembershipPropertyTypeString,
+	"memberof":              dynamicMembershipPropertyTypeObjectCollection,
+	"objectid":              dynamicMembershipPropertyTypeString,
+	"organizationalunit":    dynamicMembershipPropertyTypeString,
+	"profiletype":           dynamicMembershipPropertyTypeString,
+	"systemlabels":          dynamicMembershipPropertyTypeStringCollection,
+}
+
+var (
+	dynamicMembershipExtensionAttributeRegex = regexp.MustCompile(`^extensionattribute([1-9]|1[0-5])$`)
+	dynamicMembershipExtensionPropertyRegex  = regexp.MustCompile(`^extension_[0-9a-f]{32}_[a-z0-9_]+$`)
+)
+
+// dynamicMembershipOperators maps the lower-cased comparison operators to their canonical names
+var dynamicMembershipOperators = map[string]string{
+	"-all":           "-all",
+	"-any":           "-any",
+	"-contains":      "-contains",
+	"-eq":            "-eq",
+	"-ge":            "-ge",
+	"-gt":            "-gt",
+	"-in":            "-in",
+	"-le":            "-le",
+	"-lt":            "-lt",
+	"-match":         "-match",
+	"-ne":            "-ne",
+	"-notcontains":   "-notContains",
+	"-notin":         "-notIn",
+	"-notmatch":      "-notMatch",
+	"-notstartswith": "-notStartsWith",
+	"-startswith":    "-startsWith",
+}
+
+// dynamicMembershipKeywordOperators contains the lower-cased operators that can also be written without a leading
+// hyphen, e.g. `user.department eq "Sales" and user.country eq "US"`
+var dynamicMembershipKeywordOperators = map[string]struct{}{
+	"all": {}, "and": {}, "any": {}, "contains": {}, "eq": {}, "ge": {}, "gt": {}, "in": {}, "le": {}, "lt": {},
+	"match": {}, "minus": {}, "ne": {}, "not": {}, "notcontains": {}, "notin": {}, "notmatch": {},
+	"notstartswith": {}, "or": {}, "plus": {}, "startswith": {},
+}
+
+var dynamicMembershipStringOperators = []string{
+	"-contains", "-eq", "-in", "-match", "-ne", "-notContains", "-notIn", "-notMatch", "-notStartsWith", "-startsWith",
+}
+
+// DynamicMembershipRule is a parsed dynamic membership rule for a group or administrative unit
+type DynamicMembershipRule struct {
+	// ObjectType is the type of object the rule applies to, either `user` or `device`
+	ObjectType string
+
+	// DirectReportsFor is populated with the manager object ID when the rule is a "Direct Reports" rule
+	DirectReportsFor *string
+
+	// UnknownProperties contains any properties referenced by the rule that are not known to the provider
+	UnknownProperties []string
+
+	expression dynamicMembershipExpression
+}
+
+type dynamicMembershipExpression interface {
+	String() string
+}
+
+type dynamicMembershipLogicalExpression struct {
+	Operator string
+	Left     dynamicMembershipExpression
+	Right    dynamicMembershipExpression
+}
+
+func (e dynamicMembershipLogicalExpression) String() string {
+	return fmt.Sprintf("(%s %s %s)", e.Left, e.Operator, e.Right)
+}
+
+type dynamicMembershipNotExpression struct {
+	Expression dynamicMembershipExpression
+}
+
+func (e dynamicMembershipNotExpression) String() string {
+	return fmt.Sprintf("-not %s", e.Expression)
+}
+
+type dynamicMembershipComparison struct {
+	Property     dynamicMembershipProperty
+	Operator     string
+	Value        *dynamicMembershipValue
+	ElementMatch dynamicMembershipExpression
+}
+
+func (e dynamicMembershipComparison) String() string {
+	if e.ElementMatch != nil {
+		return fmt.Sprintf("%s %s (%s)", e.Property, e.Operator, e.ElementMatch)
+	}
+	return fmt.Sprintf("%s %s %s", e.Property, e.Operator, e.Value)
+}
+
+type dynamicMembershipProperty struct {
+	// Object is the lower-cased object prefix, e.g. `user`, `device`, `group`, or `_` for an element reference
+	Object string
+
+	// Name is the lower-cased property name, without the object prefix
+	Name string
+
+	// Raw is the property as written in the rule
+	Raw string
+
+	// Type is the type of the property
+	Type dynamicMembershipPropertyType
+
+	// Element is true when the property refers to an element of a multi-valued property
+	Element bool
+}
+
+func (p dynamicMembershipProperty) String() string {
+	return p.Raw
+}
+
+type dynamicMembershipValueKind int
+
+const (
+	dynamicMembershipValueString dynamicMembershipValueKind = iota
+	dynamicMembershipValueNumber
+	dynamicMembershipValueBool
+	dynamicMembershipValueNull
+	dynamicMembershipValueList
+	dynamicMembershipValueNow
+)
+
+type dynamicMembershipValue struct {
+	Kind   dynamicMembershipValueKind
+	String string
+	Number float64
+	Bool   bool
+	List   []dynamicMembershipValue
+
+	// OffsetOperator and Offset hold the optional date arithmetic following `system.now`, e.g. `-plus` and `p1d`
+	OffsetOperator string
+	Offset         string
+}
+
+func (v *dynamicMembershipValue) stringValue() string {
+	switch v.Kind {
+	case dynamicMembershipValueString:
+		return strconv.Quote(v.String)
+	case dynamicMembershipValueNumber:
+		return strconv.FormatFloat(v.Number, 'f', -1, 64)
+	case dynamicMembershipValueBool:
+		return strconv.FormatBool(v.Bool)
+	case dynamicMembershipValueNull:
+		return "null"
+	case dynamicMembershipValueList:
+		items := make([]string, 0, len(v.List))
+		for _, item := range v.List {
+			items = append(items, item.stringValue())
+		}
+		return fmt.Sprintf("[%s]", strings.Join(items, ", "))
+	case dynamicMembershipValueNow:
+		if v.OffsetOperator != "" {
+			return fmt.Sprintf("system.now %s %s", v.OffsetOperator, v.Offset)
+		}
+		return "system.now"
+	}
+	return ""
+}
+
+func (v *dynamicMembershipValue) Format(f fmt.State, _ rune) {
+	_, _ = f.Write([]byte(v.stringValue()))
+}
+
+type dynamicMembershipTokenKind int
+
+const (
+	dynamicMembershipTokenEOF dynamicMembershipTokenKind = iota
+	dynamicMembershipTokenIdentifier
+	dynamicMembershipTokenOperator
+	dynamicMembershipTokenString
+	dynamicMembershipTokenNumber
+	dynamicMembershipTokenLeftParen
+	dynamicMembershipTokenRightParen
+	dynamicMembershipTokenLeftBracket
+	dynamicMembershipTokenRightBracket
+	dynamicMembershipTokenComma
+)
+
+type dynamicMembershipToken struct {
+	Kind     dynamicMembershipTokenKind
+	Value    string
+	Position int
+}
+
+func (t dynamicMembershipToken) describe() string {
+	switch t.Kind {
+	case dynamicMembershipTokenEOF:
+		return "end of rule"
+	case dynamicMembershipTokenString:
+		return fmt.Sprintf("string %q", t.Value)
+	default:
+		return fmt.Sprintf("%q", t.Value)
+	}
+}
+
+func dynamicMembershipTokenize(input string) ([]dynamicMembershipToken, error) {
+	tokens := make([]dynamicMembershipToken, 0)
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, dynamicMembershipToken{Kind: dynamicMembershipTokenLeftParen, Value: "(", Position: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, dynamicMembershipToken{Kind: dynamicMembershipTokenRightParen, Value: ")", Position: i})
+			i++
+		case r == '[':
+			tokens = append(tokens, dynamicMembershipToken{Kind: dynamicMembershipTokenLeftBracket, Value: "[", Position: i})
+			i++
+		case r == ']':
+			tokens = append(tokens, dynamicMembershipToken{Kind: dynamicMembershipTokenRightBracket, Value: "]", Position: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, dynamicMembershipToken{Kind: dynamicMembershipTokenComma, Value: ",", Position: i})
+			i++
+
+		case r == '"' || r == '\'':
+			// Quotes within a string are escaped with a backtick, e.g. "Sales`"Team"
+			quote, start := r, i
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '`' && i+1 < len(runes) {
+					sb.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				if runes[i] == quote {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated string starting at position %d", start+1)
+			}
+			tokens = append(tokens, dynamicMembershipToken{Kind: dynamicMembershipTokenString, Value: sb.String(), Position: start})
+
+		case r == '-' && i+1 < len(runes) && unicode.IsLetter(runes[i+1]):
+			start := i
+			i++
+			for i < len(runes) && unicode.IsLetter(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, dynamicMembershipToken{Kind: dynamicMembershipTokenOperator, Value: string(runes[start:i]), Position: start})
+
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, dynamicMembershipToken{Kind: dynamicMembershipTokenNumber, Value: string(runes[start:i]), Position: start})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			word := string(runes[start:i])
+
+			// Operators can be written without a leading hyphen, these are normalised to their hyphenated form
+			if _, ok := dynamicMembershipKeywordOperators[strings.ToLower(word)]; ok {
+				tokens = append(tokens, dynamicMembershipToken{Kind: dynamicMembershipTokenOperator, Value: "-" + word, Position: start})
+				continue
+			}
+
+			tokens = append(tokens, dynamicMembershipToken{Kind: dynamicMembershipTokenIdentifier, Value: word, Position: start})
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, i+1)
+		}
+	}
+
+	tokens = append(tokens, dynamicMembershipToken{Kind: dynamicMembershipTokenEOF, Position: len(runes)})
+	return tokens, nil
+}
+
+type dynamicMembershipParser struct {
+	tokens     []dynamicMembershipToken
+	pos        int
+	objectType string
+
+	// deviceFilter restricts properties to those supported in device filters for conditional access policies
+	deviceFilter bool
+
+	// collection is the multi-valued property being matched when parsing the inner expression of `-any` or `-all`
+	collection *dynamicMembershipProperty
+
+	// unknownProperties contains the properties encountered that are not known to the provider
+	unknownProperties []string
+}
+
+func (p *dynamicMembershipParser) peek() dynamicMembershipToken {
+	return p.tokens[p.pos]
+}
+
+func (p *dynamicMembershipParser) next() dynamicMembershipToken {
+	t := p.tokens[p.pos]
+	if t.Kind != dynamicMembershipTokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *dynamicMembershipParser) errorf(t dynamicMembershipToken, format string, a ...interface{}) error {
+	return fmt.Errorf("%s at position %d", fmt.Sprintf(format, a...), t.Position+1)
+}
+
+func (p *dynamicMembershipParser) isOperator(t dynamicMembershipToken, op string) bool {
+	return t.Kind == dynamicMembershipTokenOperator && strings.EqualFold(t.Value, op)
+}
+
+func (p *dynamicMembershipParser) parseOr() (dynamicMembershipExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(p.peek(), "-or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = dynamicMembershipLogicalExpression{Operator: "-or", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *dynamicMembershipParser) parseAnd() (dynamicMembershipExpression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(p.peek(), "-and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = dynamicMembershipLogicalExpression{Operator: "-and", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *dynamicMembershipParser) parseUnary() (dynamicMembershipExpression, error) {
+	if p.isOperator(p.peek(), "-not") {
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return dynamicMembershipNotExpression{Expression: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *dynamicMembershipParser) parsePrimary() (dynamicMembershipExpression, error) {
+	t := p.peek()
+	switch t.Kind {
+	case dynamicMembershipTokenLeftParen:
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.Kind != dynamicMembershipTokenRightParen {
+			return nil, p.errorf(closing, "expected \")\" but found %s", closing.describe())
+		}
+		return expr, nil
+
+	case dynamicMembershipTokenIdentifier:
+		return p.parseComparison()
+	}
+
+	return nil, p.errorf(t, "expected a property or \"(\" but found %s", t.describe())
+}
+
+func (p *dynamicMembershipParser) parseProperty(t dynamicMembershipToken) (*dynamicMembershipProperty, error) {
+	raw := t.Value
+	lower := strings.ToLower(raw)
+
+	// Element references are only valid within the inner expression of `-any` or `-all`
+	if p.collection != nil {
+		if lower == "_" {
+			if p.collection.Type != dynamicMembershipPropertyTypeStringCollection {
+				return nil, p.errorf(t, "the \"_\" element reference can only be used with multi-valued string properties, %q contains objects", p.collection.Raw)
+			}
+			return &dynamicMembershipProperty{Object: "_", Raw: raw, Element: true, Type: dynamicMembershipPropertyTypeString}, nil
+		}
+
+		if collection, ok := dynamicMembershipObjectCollections[p.collection.Name]; ok {
+			if object, name, found := strings.Cut(lower, "."); found && object == collection.Element {
+				for _, v := range collection.Properties {
+					if v == name {
+						return &dynamicMembershipProperty{Object: object, Name: name, Raw: raw, Element: true, Type: dynamicMembershipPropertyTypeString}, nil
+					}
+				}
+				return nil, p.errorf(t, "unsupported property %q for elements of %q", raw, p.collection.Raw)
+			}
+		}
+
+		return nil, p.errorf(t, "unsupported property %q within expression for %q", raw, p.collection.Raw)
+	}
+
+	object, name, found := strings.Cut(lower, ".")
+	if !found || name == "" {
+		return nil, p.errorf(t, "invalid property %q, properties should be prefixed with the object type, e.g. \"user.department\"", raw)
+	}
+
+	var properties map[string]dynamicMembershipPropertyType
+	switch {
+	case p.deviceFilter && object == "device":
+		properties = deviceFilterProperties
+	case p.deviceFilter:
+		return nil, p.errorf(t, "unsupported object type %q in property %q, expected \"device\"", object, raw)
+	case object == "user":
+		properties = dynamicMembershipUserProperties
+	case object == "device":
+		properties = dynamicMembershipDeviceProperties
+	default:
+		return nil, p.errorf(t, "unsupported object type %q in property %q, expected \"user\" or \"device\"", object, raw)
+	}
+
+	if p.objectType == "" {
+		p.objectType = object
+	} else if p.objectType != object {
+		return nil, p.errorf(t, "rules cannot contain both user and device properties, found %q in a %s rule", raw, p.objectType)
+	}
+
+	property := dynamicMembershipProperty{Object: object, Name: name, Raw: raw}
+	if propertyType, ok := properties[name]; ok {
+		property.Type = propertyType
+	} else if dynamicMembershipExtensionAttributeRegex.MatchString(name) || (!p.deviceFilter && dynamicMembershipExtensionPropertyRegex.MatchString(name)) {
+		property.Type = dynamicMembershipPropertyTypeString
+	} else if p.deviceFilter {
+		return nil, p.errorf(t, "unsupported %s property %q", object, raw)
+	} else {
+		// New properties are added to dynamic membership rules from time to time, so unknown properties are left for
+		// the API to validate
+		property.Type = dynamicMembershipPropertyTypeUnknown
+		p.unknownProperties = append(p.unknownProperties, raw)
+	}
+
+	return &property, nil
+}
+
+func (p *dynamicMembershipParser) parseComparison() (dynamicMembershipExpression, error) {
+	propertyToken := p.next()
+	property, err := p.parseProperty(propertyToken)
+	if err != nil {
+		return nil, err
+	}
+
+	operatorToken := p.next()
+	if operatorToken.Kind != dynamicMembershipTokenOperator {
+		return nil, p.errorf(operatorToken, "expected an operator after %q but found %s", property.Raw, operatorToken.describe())
+	}
+	operator, ok := dynamicMembershipOperators[strings.ToLower(operatorToken.Value)]
+	if !ok {
+		return nil, p.errorf(operatorToken, "unsupported operator %q", operatorToken.Value)
+	}
+
+	comparison := dynamicMembershipComparison{
+		Property: *property,
+		Operator: operator,
+	}
+
+	if operator == "-any" || operator == "-all" {
+		if property.Type != dynamicMembershipPropertyTypeStringCollection && property.Type != dynamicMembershipPropertyTypeObjectCollection {
+			// Directory extension properties can be multi-valued, but we can't know this ahead of time
+			if property.Type != dynamicMembershipPropertyTypeUnknown && !dynamicMembershipExtensionPropertyRegex.MatchString(property.Name) {
+				return nil, p.errorf(operatorToken, "operator %q can only be used with multi-valued properties, %q is single-valued", operator, property.Raw)
+			}
+			property.Type = dynamicMembershipPropertyTypeStringCollection
+			comparison.Property = *property
+		}
+		if p.collection != nil {
+			return nil, p.errorf(operatorToken, "operator %q cannot be nested within another %q or %q expression", operator, "-any", "-all")
+		}
+
+		p.collection = property
+		inner, err := p.parseUnary()
+		p.collection = nil
+		if err != nil {
+			return nil, err
+		}
+
+		comparison.ElementMatch = inner
+		return comparison, nil
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	comparison.Value = value
+
+	if err = p.validateComparison(operatorToken, comparison); err != nil {
+		return nil, err
+	}
+
+	return comparison, nil
+}
+
+func (p *dynamicMembershipParser) parseValue() (*dynamicMembershipValue, error) {
+	t := p.next()
+	switch t.Kind {
+	case dynamicMembershipTokenString:
+		return &dynamicMembershipValue{Kind: dynamicMembershipValueString, String: t.Value}, nil
+
+	case dynamicMembershipTokenNumber:
+		n, err := strconv.ParseFloat(t.Value, 64)
+		if err != nil {
+			return nil, p.errorf(t, "invalid number %q", t.Value)
+		}
+		return &dynamicMembershipValue{Kind: dynamicMembershipValueNumber, Number: n}, nil
+
+	case dynamicMembershipTokenIdentifier:
+		switch strings.ToLower(t.Value) {
+		case "true":
+			return &dynamicMembershipValue{Kind: dynamicMembershipValueBool, Bool: true}, nil
+		case "false":
+			return &dynamicMembershipValue{Kind: dynamicMembershipValueBool, Bool: false}, nil
+		case "null":
+			return &dynamicMembershipValue{Kind: dynamicMembershipValueNull}, nil
+		case "system.now":
+			return p.parseSystemNow()
+		}
+		return nil, p.errorf(t, "expected a value but found %s, string values must be quoted", t.describe())
+
+	case dynamicMembershipTokenLeftBracket, dynamicMembershipTokenLeftParen:
+		closingKind := dynamicMembershipTokenRightBracket
+		if t.Kind == dynamicMembershipTokenLeftParen {
+			closingKind = dynamicMembershipTokenRightParen
+		}
+
+		list := make([]dynamicMembershipValue, 0)
+		for {
+			item, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			if item.Kind == dynamicMembershipValueList {
+				return nil, p.errorf(t, "lists cannot be nested")
+			}
+			list = append(list, *item)
+
+			separator := p.next()
+			if separator.Kind == closingKind {
+				break
+			}
+			if separator.Kind != dynamicMembershipTokenComma {
+				return nil, p.errorf(separator, "expected \",\" or the end of the list but found %s", separator.describe())
+			}
+		}
+		return &dynamicMembershipValue{Kind: dynamicMembershipValueList, List: list}, nil
+	}
+
+	return nil, p.errorf(t, "expected a value but found %s", t.describe())
+}
+
+// dynamicMembershipDurationRegex matches an ISO 8601 duration, as used for date arithmetic with `system.now`
+var dynamicMembershipDurationRegex = regexp.MustCompile(`(?i)^p(?:\d+y)?(?:\d+m)?(?:\d+w)?(?:\d+d)?(?:t(?:\d+h)?(?:\d+m)?(?:\d+s)?)?$`)
+
+// parseSystemNow parses the optional date arithmetic following `system.now`, e.g. `system.now -minus p30d`
+func (p *dynamicMembershipParser) parseSystemNow() (*dynamicMembershipValue, error) {
+	value := &dynamicMembershipValue{Kind: dynamicMembershipValueNow}
+
+	operatorToken := p.peek()
+	if !p.isOperator(operatorToken, "-plus") && !p.isOperator(operatorToken, "-minus") {
+		return value, nil
+	}
+	p.next()
+
+	t := p.next()
+	if t.Kind != dynamicMembershipTokenIdentifier || !dynamicMembershipDurationRegex.MatchString(t.Value) || len(t.Value) < 3 || strings.HasSuffix(strings.ToLower(t.Value), "t") {
+		return nil, p.errorf(t, "expected an ISO 8601 duration such as \"p1d\" after %q but found %s", operatorToken.Value, t.describe())
+	}
+
+	value.OffsetOperator = strings.ToLower(operatorToken.Value)
+	value.Offset = t.Value
+
+	return value, nil
+}
+
+func (p *dynamicMembershipParser) validateComparison(operatorToken dynamicMembershipToken, c dynamicMembershipComparison) error {
+	isList := c.Value.Kind == dynamicMembershipValueList
+
+	if c.Value.Kind == dynamicMembershipValueNow && c.Property.Type != dynamicMembershipPropertyTypeDateTime && c.Property.Type != dynamicMembershipPropertyTypeUnknown {
+		return p.errorf(operatorToken, "system.now can only be compared with date properties, but %q is not a date property", c.Property.Raw)
+	}
+
+	switch c.Property.Type {
+	case dynamicMembershipPropertyTypeBool:
+		if c.Operator != "-eq" && c.Operator != "-ne" {
+			return p.errorf(operatorToken, "operator %q is not supported for boolean property %q, expected \"-eq\" or \"-ne\"", c.Operator, c.Property.Raw)
+		}
+		if c.Value.Kind != dynamicMembershipValueBool && c.Value.Kind != dynamicMembershipValueNull {
+			return p.errorf(operatorToken, "boolean property %q must be compared with true or false", c.Property.Raw)
+		}
+
+	case dynamicMembershipPropertyTypeStringCollection, dynamicMembershipPropertyTypeObjectCollection:
+		// System labels can be matched directly, e.g. `device.systemLabels -contains "M365Managed"`
+		if c.Property.Name == "systemlabels" && (c.Operator == "-contains" || c.Operator == "-notContains") {
+			if c.Value.Kind != dynamicMembershipValueString {
+				return p.errorf(operatorToken, "operator %q requires a quoted string value", c.Operator)
+			}
+			return nil
+		}
+		return p.errorf(operatorToken, "operator %q cannot be used with multi-valued property %q, use \"-any\" or \"-all\"", c.Operator, c.Property.Raw)
+
+	case dynamicMembershipPropertyTypeDateTime:
+		switch c.Operator {
+		case "-eq", "-ne", "-ge", "-gt", "-le", "-lt":
+		default:
+			return p.errorf(operatorToken, "operator %q is not supported for date property %q", c.Operator, c.Property.Raw)
+		}
+		if c.Value.Kind != dynamicMembershipValueString && c.Value.Kind != dynamicMembershipValueNull && c.Value.Kind != dynamicMembershipValueNow {
+			return p.errorf(operatorToken, "date property %q must be compared with a quoted date or system.now", c.Property.Raw)
+		}
+
+	case dynamicMembershipPropertyTypeString:
+		valid := false
+		for _, op := range dynamicMembershipStringOperators {
+			if op == c.Operator {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return p.errorf(operatorToken, "operator %q is not supported for string property %q", c.Operator, c.Property.Raw)
+		}
+
+		switch c.Operator {
+		case "-in", "-notIn":
+			if !isList {
+				return p.errorf(operatorToken, "operator %q requires a list of values, e.g. [\"a\", \"b\"]", c.Operator)
+			}
+			if len(c.Value.List) > 50 {
+				return p.errorf(operatorToken, "operator %q supports a maximum of 50 values, got %d", c.Operator, len(c.Value.List))
+			}
+		case "-eq", "-ne":
+			if isList {
+				return p.errorf(operatorToken, "operator %q cannot be used with a list of values, use \"-in\" or \"-notIn\"", c.Operator)
+			}
+		default:
+			if c.Value.Kind != dynamicMembershipValueString {
+				return p.errorf(operatorToken, "operator %q requires a quoted string value", c.Operator)
+			}
+		}
+	}
+
+	return nil
+}
+
+var dynamicMembershipDirectReportsRegex = regexp.MustCompile(`(?i)^\s*direct\s+reports\s+for\s+"([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})"\s*$`)
+
+// ParseDynamicMembershipRule parses a dynamic membership rule for a group or administrative unit, returning an error
+// describing the first problem found with the rule.
+func ParseDynamicMembershipRule(input string) (*DynamicMembershipRule, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, fmt.Errorf("rule cannot be empty")
+	}
+
+	if m := dynamicMembershipDirectReportsRegex.FindStringSubmatch(input); m != nil {
+		return &DynamicMembershipRule{
+			ObjectType:       "user",
+			DirectReportsFor: &m[1],
+		}, nil
+	}
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(input)), "direct reports") {
+		return nil, fmt.Errorf("direct reports rules should be in the format `Direct Reports for \"{managerObjectId}\"`")
+	}
+
+	tokens, err := dynamicMembershipTokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dynamicMembershipParser{tokens: tokens}
+	expression, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.Kind != dynamicMembershipTokenEOF {
+		return nil, p.errorf(t, "unexpected %s", t.describe())
+	}
+
+	return &DynamicMembershipRule{
+		ObjectType:        p.objectType,
+		UnknownProperties: p.unknownProperties,
+		expression:        expression,
+	}, nil
+}
+
+// String returns a normalised representation of the rule, with explicit grouping
+func (r DynamicMembershipRule) String() string {
+	if r.DirectReportsFor != nil {
+		return fmt.Sprintf("Direct Reports for %q", *r.DirectReportsFor)
+	}
+	if r.expression == nil {
+		return ""
+	}
+	return r.expression.String()
+}
+
+// IsDynamicMembershipRule validates the syntax of a dynamic membership rule for a group or administrative unit
+func IsDynamicMembershipRule(i interface{}, path cty.Path) (ret diag.Diagnostics) {
+	v, ok := i.(string)
+	if !ok {
+		ret = append(ret, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Expected a string value",
+			AttributePath: path,
+		})
+		return
+	}
+
+	if len(v) > DynamicMembershipRuleMaxLength {
+		ret = append(ret, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Dynamic membership rule cannot be longer than %d characters, got %d", DynamicMembershipRuleMaxLength, len(v)),
+			AttributePath: path,
+		})
+		return
+	}
+
+	rule, err := ParseDynamicMembershipRule(v)
+	if err != nil {
+		ret = append(ret, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Invalid dynamic membership rule",
+			Detail:        fmt.Sprintf("Parsing %q: %v", v, err),
+			AttributePath: path,
+		})
+		return
+	}
+
+	for _, property := range rule.UnknownProperties {
+		ret = append(ret, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "Unknown property in dynamic membership rule",
+			Detail:        fmt.Sprintf("The property %q is not known to the provider, so it can only be validated by the API when the rule is applied", property),
+			AttributePath: path,
+		})
+	}
+
+	return
+}
@@ -0,0 +1,315 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DynamicMembershipRuleSubject describes a user or device against which a dynamic membership rule can be evaluated.
+// Property names are matched case-insensitively and should not include the object type prefix, e.g. `department`
+// rather than `user.department`.
+type DynamicMembershipRuleSubject struct {
+	// Attributes contains the single-valued properties of the subject. Boolean properties should be `true` or `false`.
+	Attributes map[string]string
+
+	// MultiValuedAttributes contains the multi-valued properties of the subject, such as `proxyAddresses`. For
+	// `memberOf` the values should be group object IDs, and for `assignedPlans` the values should be service plan IDs.
+	MultiValuedAttributes map[string][]string
+}
+
+func (s DynamicMembershipRuleSubject) attribute(name string) (string, bool) {
+	for k, v := range s.Attributes {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func (s DynamicMembershipRuleSubject) multiValuedAttribute(name string) []string {
+	for k, v := range s.MultiValuedAttributes {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+// Evaluate determines whether the provided subject would be a member according to the rule. An error is returned
+// when the rule references element properties that cannot be evaluated locally.
+func (r DynamicMembershipRule) Evaluate(subject DynamicMembershipRuleSubject) (bool, error) {
+	if r.DirectReportsFor != nil {
+		manager, _ := subject.attribute("manager")
+		return strings.EqualFold(manager, *r.DirectReportsFor), nil
+	}
+	if r.expression == nil {
+		return false, fmt.Errorf("rule has not been parsed")
+	}
+	return dynamicMembershipEvaluate(r.expression, subject, nil)
+}
+
+func dynamicMembershipEvaluate(expression dynamicMembershipExpression, subject DynamicMembershipRuleSubject, element *string) (bool, error) {
+	switch e := expression.(type) {
+	case dynamicMembershipLogicalExpression:
+		left, err := dynamicMembershipEvaluate(e.Left, subject, element)
+		if err != nil {
+			return false, err
+		}
+		if e.Operator == "-and" && !left {
+			return false, nil
+		}
+		if e.Operator == "-or" && left {
+			return true, nil
+		}
+		return dynamicMembershipEvaluate(e.Right, subject, element)
+
+	case dynamicMembershipNotExpression:
+		result, err := dynamicMembershipEvaluate(e.Expression, subject, element)
+		return !result, err
+
+	case dynamicMembershipComparison:
+		return dynamicMembershipEvaluateComparison(e, subject, element)
+	}
+
+	return false, fmt.Errorf("unsupported expression %s", expression)
+}
+
+func dynamicMembershipEvaluateComparison(c dynamicMembershipComparison, subject DynamicMembershipRuleSubject, element *string) (bool, error) {
+	if c.ElementMatch != nil {
+		if collection, ok := dynamicMembershipObjectCollections[c.Property.Name]; ok {
+			if err := dynamicMembershipCheckElementReferences(c.ElementMatch, c.Property.Raw, collection.Key); err != nil {
+				return false, err
+			}
+		}
+
+		values := subject.multiValuedAttribute(c.Property.Name)
+		for _, v := range values {
+			matched, err := dynamicMembershipEvaluate(c.ElementMatch, subject, &v)
+			if err != nil {
+				return false, err
+			}
+			if c.Operator == "-any" && matched {
+				return true, nil
+			}
+			if c.Operator == "-all" && !matched {
+				return false, nil
+			}
+		}
+
+		// `-all` is not satisfied by an empty collection
+		return c.Operator == "-all" && len(values) > 0, nil
+	}
+
+	// Matching a collection directly checks whether any of its values is equal to the provided value
+	if c.Property.Type == dynamicMembershipPropertyTypeStringCollection && !c.Property.Element {
+		expected := dynamicMembershipValueAsString(*c.Value)
+		found := false
+		for _, v := range subject.multiValuedAttribute(c.Property.Name) {
+			if strings.EqualFold(v, expected) {
+				found = true
+				break
+			}
+		}
+		return found == (c.Operator == "-contains"), nil
+	}
+
+	var value string
+	var present bool
+	if c.Property.Element {
+		if element == nil {
+			return false, fmt.Errorf("element reference %q used outside of an `-any` or `-all` expression", c.Property.Raw)
+		}
+		value, present = *element, true
+	} else {
+		value, present = subject.attribute(c.Property.Name)
+	}
+
+	if c.Value.Kind == dynamicMembershipValueNull {
+		isNull := !present || value == ""
+		if c.Operator == "-ne" {
+			return !isNull, nil
+		}
+		return isNull, nil
+	}
+
+	switch c.Property.Type {
+	case dynamicMembershipPropertyTypeBool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if !present || err != nil {
+			return c.Operator == "-ne", nil
+		}
+		return (b == c.Value.Bool) == (c.Operator == "-eq"), nil
+
+	case dynamicMembershipPropertyTypeDateTime:
+		return dynamicMembershipCompareDates(c, value, present)
+	}
+
+	expected := dynamicMembershipValueAsString(*c.Value)
+
+	switch c.Operator {
+	case "-eq":
+		return strings.EqualFold(value, expected), nil
+	case "-ne":
+		return !strings.EqualFold(value, expected), nil
+	case "-startsWith":
+		return strings.HasPrefix(strings.ToLower(value), strings.ToLower(expected)), nil
+	case "-notStartsWith":
+		return !strings.HasPrefix(strings.ToLower(value), strings.ToLower(expected)), nil
+	case "-contains":
+		return strings.Contains(strings.ToLower(value), strings.ToLower(expected)), nil
+	case "-notContains":
+		return !strings.Contains(strings.ToLower(value), strings.ToLower(expected)), nil
+	case "-match", "-notMatch":
+		// The API evaluates regular expressions using .NET syntax, some of which (such as lookarounds) is not supported here
+		re, err := regexp.Compile("(?i)" + expected)
+		if err != nil {
+			return false, fmt.Errorf("regular expression %q cannot be evaluated locally: %v", expected, err)
+		}
+		return re.MatchString(value) == (c.Operator == "-match"), nil
+	case "-in", "-notIn":
+		found := false
+		for _, item := range c.Value.List {
+			if strings.EqualFold(value, dynamicMembershipValueAsString(item)) {
+				found = true
+				break
+			}
+		}
+		return found == (c.Operator == "-in"), nil
+	}
+
+	return false, fmt.Errorf("operator %q is not supported for property %q", c.Operator, c.Property.Raw)
+}
+
+func dynamicMembershipCompareDates(c dynamicMembershipComparison, value string, present bool) (bool, error) {
+	if !present || value == "" {
+		return c.Operator == "-ne", nil
+	}
+
+	actual, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return false, fmt.Errorf("value %q for property %q is not a valid RFC3339 date", value, c.Property.Raw)
+	}
+	expected, err := dynamicMembershipValueAsTime(*c.Value)
+	if err != nil {
+		return false, fmt.Errorf("value %s compared with property %q: %v", c.Value, c.Property.Raw, err)
+	}
+
+	switch c.Operator {
+	case "-eq":
+		return actual.Equal(expected), nil
+	case "-ne":
+		return !actual.Equal(expected), nil
+	case "-ge":
+		return !actual.Before(expected), nil
+	case "-gt":
+		return actual.After(expected), nil
+	case "-le":
+		return !actual.After(expected), nil
+	case "-lt":
+		return actual.Before(expected), nil
+	}
+
+	return false, fmt.Errorf("operator %q is not supported for property %q", c.Operator, c.Property.Raw)
+}
+
+// dynamicMembershipCheckElementReferences ensures that only the element property represented by the values of a
+// multi-valued attribute is referenced, since other properties of object elements are not known locally
+func dynamicMembershipCheckElementReferences(expression dynamicMembershipExpression, collection, key string) error {
+	switch e := expression.(type) {
+	case dynamicMembershipLogicalExpression:
+		if err := dynamicMembershipCheckElementReferences(e.Left, collection, key); err != nil {
+			return err
+		}
+		return dynamicMembershipCheckElementReferences(e.Right, collection, key)
+
+	case dynamicMembershipNotExpression:
+		return dynamicMembershipCheckElementReferences(e.Expression, collection, key)
+
+	case dynamicMembershipComparison:
+		if e.Property.Name != key {
+			return fmt.Errorf("property %q cannot be evaluated locally, only the %q property can be evaluated for elements of %q", e.Property.Raw, key, collection)
+		}
+	}
+
+	return nil
+}
+
+// dynamicMembershipValueAsTime returns the date represented by a value, which is either a quoted RFC3339 date or
+// `system.now` with optional date arithmetic
+func dynamicMembershipValueAsTime(v dynamicMembershipValue) (time.Time, error) {
+	if v.Kind != dynamicMembershipValueNow {
+		t, err := time.Parse(time.RFC3339, v.String)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("not a valid RFC3339 date")
+		}
+		return t, nil
+	}
+
+	now := time.Now().UTC()
+	if v.Offset == "" {
+		return now, nil
+	}
+
+	sign := 1
+	if v.OffsetOperator == "-minus" {
+		sign = -1
+	}
+
+	// Durations are applied component-wise so that years and months are calendar-aware
+	timePart := false
+	digits := ""
+	for _, r := range strings.ToLower(v.Offset)[1:] {
+		if r >= '0' && r <= '9' {
+			digits += string(r)
+			continue
+		}
+		if r == 't' {
+			timePart = true
+			continue
+		}
+
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid duration %q", v.Offset)
+		}
+		n *= sign
+		digits = ""
+
+		switch {
+		case r == 'y':
+			now = now.AddDate(n, 0, 0)
+		case r == 'm' && !timePart:
+			now = now.AddDate(0, n, 0)
+		case r == 'w':
+			now = now.AddDate(0, 0, 7*n)
+		case r == 'd':
+			now = now.AddDate(0, 0, n)
+		case r == 'h':
+			now = now.Add(time.Duration(n) * time.Hour)
+		case r == 'm':
+			now = now.Add(time.Duration(n) * time.Minute)
+		case r == 's':
+			now = now.Add(time.Duration(n) * time.Second)
+		}
+	}
+
+	return now, nil
+}
+
+func dynamicMembershipValueAsString(v dynamicMembershipValue) string {
+	switch v.Kind {
+	case dynamicMembershipValueString:
+		return v.String
+	case dynamicMembershipValueNumber:
+		return strconv.FormatFloat(v.Number, 'f', -1, 64)
+	case dynamicMembershipValueBool:
+		return strconv.FormatBool(v.Bool)
+	}
+	return ""
+}
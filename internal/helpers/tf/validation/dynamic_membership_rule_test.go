@@ -0,0 +1,394 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestIsDynamicMembershipRule(t *testing.T) {
+	cases := []struct {
+		Value     string
+		TestName  string
+		ErrCount  int
+		WarnCount int
+	}{
+		{
+			Value:    `user.department -eq "Sales"`,
+			TestName: "SimpleEquals",
+			ErrCount: 0,
+		},
+		{
+			Value:    `(user.department -eq "Sales") -and (user.country -ne "US")`,
+			TestName: "ParenthesisedAnd",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.department -eq "Sales" -or user.department -eq "Marketing" -and user.accountEnabled -eq true`,
+			TestName: "MixedPrecedence",
+			ErrCount: 0,
+		},
+		{
+			Value:    `-not (user.userType -eq "Guest")`,
+			TestName: "Not",
+			ErrCount: 0,
+		},
+		{
+			Value:    `USER.Department -EQ "Sales"`,
+			TestName: "CaseInsensitive",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.jobTitle -match "^Senior.*Engineer$"`,
+			TestName: "Match",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.displayName -match "^(?!test).*"`,
+			TestName: "MatchLookahead",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.employeeHireDate -ge system.now -plus p1d`,
+			TestName: "SystemNowPlus",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.employeeHireDate -le system.now -minus P1Y6M`,
+			TestName: "SystemNowMinus",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.employeeHireDate -gt system.now`,
+			TestName: "SystemNow",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.country -in ["US", "CA", "MX"]`,
+			TestName: "InList",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.city -notIn ['London', 'Paris']`,
+			TestName: "NotInSingleQuotes",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.proxyAddresses -any (_ -contains "contoso")`,
+			TestName: "AnyStringCollection",
+			ErrCount: 0,
+		},
+		{
+			Value:    `device.devicePhysicalIds -any _ -startsWith "[ZTDId]"`,
+			TestName: "AnyWithoutParentheses",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.assignedPlans -any (assignedPlan.servicePlanId -eq "efb87545-963c-4e0d-99df-69c6916d9eb0" -and assignedPlan.capabilityStatus -eq "Enabled")`,
+			TestName: "AnyObjectCollection",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.memberof -any (group.objectId -in ['3b4a6d47-48a9-4d0e-9a2f-2b35b5f3d5a1'])`,
+			TestName: "MemberOf",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.extension_9d98ed114c4840d298fad781915f27e4_employeeLevel -eq "5"`,
+			TestName: "DirectoryExtension",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.extensionAttribute15 -eq "Marketing"`,
+			TestName: "ExtensionAttribute",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.department -eq "Sales` + "`" + `"Team"`,
+			TestName: "EscapedQuote",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.objectId -ne null`,
+			TestName: "NotNull",
+			ErrCount: 0,
+		},
+		{
+			Value:    `(device.deviceOSType -eq "Windows") -and (device.isRooted -eq false)`,
+			TestName: "Device",
+			ErrCount: 0,
+		},
+		{
+			Value:    `Direct Reports for "62e19b97-8b3d-4d4a-a106-4ce66896a863"`,
+			TestName: "DirectReports",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.department eq "Sales" and user.country ne "US"`,
+			TestName: "HyphenlessOperators",
+			ErrCount: 0,
+		},
+		{
+			Value:    `not (user.userType eq "Guest") or user.proxyAddresses any (_ startsWith "smtp:")`,
+			TestName: "HyphenlessNotAndAny",
+			ErrCount: 0,
+		},
+		{
+			Value:    `user.employeeHireDate ge system.now minus p1d`,
+			TestName: "HyphenlessSystemNow",
+			ErrCount: 0,
+		},
+		{
+			Value:    `device.systemLabels -contains "M365Managed"`,
+			TestName: "SystemLabelsContains",
+			ErrCount: 0,
+		},
+		{
+			Value:    `device.systemLabels -notContains "M365Managed"`,
+			TestName: "SystemLabelsNotContains",
+			ErrCount: 0,
+		},
+		{
+			Value:    `device.systemLabels -eq "M365Managed"`,
+			TestName: "SystemLabelsEquals",
+			ErrCount: 1,
+		},
+		{
+			Value:     `user.onPremisesExtensionAttributes.extensionAttribute1 -eq "Sales"`,
+			TestName:  "UnknownNestedProperty",
+			ErrCount:  0,
+			WarnCount: 1,
+		},
+		{
+			Value:     `user.departmnet -eq "Sales"`,
+			TestName:  "UnknownProperty",
+			ErrCount:  0,
+			WarnCount: 1,
+		},
+		{
+			Value:     `user.otherLabels -any (_ -eq "Sales")`,
+			TestName:  "UnknownMultiValuedProperty",
+			ErrCount:  0,
+			WarnCount: 1,
+		},
+		{
+			Value:    `group.displayName -eq "Sales"`,
+			TestName: "UnknownObjectType",
+			ErrCount: 1,
+		},
+		{
+			Value:    `department -eq "Sales"`,
+			TestName: "MissingObjectType",
+			ErrCount: 1,
+		},
+		{
+			Value:    `user.department -equals "Sales"`,
+			TestName: "UnknownOperator",
+			ErrCount: 1,
+		},
+		{
+			Value:    `user.department -eq Sales`,
+			TestName: "UnquotedString",
+			ErrCount: 1,
+		},
+		{
+			Value:    `(user.department -eq "Sales"`,
+			TestName: "UnbalancedParentheses",
+			ErrCount: 1,
+		},
+		{
+			Value:    `user.department -eq "Sales`,
+			TestName: "UnterminatedString",
+			ErrCount: 1,
+		},
+		{
+			Value:    `user.department -eq "Sales" user.country -eq "US"`,
+			TestName: "MissingLogicalOperator",
+			ErrCount: 1,
+		},
+		{
+			Value:    `user.accountEnabled -contains "true"`,
+			TestName: "InvalidBooleanOperator",
+			ErrCount: 1,
+		},
+		{
+			Value:    `user.proxyAddresses -contains "contoso"`,
+			TestName: "CollectionWithoutAny",
+			ErrCount: 1,
+		},
+		{
+			Value:    `user.department -any (_ -eq "Sales")`,
+			TestName: "AnyOnSingleValued",
+			ErrCount: 1,
+		},
+		{
+			Value:    `user.department -in "Sales"`,
+			TestName: "InWithoutList",
+			ErrCount: 1,
+		},
+		{
+			Value:    `user.department -eq "Sales" -and device.deviceOSType -eq "Windows"`,
+			TestName: "MixedObjectTypes",
+			ErrCount: 1,
+		},
+		{
+			Value:    `user.employeeHireDate -ge system.now -plus 1d`,
+			TestName: "SystemNowInvalidDuration",
+			ErrCount: 1,
+		},
+		{
+			Value:    `user.department -eq system.now`,
+			TestName: "SystemNowStringProperty",
+			ErrCount: 1,
+		},
+		{
+			Value:    `user.assignedPlans -any (_ -eq "Enabled")`,
+			TestName: "ElementReferenceOnObjectCollection",
+			ErrCount: 1,
+		},
+		{
+			Value:    `Direct Reports for "bob"`,
+			TestName: "DirectReportsInvalidId",
+			ErrCount: 1,
+		},
+		{
+			Value:    "",
+			TestName: "Empty",
+			ErrCount: 1,
+		},
+		{
+			Value:    strings.Repeat(`user.department -eq "Sales" -or `, 100) + `user.department -eq "Sales"`,
+			TestName: "TooLong",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			diags := IsDynamicMembershipRule(tc.Value, cty.Path{})
+
+			errCount, warnCount := 0, 0
+			for _, d := range diags {
+				if d.Severity == diag.Error {
+					errCount++
+				} else {
+					warnCount++
+				}
+			}
+
+			if errCount != tc.ErrCount {
+				t.Fatalf("Expected IsDynamicMembershipRule to have %d not %d errors for %q: %+v", tc.ErrCount, errCount, tc.Value, diags)
+			}
+			if warnCount != tc.WarnCount {
+				t.Fatalf("Expected IsDynamicMembershipRule to have %d not %d warnings for %q: %+v", tc.WarnCount, warnCount, tc.Value, diags)
+			}
+		})
+	}
+}
+
+func TestDynamicMembershipRuleEvaluate(t *testing.T) {
+	sales := DynamicMembershipRuleSubject{
+		Attributes: map[string]string{
+			"accountEnabled":    "true",
+			"country":           "US",
+			"department":        "Sales",
+			"jobTitle":          "Senior Account Executive",
+			"manager":           "62e19b97-8b3d-4d4a-a106-4ce66896a863",
+			"userPrincipalName": "alice@contoso.com",
+			"employeeHireDate":  "2024-03-01T00:00:00Z",
+		},
+		MultiValuedAttributes: map[string][]string{
+			"proxyAddresses": {"SMTP:alice@contoso.com", "smtp:alice@fabrikam.com"},
+			"memberOf":       {"3b4a6d47-48a9-4d0e-9a2f-2b35b5f3d5a1"},
+		},
+	}
+
+	guest := DynamicMembershipRuleSubject{
+		Attributes: map[string]string{
+			"accountEnabled": "false",
+			"department":     "Marketing",
+			"userType":       "Guest",
+		},
+	}
+
+	managedDevice := DynamicMembershipRuleSubject{
+		MultiValuedAttributes: map[string][]string{
+			"systemLabels": {"M365Managed"},
+		},
+	}
+
+	onPremises := DynamicMembershipRuleSubject{
+		Attributes: map[string]string{
+			"onPremisesExtensionAttributes.extensionAttribute1": "Sales",
+		},
+	}
+
+	cases := []struct {
+		Rule     string
+		Subject  DynamicMembershipRuleSubject
+		Expected bool
+		Error    bool
+	}{
+		{Rule: `user.department -eq "sales"`, Subject: sales, Expected: true},
+		{Rule: `user.department -eq "Sales"`, Subject: guest, Expected: false},
+		{Rule: `user.department -ne "Sales"`, Subject: guest, Expected: true},
+		{Rule: `user.department -eq "Sales" -and user.accountEnabled -eq true`, Subject: sales, Expected: true},
+		{Rule: `user.department -eq "Sales" -or user.userType -eq "Guest"`, Subject: guest, Expected: true},
+		{Rule: `-not (user.userType -eq "Guest")`, Subject: guest, Expected: false},
+		{Rule: `-not (user.userType -eq "Guest")`, Subject: sales, Expected: true},
+		{Rule: `user.userPrincipalName -startsWith "ALICE@"`, Subject: sales, Expected: true},
+		{Rule: `user.jobTitle -contains "account"`, Subject: sales, Expected: true},
+		{Rule: `user.jobTitle -notContains "account"`, Subject: sales, Expected: false},
+		{Rule: `user.jobTitle -match "^senior"`, Subject: sales, Expected: true},
+		{Rule: `user.country -in ["CA", "US"]`, Subject: sales, Expected: true},
+		{Rule: `user.country -notIn ["CA", "US"]`, Subject: sales, Expected: false},
+		{Rule: `user.country -eq null`, Subject: guest, Expected: true},
+		{Rule: `user.country -ne null`, Subject: sales, Expected: true},
+		{Rule: `user.accountEnabled -eq false`, Subject: guest, Expected: true},
+		{Rule: `user.proxyAddresses -any (_ -contains "fabrikam")`, Subject: sales, Expected: true},
+		{Rule: `user.proxyAddresses -all (_ -contains "contoso")`, Subject: sales, Expected: false},
+		{Rule: `user.proxyAddresses -any (_ -contains "fabrikam")`, Subject: guest, Expected: false},
+		{Rule: `user.proxyAddresses -all (_ -contains "fabrikam")`, Subject: guest, Expected: false},
+		{Rule: `user.memberof -any (group.objectId -in ['3b4a6d47-48a9-4d0e-9a2f-2b35b5f3d5a1'])`, Subject: sales, Expected: true},
+		{Rule: `user.employeeHireDate -ge "2024-01-01T00:00:00Z"`, Subject: sales, Expected: true},
+		{Rule: `user.employeeHireDate -lt "2024-01-01T00:00:00Z"`, Subject: sales, Expected: false},
+		{Rule: `user.employeeHireDate -le system.now -minus p30d`, Subject: sales, Expected: true},
+		{Rule: `user.employeeHireDate -ge system.now -minus pt1h`, Subject: sales, Expected: false},
+		{Rule: `user.jobTitle -match "^(?!junior)"`, Subject: sales, Error: true},
+		{Rule: `Direct Reports for "62e19b97-8b3d-4d4a-a106-4ce66896a863"`, Subject: sales, Expected: true},
+		{Rule: `Direct Reports for "62e19b97-8b3d-4d4a-a106-4ce66896a863"`, Subject: guest, Expected: false},
+		{Rule: `user.assignedPlans -any (assignedPlan.capabilityStatus -eq "Enabled")`, Subject: sales, Error: true},
+		{Rule: `user.department eq "Sales" and user.accountEnabled eq true`, Subject: sales, Expected: true},
+		{Rule: `not (user.department eq "Sales")`, Subject: sales, Expected: false},
+		{Rule: `device.systemLabels -contains "m365managed"`, Subject: managedDevice, Expected: true},
+		{Rule: `device.systemLabels -notContains "M365Managed"`, Subject: managedDevice, Expected: false},
+		{Rule: `device.systemLabels -contains "Kiosk"`, Subject: managedDevice, Expected: false},
+		{Rule: `user.onPremisesExtensionAttributes.extensionAttribute1 -eq "sales"`, Subject: onPremises, Expected: true},
+	}
+
+	for _, tc := range cases {
+		rule, err := ParseDynamicMembershipRule(tc.Rule)
+		if err != nil {
+			t.Fatalf("parsing rule %q: %v", tc.Rule, err)
+		}
+
+		actual, err := rule.Evaluate(tc.Subject)
+		if tc.Error {
+			if err == nil {
+				t.Fatalf("expected an error evaluating rule %q, got none", tc.Rule)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("evaluating rule %q: %v", tc.Rule, err)
+		}
+		if actual != tc.Expected {
+			t.Fatalf("expected rule %q to evaluate to %t for %+v, got %t", tc.Rule, tc.Expected, tc.Subject.Attributes, actual)
+		}
+	}
+}
@@ -205,6 +205,12 @@ func IsUUID(i interface{}, k string) ([]string, []error) {
 	return validation.IsUUID(i, k)
 }
 
+// MapKeyMatch returns a SchemaValidateDiagFunc which tests if the provided value
+// is of type map and all keys match a given regexp
+func MapKeyMatch(r *regexp.Regexp, message string) schema.SchemaValidateDiagFunc {
+	return validation.MapKeyMatch(r, message)
+}
+
 // None returns a SchemaValidateFunc which tests if the provided value
 // returns errors for all of the provided SchemaValidateFunc
 func None(validators map[string]func(interface{}, string) ([]string, []error)) func(interface{}, string) ([]string, []error) {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directory/stable/administrativeunit"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directory/stable/administrativeunitmember"
@@ -54,6 +55,27 @@ func administrativeUnitDataSource() *pluginsdk.Resource {
 				Computed:    true,
 			},
 
+			"dynamic_membership": {
+				Description: "The dynamic membership configuration for the administrative unit",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"enabled": {
+							Description: "Whether rule processing is enabled",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+
+						"rule": {
+							Description: "Rule used to determine members for a dynamic administrative unit",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+
 			"members": {
 				Description: "A list of object IDs of members who are be present in this administrative unit.",
 				Type:        pluginsdk.TypeList,
@@ -74,6 +96,7 @@ func administrativeUnitDataSource() *pluginsdk.Resource {
 
 func administrativeUnitDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).AdministrativeUnits.AdministrativeUnitClient
+	clientBeta := meta.(*clients.Client).AdministrativeUnits.AdministrativeUnitClientBeta
 	memberClient := meta.(*clients.Client).AdministrativeUnits.AdministrativeUnitMemberClient
 
 	var administrativeUnit stable.AdministrativeUnit
@@ -126,6 +149,12 @@ func administrativeUnitDataSourceRead(ctx context.Context, d *pluginsdk.Resource
 	tf.Set(d, "object_id", pointer.From(administrativeUnit.Id))
 	tf.Set(d, "visibility", administrativeUnit.Visibility.GetOrZero())
 
+	dynamicMembership, err := administrativeUnitGetDynamicMembership(ctx, clientBeta, beta.NewAdministrativeUnitID(*administrativeUnit.Id))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "dynamic_membership", "Could not retrieve dynamic membership for administrative unit with object ID %q", d.Id())
+	}
+	tf.Set(d, "dynamic_membership", dynamicMembership)
+
 	membersResp, err := memberClient.ListAdministrativeUnitMembers(ctx, stable.NewDirectoryAdministrativeUnitID(*administrativeUnit.Id), administrativeunitmember.DefaultListAdministrativeUnitMembersOperationOptions())
 	if err != nil {
 		return tf.ErrorDiagPathF(err, "members", "Could not retrieve members for administrative unit with object ID %q", d.Id())
@@ -53,6 +53,21 @@ func TestAccAdministrativeUnitDataSource_members(t *testing.T) {
 	})
 }
 
+func TestAccAdministrativeUnitDataSource_dynamicMembership(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_administrative_unit", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: AdministrativeUnitDataSource{}.dynamicMembership(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("dynamic_membership.#").HasValue("1"),
+				check.That(data.ResourceName).Key("dynamic_membership.0.enabled").HasValue("true"),
+				check.That(data.ResourceName).Key("dynamic_membership.0.rule").HasValue("user.department -eq \"Sales\""),
+			),
+		},
+	})
+}
+
 func (AdministrativeUnitDataSource) displayName(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -82,3 +97,13 @@ data "azuread_administrative_unit" "test" {
 }
 `, AdministrativeUnitResource{}.withMembers(data))
 }
+
+func (AdministrativeUnitDataSource) dynamicMembership(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_administrative_unit" "test" {
+  object_id = azuread_administrative_unit.test.object_id
+}
+`, AdministrativeUnitResource{}.dynamicMembership(data, true))
+}
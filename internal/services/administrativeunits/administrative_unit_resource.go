@@ -82,12 +82,37 @@ func administrativeUnitResource() *pluginsdk.Resource {
 				Optional:    true,
 			},
 
+			"dynamic_membership": {
+				Description:   "An optional block to configure dynamic membership for the administrative unit. Cannot be used with `members`",
+				Type:          pluginsdk.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"members"},
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"enabled": {
+							Description: "Whether rule processing is enabled. When `false`, dynamic membership is paused",
+							Type:        pluginsdk.TypeBool,
+							Required:    true,
+						},
+
+						"rule": {
+							Description:      "Rule to determine members for a dynamic administrative unit",
+							Type:             pluginsdk.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.IsDynamicMembershipRule,
+						},
+					},
+				},
+			},
+
 			"members": {
-				Description: "A set of object IDs of members who should be present in this administrative unit. Supported object types are Users or Groups",
-				Type:        pluginsdk.TypeSet,
-				Optional:    true,
-				Computed:    true,
-				Set:         pluginsdk.HashString,
+				Description:   "A set of object IDs of members who should be present in this administrative unit. Supported object types are Users or Groups",
+				Type:          pluginsdk.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"dynamic_membership"},
+				Set:           pluginsdk.HashString,
 				Elem: &pluginsdk.Schema{
 					Type:         pluginsdk.TypeString,
 					ValidateFunc: validation.IsUUID,
@@ -146,6 +171,7 @@ func administrativeUnitResourceCustomizeDiff(ctx context.Context, diff *pluginsd
 
 func administrativeUnitResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).AdministrativeUnits.AdministrativeUnitClient
+	clientBeta := meta.(*clients.Client).AdministrativeUnits.AdministrativeUnitClientBeta
 	memberClient := meta.(*clients.Client).AdministrativeUnits.AdministrativeUnitMemberClient
 
 	displayName := d.Get("display_name").(string)
@@ -233,6 +259,13 @@ func administrativeUnitResourceCreate(ctx context.Context, d *pluginsdk.Resource
 		return tf.ErrorDiagF(err, "Failed to patch %s after creating", id)
 	}
 
+	// Dynamic membership properties are only supported by the beta API
+	if v, ok := d.GetOk("dynamic_membership"); ok && len(v.([]interface{})) > 0 {
+		if _, err = clientBeta.UpdateAdministrativeUnit(ctx, beta.NewAdministrativeUnitID(id.AdministrativeUnitId), administrativeUnitExpandDynamicMembership(v.([]interface{})), administrativeunitBeta.DefaultUpdateAdministrativeUnitOperationOptions()); err != nil {
+			return tf.ErrorDiagPathF(err, "dynamic_membership", "Could not configure dynamic membership for %s", id)
+		}
+	}
+
 	// Add members after the administrative unit is created
 	if v, ok := d.GetOk("members"); ok {
 		for _, memberIdRaw := range v.(*pluginsdk.Set).List() {
@@ -253,6 +286,7 @@ func administrativeUnitResourceCreate(ctx context.Context, d *pluginsdk.Resource
 
 func administrativeUnitResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).AdministrativeUnits.AdministrativeUnitClient
+	clientBeta := meta.(*clients.Client).AdministrativeUnits.AdministrativeUnitClientBeta
 	memberClient := meta.(*clients.Client).AdministrativeUnits.AdministrativeUnitMemberClient
 
 	id, err := stable.ParseDirectoryAdministrativeUnitID(d.Id())
@@ -298,7 +332,14 @@ func administrativeUnitResourceUpdate(ctx context.Context, d *pluginsdk.Resource
 		return tf.ErrorDiagF(err, "Updating %s", id)
 	}
 
-	if d.HasChange("members") {
+	if d.HasChange("dynamic_membership") {
+		if _, err = clientBeta.UpdateAdministrativeUnit(ctx, beta.NewAdministrativeUnitID(id.AdministrativeUnitId), administrativeUnitExpandDynamicMembership(d.Get("dynamic_membership").([]interface{})), administrativeunitBeta.DefaultUpdateAdministrativeUnitOperationOptions()); err != nil {
+			return tf.ErrorDiagPathF(err, "dynamic_membership", "Could not update dynamic membership for %s", id)
+		}
+	}
+
+	// Members of dynamic administrative units are managed by the membership rule and cannot be modified
+	if v, ok := d.GetOk("dynamic_membership"); d.HasChange("members") && (!ok || len(v.([]interface{})) == 0) {
 		membersResp, err := memberClient.ListAdministrativeUnitMembers(ctx, *id, administrativeunitmember.DefaultListAdministrativeUnitMembersOperationOptions())
 		if err != nil {
 			return tf.ErrorDiagF(err, "Could not retrieve members for %s", id)
@@ -336,6 +377,7 @@ func administrativeUnitResourceUpdate(ctx context.Context, d *pluginsdk.Resource
 
 func administrativeUnitResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).AdministrativeUnits.AdministrativeUnitClient
+	clientBeta := meta.(*clients.Client).AdministrativeUnits.AdministrativeUnitClientBeta
 	memberClient := meta.(*clients.Client).AdministrativeUnits.AdministrativeUnitMemberClient
 
 	id, err := stable.ParseDirectoryAdministrativeUnitID(d.Id())
@@ -361,6 +403,12 @@ func administrativeUnitResourceRead(ctx context.Context, d *pluginsdk.ResourceDa
 	hiddenMembershipEnabled := strings.EqualFold(administrativeUnit.Visibility.GetOrZero(), administrativeUnitVisibilityHiddenMembership)
 	tf.Set(d, "hidden_membership_enabled", hiddenMembershipEnabled)
 
+	dynamicMembership, err := administrativeUnitGetDynamicMembership(ctx, clientBeta, beta.NewAdministrativeUnitID(id.AdministrativeUnitId))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "dynamic_membership", "Could not retrieve dynamic membership for %s", id)
+	}
+	tf.Set(d, "dynamic_membership", dynamicMembership)
+
 	membersResp, err := memberClient.ListAdministrativeUnitMembers(ctx, *id, administrativeunitmember.DefaultListAdministrativeUnitMembersOperationOptions())
 	if err != nil {
 		return tf.ErrorDiagF(err, "Could not retrieve members for %s", id)
@@ -68,6 +68,39 @@ func TestAccAdministrativeUnit_withMembers(t *testing.T) {
 	})
 }
 
+func TestAccAdministrativeUnit_dynamicMembership(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_administrative_unit", "test")
+	r := AdministrativeUnitResource{}
+
+	data.ResourceTestIgnoreDangling(t, r, []acceptance.TestStep{
+		{
+			Config: r.dynamicMembership(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("dynamic_membership.#").HasValue("1"),
+				check.That(data.ResourceName).Key("dynamic_membership.0.enabled").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.dynamicMembership(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("dynamic_membership.0.enabled").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("dynamic_membership.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccGroup_preventDuplicateNamesPass(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_administrative_unit", "test")
 	r := AdministrativeUnitResource{}
@@ -133,6 +166,21 @@ resource "azuread_administrative_unit" "test" {
 `, data.RandomInteger, data.RandomString)
 }
 
+func (AdministrativeUnitResource) dynamicMembership(data acceptance.TestData, enabled bool) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_administrative_unit" "test" {
+  display_name = "acctestAdministrativeUnit-%[1]d"
+
+  dynamic_membership {
+    enabled = %[2]t
+    rule    = "user.department -eq \"Sales\""
+  }
+}
+`, data.RandomInteger, enabled)
+}
+
 func (AdministrativeUnitResource) withMembers(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azuread" {}
@@ -9,9 +9,12 @@ import (
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
+	administrativeunitBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/administrativeunits/beta/administrativeunit"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directory/stable/administrativeunit"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directory/stable/administrativeunitmember"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
 )
 
 func administrativeUnitFindByName(ctx context.Context, client *administrativeunit.AdministrativeUnitClient, displayName string) (*[]stable.AdministrativeUnit, error) {
@@ -59,3 +62,56 @@ func administrativeUnitGetMember(ctx context.Context, client *administrativeunit
 
 	return nil, nil
 }
+
+// administrativeUnitGetDynamicMembership retrieves the dynamic membership properties of an administrative unit, which
+// are only available with the beta API, and returns them flattened for the `dynamic_membership` block.
+func administrativeUnitGetDynamicMembership(ctx context.Context, client *administrativeunitBeta.AdministrativeUnitClient, id beta.AdministrativeUnitId) ([]interface{}, error) {
+	options := administrativeunitBeta.GetAdministrativeUnitOperationOptions{
+		Select: &[]string{
+			"membershipRule",
+			"membershipRuleProcessingState",
+			"membershipType",
+		},
+	}
+
+	resp, err := client.GetAdministrativeUnit(ctx, id, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, 0)
+	if administrativeUnit := resp.Model; administrativeUnit != nil && administrativeUnit.MembershipType.GetOrZero() == administrativeUnitMembershipTypeDynamic {
+		result = append(result, map[string]interface{}{
+			"enabled": administrativeUnit.MembershipRuleProcessingState.GetOrZero() != administrativeUnitMembershipRuleProcessingStatePaused,
+			"rule":    administrativeUnit.MembershipRule.GetOrZero(),
+		})
+	}
+
+	return result, nil
+}
+
+// administrativeUnitExpandDynamicMembership returns an administrative unit model containing the dynamic membership
+// properties for the provided `dynamic_membership` block. When the block is empty, the administrative unit will be
+// reverted to assigned membership.
+func administrativeUnitExpandDynamicMembership(input []interface{}) beta.AdministrativeUnit {
+	if len(input) == 0 || input[0] == nil {
+		return beta.AdministrativeUnit{
+			MembershipType:                nullable.Value(administrativeUnitMembershipTypeAssigned),
+			MembershipRule:                nullable.NoZero(""),
+			MembershipRuleProcessingState: nullable.NoZero(""),
+		}
+	}
+
+	in := input[0].(map[string]interface{})
+
+	processingState := administrativeUnitMembershipRuleProcessingStateOn
+	if !in["enabled"].(bool) {
+		processingState = administrativeUnitMembershipRuleProcessingStatePaused
+	}
+
+	return beta.AdministrativeUnit{
+		MembershipType:                nullable.Value(administrativeUnitMembershipTypeDynamic),
+		MembershipRule:                nullable.Value(in["rule"].(string)),
+		MembershipRuleProcessingState: nullable.Value(processingState),
+	}
+}
@@ -7,3 +7,13 @@ const (
 	administrativeUnitVisibilityHiddenMembership = "HiddenMembership"
 	administrativeUnitVisibilityPublic           = "Public"
 )
+
+const (
+	administrativeUnitMembershipTypeAssigned = "Assigned"
+	administrativeUnitMembershipTypeDynamic  = "Dynamic"
+)
+
+const (
+	administrativeUnitMembershipRuleProcessingStateOn     = "On"
+	administrativeUnitMembershipRuleProcessingStatePaused = "Paused"
+)
@@ -0,0 +1,845 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/oauth2permissiongrants/stable/oauth2permissiongrant"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/approleassignment"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+)
+
+const adminConsentTypeAllPrincipals = "AllPrincipals"
+
+func applicationAdminConsentResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: applicationAdminConsentResourceCreateUpdate,
+		ReadContext:   applicationAdminConsentResourceRead,
+		UpdateContext: applicationAdminConsentResourceCreateUpdate,
+		DeleteContext: applicationAdminConsentResourceDelete,
+
+		CustomizeDiff: applicationAdminConsentResourceCustomizeDiff,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(10 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(10 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(10 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.ParseAdminConsentID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"application_id": {
+				Description:  "The resource ID of the application for which to grant admin consent",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: stable.ValidateApplicationID,
+			},
+
+			"consent_when_changed": {
+				Description: "Arbitrary map of values that, when changed, will trigger consent to be granted again",
+				Type:        pluginsdk.TypeMap,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"service_principal_object_id": {
+				Description: "The object ID of the service principal for the application, to which permissions are granted",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"permissions": {
+				Description: "The permissions granted to the service principal by this resource, for each resource required by the application",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"resource_object_id": {
+							Description: "The object ID of the service principal representing the resource",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"resource_client_id": {
+							Description: "The client ID of the application representing the resource",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"delegated_permission_scopes": {
+							Description: "The values of the delegated permission scopes granted for all users",
+							Type:        pluginsdk.TypeSet,
+							Computed:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"app_role_ids": {
+							Description: "The IDs of the app roles assigned to the service principal",
+							Type:        pluginsdk.TypeSet,
+							Computed:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+					},
+				},
+			},
+
+			"stale": {
+				Description: "Whether the granted permissions differ from those currently required by the application",
+				Type:        pluginsdk.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// applicationAdminConsentPermissions describes the permissions for a single resource service principal
+type applicationAdminConsentPermissions struct {
+	ResourceObjectId string
+	ResourceClientId string
+	Scopes           []string
+	AppRoleIds       []string
+}
+
+// applicationAdminConsentGrants describes the existing grants for a single resource service principal
+type applicationAdminConsentGrants struct {
+	PermissionGrant *stable.OAuth2PermissionGrant
+	AppRoles        map[string]string
+}
+
+func applicationAdminConsentResourceCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+	// When the application's required permissions have changed since consent was last granted, plan an update
+	// to bring the granted permissions back in line
+	if diff.Id() != "" && diff.Get("stale").(bool) {
+		if err := diff.SetNew("stale", false); err != nil {
+			return err
+		}
+		if err := diff.SetNewComputed("permissions"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applicationAdminConsentResourceCreateUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationClient
+	appRoleAssignmentClient := meta.(*clients.Client).Applications.ServicePrincipalAppRoleAssignmentClient
+	grantClient := meta.(*clients.Client).Applications.OAuth2PermissionGrantClient
+
+	applicationId, err := stable.ParseApplicationID(d.Get("application_id").(string))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "application_id", "Parsing `application_id`")
+	}
+
+	id := parse.NewAdminConsentID(applicationId.ApplicationId)
+
+	tf.LockByName(applicationResourceName, id.ApplicationId)
+	defer tf.UnlockByName(applicationResourceName, id.ApplicationId)
+
+	resp, err := client.GetApplication(ctx, *applicationId, application.DefaultGetApplicationOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return tf.ErrorDiagPathF(err, "application_id", "%s was not found", applicationId)
+		}
+		return tf.ErrorDiagPathF(err, "application_id", "Retrieving %s", applicationId)
+	}
+
+	app := resp.Model
+	if app == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", applicationId)
+	}
+
+	servicePrincipal, err := applicationAdminConsentFindServicePrincipal(ctx, meta, app.AppId.GetOrZero())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving service principal for %s", applicationId)
+	}
+	if servicePrincipal == nil {
+		return tf.ErrorDiagPathF(fmt.Errorf("no service principal exists for %s, please create one with the `azuread_service_principal` resource", applicationId), "application_id", "Retrieving service principal for %s", applicationId)
+	}
+
+	servicePrincipalObjectId := pointer.From(servicePrincipal.Id)
+	servicePrincipalId := stable.NewServicePrincipalID(servicePrincipalObjectId)
+
+	required, unresolved, err := applicationAdminConsentRequiredPermissions(ctx, meta, app.RequiredResourceAccess)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Resolving required permissions for %s", applicationId)
+	}
+	if len(unresolved) > 0 {
+		return tf.ErrorDiagF(errors.New(strings.Join(unresolved, "; ")), "Resolving required permissions for %s", applicationId)
+	}
+
+	granted, err := applicationAdminConsentExistingGrants(ctx, meta, servicePrincipalObjectId)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving existing grants for %s", servicePrincipalId)
+	}
+
+	// The permissions previously granted by this resource are used to determine which grants can be revoked, so that
+	// permissions granted by other means are left in place. The prior state is used, since `permissions` is
+	// recomputed when the consent is stale.
+	previousPermissions, _ := d.GetChange("permissions")
+	managed := applicationAdminConsentManagedPermissions(previousPermissions.([]interface{}))
+
+	// Reconcile resources required by the application, as well as any previously consented resources which are no longer required
+	resourceObjectIds := applicationAdminConsentResourceObjectIds(required, managed)
+
+	// Permissions that were already granted before this resource granted consent are not recorded as managed, so
+	// that they are not revoked when no longer required, or when this resource is destroyed
+	permissions := make([]interface{}, 0)
+
+	for _, resourceObjectId := range resourceObjectIds {
+		desired := required[resourceObjectId]
+		if desired == nil {
+			desired = &applicationAdminConsentPermissions{ResourceObjectId: resourceObjectId}
+		}
+
+		previous := managed[resourceObjectId]
+		if previous == nil {
+			previous = &applicationAdminConsentPermissions{ResourceObjectId: resourceObjectId}
+		}
+
+		existing := granted[resourceObjectId]
+		if existing == nil {
+			existing = &applicationAdminConsentGrants{AppRoles: map[string]string{}}
+		}
+
+		existingScopes, existingAppRoleIds := existing.values()
+		if required[resourceObjectId] != nil {
+			resourceClientId := desired.ResourceClientId
+			if resourceClientId == "" {
+				resourceClientId = previous.ResourceClientId
+			}
+			permissions = append(permissions, map[string]interface{}{
+				"resource_object_id":          resourceObjectId,
+				"resource_client_id":          resourceClientId,
+				"delegated_permission_scopes": applicationAdminConsentManagedValues(previous.Scopes, desired.Scopes, existingScopes),
+				"app_role_ids":                applicationAdminConsentManagedValues(previous.AppRoleIds, desired.AppRoleIds, existingAppRoleIds),
+			})
+		}
+
+		// Delegated permissions are granted for all users with a single oauth2PermissionGrant per resource. Scopes
+		// previously granted by this resource which are no longer required are removed, and any other scopes are retained.
+		scopes := make([]string, 0)
+		if existing.PermissionGrant != nil {
+			for _, v := range strings.Fields(existing.PermissionGrant.Scope.GetOrZero()) {
+				if applicationAdminConsentContains(previous.Scopes, v) && !applicationAdminConsentContains(desired.Scopes, v) {
+					continue
+				}
+				if !applicationAdminConsentContains(scopes, v) {
+					scopes = append(scopes, v)
+				}
+			}
+		}
+		for _, v := range desired.Scopes {
+			if !applicationAdminConsentContains(scopes, v) {
+				scopes = append(scopes, v)
+			}
+		}
+
+		scope := strings.Join(scopes, " ")
+		if grant := existing.PermissionGrant; grant != nil && grant.Id != nil {
+			grantId := stable.NewOAuth2PermissionGrantID(*grant.Id)
+			if scope == "" {
+				if resp, err := grantClient.DeleteOAuth2PermissionGrant(ctx, grantId, oauth2permissiongrant.DefaultDeleteOAuth2PermissionGrantOperationOptions()); err != nil && !response.WasNotFound(resp.HttpResponse) {
+					return tf.ErrorDiagF(err, "Deleting %s", grantId)
+				}
+			} else if !applicationAdminConsentScopesEqual(grant.Scope.GetOrZero(), scopes) {
+				properties := stable.OAuth2PermissionGrant{
+					Scope: nullable.Value(scope),
+				}
+				if _, err := grantClient.UpdateOAuth2PermissionGrant(ctx, grantId, properties, oauth2permissiongrant.DefaultUpdateOAuth2PermissionGrantOperationOptions()); err != nil {
+					return tf.ErrorDiagF(err, "Updating %s", grantId)
+				}
+			}
+		} else if scope != "" {
+			properties := stable.OAuth2PermissionGrant{
+				ClientId:    servicePrincipalObjectId,
+				ConsentType: nullable.Value(adminConsentTypeAllPrincipals),
+				ResourceId:  pointer.To(resourceObjectId),
+				Scope:       nullable.Value(scope),
+			}
+
+			options := oauth2permissiongrant.CreateOAuth2PermissionGrantOperationOptions{
+				RetryFunc: func(resp *http.Response, o *odata.OData) (bool, error) {
+					if response.WasNotFound(resp) {
+						return true, nil
+					} else if response.WasBadRequest(resp) && o != nil && o.Error != nil {
+						return o.Error.Match("does not exist or one of its queried reference-property objects are not present"), nil
+					}
+					return false, nil
+				},
+			}
+
+			if _, err := grantClient.CreateOAuth2PermissionGrant(ctx, properties, options); err != nil {
+				return tf.ErrorDiagF(err, "Granting delegated permissions for resource service principal %q", resourceObjectId)
+			}
+		}
+
+		// Application permissions are granted with an app role assignment for each role
+		for _, appRoleId := range desired.AppRoleIds {
+			if _, ok := existing.AppRoles[appRoleId]; ok {
+				continue
+			}
+
+			properties := stable.AppRoleAssignment{
+				AppRoleId:   pointer.To(appRoleId),
+				PrincipalId: nullable.Value(servicePrincipalObjectId),
+				ResourceId:  nullable.Value(resourceObjectId),
+			}
+
+			options := approleassignment.CreateAppRoleAssignmentOperationOptions{
+				RetryFunc: func(resp *http.Response, o *odata.OData) (bool, error) {
+					if response.WasNotFound(resp) {
+						return true, nil
+					} else if response.WasBadRequest(resp) && o != nil && o.Error != nil {
+						return o.Error.Match("Not a valid reference update"), nil
+					}
+					return false, nil
+				},
+			}
+
+			if _, err := appRoleAssignmentClient.CreateAppRoleAssignment(ctx, servicePrincipalId, properties, options); err != nil {
+				return tf.ErrorDiagF(err, "Assigning app role %q for resource service principal %q", appRoleId, resourceObjectId)
+			}
+		}
+
+		// Only app role assignments previously granted by this resource are revoked
+		for appRoleId, assignmentId := range existing.AppRoles {
+			if applicationAdminConsentContains(desired.AppRoleIds, appRoleId) || !applicationAdminConsentContains(previous.AppRoleIds, appRoleId) {
+				continue
+			}
+
+			id := stable.NewServicePrincipalIdAppRoleAssignmentID(servicePrincipalObjectId, assignmentId)
+			if resp, err := appRoleAssignmentClient.DeleteAppRoleAssignment(ctx, id, approleassignment.DefaultDeleteAppRoleAssignmentOperationOptions()); err != nil && !response.WasNotFound(resp.HttpResponse) {
+				return tf.ErrorDiagF(err, "Deleting %s", id)
+			}
+		}
+	}
+
+	// Wait for the granted permissions to be replicated, so that they are not reported as stale when read back
+	if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		current, err := applicationAdminConsentExistingGrants(ctx, meta, servicePrincipalObjectId)
+		if err != nil {
+			return nil, err
+		}
+		for resourceObjectId, desired := range required {
+			scopes, appRoleIds := current[resourceObjectId].values()
+			for _, v := range desired.Scopes {
+				if !applicationAdminConsentContains(scopes, v) {
+					return pointer.To(false), nil
+				}
+			}
+			for _, v := range desired.AppRoleIds {
+				if !applicationAdminConsentContains(appRoleIds, v) {
+					return pointer.To(false), nil
+				}
+			}
+		}
+		return pointer.To(true), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for admin consent to be granted for %s", applicationId)
+	}
+
+	d.SetId(id.ID())
+	tf.Set(d, "permissions", permissions)
+
+	return applicationAdminConsentResourceRead(ctx, d, meta)
+}
+
+func applicationAdminConsentResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationClient
+
+	id, err := parse.ParseAdminConsentID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing ID")
+	}
+
+	applicationId := stable.NewApplicationID(id.ApplicationId)
+
+	resp, err := client.GetApplication(ctx, applicationId, application.DefaultGetApplicationOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing from state!", applicationId)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving %s", applicationId)
+	}
+
+	app := resp.Model
+	if app == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", applicationId)
+	}
+
+	servicePrincipal, err := applicationAdminConsentFindServicePrincipal(ctx, meta, app.AppId.GetOrZero())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving service principal for %s", applicationId)
+	}
+	if servicePrincipal == nil {
+		log.Printf("[DEBUG] Service principal for %s was not found - removing from state!", applicationId)
+		d.SetId("")
+		return nil
+	}
+
+	servicePrincipalObjectId := pointer.From(servicePrincipal.Id)
+
+	// Permissions which cannot currently be resolved, for example because a resource service principal has been
+	// deleted, cause the consent to be considered stale rather than failing to refresh
+	required, unresolved, err := applicationAdminConsentRequiredPermissions(ctx, meta, app.RequiredResourceAccess)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Resolving required permissions for %s", applicationId)
+	}
+
+	granted, err := applicationAdminConsentExistingGrants(ctx, meta, servicePrincipalObjectId)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving existing grants for service principal %q", servicePrincipalObjectId)
+	}
+
+	stale := false
+	for _, v := range unresolved {
+		log.Printf("[DEBUG] Admin consent is stale for %s: %s", applicationId, v)
+		stale = true
+	}
+
+	managed := applicationAdminConsentManagedPermissions(d.Get("permissions").([]interface{}))
+	permissions := make([]interface{}, 0)
+
+	for _, resourceObjectId := range applicationAdminConsentResourceObjectIds(required, managed) {
+		desired := required[resourceObjectId]
+		if desired == nil {
+			desired = &applicationAdminConsentPermissions{ResourceObjectId: resourceObjectId}
+		}
+
+		previous := managed[resourceObjectId]
+		if previous == nil {
+			previous = &applicationAdminConsentPermissions{ResourceObjectId: resourceObjectId}
+		}
+
+		resourceClientId := desired.ResourceClientId
+		if resourceClientId == "" {
+			resourceClientId = previous.ResourceClientId
+		}
+
+		// Only report the existing permissions that were granted by this resource
+		scopes := make([]string, 0)
+		appRoleIds := make([]string, 0)
+		existingScopes, existingAppRoleIds := granted[resourceObjectId].values()
+		for _, v := range existingScopes {
+			if applicationAdminConsentContains(previous.Scopes, v) {
+				scopes = append(scopes, v)
+			}
+		}
+		for _, v := range existingAppRoleIds {
+			if applicationAdminConsentContains(previous.AppRoleIds, v) {
+				appRoleIds = append(appRoleIds, v)
+			}
+		}
+
+		// Consent is stale when a required permission has not been granted, regardless of how it was granted, or when
+		// a permission granted by this resource is no longer required
+		if !applicationAdminConsentContainsAll(existingScopes, desired.Scopes) || !applicationAdminConsentContainsAll(existingAppRoleIds, desired.AppRoleIds) ||
+			!applicationAdminConsentContainsAll(desired.Scopes, scopes) || !applicationAdminConsentContainsAll(desired.AppRoleIds, appRoleIds) {
+			log.Printf("[DEBUG] Admin consent for resource service principal %q is stale for %s", resourceObjectId, applicationId)
+			stale = true
+		}
+
+		// Omit resources which are no longer required and have nothing granted
+		if len(scopes) == 0 && len(appRoleIds) == 0 && required[resourceObjectId] == nil {
+			continue
+		}
+
+		permissions = append(permissions, map[string]interface{}{
+			"resource_object_id":          resourceObjectId,
+			"resource_client_id":          resourceClientId,
+			"delegated_permission_scopes": scopes,
+			"app_role_ids":                appRoleIds,
+		})
+	}
+
+	tf.Set(d, "application_id", applicationId.ID())
+	tf.Set(d, "permissions", permissions)
+	tf.Set(d, "service_principal_object_id", servicePrincipalObjectId)
+	tf.Set(d, "stale", stale)
+
+	return nil
+}
+
+func applicationAdminConsentResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	appRoleAssignmentClient := meta.(*clients.Client).Applications.ServicePrincipalAppRoleAssignmentClient
+	grantClient := meta.(*clients.Client).Applications.OAuth2PermissionGrantClient
+
+	id, err := parse.ParseAdminConsentID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing ID")
+	}
+
+	servicePrincipalObjectId := d.Get("service_principal_object_id").(string)
+	if servicePrincipalObjectId == "" {
+		return nil
+	}
+
+	tf.LockByName(applicationResourceName, id.ApplicationId)
+	defer tf.UnlockByName(applicationResourceName, id.ApplicationId)
+
+	granted, err := applicationAdminConsentExistingGrants(ctx, meta, servicePrincipalObjectId)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving existing grants for service principal %q", servicePrincipalObjectId)
+	}
+
+	// Only revoke the permissions which were granted by this resource
+	managed := applicationAdminConsentManagedPermissions(d.Get("permissions").([]interface{}))
+
+	for _, resourceObjectId := range applicationAdminConsentResourceObjectIds(nil, managed) {
+		previous := managed[resourceObjectId]
+		existing := granted[resourceObjectId]
+		if existing == nil {
+			continue
+		}
+
+		if grant := existing.PermissionGrant; grant != nil && grant.Id != nil {
+			grantId := stable.NewOAuth2PermissionGrantID(*grant.Id)
+
+			remaining := make([]string, 0)
+			for _, v := range strings.Fields(grant.Scope.GetOrZero()) {
+				if !applicationAdminConsentContains(previous.Scopes, v) {
+					remaining = append(remaining, v)
+				}
+			}
+
+			if len(remaining) == 0 {
+				if resp, err := grantClient.DeleteOAuth2PermissionGrant(ctx, grantId, oauth2permissiongrant.DefaultDeleteOAuth2PermissionGrantOperationOptions()); err != nil && !response.WasNotFound(resp.HttpResponse) {
+					return tf.ErrorDiagF(err, "Deleting %s", grantId)
+				}
+			} else if !applicationAdminConsentScopesEqual(grant.Scope.GetOrZero(), remaining) {
+				properties := stable.OAuth2PermissionGrant{
+					Scope: nullable.Value(strings.Join(remaining, " ")),
+				}
+				if _, err := grantClient.UpdateOAuth2PermissionGrant(ctx, grantId, properties, oauth2permissiongrant.DefaultUpdateOAuth2PermissionGrantOperationOptions()); err != nil {
+					return tf.ErrorDiagF(err, "Updating %s", grantId)
+				}
+			}
+		}
+
+		for appRoleId, assignmentId := range existing.AppRoles {
+			if !applicationAdminConsentContains(previous.AppRoleIds, appRoleId) {
+				continue
+			}
+			assignment := stable.NewServicePrincipalIdAppRoleAssignmentID(servicePrincipalObjectId, assignmentId)
+			if resp, err := appRoleAssignmentClient.DeleteAppRoleAssignment(ctx, assignment, approleassignment.DefaultDeleteAppRoleAssignmentOperationOptions()); err != nil && !response.WasNotFound(resp.HttpResponse) {
+				return tf.ErrorDiagF(err, "Deleting %s", assignment)
+			}
+		}
+	}
+
+	return nil
+}
+
+// applicationAdminConsentFindServicePrincipal returns the service principal for the specified client ID, or nil if none exists
+func applicationAdminConsentFindServicePrincipal(ctx context.Context, meta interface{}, clientId string) (*stable.ServicePrincipal, error) {
+	client := meta.(*clients.Client).Applications.ServicePrincipalClient
+
+	options := serviceprincipal.ListServicePrincipalsOperationOptions{
+		Filter: pointer.To(fmt.Sprintf("appId eq '%s'", odata.EscapeSingleQuote(clientId))),
+	}
+	resp, err := client.ListServicePrincipals(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("listing service principals for client ID %q: %+v", clientId, err)
+	}
+	if resp.Model == nil {
+		return nil, errors.New("model was nil")
+	}
+
+	for _, servicePrincipal := range *resp.Model {
+		if servicePrincipal.Id != nil && strings.EqualFold(servicePrincipal.AppId.GetOrZero(), clientId) {
+			return &servicePrincipal, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// applicationAdminConsentRequiredPermissions resolves the required resource access of an application into the
+// delegated permission scope values and app role IDs for each resource service principal, keyed by its object ID.
+// Permissions that cannot be resolved, such as those for a resource without a service principal, are described in
+// the returned slice of strings. An error is only returned when the API could not be queried.
+func applicationAdminConsentRequiredPermissions(ctx context.Context, meta interface{}, requiredResourceAccess *[]stable.RequiredResourceAccess) (map[string]*applicationAdminConsentPermissions, []string, error) {
+	result := make(map[string]*applicationAdminConsentPermissions)
+	unresolved := make([]string, 0)
+	if requiredResourceAccess == nil {
+		return result, unresolved, nil
+	}
+
+	for _, rra := range *requiredResourceAccess {
+		resourceClientId := pointer.From(rra.ResourceAppId)
+		if resourceClientId == "" || rra.ResourceAccess == nil || len(*rra.ResourceAccess) == 0 {
+			continue
+		}
+
+		resource, err := applicationAdminConsentFindServicePrincipal(ctx, meta, resourceClientId)
+		if err != nil {
+			return nil, nil, err
+		}
+		if resource == nil {
+			unresolved = append(unresolved, fmt.Sprintf("no service principal exists for resource with client ID %q", resourceClientId))
+			continue
+		}
+
+		scopeValues := make(map[string]string)
+		if resource.OAuth2PermissionScopes != nil {
+			for _, scope := range *resource.OAuth2PermissionScopes {
+				scopeValues[strings.ToLower(pointer.From(scope.Id))] = scope.Value.GetOrZero()
+			}
+		}
+
+		appRoleIds := make(map[string]string)
+		if resource.AppRoles != nil {
+			for _, appRole := range *resource.AppRoles {
+				appRoleIds[strings.ToLower(pointer.From(appRole.Id))] = pointer.From(appRole.Id)
+			}
+		}
+
+		permissions := result[*resource.Id]
+		if permissions == nil {
+			permissions = &applicationAdminConsentPermissions{
+				ResourceObjectId: *resource.Id,
+				ResourceClientId: resourceClientId,
+				Scopes:           make([]string, 0),
+				AppRoleIds:       make([]string, 0),
+			}
+			result[*resource.Id] = permissions
+		}
+
+		for _, access := range *rra.ResourceAccess {
+			accessId := strings.ToLower(pointer.From(access.Id))
+
+			switch access.Type.GetOrZero() {
+			case "Scope":
+				value, ok := scopeValues[accessId]
+				if !ok || value == "" {
+					unresolved = append(unresolved, fmt.Sprintf("delegated permission scope %q was not found for resource with client ID %q", accessId, resourceClientId))
+					continue
+				}
+				permissions.Scopes = append(permissions.Scopes, value)
+
+			case "Role":
+				appRoleId, ok := appRoleIds[accessId]
+				if !ok {
+					unresolved = append(unresolved, fmt.Sprintf("app role %q was not found for resource with client ID %q", accessId, resourceClientId))
+					continue
+				}
+				permissions.AppRoleIds = append(permissions.AppRoleIds, appRoleId)
+			}
+		}
+
+		sort.Strings(permissions.Scopes)
+		sort.Strings(permissions.AppRoleIds)
+	}
+
+	return result, unresolved, nil
+}
+
+// applicationAdminConsentExistingGrants returns the tenant-wide delegated permission grants and app role assignments
+// for the specified service principal, keyed by the object ID of the resource service principal
+func applicationAdminConsentExistingGrants(ctx context.Context, meta interface{}, servicePrincipalObjectId string) (map[string]*applicationAdminConsentGrants, error) {
+	appRoleAssignmentClient := meta.(*clients.Client).Applications.ServicePrincipalAppRoleAssignmentClient
+	grantClient := meta.(*clients.Client).Applications.OAuth2PermissionGrantClient
+
+	result := make(map[string]*applicationAdminConsentGrants)
+	get := func(resourceObjectId string) *applicationAdminConsentGrants {
+		if result[resourceObjectId] == nil {
+			result[resourceObjectId] = &applicationAdminConsentGrants{AppRoles: map[string]string{}}
+		}
+		return result[resourceObjectId]
+	}
+
+	grantOptions := oauth2permissiongrant.ListOAuth2PermissionGrantsOperationOptions{
+		Filter: pointer.To(fmt.Sprintf("clientId eq '%s' and consentType eq '%s'", odata.EscapeSingleQuote(servicePrincipalObjectId), adminConsentTypeAllPrincipals)),
+	}
+	grantsResp, err := grantClient.ListOAuth2PermissionGrants(ctx, grantOptions)
+	if err != nil {
+		return nil, fmt.Errorf("listing delegated permission grants: %+v", err)
+	}
+	if grantsResp.Model != nil {
+		for _, grant := range *grantsResp.Model {
+			if grant.ResourceId == nil {
+				continue
+			}
+			get(*grant.ResourceId).PermissionGrant = pointer.To(grant)
+		}
+	}
+
+	assignmentsResp, err := appRoleAssignmentClient.ListAppRoleAssignments(ctx, stable.NewServicePrincipalID(servicePrincipalObjectId), approleassignment.DefaultListAppRoleAssignmentsOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("listing app role assignments: %+v", err)
+	}
+	if assignmentsResp.Model != nil {
+		for _, assignment := range *assignmentsResp.Model {
+			if assignment.Id == nil || assignment.AppRoleId == nil || assignment.ResourceId.GetOrZero() == "" {
+				continue
+			}
+			get(assignment.ResourceId.GetOrZero()).AppRoles[*assignment.AppRoleId] = *assignment.Id
+		}
+	}
+
+	return result, nil
+}
+
+// applicationAdminConsentManagedPermissions returns the permissions previously granted by the resource, as recorded in
+// the `permissions` attribute, keyed by the object ID of the resource service principal
+func applicationAdminConsentManagedPermissions(in []interface{}) map[string]*applicationAdminConsentPermissions {
+	result := make(map[string]*applicationAdminConsentPermissions)
+
+	for _, raw := range in {
+		permission, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		resourceObjectId, _ := permission["resource_object_id"].(string)
+		if resourceObjectId == "" {
+			continue
+		}
+
+		permissions := &applicationAdminConsentPermissions{
+			ResourceObjectId: resourceObjectId,
+			Scopes:           make([]string, 0),
+			AppRoleIds:       make([]string, 0),
+		}
+		permissions.ResourceClientId, _ = permission["resource_client_id"].(string)
+		if v, ok := permission["delegated_permission_scopes"].(*pluginsdk.Set); ok {
+			permissions.Scopes = tf.ExpandStringSlice(v.List())
+		}
+		if v, ok := permission["app_role_ids"].(*pluginsdk.Set); ok {
+			permissions.AppRoleIds = tf.ExpandStringSlice(v.List())
+		}
+
+		result[resourceObjectId] = permissions
+	}
+
+	return result
+}
+
+// applicationAdminConsentResourceObjectIds returns the sorted object IDs of resource service principals which are
+// either required or were previously consented
+func applicationAdminConsentResourceObjectIds(required, managed map[string]*applicationAdminConsentPermissions) []string {
+	ids := make(map[string]bool)
+	for resourceObjectId := range required {
+		ids[resourceObjectId] = true
+	}
+	for resourceObjectId := range managed {
+		ids[resourceObjectId] = true
+	}
+
+	result := make([]string, 0, len(ids))
+	for resourceObjectId := range ids {
+		result = append(result, resourceObjectId)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// values returns the delegated permission scopes and app role IDs that have been granted
+func (g *applicationAdminConsentGrants) values() (scopes []string, appRoleIds []string) {
+	scopes = make([]string, 0)
+	appRoleIds = make([]string, 0)
+	if g == nil {
+		return
+	}
+
+	if g.PermissionGrant != nil {
+		scopes = strings.Fields(g.PermissionGrant.Scope.GetOrZero())
+	}
+	for appRoleId := range g.AppRoles {
+		appRoleIds = append(appRoleIds, appRoleId)
+	}
+	sort.Strings(appRoleIds)
+
+	return
+}
+
+// applicationAdminConsentManagedValues returns the permission values that are managed by the resource after granting
+// consent, which are those previously granted by the resource that are still desired, along with those desired that
+// were not already granted
+func applicationAdminConsentManagedValues(previous, desired, existing []string) []string {
+	result := make([]string, 0)
+	for _, v := range desired {
+		if applicationAdminConsentContains(previous, v) || !applicationAdminConsentContains(existing, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// applicationAdminConsentContainsAll returns whether a slice contains all the specified values, ignoring case
+func applicationAdminConsentContainsAll(values []string, want []string) bool {
+	for _, v := range want {
+		if !applicationAdminConsentContains(values, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// applicationAdminConsentContains returns whether a slice contains the specified value, ignoring case
+func applicationAdminConsentContains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// applicationAdminConsentScopesEqual compares a space-separated list of values with a slice of values, ignoring order and case
+func applicationAdminConsentScopesEqual(current string, desired []string) bool {
+	currentValues := strings.Fields(strings.ToLower(current))
+	if len(currentValues) != len(desired) {
+		return false
+	}
+
+	sort.Strings(currentValues)
+	desiredValues := make([]string, 0, len(desired))
+	for _, v := range desired {
+		desiredValues = append(desiredValues, strings.ToLower(v))
+	}
+	sort.Strings(desiredValues)
+
+	for i := range currentValues {
+		if currentValues[i] != desiredValues[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,247 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+)
+
+type ApplicationAdminConsentResource struct{}
+
+func TestAccApplicationAdminConsent_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_admin_consent", "test")
+	r := ApplicationAdminConsentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("permissions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("permissions.0.delegated_permission_scopes.#").HasValue("2"),
+				check.That(data.ResourceName).Key("permissions.0.app_role_ids.#").HasValue("1"),
+				check.That(data.ResourceName).Key("service_principal_object_id").IsUuid(),
+				check.That(data.ResourceName).Key("stale").HasValue("false"),
+			),
+		},
+		data.ImportStep("permissions"),
+	})
+}
+
+func TestAccApplicationAdminConsent_consentWhenChanged(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_admin_consent", "test")
+	r := ApplicationAdminConsentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.consentWhenChanged(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("permissions.0.app_role_ids.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("consent_when_changed", "permissions"),
+		{
+			Config: r.consentWhenChanged(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("permissions.0.app_role_ids.#").HasValue("0"),
+				check.That(data.ResourceName).Key("stale").HasValue("false"),
+			),
+		},
+		data.ImportStep("consent_when_changed", "permissions"),
+	})
+}
+
+func TestAccApplicationAdminConsent_permissionsChanged(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_admin_consent", "test")
+	r := ApplicationAdminConsentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("stale").HasValue("false"),
+			),
+		},
+		{
+			// Changing the application's permissions makes the consent stale, which is detected on the next plan
+			Config:             r.updated(data),
+			ExpectNonEmptyPlan: true,
+		},
+		{
+			Config: r.updated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("permissions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("permissions.0.delegated_permission_scopes.#").HasValue("1"),
+				check.That(data.ResourceName).Key("permissions.0.app_role_ids.#").HasValue("0"),
+				check.That(data.ResourceName).Key("stale").HasValue("false"),
+			),
+		},
+		data.ImportStep("permissions"),
+	})
+}
+
+func TestAccApplicationAdminConsent_existingAppRoleAssignment(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_admin_consent", "test")
+	r := ApplicationAdminConsentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.existingAppRoleAssignment(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("permissions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("permissions.0.delegated_permission_scopes.#").HasValue("2"),
+				check.That(data.ResourceName).Key("permissions.0.app_role_ids.#").HasValue("0"),
+				check.That(data.ResourceName).Key("stale").HasValue("false"),
+			),
+		},
+		{
+			// The app role assignment that existed before consent was granted should not be revoked
+			Config: r.existingAppRoleAssignment(data, false),
+		},
+	})
+}
+
+func (r ApplicationAdminConsentResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Applications.ApplicationClient
+
+	id, err := parse.ParseAdminConsentID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	applicationId := stable.NewApplicationID(id.ApplicationId)
+
+	resp, err := client.GetApplication(ctx, applicationId, application.DefaultGetApplicationOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %+v", applicationId, err)
+	}
+
+	return pointer.To(true), nil
+}
+
+func (ApplicationAdminConsentResource) template(data acceptance.TestData, allPermissions bool) string {
+	appRole := ""
+	if allPermissions {
+		appRole = `
+    resource_access {
+      id   = azuread_service_principal.msgraph.oauth2_permission_scope_ids["openid"]
+      type = "Scope"
+    }
+
+    resource_access {
+      id   = azuread_service_principal.msgraph.app_role_ids["User.Read.All"]
+      type = "Role"
+    }
+`
+	}
+
+	return fmt.Sprintf(`
+data "azuread_application_published_app_ids" "well_known" {}
+
+resource "azuread_service_principal" "msgraph" {
+  client_id    = data.azuread_application_published_app_ids.well_known.result.MicrosoftGraph
+  use_existing = true
+}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-AdminConsent-%[1]d"
+
+  required_resource_access {
+    resource_app_id = data.azuread_application_published_app_ids.well_known.result.MicrosoftGraph
+
+    resource_access {
+      id   = azuread_service_principal.msgraph.oauth2_permission_scope_ids["User.Read"]
+      type = "Scope"
+    }
+%[2]s  }
+}
+
+resource "azuread_service_principal" "test" {
+  client_id = azuread_application.test.client_id
+}
+`, data.RandomInteger, appRole)
+}
+
+func (r ApplicationAdminConsentResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_admin_consent" "test" {
+  application_id = azuread_application.test.id
+
+  depends_on = [azuread_service_principal.test]
+}
+`, r.template(data, true))
+}
+
+func (r ApplicationAdminConsentResource) updated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_admin_consent" "test" {
+  application_id = azuread_application.test.id
+
+  depends_on = [azuread_service_principal.test]
+}
+`, r.template(data, false))
+}
+
+func (r ApplicationAdminConsentResource) consentWhenChanged(data acceptance.TestData, allPermissions bool) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_admin_consent" "test" {
+  application_id = azuread_application.test.id
+
+  consent_when_changed = {
+    required_resource_access = sha1(jsonencode(azuread_application.test.required_resource_access))
+  }
+
+  depends_on = [azuread_service_principal.test]
+}
+`, r.template(data, allPermissions))
+}
+
+func (r ApplicationAdminConsentResource) existingAppRoleAssignment(data acceptance.TestData, consent bool) string {
+	adminConsent := ""
+	if consent {
+		adminConsent = `
+resource "azuread_application_admin_consent" "test" {
+  application_id = azuread_application.test.id
+
+  depends_on = [azuread_app_role_assignment.test]
+}
+`
+	}
+
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_app_role_assignment" "test" {
+  app_role_id         = azuread_service_principal.msgraph.app_role_ids["User.Read.All"]
+  principal_object_id = azuread_service_principal.test.object_id
+  resource_object_id  = azuread_service_principal.msgraph.object_id
+}
+%[2]s`, r.template(data, true), adminConsent)
+}
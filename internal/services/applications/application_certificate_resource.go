@@ -28,6 +28,8 @@ func applicationCertificateResource() *pluginsdk.Resource {
 		ReadContext:   applicationCertificateResourceRead,
 		DeleteContext: applicationCertificateResourceDelete,
 
+		CustomizeDiff: credentials.GeneratedCertificateRenewalCustomizeDiff,
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(10 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -112,12 +114,36 @@ func applicationCertificateResource() *pluginsdk.Resource {
 			},
 
 			"value": {
-				Description: "The certificate data, which can be PEM encoded, base64 encoded DER or hexadecimal encoded DER. See also the `encoding` argument",
+				Description:  "The certificate data, which can be PEM encoded, base64 encoded DER or hexadecimal encoded DER. See also the `encoding` argument",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"generate", "value"},
+			},
+
+			"generate": credentials.GenerateCertificateSchema(),
+
+			"private_key_pem": {
+				Description: "The PEM encoded private key of the generated certificate",
 				Type:        pluginsdk.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Computed:    true,
+				Sensitive:   true,
+			},
+
+			"pkcs12_base64": {
+				Description: "A base64 encoded PKCS#12 bundle containing the generated certificate and its private key",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
 				Sensitive:   true,
 			},
+
+			"ready_for_renewal": {
+				Description: "Whether the generated certificate is due to be replaced",
+				Type:        pluginsdk.TypeBool,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -130,6 +156,15 @@ func applicationCertificateResourceCreate(ctx context.Context, d *pluginsdk.Reso
 		return tf.ErrorDiagPathF(err, "application_id", "Parsing `application_id`")
 	}
 
+	generated, err := credentials.GenerateCertificateForResource(d)
+	if err != nil {
+		attr := ""
+		if kerr, ok := err.(credentials.CredentialError); ok {
+			attr = kerr.Attr()
+		}
+		return tf.ErrorDiagPathF(err, attr, "Generating certificate for %s", applicationId)
+	}
+
 	credential, err := credentials.KeyCredentialForResource(d)
 	if err != nil {
 		attr := ""
@@ -215,6 +250,12 @@ func applicationCertificateResourceCreate(ctx context.Context, d *pluginsdk.Reso
 
 	d.SetId(id.String())
 
+	if generated != nil {
+		tf.Set(d, "private_key_pem", generated.PrivateKeyPem)
+		tf.Set(d, "pkcs12_base64", generated.PKCS12Base64)
+	}
+	tf.Set(d, "ready_for_renewal", false)
+
 	return applicationCertificateResourceRead(ctx, d, meta)
 }
 
@@ -6,6 +6,7 @@ package applications_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -155,6 +156,57 @@ func TestAccApplicationCertificate_relativeEndDate(t *testing.T) {
 	})
 }
 
+func TestAccApplicationCertificate_generate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_certificate", "test")
+	r := ApplicationCertificateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.generate(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("key_id").Exists(),
+				check.That(data.ResourceName).Key("end_date").Exists(),
+				check.That(data.ResourceName).Key("private_key_pem").Exists(),
+				check.That(data.ResourceName).Key("pkcs12_base64").Exists(),
+				check.That(data.ResourceName).Key("value").Exists(),
+			),
+		},
+		data.ImportStep("encoding", "generate", "pkcs12_base64", "private_key_pem", "ready_for_renewal", "value"),
+	})
+}
+
+func TestAccApplicationCertificate_generateECDSA(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_certificate", "test")
+	endDate := time.Now().AddDate(0, 3, 27).UTC().Format(time.RFC3339)
+	r := ApplicationCertificateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.generateECDSA(data, endDate),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("end_date").HasValue(endDate),
+				check.That(data.ResourceName).Key("private_key_pem").Exists(),
+				check.That(data.ResourceName).Key("pkcs12_base64").Exists(),
+			),
+		},
+		data.ImportStep("encoding", "generate", "pkcs12_base64", "private_key_pem", "ready_for_renewal", "value"),
+	})
+}
+
+func TestAccApplicationCertificate_generateWithEncoding(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_certificate", "test")
+	r := ApplicationCertificateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.generateWithEncoding(data),
+			ExpectError: regexp.MustCompile("conflicts with encoding"),
+		},
+	})
+}
+
 func TestAccApplicationCertificate_requiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_application_certificate", "test")
 	endDate := time.Now().AddDate(0, 3, 27).UTC().Format(time.RFC3339)
@@ -307,3 +359,54 @@ resource "azuread_application_certificate" "import" {
 }
 `, r.basic(data, endDate))
 }
+
+func (r ApplicationCertificateResource) generate(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_certificate" "test" {
+  application_id = azuread_application.test.id
+
+  generate {
+    common_name       = "acctest-%[2]d"
+    validity_in_days  = 90
+    renew_before_days = 14
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ApplicationCertificateResource) generateWithEncoding(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_certificate" "test" {
+  application_id = azuread_application.test.id
+  encoding       = "base64"
+
+  generate {
+    common_name       = "acctest-%[2]d"
+    validity_in_days  = 90
+    renew_before_days = 14
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ApplicationCertificateResource) generateECDSA(data acceptance.TestData, endDate string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_certificate" "test" {
+  application_id = azuread_application.test.id
+  end_date       = "%[3]s"
+
+  generate {
+    common_name     = "acctest-%[2]d"
+    key_algorithm   = "ECDSA"
+    ecdsa_curve     = "P384"
+    pkcs12_password = "%[4]s"
+  }
+}
+`, r.template(data), data.RandomInteger, endDate, data.RandomPassword)
+}
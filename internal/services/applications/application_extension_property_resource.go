@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/extensionproperty"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/directoryextensions"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+)
+
+type ApplicationExtensionPropertyModel struct {
+	ApplicationId       string   `tfschema:"application_id"`
+	Name                string   `tfschema:"name"`
+	DataType            string   `tfschema:"data_type"`
+	TargetObjects       []string `tfschema:"target_objects"`
+	MultiValued         bool     `tfschema:"multi_valued"`
+	ExtensionName       string   `tfschema:"extension_name"`
+	ExtensionPropertyId string   `tfschema:"extension_property_id"`
+}
+
+var _ sdk.Resource = ApplicationExtensionPropertyResource{}
+
+type ApplicationExtensionPropertyResource struct{}
+
+func (r ApplicationExtensionPropertyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return stable.ValidateApplicationIdExtensionPropertyID
+}
+
+func (r ApplicationExtensionPropertyResource) ResourceType() string {
+	return "azuread_application_extension_property"
+}
+
+func (r ApplicationExtensionPropertyResource) ModelObject() interface{} {
+	return &ApplicationExtensionPropertyModel{}
+}
+
+func (r ApplicationExtensionPropertyResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"application_id": {
+			Description:  "The resource ID of the application on which to declare the extension property",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: stable.ValidateApplicationID,
+		},
+
+		"name": {
+			Description: "The name of the extension property, without the `extension_{appId}_` prefix",
+			Type:        pluginsdk.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			ValidateFunc: validation.All(
+				validation.StringLenBetween(1, 77),
+				validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9_]+$`), "must contain only letters, numbers and underscores"),
+			),
+		},
+
+		"data_type": {
+			Description:  "The data type of the values that the extension property can hold",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice(directoryextensions.PossibleValuesForDataType, false),
+		},
+
+		"target_objects": {
+			Description: "The types of directory objects to which the extension property can be applied",
+			Type:        pluginsdk.TypeSet,
+			Required:    true,
+			ForceNew:    true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringInSlice(directoryextensions.PossibleValuesForTargetObject, false),
+			},
+		},
+
+		"multi_valued": {
+			Description: "Whether the extension property can store a collection of values",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     false,
+		},
+	}
+}
+
+func (r ApplicationExtensionPropertyResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"extension_name": {
+			Description: "The full name of the extension property, in the format `extension_{appId}_{name}`",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+
+		"extension_property_id": {
+			Description: "The object ID of the extension property",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func (r ApplicationExtensionPropertyResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Applications.ApplicationClient
+			extensionPropertyClient := metadata.Client.Applications.ApplicationExtensionPropertyClient
+
+			var model ApplicationExtensionPropertyModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			applicationId, err := stable.ParseApplicationID(model.ApplicationId)
+			if err != nil {
+				return err
+			}
+
+			tf.LockByName(applicationResourceName, applicationId.ApplicationId)
+			defer tf.UnlockByName(applicationResourceName, applicationId.ApplicationId)
+
+			resp, err := client.GetApplication(ctx, *applicationId, application.DefaultGetApplicationOperationOptions())
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", applicationId, err)
+			}
+
+			app := resp.Model
+			if app == nil {
+				return fmt.Errorf("retrieving %s: model was nil", applicationId)
+			}
+
+			extensionName := directoryextensions.Name(app.AppId.GetOrZero(), model.Name)
+
+			// Check for an existing extension property with the same name
+			listResp, err := extensionPropertyClient.ListExtensionPropertiesComplete(ctx, *applicationId, extensionproperty.DefaultListExtensionPropertiesOperationOptions())
+			if err != nil {
+				return fmt.Errorf("listing extension properties for %s: %+v", applicationId, err)
+			}
+			for _, existing := range listResp.Items {
+				if existing.Id != nil && strings.EqualFold(pointer.From(existing.Name), extensionName) {
+					return metadata.ResourceRequiresImport(r.ResourceType(), stable.NewApplicationIdExtensionPropertyID(applicationId.ApplicationId, *existing.Id))
+				}
+			}
+
+			properties := stable.ExtensionProperty{
+				Name:          pointer.To(model.Name),
+				DataType:      pointer.To(model.DataType),
+				IsMultiValued: pointer.To(model.MultiValued),
+				TargetObjects: pointer.To(model.TargetObjects),
+			}
+
+			createResp, err := extensionPropertyClient.CreateExtensionProperty(ctx, *applicationId, properties, extensionproperty.DefaultCreateExtensionPropertyOperationOptions())
+			if err != nil {
+				return fmt.Errorf("creating extension property %q for %s: %+v", model.Name, applicationId, err)
+			}
+
+			extensionProperty := createResp.Model
+			if extensionProperty == nil || extensionProperty.Id == nil {
+				return fmt.Errorf("creating extension property %q for %s: nil or empty ID received", model.Name, applicationId)
+			}
+
+			id := stable.NewApplicationIdExtensionPropertyID(applicationId.ApplicationId, *extensionProperty.Id)
+
+			// Wait for the extension property to replicate
+			if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+				resp, err := extensionPropertyClient.GetExtensionProperty(ctx, id, extensionproperty.DefaultGetExtensionPropertyOperationOptions())
+				if err != nil {
+					if response.WasNotFound(resp.HttpResponse) {
+						return pointer.To(false), nil
+					}
+					return nil, err
+				}
+				return pointer.To(resp.Model != nil), nil
+			}); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r ApplicationExtensionPropertyResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Applications.ApplicationExtensionPropertyClient
+
+			id, err := stable.ParseApplicationIdExtensionPropertyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.GetExtensionProperty(ctx, *id, extensionproperty.DefaultGetExtensionPropertyOperationOptions())
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			extensionProperty := resp.Model
+			if extensionProperty == nil {
+				return fmt.Errorf("retrieving %s: model was nil", id)
+			}
+
+			extensionName := pointer.From(extensionProperty.Name)
+			name := extensionName
+			if m := directoryextensions.NameRegex.FindStringSubmatch(extensionName); m != nil {
+				name = m[2]
+			}
+
+			state := ApplicationExtensionPropertyModel{
+				ApplicationId:       stable.NewApplicationID(id.ApplicationId).ID(),
+				Name:                name,
+				DataType:            pointer.From(extensionProperty.DataType),
+				TargetObjects:       pointer.From(extensionProperty.TargetObjects),
+				MultiValued:         pointer.From(extensionProperty.IsMultiValued),
+				ExtensionName:       extensionName,
+				ExtensionPropertyId: id.ExtensionPropertyId,
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ApplicationExtensionPropertyResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Applications.ApplicationExtensionPropertyClient
+
+			id, err := stable.ParseApplicationIdExtensionPropertyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			tf.LockByName(applicationResourceName, id.ApplicationId)
+			defer tf.UnlockByName(applicationResourceName, id.ApplicationId)
+
+			if resp, err := client.DeleteExtensionProperty(ctx, *id, extensionproperty.DefaultDeleteExtensionPropertyOperationOptions()); err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return nil
+				}
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			if err = consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
+				resp, err := client.GetExtensionProperty(ctx, *id, extensionproperty.DefaultGetExtensionPropertyOperationOptions())
+				if err != nil {
+					if response.WasNotFound(resp.HttpResponse) {
+						return pointer.To(false), nil
+					}
+					return nil, err
+				}
+				return pointer.To(resp.Model != nil), nil
+			}); err != nil {
+				return fmt.Errorf("waiting for deletion of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
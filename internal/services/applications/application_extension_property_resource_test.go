@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/extensionproperty"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type ApplicationExtensionPropertyResource struct{}
+
+func TestAccApplicationExtensionProperty_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_extension_property", "test")
+	r := ApplicationExtensionPropertyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("extension_name").MatchesRegex(regexp.MustCompile(`^extension_[0-9a-f]{32}_costCenter$`)),
+				check.That(data.ResourceName).Key("extension_property_id").IsUuid(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplicationExtensionProperty_multiValued(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_extension_property", "test")
+	r := ApplicationExtensionPropertyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.multiValued(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("multi_valued").HasValue("true"),
+				check.That(data.ResourceName).Key("target_objects.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplicationExtensionProperty_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_extension_property", "test")
+	r := ApplicationExtensionPropertyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport(data)),
+	})
+}
+
+func (r ApplicationExtensionPropertyResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Applications.ApplicationExtensionPropertyClient
+
+	id, err := stable.ParseApplicationIdExtensionPropertyID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetExtensionProperty(ctx, *id, extensionproperty.DefaultGetExtensionPropertyOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (ApplicationExtensionPropertyResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application_registration" "test" {
+  display_name = "acctest-ExtensionProperty-%[1]d"
+}
+
+resource "azuread_application_extension_property" "test" {
+  application_id = azuread_application_registration.test.id
+  name           = "costCenter"
+  data_type      = "String"
+  target_objects = ["User"]
+}
+`, data.RandomInteger)
+}
+
+func (ApplicationExtensionPropertyResource) multiValued(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application_registration" "test" {
+  display_name = "acctest-ExtensionProperty-%[1]d"
+}
+
+resource "azuread_application_extension_property" "test" {
+  application_id = azuread_application_registration.test.id
+  name           = "projects"
+  data_type      = "String"
+  multi_valued   = true
+  target_objects = ["User", "Group"]
+}
+`, data.RandomInteger)
+}
+
+func (r ApplicationExtensionPropertyResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_extension_property" "import" {
+  application_id = azuread_application_extension_property.test.application_id
+  name           = azuread_application_extension_property.test.name
+  data_type      = azuread_application_extension_property.test.data_type
+  target_objects = azuread_application_extension_property.test.target_objects
+}
+`, r.basic(data))
+}
@@ -6,7 +6,9 @@ package applications
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"regexp"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
@@ -17,6 +19,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/federatedidentity"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
@@ -30,6 +33,8 @@ func applicationFederatedIdentityCredentialResource() *pluginsdk.Resource {
 		ReadContext:   applicationFederatedIdentityCredentialResourceRead,
 		DeleteContext: applicationFederatedIdentityCredentialResourceDelete,
 
+		CustomizeDiff: applicationFederatedIdentityCredentialResourceCustomizeDiff,
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(15 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -54,7 +59,8 @@ func applicationFederatedIdentityCredentialResource() *pluginsdk.Resource {
 			"audiences": {
 				Description: "List of audiences that can appear in the external token. This specifies what should be accepted in the `aud` claim of incoming tokens.",
 				Type:        pluginsdk.TypeList,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				MaxItems:    1,
 				// TODO: consider making this a scalar value instead of a list in v3.0 (the API now only accepts a single value)
 				Elem: &pluginsdk.Schema{
@@ -72,15 +78,220 @@ func applicationFederatedIdentityCredentialResource() *pluginsdk.Resource {
 			},
 
 			"issuer": {
-				Description: "The URL of the external identity provider, which must match the issuer claim of the external token being exchanged. The combination of the values of issuer and subject must be unique on the app.",
-				Type:        pluginsdk.TypeString,
-				Required:    true,
+				Description:   "The URL of the external identity provider, which must match the issuer claim of the external token being exchanged. The combination of the values of issuer and subject must be unique on the app.",
+				Type:          pluginsdk.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: applicationFederatedIdentityCredentialPresets,
 			},
 
 			"subject": {
-				Description: "The identifier of the external software workload within the external identity provider. The combination of issuer and subject must be unique on the app.",
-				Type:        pluginsdk.TypeString,
-				Required:    true,
+				Description:  "The identifier of the external software workload within the external identity provider. The combination of issuer and subject must be unique on the app.",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: applicationFederatedIdentityCredentialSources,
+			},
+
+			"claims_matching_expression": {
+				Description:  "An expression that is evaluated against the claims of incoming tokens, for use in place of `subject`",
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: applicationFederatedIdentityCredentialSources,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"value": {
+							Description:      "The expression to evaluate, e.g. `claims['sub'] matches 'repo:my-organization/my-repo:*'`",
+							Type:             pluginsdk.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.IsClaimsMatchingExpression,
+						},
+
+						"language_version": {
+							Description:  "The version of the expression language",
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							Default:      validation.ClaimsMatchingExpressionLanguageVersion,
+							ValidateFunc: validation.IntInSlice([]int{validation.ClaimsMatchingExpressionLanguageVersion}),
+						},
+					},
+				},
+			},
+
+			"azure_devops": {
+				Description:  "Computes the issuer, subject and audience for an Azure DevOps service connection",
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: applicationFederatedIdentityCredentialSources,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"organization_id": {
+							Description:  "The ID of the Azure DevOps organization",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+
+						"organization_name": {
+							Description:  "The name of the Azure DevOps organization",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"project_name": {
+							Description:  "The name of the Azure DevOps project",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"service_connection_name": {
+							Description:  "The name of the service connection",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"github": {
+				Description:  "Computes the issuer, subject and audience for a GitHub Actions workflow",
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: applicationFederatedIdentityCredentialSources,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"repository": {
+							Description:  "The repository in which the workflow runs, in the format `{organization}/{repository}`",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^/\s]+/[^/\s]+$`), "repository should be in the format `{organization}/{repository}`"),
+						},
+
+						"branch": {
+							Description:  "The branch for which workflows are trusted",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ExactlyOneOf: applicationFederatedIdentityCredentialGitHubEntities,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"environment": {
+							Description:  "The deployment environment for which workflows are trusted",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ExactlyOneOf: applicationFederatedIdentityCredentialGitHubEntities,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"pull_request": {
+							Description:  "Whether workflows triggered by pull requests are trusted",
+							Type:         pluginsdk.TypeBool,
+							Optional:     true,
+							ExactlyOneOf: applicationFederatedIdentityCredentialGitHubEntities,
+						},
+
+						"tag": {
+							Description:  "The tag for which workflows are trusted",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ExactlyOneOf: applicationFederatedIdentityCredentialGitHubEntities,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"enterprise_slug": {
+							Description:  "The slug of a GitHub Enterprise Cloud enterprise that uses a customized issuer URL",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"kubernetes": {
+				Description:  "Computes the issuer, subject and audience for a Kubernetes service account",
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: applicationFederatedIdentityCredentialSources,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"issuer_url": {
+							Description:  "The OIDC issuer URL of the cluster",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsHttpsUrl,
+						},
+
+						"namespace": {
+							Description:  "The namespace of the service account",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"service_account_name": {
+							Description:  "The name of the service account",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"terraform_cloud": {
+				Description:  "Computes the issuer, subject and audience for a run phase of an HCP Terraform or Terraform Enterprise workspace",
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: applicationFederatedIdentityCredentialSources,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"hostname": {
+							Description:  "The hostname of HCP Terraform or Terraform Enterprise",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      federatedidentity.TerraformCloudDefaultHost,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"organization": {
+							Description:  "The name of the organization",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"project": {
+							Description:  "The name of the project containing the workspace",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      federatedidentity.TerraformCloudDefaultProject,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"workspace": {
+							Description:  "The name of the workspace",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"run_phase": {
+							Description:  "The run phase for which the credential is trusted",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(federatedidentity.PossibleValuesForTerraformCloudRunPhase(), false),
+						},
+					},
+				},
 			},
 
 			"description": {
@@ -98,9 +309,155 @@ func applicationFederatedIdentityCredentialResource() *pluginsdk.Resource {
 	}
 }
 
+// applicationFederatedIdentityCredentialPresets are blocks which compute the issuer, subject and audience of the
+// credential for well-known platforms
+var applicationFederatedIdentityCredentialPresets = []string{"azure_devops", "github", "kubernetes", "terraform_cloud"}
+
+// applicationFederatedIdentityCredentialSources are the mutually exclusive ways of matching incoming tokens
+var applicationFederatedIdentityCredentialSources = append([]string{"claims_matching_expression", "subject"}, applicationFederatedIdentityCredentialPresets...)
+
+var applicationFederatedIdentityCredentialGitHubEntities = []string{"github.0.branch", "github.0.environment", "github.0.pull_request", "github.0.tag"}
+
+func applicationFederatedIdentityCredentialResourceCustomizeDiff(ctx context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
+	config := diff.GetRawConfig()
+	if config.IsNull() {
+		return nil
+	}
+
+	presetKey := applicationFederatedIdentityCredentialPresetKey(diff.Get)
+	if presetKey == "" {
+		if config.GetAttr("issuer").IsNull() {
+			return errors.New("`issuer` must be specified when not using one of the `azure_devops`, `github`, `kubernetes` or `terraform_cloud` blocks")
+		}
+		if config.GetAttr("audiences").IsNull() {
+			return errors.New("`audiences` must be specified when not using one of the `azure_devops`, `github`, `kubernetes` or `terraform_cloud` blocks")
+		}
+
+		// Subject is computed, so ensure it is removed when switching to a claims matching expression
+		if config.GetAttr("subject").IsNull() {
+			return diff.SetNew("subject", "")
+		}
+
+		return nil
+	}
+
+	if !config.GetAttr(presetKey).IsWhollyKnown() {
+		if err := diff.SetNewComputed("issuer"); err != nil {
+			return err
+		}
+		if err := diff.SetNewComputed("subject"); err != nil {
+			return err
+		}
+		if config.GetAttr("audiences").IsNull() {
+			return diff.SetNewComputed("audiences")
+		}
+		return nil
+	}
+
+	preset, err := applicationFederatedIdentityCredentialPreset(presetKey, diff.Get)
+	if err != nil {
+		return fmt.Errorf("computing federated identity credential from `%s` block: %v", presetKey, err)
+	}
+
+	if err = diff.SetNew("issuer", preset.Issuer); err != nil {
+		return err
+	}
+	if err = diff.SetNew("subject", preset.Subject); err != nil {
+		return err
+	}
+	if config.GetAttr("audiences").IsNull() {
+		return diff.SetNew("audiences", []interface{}{preset.Audience})
+	}
+
+	return nil
+}
+
+// applicationFederatedIdentityCredentialPresetKey returns the name of the preset block that is configured, if any
+func applicationFederatedIdentityCredentialPresetKey(get func(string) interface{}) string {
+	for _, key := range applicationFederatedIdentityCredentialPresets {
+		if v, ok := get(key).([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			return key
+		}
+	}
+	return ""
+}
+
+func applicationFederatedIdentityCredentialPreset(key string, get func(string) interface{}) (*federatedidentity.Preset, error) {
+	in := get(key).([]interface{})[0].(map[string]interface{})
+
+	switch key {
+	case "azure_devops":
+		return federatedidentity.AzureDevOps(federatedidentity.AzureDevOpsOptions{
+			OrganizationId:        in["organization_id"].(string),
+			OrganizationName:      in["organization_name"].(string),
+			ProjectName:           in["project_name"].(string),
+			ServiceConnectionName: in["service_connection_name"].(string),
+		})
+
+	case "github":
+		return federatedidentity.GitHub(federatedidentity.GitHubOptions{
+			EnterpriseSlug: in["enterprise_slug"].(string),
+			Repository:     in["repository"].(string),
+			Branch:         in["branch"].(string),
+			Environment:    in["environment"].(string),
+			PullRequest:    in["pull_request"].(bool),
+			Tag:            in["tag"].(string),
+		})
+
+	case "kubernetes":
+		return federatedidentity.Kubernetes(federatedidentity.KubernetesOptions{
+			IssuerUrl:          in["issuer_url"].(string),
+			Namespace:          in["namespace"].(string),
+			ServiceAccountName: in["service_account_name"].(string),
+		})
+
+	case "terraform_cloud":
+		return federatedidentity.TerraformCloud(federatedidentity.TerraformCloudOptions{
+			Hostname:     in["hostname"].(string),
+			Organization: in["organization"].(string),
+			Project:      in["project"].(string),
+			Workspace:    in["workspace"].(string),
+			RunPhase:     in["run_phase"].(string),
+		})
+	}
+
+	return nil, fmt.Errorf("unsupported preset %q", key)
+}
+
+// applicationFederatedIdentityCredentialExpand builds the credential from either the configured preset block, or the
+// raw `issuer`, `subject` and `claims_matching_expression` fields. Values computed from a preset are calculated again
+// here, since they may not have been known at plan time.
+func applicationFederatedIdentityCredentialExpand(d *pluginsdk.ResourceData) (*federatedidentity.Credential, error) {
+	credential := federatedidentity.Credential{
+		Audiences:   tf.ExpandStringSlice(d.Get("audiences").([]interface{})),
+		Description: nullable.Value(d.Get("description").(string)),
+		Issuer:      d.Get("issuer").(string),
+		Name:        d.Get("display_name").(string),
+
+		// Exactly one of these is specified, the other must be nulled when switching between them
+		ClaimsMatchingExpression: federatedidentity.ExpandClaimsMatchingExpression(d.Get("claims_matching_expression").([]interface{})),
+		Subject:                  nullable.NoZero(d.Get("subject").(string)),
+	}
+
+	if presetKey := applicationFederatedIdentityCredentialPresetKey(d.Get); presetKey != "" {
+		preset, err := applicationFederatedIdentityCredentialPreset(presetKey, d.Get)
+		if err != nil {
+			return nil, fmt.Errorf("computing federated identity credential from `%s` block: %v", presetKey, err)
+		}
+
+		credential.Issuer = preset.Issuer
+		credential.Subject = nullable.Value(preset.Subject)
+		if len(credential.Audiences) == 0 {
+			credential.Audiences = []string{preset.Audience}
+		}
+	}
+
+	return &credential, nil
+}
+
 func applicationFederatedIdentityCredentialResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics { //nolint
 	client := meta.(*clients.Client).Applications.ApplicationClient
-	federatedIdentityCredentialClient := meta.(*clients.Client).Applications.ApplicationFederatedIdentityCredential
+	federatedIdentityCredentialClient := meta.(*clients.Client).Applications.ApplicationFederatedIdentityCredentialBeta
 
 	applicationId, err := stable.ParseApplicationID(d.Get("application_id").(string))
 	if err != nil {
@@ -120,20 +477,17 @@ func applicationFederatedIdentityCredentialResourceCreate(ctx context.Context, d
 		return tf.ErrorDiagF(errors.New("model was nil"), "retrieving %s", applicationId)
 	}
 
-	credential := stable.FederatedIdentityCredential{
-		Audiences:   tf.ExpandStringSlice(d.Get("audiences").([]interface{})),
-		Description: nullable.Value(d.Get("description").(string)),
-		Issuer:      d.Get("issuer").(string),
-		Name:        d.Get("display_name").(string),
-		Subject:     d.Get("subject").(string),
+	credential, err := applicationFederatedIdentityCredentialExpand(d)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Building federated identity credential for %s", applicationId)
 	}
 
-	federatedIdentityCredentialResp, err := federatedIdentityCredentialClient.CreateFederatedIdentityCredential(ctx, *applicationId, credential, federatedidentitycredential.DefaultCreateFederatedIdentityCredentialOperationOptions())
+	// Flexible federated identity credentials are only supported by the beta API
+	newCredential, _, err := federatedidentity.Create(ctx, federatedIdentityCredentialClient.Client, applicationId.ID(), *credential)
 	if err != nil {
 		return tf.ErrorDiagF(err, "Adding federated identity credential for %s", applicationId)
 	}
 
-	newCredential := federatedIdentityCredentialResp.Model
 	if newCredential == nil {
 		return tf.ErrorDiagF(errors.New("nil credential received when adding federated identity credential"), "API error adding federated identity credential for %s", applicationId)
 	}
@@ -152,14 +506,13 @@ func applicationFederatedIdentityCredentialResourceCreate(ctx context.Context, d
 		MinTimeout:                1 * time.Second,
 		ContinuousTargetOccurence: 5,
 		Refresh: func() (interface{}, string, error) {
-			resp, err := federatedIdentityCredentialClient.GetFederatedIdentityCredential(ctx, id, federatedidentitycredential.DefaultGetFederatedIdentityCredentialOperationOptions())
+			credential, resp, err := federatedidentity.Get(ctx, federatedIdentityCredentialClient.Client, id.ID())
 			if err != nil {
-				if response.WasNotFound(resp.HttpResponse) {
+				if response.WasNotFound(resp) {
 					return nil, "Waiting", nil
 				}
 				return nil, "Error", err
 			}
-			credential := resp.Model
 			if credential == nil {
 				return nil, "Waiting", nil
 			}
@@ -182,7 +535,7 @@ func applicationFederatedIdentityCredentialResourceCreate(ctx context.Context, d
 }
 
 func applicationFederatedIdentityCredentialResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics { //nolint
-	federatedIdentityCredentialClient := meta.(*clients.Client).Applications.ApplicationFederatedIdentityCredential
+	federatedIdentityCredentialClient := meta.(*clients.Client).Applications.ApplicationFederatedIdentityCredentialBeta
 
 	id, err := parse.FederatedIdentityCredentialID(d.Id())
 	if err != nil {
@@ -192,20 +545,16 @@ func applicationFederatedIdentityCredentialResourceUpdate(ctx context.Context, d
 	tf.LockByName(applicationResourceName, id.ObjectId)
 	defer tf.UnlockByName(applicationResourceName, id.ObjectId)
 
-	credential := stable.FederatedIdentityCredential{
-		Id:          pointer.To(id.KeyId),
-		Audiences:   tf.ExpandStringSlice(d.Get("audiences").([]interface{})),
-		Description: nullable.Value(d.Get("description").(string)),
-		Issuer:      d.Get("issuer").(string),
-		Subject:     d.Get("subject").(string),
-
-		// Name is immutable but must be specified as it is a required field
-		Name: d.Get("display_name").(string),
+	// Name is immutable but is always included in the request as it is a required field
+	credential, err := applicationFederatedIdentityCredentialExpand(d)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Building federated identity credential with ID %q for application with object ID %q", id.KeyId, id.ObjectId)
 	}
+	credential.Id = pointer.To(id.KeyId)
 
 	credentialId := stable.NewApplicationIdFederatedIdentityCredentialID(id.ObjectId, id.KeyId)
 
-	if _, err = federatedIdentityCredentialClient.UpdateFederatedIdentityCredential(ctx, credentialId, credential, federatedidentitycredential.DefaultUpdateFederatedIdentityCredentialOperationOptions()); err != nil {
+	if _, err = federatedidentity.Update(ctx, federatedIdentityCredentialClient.Client, credentialId.ID(), *credential); err != nil {
 		return tf.ErrorDiagF(err, "Updating federated identity credential with ID %q for application with object ID %q", id.KeyId, id.ObjectId)
 	}
 
@@ -213,7 +562,7 @@ func applicationFederatedIdentityCredentialResourceUpdate(ctx context.Context, d
 }
 
 func applicationFederatedIdentityCredentialResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics { //nolint
-	federatedIdentityCredentialClient := meta.(*clients.Client).Applications.ApplicationFederatedIdentityCredential
+	federatedIdentityCredentialClient := meta.(*clients.Client).Applications.ApplicationFederatedIdentityCredentialBeta
 
 	id, err := parse.FederatedIdentityCredentialID(d.Id())
 	if err != nil {
@@ -223,9 +572,9 @@ func applicationFederatedIdentityCredentialResourceRead(ctx context.Context, d *
 	applicationId := stable.NewApplicationID(id.ObjectId)
 	credentialId := stable.NewApplicationIdFederatedIdentityCredentialID(id.ObjectId, id.KeyId)
 
-	resp, err := federatedIdentityCredentialClient.GetFederatedIdentityCredential(ctx, credentialId, federatedidentitycredential.DefaultGetFederatedIdentityCredentialOperationOptions())
+	credential, resp, err := federatedidentity.Get(ctx, federatedIdentityCredentialClient.Client, credentialId.ID())
 	if err != nil {
-		if response.WasNotFound(resp.HttpResponse) {
+		if response.WasNotFound(resp) {
 			log.Printf("[DEBUG] Federated Identity Credential with ID %q for Application %s was not found - removing from state!", id.KeyId, id.ObjectId)
 			d.SetId("")
 			return nil
@@ -233,7 +582,6 @@ func applicationFederatedIdentityCredentialResourceRead(ctx context.Context, d *
 		return tf.ErrorDiagPathF(err, "id", "Retrieving federated identity credential with ID %q for application with object ID %q", id.KeyId, id.ObjectId)
 	}
 
-	credential := resp.Model
 	if credential == nil {
 		return tf.ErrorDiagF(errors.New("model was nil"), "retrieving %s", credentialId)
 	}
@@ -242,10 +590,11 @@ func applicationFederatedIdentityCredentialResourceRead(ctx context.Context, d *
 	tf.Set(d, "credential_id", id.KeyId)
 
 	tf.Set(d, "audiences", tf.FlattenStringSlice(credential.Audiences))
+	tf.Set(d, "claims_matching_expression", federatedidentity.FlattenClaimsMatchingExpression(credential.ClaimsMatchingExpression))
 	tf.Set(d, "description", credential.Description.GetOrZero())
 	tf.Set(d, "display_name", credential.Name)
 	tf.Set(d, "issuer", credential.Issuer)
-	tf.Set(d, "subject", credential.Subject)
+	tf.Set(d, "subject", credential.Subject.GetOrZero())
 
 	return nil
 }
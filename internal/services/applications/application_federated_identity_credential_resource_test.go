@@ -85,6 +85,100 @@ func TestAccApplicationFederatedIdentityCredential_update(t *testing.T) {
 	})
 }
 
+func TestAccApplicationFederatedIdentityCredential_claimsMatchingExpression(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_federated_identity_credential", "test")
+	r := ApplicationFederatedIdentityCredentialResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.claimsMatchingExpression(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("claims_matching_expression.0.language_version").HasValue("1"),
+				check.That(data.ResourceName).Key("subject").HasValue(""),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("claims_matching_expression.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.claimsMatchingExpression(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("claims_matching_expression.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplicationFederatedIdentityCredential_github(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_federated_identity_credential", "test")
+	r := ApplicationFederatedIdentityCredentialResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.github(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("audiences.0").HasValue("api://AzureADTokenExchange"),
+				check.That(data.ResourceName).Key("issuer").HasValue("https://token.actions.githubusercontent.com"),
+				check.That(data.ResourceName).Key("subject").HasValue(fmt.Sprintf("repo:hashitown/acctest-%s:environment:prod", data.RandomString)),
+			),
+		},
+		data.ImportStep("github"),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("subject").HasValue(data.RandomID),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplicationFederatedIdentityCredential_presets(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_federated_identity_credential", "test")
+	r := ApplicationFederatedIdentityCredentialResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.azureDevOps(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("issuer").HasValue(fmt.Sprintf("https://vstoken.dev.azure.com/%s", data.RandomID)),
+				check.That(data.ResourceName).Key("subject").HasValue(fmt.Sprintf("sc://hashitown/acctest-%s/azure", data.RandomString)),
+			),
+		},
+		data.ImportStep("azure_devops"),
+		{
+			Config: r.kubernetes(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("issuer").HasValue("https://oidc.hashitown.example.com/"),
+				check.That(data.ResourceName).Key("subject").HasValue(fmt.Sprintf("system:serviceaccount:acctest-%s:deployer", data.RandomString)),
+			),
+		},
+		data.ImportStep("kubernetes"),
+		{
+			Config: r.terraformCloud(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("issuer").HasValue("https://app.terraform.io"),
+				check.That(data.ResourceName).Key("subject").HasValue(fmt.Sprintf("organization:hashitown:project:Default Project:workspace:acctest-%s:run_phase:apply", data.RandomString)),
+			),
+		},
+		data.ImportStep("terraform_cloud"),
+	})
+}
+
 func (r ApplicationFederatedIdentityCredentialResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	client := clients.Applications.ApplicationFederatedIdentityCredential
 
@@ -142,3 +236,88 @@ resource "azuread_application_federated_identity_credential" "test" {
 }
 `, r.template(data), data.RandomString, data.UUID())
 }
+
+func (r ApplicationFederatedIdentityCredentialResource) claimsMatchingExpression(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_federated_identity_credential" "test" {
+  application_id = azuread_application.test.id
+  display_name   = "hashitown.example.com-%[2]s"
+  audiences      = ["api://AzureADTokenExchange"]
+  issuer         = "https://token.actions.githubusercontent.com"
+
+  claims_matching_expression {
+    value = "claims['sub'] matches 'repo:hashitown/acctest-%[2]s:*'"
+  }
+}
+`, r.template(data), data.RandomString)
+}
+
+func (r ApplicationFederatedIdentityCredentialResource) github(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_federated_identity_credential" "test" {
+  application_id = azuread_application.test.id
+  display_name   = "hashitown.example.com-%[2]s"
+
+  github {
+    repository  = "hashitown/acctest-%[2]s"
+    environment = "prod"
+  }
+}
+`, r.template(data), data.RandomString)
+}
+
+func (r ApplicationFederatedIdentityCredentialResource) azureDevOps(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_federated_identity_credential" "test" {
+  application_id = azuread_application.test.id
+  display_name   = "hashitown.example.com-%[2]s"
+
+  azure_devops {
+    organization_id         = "%[3]s"
+    organization_name       = "hashitown"
+    project_name            = "acctest-%[2]s"
+    service_connection_name = "azure"
+  }
+}
+`, r.template(data), data.RandomString, data.RandomID)
+}
+
+func (r ApplicationFederatedIdentityCredentialResource) kubernetes(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_federated_identity_credential" "test" {
+  application_id = azuread_application.test.id
+  display_name   = "hashitown.example.com-%[2]s"
+
+  kubernetes {
+    issuer_url           = "https://oidc.hashitown.example.com/"
+    namespace            = "acctest-%[2]s"
+    service_account_name = "deployer"
+  }
+}
+`, r.template(data), data.RandomString)
+}
+
+func (r ApplicationFederatedIdentityCredentialResource) terraformCloud(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_federated_identity_credential" "test" {
+  application_id = azuread_application.test.id
+  display_name   = "hashitown.example.com-%[2]s"
+
+  terraform_cloud {
+    organization = "hashitown"
+    workspace    = "acctest-%[2]s"
+    run_phase    = "apply"
+  }
+}
+`, r.template(data), data.RandomString)
+}
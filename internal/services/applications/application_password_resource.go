@@ -8,15 +8,12 @@ import (
 	"encoding/base64"
 	"errors"
 	"log"
-	"strings"
 	"time"
 
-	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
-	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/credentials"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
@@ -179,35 +176,8 @@ func applicationPasswordResourceCreate(ctx context.Context, d *pluginsdk.Resourc
 	id := parse.NewCredentialID(applicationId.ApplicationId, "password", newCredential.KeyId.GetOrZero())
 
 	// Wait for the credential to appear in the application manifest, this can take several minutes
-	timeout, _ := ctx.Deadline()
-	polledForCredential, err := (&pluginsdk.StateChangeConf{ //nolint:staticcheck
-		Pending:                   []string{"Waiting"},
-		Target:                    []string{"Done"},
-		Timeout:                   time.Until(timeout),
-		MinTimeout:                1 * time.Second,
-		ContinuousTargetOccurence: 5,
-		Refresh: func() (interface{}, string, error) {
-			resp, err := client.GetApplication(ctx, *applicationId, application.DefaultGetApplicationOperationOptions())
-			if err != nil {
-				return nil, "Error", err
-			}
-
-			if resp.Model.PasswordCredentials != nil {
-				for _, cred := range *resp.Model.PasswordCredentials {
-					if strings.EqualFold(cred.KeyId.GetOrZero(), id.KeyId) {
-						return &cred, "Done", nil
-					}
-				}
-			}
-
-			return nil, "Waiting", nil
-		},
-	}).WaitForStateContext(ctx)
-
-	if err != nil {
+	if err = applicationWaitForPasswordCredential(ctx, client, *applicationId, id.KeyId); err != nil {
 		return tf.ErrorDiagF(err, "Waiting for password credential for %s", applicationId)
-	} else if polledForCredential == nil {
-		return tf.ErrorDiagF(errors.New("password credential not found in application manifest"), "Waiting for password credential for %s", applicationId)
 	}
 
 	d.SetId(id.String())
@@ -280,29 +250,9 @@ func applicationPasswordResourceDelete(ctx context.Context, d *pluginsdk.Resourc
 	tf.LockByName(applicationResourceName, id.ObjectId)
 	defer tf.UnlockByName(applicationResourceName, id.ObjectId)
 
-	request := application.RemovePasswordRequest{
-		KeyId: pointer.To(id.KeyId),
-	}
-	if _, err = client.RemovePassword(ctx, applicationId, request, application.DefaultRemovePasswordOperationOptions()); err != nil {
+	if err = applicationRemovePasswordCredential(ctx, client, applicationId, id.KeyId); err != nil {
 		return tf.ErrorDiagF(err, "Removing password credential %q from %s", id.KeyId, applicationId)
 	}
 
-	// Wait for application password to be deleted
-	if err := consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
-		resp, err := client.GetApplication(ctx, applicationId, application.DefaultGetApplicationOperationOptions())
-		if err != nil {
-			return nil, err
-		}
-
-		app := resp.Model
-		if app == nil {
-			return nil, errors.New("model was nil")
-		}
-
-		return pointer.To(credentials.GetPasswordCredential(app.PasswordCredentials, id.KeyId) != nil), nil
-	}); err != nil {
-		return tf.ErrorDiagF(err, "Waiting for deletion of password credential %q from %s", id.KeyId, applicationId)
-	}
-
 	return nil
 }
@@ -0,0 +1,559 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/credentials"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+)
+
+func applicationPasswordRotationResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: applicationPasswordRotationResourceCreate,
+		ReadContext:   applicationPasswordRotationResourceRead,
+		UpdateContext: applicationPasswordRotationResourceUpdate,
+		DeleteContext: applicationPasswordRotationResourceDelete,
+
+		CustomizeDiff: applicationPasswordRotationResourceCustomizeDiff,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(15 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(15 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(15 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"application_id": {
+				Description:  "The resource ID of the application for which passwords should be rotated",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: stable.ValidateApplicationID,
+			},
+
+			"display_name": {
+				Description: "A display name for each password created by this resource",
+				Type:        pluginsdk.TypeString,
+				Optional:    true,
+			},
+
+			"validity_in_days": {
+				Description:  "The number of days for which each new password is valid",
+				Type:         pluginsdk.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"rotate_days_before_expiry": {
+				Description:  "The number of days before the current password expires at which a new password is created",
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"grace_period_in_days": {
+				Description:  "The number of days for which a password remains valid after it has been superseded by a new password, before it is removed",
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      7,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"password_count": {
+				Description:  "The maximum number of overlapping passwords to keep. When exceeded, the oldest superseded passwords are removed regardless of the grace period",
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      2,
+				ValidateFunc: validation.IntBetween(2, 10),
+			},
+
+			"rotate_when_changed": {
+				Description: "Arbitrary map of values that, when changed, will trigger an immediate rotation of the current password",
+				Type:        pluginsdk.TypeMap,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"current_key_id": {
+				Description: "The key ID of the current password",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"current_value": {
+				Description: "The value of the current password",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+
+			"previous_key_id": {
+				Description: "The key ID of the most recently superseded password, until it is removed",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"previous_value": {
+				Description: "The value of the most recently superseded password, until it is removed",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+
+			"next_rotation_date": {
+				Description: "The date after which the next plan will create a new password",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"passwords": {
+				Description: "The passwords managed by this resource, newest first",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"key_id": {
+							Description: "The key ID of the password",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"display_name": {
+							Description: "The display name of the password",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"start_date": {
+							Description: "The start date from which the password is valid",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"end_date": {
+							Description: "The end date until which the password is valid",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"retire_date": {
+							Description: "The date after which the password will be removed, for superseded passwords",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"value": {
+							Description: "The value of the password",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type applicationRotatedPassword struct {
+	KeyId       string
+	DisplayName string
+	StartDate   string
+	EndDate     string
+	RetireDate  string
+	Value       string
+}
+
+func applicationPasswordRotationResourceCustomizeDiff(ctx context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("validity_in_days") || !diff.NewValueKnown("rotate_days_before_expiry") {
+		return nil
+	}
+
+	if validity, rotateBefore := diff.Get("validity_in_days").(int), diff.Get("rotate_days_before_expiry").(int); rotateBefore >= validity {
+		return fmt.Errorf("`rotate_days_before_expiry` (%d) must be less than `validity_in_days` (%d)", rotateBefore, validity)
+	}
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	now := time.Now()
+	currentKeyId := diff.Get("current_key_id").(string)
+	passwords := applicationExpandRotatedPasswords(diff.Get("passwords").([]interface{}))
+
+	rotate := currentKeyId == "" || diff.HasChange("rotate_when_changed")
+	if nextRotation, err := time.Parse(time.RFC3339, diff.Get("next_rotation_date").(string)); err != nil || !now.Before(nextRotation) {
+		rotate = true
+	}
+
+	if rotate {
+		for _, attr := range []string{"current_key_id", "current_value", "next_rotation_date", "passwords", "previous_key_id", "previous_value"} {
+			if err := diff.SetNewComputed(attr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if _, retired := applicationRetirePasswords(passwords, currentKeyId, diff.Get("password_count").(int), now); len(retired) > 0 {
+		for _, attr := range []string{"passwords", "previous_key_id", "previous_value"} {
+			if err := diff.SetNewComputed(attr); err != nil {
+				return err
+			}
+		}
+	}
+
+	if diff.HasChange("rotate_days_before_expiry") {
+		if err := diff.SetNewComputed("next_rotation_date"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applicationPasswordRotationResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationClient
+
+	applicationId, err := stable.ParseApplicationID(d.Get("application_id").(string))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "application_id", "Parsing `application_id`")
+	}
+
+	tf.LockByName(applicationResourceName, applicationId.ApplicationId)
+	defer tf.UnlockByName(applicationResourceName, applicationId.ApplicationId)
+
+	resp, err := client.GetApplication(ctx, *applicationId, application.DefaultGetApplicationOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return tf.ErrorDiagPathF(nil, "application_id", "%s was not found", applicationId)
+		}
+		return tf.ErrorDiagPathF(err, "application_id", "Retrieving %s", applicationId)
+	}
+
+	password, err := applicationAddRotatedPassword(ctx, client, *applicationId, d.Get("display_name").(string), d.Get("validity_in_days").(int), time.Now())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Adding password for %s", applicationId)
+	}
+
+	id := parse.NewPasswordRotationID(applicationId.ApplicationId)
+	d.SetId(id.ID())
+
+	applicationSetRotatedPasswords(d, []applicationRotatedPassword{*password}, password.KeyId)
+
+	return applicationPasswordRotationResourceRead(ctx, d, meta)
+}
+
+func applicationPasswordRotationResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationClient
+
+	id, err := parse.ParsePasswordRotationID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing password rotation ID %q", d.Id())
+	}
+
+	applicationId := stable.NewApplicationID(id.ApplicationId)
+
+	tf.LockByName(applicationResourceName, id.ApplicationId)
+	defer tf.UnlockByName(applicationResourceName, id.ApplicationId)
+
+	resp, err := client.GetApplication(ctx, applicationId, application.DefaultGetApplicationOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving %s", applicationId)
+	}
+	if resp.Model == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", applicationId)
+	}
+
+	// Computed attributes may have been marked as unknown in the plan, so work from the prior state
+	oldCurrentKeyId, _ := d.GetChange("current_key_id")
+	oldPasswords, _ := d.GetChange("passwords")
+	oldNextRotation, _ := d.GetChange("next_rotation_date")
+
+	currentKeyId := oldCurrentKeyId.(string)
+	passwords := applicationFilterRotatedPasswords(applicationExpandRotatedPasswords(oldPasswords.([]interface{})), resp.Model.PasswordCredentials)
+
+	now := time.Now()
+	rotate := d.HasChange("rotate_when_changed") || applicationFindRotatedPassword(passwords, currentKeyId) == nil
+	if nextRotation, err := time.Parse(time.RFC3339, oldNextRotation.(string)); err != nil || !now.Before(nextRotation) {
+		rotate = true
+	}
+
+	if rotate {
+		password, err := applicationAddRotatedPassword(ctx, client, applicationId, d.Get("display_name").(string), d.Get("validity_in_days").(int), now)
+		if err != nil {
+			applicationSetRotatedPasswords(d, passwords, currentKeyId)
+			return tf.ErrorDiagF(err, "Adding password for %s", applicationId)
+		}
+
+		// The superseded password remains valid until the grace period has elapsed, or until it expires
+		if previous := applicationFindRotatedPassword(passwords, currentKeyId); previous != nil {
+			retireDate := now.AddDate(0, 0, d.Get("grace_period_in_days").(int))
+			if endDate, err := time.Parse(time.RFC3339, previous.EndDate); err == nil && endDate.Before(retireDate) {
+				retireDate = endDate
+			}
+			previous.RetireDate = retireDate.Format(time.RFC3339)
+		}
+
+		passwords = append([]applicationRotatedPassword{*password}, passwords...)
+		currentKeyId = password.KeyId
+	}
+
+	remaining, retired := applicationRetirePasswords(passwords, currentKeyId, d.Get("password_count").(int), now)
+	for i, password := range retired {
+		log.Printf("[DEBUG] Removing superseded password credential %q from %s", password.KeyId, applicationId)
+		if err = applicationRemovePasswordCredential(ctx, client, applicationId, password.KeyId); err != nil {
+			remaining = append(remaining, retired[i:]...)
+			applicationSetRotatedPasswords(d, remaining, currentKeyId)
+			return tf.ErrorDiagF(err, "Removing password credential %q from %s", password.KeyId, applicationId)
+		}
+	}
+
+	applicationSetRotatedPasswords(d, remaining, currentKeyId)
+
+	return applicationPasswordRotationResourceRead(ctx, d, meta)
+}
+
+func applicationPasswordRotationResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationClient
+
+	id, err := parse.ParsePasswordRotationID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing password rotation ID %q", d.Id())
+	}
+
+	applicationId := stable.NewApplicationID(id.ApplicationId)
+
+	resp, err := client.GetApplication(ctx, applicationId, application.DefaultGetApplicationOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing password rotation from state!", applicationId)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagPathF(err, "application_id", "Retrieving %s", applicationId)
+	}
+
+	app := resp.Model
+	if app == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", applicationId)
+	}
+
+	// Passwords that have been removed outside of Terraform are dropped, and if the current password is among them a
+	// new password will be created on the next apply
+	passwords := applicationFilterRotatedPasswords(applicationExpandRotatedPasswords(d.Get("passwords").([]interface{})), app.PasswordCredentials)
+
+	tf.Set(d, "application_id", applicationId.ID())
+	applicationSetRotatedPasswords(d, passwords, d.Get("current_key_id").(string))
+
+	return nil
+}
+
+func applicationPasswordRotationResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationClient
+
+	id, err := parse.ParsePasswordRotationID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing password rotation ID %q", d.Id())
+	}
+
+	applicationId := stable.NewApplicationID(id.ApplicationId)
+
+	tf.LockByName(applicationResourceName, id.ApplicationId)
+	defer tf.UnlockByName(applicationResourceName, id.ApplicationId)
+
+	resp, err := client.GetApplication(ctx, applicationId, application.DefaultGetApplicationOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving %s", applicationId)
+	}
+	if resp.Model == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", applicationId)
+	}
+
+	for _, password := range applicationFilterRotatedPasswords(applicationExpandRotatedPasswords(d.Get("passwords").([]interface{})), resp.Model.PasswordCredentials) {
+		if err = applicationRemovePasswordCredential(ctx, client, applicationId, password.KeyId); err != nil {
+			return tf.ErrorDiagF(err, "Removing password credential %q from %s", password.KeyId, applicationId)
+		}
+	}
+
+	return nil
+}
+
+func applicationAddRotatedPassword(ctx context.Context, client *application.ApplicationClient, applicationId stable.ApplicationId, displayName string, validityInDays int, now time.Time) (*applicationRotatedPassword, error) {
+	credential := stable.PasswordCredential{
+		StartDateTime: nullable.Value(now.Format(time.RFC3339)),
+		EndDateTime:   nullable.Value(now.AddDate(0, 0, validityInDays).Format(time.RFC3339)),
+	}
+	if displayName != "" {
+		credential.DisplayName = nullable.Value(displayName)
+	}
+
+	resp, err := client.AddPassword(ctx, applicationId, application.AddPasswordRequest{PasswordCredential: &credential}, application.DefaultAddPasswordOperationOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	newCredential := resp.Model
+	if newCredential == nil {
+		return nil, errors.New("nil credential received when adding password")
+	}
+	if newCredential.KeyId.GetOrZero() == "" {
+		return nil, errors.New("nil or empty keyId received")
+	}
+	if newCredential.SecretText.GetOrZero() == "" {
+		return nil, errors.New("nil or empty password received")
+	}
+
+	if err = applicationWaitForPasswordCredential(ctx, client, applicationId, newCredential.KeyId.GetOrZero()); err != nil {
+		return nil, fmt.Errorf("waiting for password credential %q: %v", newCredential.KeyId.GetOrZero(), err)
+	}
+
+	return &applicationRotatedPassword{
+		KeyId:       newCredential.KeyId.GetOrZero(),
+		DisplayName: newCredential.DisplayName.GetOrZero(),
+		StartDate:   newCredential.StartDateTime.GetOrZero(),
+		EndDate:     newCredential.EndDateTime.GetOrZero(),
+		Value:       newCredential.SecretText.GetOrZero(),
+	}, nil
+}
+
+// applicationRetirePasswords returns the passwords to keep and the passwords to remove. Superseded passwords are
+// removed once their retire date has passed or they have expired, and when more than `count` passwords remain the
+// oldest superseded passwords are also removed.
+func applicationRetirePasswords(passwords []applicationRotatedPassword, currentKeyId string, count int, now time.Time) (remaining []applicationRotatedPassword, retired []applicationRotatedPassword) {
+	remaining = make([]applicationRotatedPassword, 0)
+	retired = make([]applicationRotatedPassword, 0)
+
+	for _, password := range passwords {
+		if password.KeyId != currentKeyId {
+			if retireDate, err := time.Parse(time.RFC3339, password.RetireDate); err == nil && !now.Before(retireDate) {
+				retired = append(retired, password)
+				continue
+			}
+			if endDate, err := time.Parse(time.RFC3339, password.EndDate); err == nil && !now.Before(endDate) {
+				retired = append(retired, password)
+				continue
+			}
+		}
+		remaining = append(remaining, password)
+	}
+
+	// Passwords are ordered newest first, so trim superseded passwords from the end
+	for i := len(remaining) - 1; i >= 0 && len(remaining) > count; i-- {
+		if remaining[i].KeyId == currentKeyId {
+			continue
+		}
+		retired = append(retired, remaining[i])
+		remaining = append(remaining[:i], remaining[i+1:]...)
+	}
+
+	return remaining, retired
+}
+
+func applicationFilterRotatedPasswords(passwords []applicationRotatedPassword, existing *[]stable.PasswordCredential) []applicationRotatedPassword {
+	result := make([]applicationRotatedPassword, 0)
+	for _, password := range passwords {
+		if credentials.GetPasswordCredential(existing, password.KeyId) == nil {
+			log.Printf("[DEBUG] Password credential %q was not found - removing from rotation", password.KeyId)
+			continue
+		}
+		result = append(result, password)
+	}
+	return result
+}
+
+func applicationFindRotatedPassword(passwords []applicationRotatedPassword, keyId string) *applicationRotatedPassword {
+	if keyId == "" {
+		return nil
+	}
+	for i := range passwords {
+		if passwords[i].KeyId == keyId {
+			return &passwords[i]
+		}
+	}
+	return nil
+}
+
+func applicationSetRotatedPasswords(d *pluginsdk.ResourceData, passwords []applicationRotatedPassword, currentKeyId string) {
+	var currentValue, nextRotationDate, previousKeyId, previousValue string
+
+	if current := applicationFindRotatedPassword(passwords, currentKeyId); current != nil {
+		currentValue = current.Value
+		if endDate, err := time.Parse(time.RFC3339, current.EndDate); err == nil {
+			nextRotationDate = endDate.AddDate(0, 0, -d.Get("rotate_days_before_expiry").(int)).Format(time.RFC3339)
+		}
+	} else {
+		currentKeyId = ""
+	}
+
+	for _, password := range passwords {
+		if password.KeyId != currentKeyId {
+			previousKeyId = password.KeyId
+			previousValue = password.Value
+			break
+		}
+	}
+
+	tf.Set(d, "current_key_id", currentKeyId)
+	tf.Set(d, "current_value", currentValue)
+	tf.Set(d, "next_rotation_date", nextRotationDate)
+	tf.Set(d, "passwords", applicationFlattenRotatedPasswords(passwords))
+	tf.Set(d, "previous_key_id", previousKeyId)
+	tf.Set(d, "previous_value", previousValue)
+}
+
+func applicationExpandRotatedPasswords(input []interface{}) []applicationRotatedPassword {
+	result := make([]applicationRotatedPassword, 0)
+	for _, raw := range input {
+		v, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, applicationRotatedPassword{
+			KeyId:       v["key_id"].(string),
+			DisplayName: v["display_name"].(string),
+			StartDate:   v["start_date"].(string),
+			EndDate:     v["end_date"].(string),
+			RetireDate:  v["retire_date"].(string),
+			Value:       v["value"].(string),
+		})
+	}
+	return result
+}
+
+func applicationFlattenRotatedPasswords(input []applicationRotatedPassword) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+	for _, password := range input {
+		result = append(result, map[string]interface{}{
+			"key_id":       password.KeyId,
+			"display_name": password.DisplayName,
+			"start_date":   password.StartDate,
+			"end_date":     password.EndDate,
+			"retire_date":  password.RetireDate,
+			"value":        password.Value,
+		})
+	}
+	return result
+}
@@ -0,0 +1,202 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/credentials"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+)
+
+type ApplicationPasswordRotationResource struct{}
+
+func TestAccApplicationPasswordRotation_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_password_rotation", "test")
+	r := ApplicationPasswordRotationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "1"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("current_key_id").IsUuid(),
+				check.That(data.ResourceName).Key("current_value").Exists(),
+				check.That(data.ResourceName).Key("next_rotation_date").Exists(),
+				check.That(data.ResourceName).Key("passwords.#").HasValue("1"),
+				check.That(data.ResourceName).Key("previous_key_id").HasValue(""),
+			),
+		},
+	})
+}
+
+func TestAccApplicationPasswordRotation_rotate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_password_rotation", "test")
+	r := ApplicationPasswordRotationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "1"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("passwords.#").HasValue("1"),
+			),
+		},
+		{
+			Config: r.basic(data, "2"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("passwords.#").HasValue("2"),
+				check.That(data.ResourceName).Key("previous_key_id").IsUuid(),
+				check.That(data.ResourceName).Key("previous_value").Exists(),
+				check.That(data.ResourceName).Key("passwords.1.retire_date").Exists(),
+			),
+		},
+		{
+			// With the default password_count of 2, the oldest password is removed
+			Config: r.basic(data, "3"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("passwords.#").HasValue("2"),
+			),
+		},
+	})
+}
+
+func TestAccApplicationPasswordRotation_passwordCount(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_password_rotation", "test")
+	r := ApplicationPasswordRotationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.passwordCount(data, "1", 3),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config: r.passwordCount(data, "2", 3),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("passwords.#").HasValue("2"),
+			),
+		},
+		{
+			Config: r.passwordCount(data, "3", 3),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("passwords.#").HasValue("3"),
+			),
+		},
+		{
+			Config: r.passwordCount(data, "3", 2),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("passwords.#").HasValue("2"),
+			),
+		},
+	})
+}
+
+func TestAccApplicationPasswordRotation_invalidRotationWindow(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_password_rotation", "test")
+	r := ApplicationPasswordRotationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.invalidRotationWindow(data),
+			ExpectError: regexp.MustCompile("`rotate_days_before_expiry` \\(30\\) must be less than `validity_in_days` \\(30\\)"),
+		},
+	})
+}
+
+func (r ApplicationPasswordRotationResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Applications.ApplicationClient
+
+	id, err := parse.ParsePasswordRotationID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	applicationId := stable.NewApplicationID(id.ApplicationId)
+
+	resp, err := client.GetApplication(ctx, applicationId, application.DefaultGetApplicationOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %+v", applicationId, err)
+	}
+
+	app := resp.Model
+	if app == nil {
+		return pointer.To(false), nil
+	}
+
+	return pointer.To(credentials.GetPasswordCredential(app.PasswordCredentials, state.Attributes["current_key_id"]) != nil), nil
+}
+
+func (ApplicationPasswordRotationResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctestAppPasswordRotation-%[1]d"
+}
+`, data.RandomInteger)
+}
+
+func (r ApplicationPasswordRotationResource) basic(data acceptance.TestData, rotation string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_password_rotation" "test" {
+  application_id   = azuread_application.test.id
+  display_name     = "acctest-rotation"
+  validity_in_days = 90
+
+  rotate_when_changed = {
+    rotation = "%[2]s"
+  }
+}
+`, r.template(data), rotation)
+}
+
+func (r ApplicationPasswordRotationResource) passwordCount(data acceptance.TestData, rotation string, count int) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_password_rotation" "test" {
+  application_id            = azuread_application.test.id
+  validity_in_days          = 90
+  rotate_days_before_expiry = 14
+  grace_period_in_days      = 30
+  password_count            = %[3]d
+
+  rotate_when_changed = {
+    rotation = "%[2]s"
+  }
+}
+`, r.template(data), rotation, count)
+}
+
+func (r ApplicationPasswordRotationResource) invalidRotationWindow(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_password_rotation" "test" {
+  application_id   = azuread_application.test.id
+  validity_in_days = 30
+}
+`, r.template(data))
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/tokenlifetimepolicy"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+)
+
+func applicationTokenLifetimePolicyAssignmentResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: applicationTokenLifetimePolicyAssignmentResourceCreate,
+		ReadContext:   applicationTokenLifetimePolicyAssignmentResourceRead,
+		DeleteContext: applicationTokenLifetimePolicyAssignmentResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			if _, errs := stable.ValidateApplicationIdTokenLifetimePolicyID(id, "id"); len(errs) > 0 {
+				out := ""
+				for _, err := range errs {
+					out += err.Error()
+				}
+				return errors.New(out)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"application_id": {
+				Description:  "The resource ID of the application to which the policy should be assigned",
+				Type:         pluginsdk.TypeString,
+				ForceNew:     true,
+				Required:     true,
+				ValidateFunc: stable.ValidateApplicationID,
+			},
+
+			"token_lifetime_policy_id": {
+				Description:  "ID of the token lifetime policy to assign",
+				Type:         pluginsdk.TypeString,
+				ForceNew:     true,
+				Required:     true,
+				ValidateFunc: stable.ValidatePolicyTokenLifetimePolicyID,
+			},
+		},
+	}
+}
+
+func applicationTokenLifetimePolicyAssignmentResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationTokenLifetimePolicyClient
+
+	applicationId, err := stable.ParseApplicationID(d.Get("application_id").(string))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "application_id", "Parsing `application_id`")
+	}
+
+	policyId, err := stable.ParsePolicyTokenLifetimePolicyID(d.Get("token_lifetime_policy_id").(string))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "token_lifetime_policy_id", "Parsing `token_lifetime_policy_id`")
+	}
+
+	ref := stable.ReferenceCreate{
+		ODataId: pointer.To(client.Client.BaseUri + stable.NewDirectoryObjectID(policyId.TokenLifetimePolicyId).ID()),
+	}
+
+	if _, err := client.AddTokenLifetimePolicyRef(ctx, *applicationId, ref, tokenlifetimepolicy.DefaultAddTokenLifetimePolicyRefOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Creating TokenLifetimePolicyAssignment for %s", applicationId)
+	}
+
+	id := stable.NewApplicationIdTokenLifetimePolicyID(applicationId.ApplicationId, policyId.TokenLifetimePolicyId)
+	d.SetId(id.ID())
+
+	return applicationTokenLifetimePolicyAssignmentResourceRead(ctx, d, meta)
+}
+
+func applicationTokenLifetimePolicyAssignmentResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationTokenLifetimePolicyClient
+
+	id, err := stable.ParseApplicationIdTokenLifetimePolicyID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Token Lifetime Policy Assignment ID %q", d.Id())
+	}
+
+	policyId := stable.NewPolicyTokenLifetimePolicyID(id.TokenLifetimePolicyId)
+	applicationId := stable.NewApplicationID(id.ApplicationId)
+
+	resp, err := client.ListTokenLifetimePolicies(ctx, applicationId, tokenlifetimepolicy.DefaultListTokenLifetimePoliciesOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing token lifetime policy assignment from state!", applicationId)
+			d.SetId("")
+			return nil
+		}
+
+		return tf.ErrorDiagF(err, "listing Token Lifetime Policy Assignments for %s", applicationId)
+	}
+
+	policies := resp.Model
+	if policies == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "listing Token Lifetime Policy Assignments for %s", applicationId)
+	}
+
+	var policy *stable.TokenLifetimePolicy
+
+	// Check the assignment is found in the currently assigned policies
+	for _, p := range *policies {
+		if pointer.From(p.Id) == id.TokenLifetimePolicyId {
+			policy = &p
+			break
+		}
+	}
+	if policy == nil {
+		d.SetId("")
+		log.Printf("[DEBUG] Token Lifetime Policy with Object ID %q was not found - removing assignment from state!", id.TokenLifetimePolicyId)
+		return nil
+	}
+
+	tf.Set(d, "application_id", applicationId.ID())
+	tf.Set(d, "token_lifetime_policy_id", policyId.ID())
+
+	return nil
+}
+
+func applicationTokenLifetimePolicyAssignmentResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationTokenLifetimePolicyClient
+
+	id, err := stable.ParseApplicationIdTokenLifetimePolicyID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Token Lifetime Policy Assignment ID %q", d.Id())
+	}
+
+	if _, err = client.RemoveTokenLifetimePolicyRef(ctx, *id, tokenlifetimepolicy.DefaultRemoveTokenLifetimePolicyRefOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "removing %s", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/tokenlifetimepolicy"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type ApplicationTokenLifetimePolicyAssignmentResource struct{}
+
+func TestAccApplicationTokenLifetimePolicyAssignment_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_token_lifetime_policy_assignment", "test")
+	r := ApplicationTokenLifetimePolicyAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r ApplicationTokenLifetimePolicyAssignmentResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Applications.ApplicationTokenLifetimePolicyClient
+
+	id, err := stable.ParseApplicationIdTokenLifetimePolicyID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Token Lifetime Policy Assignment ID: %v", err)
+	}
+
+	applicationId := stable.NewApplicationID(id.ApplicationId)
+
+	resp, err := client.ListTokenLifetimePolicies(ctx, applicationId, tokenlifetimepolicy.DefaultListTokenLifetimePoliciesOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, fmt.Errorf("%s does not exist", applicationId)
+		}
+		return nil, fmt.Errorf("failed to retrieve token lifetime policy assignments for %s: %+v", applicationId, err)
+	}
+
+	if resp.Model != nil {
+		for _, p := range *resp.Model {
+			if pointer.From(p.Id) == id.TokenLifetimePolicyId {
+				return pointer.To(true), nil
+			}
+		}
+	}
+
+	return pointer.To(false), nil
+}
+
+func (ApplicationTokenLifetimePolicyAssignmentResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+}
+
+resource "azuread_token_lifetime_policy" "test" {
+  display_name          = "acctest-%[2]s"
+  access_token_lifetime = "04:00:00"
+}
+
+resource "azuread_application_token_lifetime_policy_assignment" "test" {
+  application_id           = azuread_application.test.id
+  token_lifetime_policy_id = azuread_token_lifetime_policy.test.id
+}
+`, data.RandomInteger, data.RandomString)
+}
@@ -6,6 +6,7 @@ package applications
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -20,6 +21,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
 	"github.com/hashicorp/go-azure-sdk/sdk/odata"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/applications"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/credentials"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
@@ -312,6 +314,66 @@ func applicationFindByName(ctx context.Context, client *application.ApplicationC
 	return &result, nil
 }
 
+// applicationWaitForPasswordCredential waits for a newly added password credential to appear in the application
+// manifest, which can take several minutes
+func applicationWaitForPasswordCredential(ctx context.Context, client *application.ApplicationClient, applicationId stable.ApplicationId, keyId string) error {
+	timeout, _ := ctx.Deadline()
+	polledForCredential, err := (&pluginsdk.StateChangeConf{ //nolint:staticcheck
+		Pending:                   []string{"Waiting"},
+		Target:                    []string{"Done"},
+		Timeout:                   time.Until(timeout),
+		MinTimeout:                1 * time.Second,
+		ContinuousTargetOccurence: 5,
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.GetApplication(ctx, applicationId, application.DefaultGetApplicationOperationOptions())
+			if err != nil {
+				return nil, "Error", err
+			}
+
+			if resp.Model != nil {
+				if cred := credentials.GetPasswordCredential(resp.Model.PasswordCredentials, keyId); cred != nil {
+					return cred, "Done", nil
+				}
+			}
+
+			return nil, "Waiting", nil
+		},
+	}).WaitForStateContext(ctx)
+
+	if err != nil {
+		return err
+	} else if polledForCredential == nil {
+		return errors.New("password credential not found in application manifest")
+	}
+
+	return nil
+}
+
+// applicationRemovePasswordCredential removes a password credential from an application and waits for the removal
+// to be reflected in the application manifest
+func applicationRemovePasswordCredential(ctx context.Context, client *application.ApplicationClient, applicationId stable.ApplicationId, keyId string) error {
+	request := application.RemovePasswordRequest{
+		KeyId: pointer.To(keyId),
+	}
+	if _, err := client.RemovePassword(ctx, applicationId, request, application.DefaultRemovePasswordOperationOptions()); err != nil {
+		return err
+	}
+
+	return consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
+		resp, err := client.GetApplication(ctx, applicationId, application.DefaultGetApplicationOperationOptions())
+		if err != nil {
+			return nil, err
+		}
+
+		app := resp.Model
+		if app == nil {
+			return nil, errors.New("model was nil")
+		}
+
+		return pointer.To(credentials.GetPasswordCredential(app.PasswordCredentials, keyId) != nil), nil
+	})
+}
+
 func applicationParseLogoImage(encodedImage string) (string, []byte, error) {
 	imageData, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedImage))
 	if err != nil {
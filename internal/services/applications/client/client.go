@@ -5,24 +5,34 @@ package client
 
 import (
 	applicationBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/beta/application"
+	federatedidentitycredentialBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/beta/federatedidentitycredential"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/extensionproperty"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/federatedidentitycredential"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/logo"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/owner"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/tokenlifetimepolicy"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applicationtemplates/stable/applicationtemplate"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directoryobjects/stable/directoryobject"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/oauth2permissiongrants/stable/oauth2permissiongrant"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/approleassignment"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
 	"github.com/hashicorp/terraform-provider-azuread/internal/common"
 )
 
 type Client struct {
-	ApplicationClient                      *application.ApplicationClient
-	ApplicationClientBeta                  *applicationBeta.ApplicationClient
-	ApplicationLogoClient                  *logo.LogoClient
-	ApplicationOwnerClient                 *owner.OwnerClient
-	ApplicationFederatedIdentityCredential *federatedidentitycredential.FederatedIdentityCredentialClient
-	ApplicationTemplateClient              *applicationtemplate.ApplicationTemplateClient
-	ServicePrincipalClient                 *serviceprincipal.ServicePrincipalClient
+	ApplicationClient                          *application.ApplicationClient
+	ApplicationClientBeta                      *applicationBeta.ApplicationClient
+	ApplicationExtensionPropertyClient         *extensionproperty.ExtensionPropertyClient
+	ApplicationLogoClient                      *logo.LogoClient
+	ApplicationOwnerClient                     *owner.OwnerClient
+	ApplicationFederatedIdentityCredential     *federatedidentitycredential.FederatedIdentityCredentialClient
+	ApplicationFederatedIdentityCredentialBeta *federatedidentitycredentialBeta.FederatedIdentityCredentialClient
+	ApplicationTemplateClient                  *applicationtemplate.ApplicationTemplateClient
+	ApplicationTokenLifetimePolicyClient       *tokenlifetimepolicy.TokenLifetimePolicyClient
+	OAuth2PermissionGrantClient                *oauth2permissiongrant.OAuth2PermissionGrantClient
+	ServicePrincipalAppRoleAssignmentClient    *approleassignment.AppRoleAssignmentClient
+	ServicePrincipalClient                     *serviceprincipal.ServicePrincipalClient
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
@@ -39,6 +49,12 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(applicationClientBeta.Client)
 
+	applicationExtensionPropertyClient, err := extensionproperty.NewExtensionPropertyClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(applicationExtensionPropertyClient.Client)
+
 	applicationLogoClient, err := logo.NewLogoClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
@@ -57,18 +73,43 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(applicationFederatedIdentityCredentialClient.Client)
 
+	// Needed because v1.0 API doesn't support `claimsMatchingExpression`
+	applicationFederatedIdentityCredentialClientBeta, err := federatedidentitycredentialBeta.NewFederatedIdentityCredentialClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(applicationFederatedIdentityCredentialClientBeta.Client)
+
 	applicationTemplateClient, err := applicationtemplate.NewApplicationTemplateClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
 	}
 	o.Configure(applicationTemplateClient.Client)
 
+	applicationTokenLifetimePolicyClient, err := tokenlifetimepolicy.NewTokenLifetimePolicyClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(applicationTokenLifetimePolicyClient.Client)
+
 	directoryObjectClient, err := directoryobject.NewDirectoryObjectClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
 	}
 	o.Configure(directoryObjectClient.Client)
 
+	oAuth2PermissionGrantClient, err := oauth2permissiongrant.NewOAuth2PermissionGrantClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(oAuth2PermissionGrantClient.Client)
+
+	servicePrincipalAppRoleAssignmentClient, err := approleassignment.NewAppRoleAssignmentClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(servicePrincipalAppRoleAssignmentClient.Client)
+
 	servicePrincipalClient, err := serviceprincipal.NewServicePrincipalClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
@@ -76,12 +117,17 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	o.Configure(servicePrincipalClient.Client)
 
 	return &Client{
-		ApplicationClient:                      applicationClient,
-		ApplicationClientBeta:                  applicationClientBeta,
-		ApplicationLogoClient:                  applicationLogoClient,
-		ApplicationOwnerClient:                 applicationOwnerClient,
-		ApplicationFederatedIdentityCredential: applicationFederatedIdentityCredentialClient,
-		ApplicationTemplateClient:              applicationTemplateClient,
-		ServicePrincipalClient:                 servicePrincipalClient,
+		ApplicationClient:                          applicationClient,
+		ApplicationClientBeta:                      applicationClientBeta,
+		ApplicationExtensionPropertyClient:         applicationExtensionPropertyClient,
+		ApplicationLogoClient:                      applicationLogoClient,
+		ApplicationOwnerClient:                     applicationOwnerClient,
+		ApplicationFederatedIdentityCredential:     applicationFederatedIdentityCredentialClient,
+		ApplicationFederatedIdentityCredentialBeta: applicationFederatedIdentityCredentialClientBeta,
+		ApplicationTemplateClient:                  applicationTemplateClient,
+		ApplicationTokenLifetimePolicyClient:       applicationTokenLifetimePolicyClient,
+		OAuth2PermissionGrantClient:                oAuth2PermissionGrantClient,
+		ServicePrincipalAppRoleAssignmentClient:    servicePrincipalAppRoleAssignmentClient,
+		ServicePrincipalClient:                     servicePrincipalClient,
 	}, nil
 }
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+type PasswordRotationId struct {
+	ApplicationId string
+}
+
+func NewPasswordRotationID(applicationId string) *PasswordRotationId {
+	return &PasswordRotationId{
+		ApplicationId: applicationId,
+	}
+}
+
+// ParsePasswordRotationID parses 'input' into a PasswordRotationId
+func ParsePasswordRotationID(input string) (*PasswordRotationId, error) {
+	parser := resourceids.NewParserFromResourceIdType(&PasswordRotationId{})
+	parsed, err := parser.Parse(input, false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	var ok bool
+	id := &PasswordRotationId{}
+
+	if id.ApplicationId, ok = parsed.Parsed["applicationId"]; !ok {
+		return nil, resourceids.NewSegmentNotSpecifiedError(id, "applicationId", *parsed)
+	}
+
+	return id, nil
+}
+
+// ValidatePasswordRotationID checks that 'input' can be parsed as a Password Rotation ID
+func ValidatePasswordRotationID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	id, err := ParsePasswordRotationID(v)
+	if err != nil {
+		errors = append(errors, err)
+		return
+	}
+
+	return validation.IsUUID(id.ApplicationId, "ID")
+}
+
+func (id *PasswordRotationId) ID() string {
+	fmtString := "/applications/%s/passwordRotation"
+	return fmt.Sprintf(fmtString, id.ApplicationId)
+}
+
+// Segments returns a slice of Resource ID Segments which comprise this ID
+func (id *PasswordRotationId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("applications", "applications", "applications"),
+		resourceids.UserSpecifiedSegment("applicationId", "00000000-0000-0000-0000-000000000000"),
+		resourceids.StaticSegment("passwordRotation", "passwordRotation", "passwordRotation"),
+	}
+}
+
+func (id *PasswordRotationId) String() string {
+	return fmt.Sprintf("Password Rotation (Application ID: %q)", id.ApplicationId)
+}
+
+func (id *PasswordRotationId) FromParseResult(input resourceids.ParseResult) error {
+	var ok bool
+
+	if id.ApplicationId, ok = input.Parsed["applicationId"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "applicationId", input)
+	}
+
+	return nil
+}
@@ -39,11 +39,14 @@ func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azuread_application":                               applicationResource(),
-		"azuread_application_certificate":                   applicationCertificateResource(),
-		"azuread_application_federated_identity_credential": applicationFederatedIdentityCredentialResource(),
-		"azuread_application_password":                      applicationPasswordResource(),
-		"azuread_application_pre_authorized":                applicationPreAuthorizedResource(),
+		"azuread_application":                                  applicationResource(),
+		"azuread_application_admin_consent":                    applicationAdminConsentResource(),
+		"azuread_application_certificate":                      applicationCertificateResource(),
+		"azuread_application_federated_identity_credential":    applicationFederatedIdentityCredentialResource(),
+		"azuread_application_password":                         applicationPasswordResource(),
+		"azuread_application_password_rotation":                applicationPasswordRotationResource(),
+		"azuread_application_pre_authorized":                   applicationPreAuthorizedResource(),
+		"azuread_application_token_lifetime_policy_assignment": applicationTokenLifetimePolicyAssignmentResource(),
 	}
 }
 
@@ -57,6 +60,7 @@ func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
 		ApplicationApiAccessResource{},
 		ApplicationAppRoleResource{},
+		ApplicationExtensionPropertyResource{},
 		ApplicationFallbackPublicClientResource{},
 		ApplicationFromTemplateResource{},
 		ApplicationIdentifierUriResource{},
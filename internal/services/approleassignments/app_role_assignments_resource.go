@@ -0,0 +1,310 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/approleassignedto"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/approleassignments/parse"
+	"golang.org/x/sync/errgroup"
+)
+
+// appRoleAssignmentsParallelism is the maximum number of concurrent requests made when reconciling app role assignments
+const appRoleAssignmentsParallelism = 10
+
+func appRoleAssignmentsResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: appRoleAssignmentsResourceCreate,
+		ReadContext:   appRoleAssignmentsResourceRead,
+		UpdateContext: appRoleAssignmentsResourceUpdate,
+		DeleteContext: appRoleAssignmentsResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.AppRoleAssignmentsID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"app_role_id": {
+				Description:  "The ID of the app role to be assigned",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"resource_object_id": {
+				Description:  "The object ID of the service principal representing the resource",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"principal_object_ids": {
+				Description: "The object IDs of the users, groups or service principals to be assigned this app role",
+				Type:        pluginsdk.TypeSet,
+				Required:    true,
+				Set: func(v interface{}) int {
+					return pluginsdk.HashString(strings.ToLower(v.(string)))
+				},
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.IsUUID,
+					StateFunc: func(v interface{}) string {
+						return strings.ToLower(v.(string))
+					},
+				},
+			},
+
+			"resource_display_name": {
+				Description: "The display name of the application representing the resource",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func appRoleAssignmentsResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	servicePrincipalClient := meta.(*clients.Client).AppRoleAssignments.ServicePrincipalClient
+
+	appRoleId := d.Get("app_role_id").(string)
+	resourceId := d.Get("resource_object_id").(string)
+
+	if resp, err := servicePrincipalClient.GetServicePrincipal(ctx, stable.NewServicePrincipalID(resourceId), serviceprincipal.DefaultGetServicePrincipalOperationOptions()); err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return tf.ErrorDiagPathF(err, "resource_object_id", "Service principal not found for resource (Object ID: %q)", resourceId)
+		}
+		return tf.ErrorDiagF(err, "Could not retrieve service principal for resource (Object ID: %q)", resourceId)
+	}
+
+	id := parse.NewAppRoleAssignmentsID(resourceId, appRoleId)
+
+	principalIds := tf.ExpandStringSlice(d.Get("principal_object_ids").(*pluginsdk.Set).List())
+	if err := appRoleAssignmentsReconcile(ctx, meta, id, principalIds); err != nil {
+		return tf.ErrorDiagF(err, "Could not create app role assignments for %s", id)
+	}
+
+	d.SetId(id.String())
+
+	return appRoleAssignmentsResourceRead(ctx, d, meta)
+}
+
+func appRoleAssignmentsResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	id, err := parse.AppRoleAssignmentsID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing App Role Assignments ID")
+	}
+
+	principalIds := tf.ExpandStringSlice(d.Get("principal_object_ids").(*pluginsdk.Set).List())
+	if err := appRoleAssignmentsReconcile(ctx, meta, *id, principalIds); err != nil {
+		return tf.ErrorDiagF(err, "Could not update app role assignments for %s", id)
+	}
+
+	return appRoleAssignmentsResourceRead(ctx, d, meta)
+}
+
+func appRoleAssignmentsResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).AppRoleAssignments.AppRoleAssignedToClient
+
+	id, err := parse.AppRoleAssignmentsID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing App Role Assignments ID")
+	}
+
+	servicePrincipalId := stable.NewServicePrincipalID(id.ResourceId)
+
+	resp, err := client.ListAppRoleAssignedTos(ctx, servicePrincipalId, approleassignedto.DefaultListAppRoleAssignedTosOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing from state!", servicePrincipalId)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Listing app role assignments for %s", servicePrincipalId)
+	}
+
+	if resp.Model == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Listing app role assignments for %s", servicePrincipalId)
+	}
+
+	principalIds := make([]string, 0)
+	resourceDisplayName := ""
+	for _, assignment := range *resp.Model {
+		if !strings.EqualFold(pointer.From(assignment.AppRoleId), id.AppRoleId) {
+			continue
+		}
+		principalIds = append(principalIds, strings.ToLower(assignment.PrincipalId.GetOrZero()))
+		resourceDisplayName = assignment.ResourceDisplayName.GetOrZero()
+	}
+
+	tf.Set(d, "app_role_id", id.AppRoleId)
+	tf.Set(d, "principal_object_ids", principalIds)
+	tf.Set(d, "resource_display_name", resourceDisplayName)
+	tf.Set(d, "resource_object_id", id.ResourceId)
+
+	return nil
+}
+
+func appRoleAssignmentsResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	id, err := parse.AppRoleAssignmentsID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing App Role Assignments ID")
+	}
+
+	if err = appRoleAssignmentsReconcile(ctx, meta, *id, []string{}); err != nil {
+		return tf.ErrorDiagF(err, "Could not delete app role assignments for %s", id)
+	}
+
+	return nil
+}
+
+// appRoleAssignmentsReconcile ensures that the specified app role is assigned to exactly the specified principals,
+// creating and deleting assignments in parallel and then waiting for the changes to become consistent
+func appRoleAssignmentsReconcile(ctx context.Context, meta interface{}, id parse.AppRoleAssignmentsId, principalIds []string) error {
+	client := meta.(*clients.Client).AppRoleAssignments.AppRoleAssignedToClient
+
+	servicePrincipalId := stable.NewServicePrincipalID(id.ResourceId)
+
+	existing, err := appRoleAssignmentsList(ctx, client, id)
+	if err != nil {
+		return err
+	}
+
+	desired := make(map[string]bool)
+	for _, principalId := range principalIds {
+		desired[strings.ToLower(principalId)] = true
+	}
+
+	toCreate := make([]string, 0)
+	for principalId := range desired {
+		if _, ok := existing[principalId]; !ok {
+			toCreate = append(toCreate, principalId)
+		}
+	}
+
+	toDelete := make([]stable.ServicePrincipalIdAppRoleAssignedToId, 0)
+	for principalId, assignmentIds := range existing {
+		if !desired[principalId] {
+			for _, assignmentId := range assignmentIds {
+				toDelete = append(toDelete, stable.NewServicePrincipalIdAppRoleAssignedToID(id.ResourceId, assignmentId))
+			}
+		}
+	}
+
+	log.Printf("[DEBUG] Reconciling %s: creating %d and deleting %d assignments", id, len(toCreate), len(toDelete))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(appRoleAssignmentsParallelism)
+
+	for _, assignmentId := range toDelete {
+		g.Go(func() error {
+			if resp, err := client.DeleteAppRoleAssignedTo(gctx, assignmentId, approleassignedto.DefaultDeleteAppRoleAssignedToOperationOptions()); err != nil && !response.WasNotFound(resp.HttpResponse) {
+				return fmt.Errorf("deleting %s: %+v", assignmentId, err)
+			}
+			return nil
+		})
+	}
+
+	for _, principalId := range toCreate {
+		g.Go(func() error {
+			properties := stable.AppRoleAssignment{
+				AppRoleId:   pointer.To(id.AppRoleId),
+				PrincipalId: nullable.Value(principalId),
+				ResourceId:  nullable.Value(id.ResourceId),
+			}
+
+			options := approleassignedto.CreateAppRoleAssignedToOperationOptions{
+				RetryFunc: func(resp *http.Response, o *odata.OData) (bool, error) {
+					if response.WasNotFound(resp) {
+						return true, nil
+					} else if response.WasBadRequest(resp) && o != nil && o.Error != nil {
+						return o.Error.Match("Not a valid reference update"), nil
+					}
+					return false, nil
+				},
+			}
+
+			if _, err := client.CreateAppRoleAssignedTo(gctx, servicePrincipalId, properties, options); err != nil {
+				return fmt.Errorf("assigning app role to principal %q: %+v", principalId, err)
+			}
+			return nil
+		})
+	}
+
+	if err = g.Wait(); err != nil {
+		return err
+	}
+
+	if len(toCreate) == 0 && len(toDelete) == 0 {
+		return nil
+	}
+
+	// Wait for the assignments to be consistently listed for the resource
+	return consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		current, err := appRoleAssignmentsList(ctx, client, id)
+		if err != nil {
+			return nil, err
+		}
+		if len(current) != len(desired) {
+			return pointer.To(false), nil
+		}
+		for principalId := range desired {
+			if _, ok := current[principalId]; !ok {
+				return pointer.To(false), nil
+			}
+		}
+		return pointer.To(true), nil
+	})
+}
+
+// appRoleAssignmentsList returns the IDs of assignments for the specified app role, keyed by lower-cased principal object ID
+func appRoleAssignmentsList(ctx context.Context, client *approleassignedto.AppRoleAssignedToClient, id parse.AppRoleAssignmentsId) (map[string][]string, error) {
+	servicePrincipalId := stable.NewServicePrincipalID(id.ResourceId)
+
+	resp, err := client.ListAppRoleAssignedTos(ctx, servicePrincipalId, approleassignedto.DefaultListAppRoleAssignedTosOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("listing app role assignments for %s: %+v", servicePrincipalId, err)
+	}
+	if resp.Model == nil {
+		return nil, fmt.Errorf("listing app role assignments for %s: model was nil", servicePrincipalId)
+	}
+
+	result := make(map[string][]string)
+	for _, assignment := range *resp.Model {
+		if !strings.EqualFold(pointer.From(assignment.AppRoleId), id.AppRoleId) || assignment.Id == nil {
+			continue
+		}
+		principalId := strings.ToLower(assignment.PrincipalId.GetOrZero())
+		result[principalId] = append(result[principalId], *assignment.Id)
+	}
+
+	return result, nil
+}
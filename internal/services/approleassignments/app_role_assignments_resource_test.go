@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/approleassignedto"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/approleassignments/parse"
+)
+
+type AppRoleAssignmentsResource struct{}
+
+func TestAccAppRoleAssignments_groupsForTenantApp(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_app_role_assignments", "test")
+	r := AppRoleAssignmentsResource{}
+	appRoleId := data.UUID()
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.groupsForTenantApp(data, appRoleId, 3, "[0, 1, 2]"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("principal_object_ids.#").HasValue("3"),
+				check.That(data.ResourceName).Key("resource_display_name").Exists(),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.groupsForTenantApp(data, appRoleId, 5, "[1, 3, 4]"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("principal_object_ids.#").HasValue("3"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.groupsForTenantApp(data, appRoleId, 5, "[0, 1, 2, 3, 4]"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("principal_object_ids.#").HasValue("5"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccAppRoleAssignments_upperCasePrincipalIds(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_app_role_assignments", "test")
+	r := AppRoleAssignmentsResource{}
+	appRoleId := data.UUID()
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.upperCasePrincipalIds(data, appRoleId),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("principal_object_ids.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r AppRoleAssignmentsResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.AppRoleAssignments.AppRoleAssignedToClient
+
+	id, err := parse.AppRoleAssignmentsID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing App Role Assignments ID: %v", err)
+	}
+
+	servicePrincipalId := stable.NewServicePrincipalID(id.ResourceId)
+
+	resp, err := client.ListAppRoleAssignedTos(ctx, servicePrincipalId, approleassignedto.DefaultListAppRoleAssignedTosOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to list app role assignments for %s: %+v", servicePrincipalId, err)
+	}
+
+	if resp.Model != nil {
+		for _, assignment := range *resp.Model {
+			if strings.EqualFold(pointer.From(assignment.AppRoleId), id.AppRoleId) {
+				return pointer.To(true), nil
+			}
+		}
+	}
+
+	return pointer.To(false), nil
+}
+
+func (AppRoleAssignmentsResource) template(data acceptance.TestData, appRoleId string) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "internal" {
+  display_name = "acctest-AppRoleAssignments-internal-%[1]d"
+
+  app_role {
+    allowed_member_types = ["User"]
+    description          = "Admins can perform all task actions"
+    display_name         = "Admin"
+    enabled              = true
+    id                   = "%[2]s"
+    value                = "Admin.All"
+  }
+}
+
+resource "azuread_service_principal" "internal" {
+  client_id = azuread_application.internal.client_id
+}
+`, data.RandomInteger, appRoleId)
+}
+
+func (r AppRoleAssignmentsResource) groupsForTenantApp(data acceptance.TestData, appRoleId string, groupCount int, assignedIndexes string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group" "test" {
+  count            = %[3]d
+  display_name     = "acctest-appRoleAssignments-${count.index}-%[2]d"
+  security_enabled = true
+}
+
+resource "azuread_app_role_assignments" "test" {
+  app_role_id          = azuread_service_principal.internal.app_role_ids["Admin.All"]
+  principal_object_ids = [for i in %[4]s : azuread_group.test[i].object_id]
+  resource_object_id   = azuread_service_principal.internal.object_id
+}
+`, r.template(data, appRoleId), data.RandomInteger, groupCount, assignedIndexes)
+}
+
+func (r AppRoleAssignmentsResource) upperCasePrincipalIds(data acceptance.TestData, appRoleId string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group" "test" {
+  count            = 2
+  display_name     = "acctest-appRoleAssignments-${count.index}-%[2]d"
+  security_enabled = true
+}
+
+resource "azuread_app_role_assignments" "test" {
+  app_role_id          = azuread_service_principal.internal.app_role_ids["Admin.All"]
+  principal_object_ids = [for g in azuread_group.test : upper(g.object_id)]
+  resource_object_id   = azuread_service_principal.internal.object_id
+}
+`, r.template(data, appRoleId), data.RandomInteger)
+}
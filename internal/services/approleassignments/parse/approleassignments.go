@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+)
+
+const appRole = "appRole"
+
+type AppRoleAssignmentsId struct {
+	ResourceId string
+	AppRoleId  string
+}
+
+func NewAppRoleAssignmentsID(resourceId, appRoleId string) AppRoleAssignmentsId {
+	return AppRoleAssignmentsId{
+		ResourceId: resourceId,
+		AppRoleId:  appRoleId,
+	}
+}
+
+func (id AppRoleAssignmentsId) String() string {
+	return id.ResourceId + "/" + appRole + "/" + id.AppRoleId
+}
+
+func AppRoleAssignmentsID(idString string) (*AppRoleAssignmentsId, error) {
+	id, err := ObjectSubResourceID(idString, appRole)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse App Role Assignments ID: %v", err)
+	}
+
+	return &AppRoleAssignmentsId{
+		ResourceId: id.objectId,
+		AppRoleId:  id.subId,
+	}, nil
+}
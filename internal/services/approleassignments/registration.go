@@ -32,6 +32,7 @@ func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azuread_app_role_assignment": appRoleAssignmentResource(),
+		"azuread_app_role_assignment":  appRoleAssignmentResource(),
+		"azuread_app_role_assignments": appRoleAssignmentsResource(),
 	}
 }
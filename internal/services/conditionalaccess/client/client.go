@@ -4,8 +4,11 @@
 package client
 
 import (
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccessauthenticationcontextclassreference"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccessnamedlocation"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccesspolicy"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccesstemplate"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/msgraph"
 	"github.com/hashicorp/terraform-provider-azuread/internal/common"
 )
 
@@ -16,27 +19,53 @@ import (
 // For this reason, we are bound to using the Stable API here, as to use the Beta API, even to update a single property
 // for a Conditional Access Policy, will break that policy for users. The only way to go back to the Stable API after
 // breaking a policy in this way, is to delete and recreate it, which is wholly undesirable for a critical security resource.
+// The Beta API is therefore only used for policies that are explicitly configured with properties that are not available
+// in the Stable API, and PolicyClientBeta must not be used for any other policy.
 
 type Client struct {
-	PolicyClient        *conditionalaccesspolicy.ConditionalAccessPolicyClient
-	NamedLocationClient *conditionalaccessnamedlocation.ConditionalAccessNamedLocationClient
+	AuthenticationContextClient *conditionalaccessauthenticationcontextclassreference.ConditionalAccessAuthenticationContextClassReferenceClient
+	PolicyClient                *conditionalaccesspolicy.ConditionalAccessPolicyClient
+	PolicyClientBeta            *msgraph.Client
+	NamedLocationClient         *conditionalaccessnamedlocation.ConditionalAccessNamedLocationClient
+	TemplateClient              *conditionalaccesstemplate.ConditionalAccessTemplateClient
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
+	authenticationContextClient, err := conditionalaccessauthenticationcontextclassreference.NewConditionalAccessAuthenticationContextClassReferenceClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(authenticationContextClient.Client)
+
 	policyClient, err := conditionalaccesspolicy.NewConditionalAccessPolicyClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
 	}
 	o.Configure(policyClient.Client)
 
+	policyClientBeta, err := msgraph.NewClient(o.Environment.MicrosoftGraph, "conditionalaccesspolicy", msgraph.VersionBeta)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(policyClientBeta)
+
 	namedLocationClient, err := conditionalaccessnamedlocation.NewConditionalAccessNamedLocationClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
 	}
 	o.Configure(namedLocationClient.Client)
 
+	templateClient, err := conditionalaccesstemplate.NewConditionalAccessTemplateClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(templateClient.Client)
+
 	return &Client{
-		PolicyClient:        policyClient,
-		NamedLocationClient: namedLocationClient,
+		AuthenticationContextClient: authenticationContextClient,
+		PolicyClient:                policyClient,
+		PolicyClientBeta:            policyClientBeta,
+		NamedLocationClient:         namedLocationClient,
+		TemplateClient:              templateClient,
 	}, nil
 }
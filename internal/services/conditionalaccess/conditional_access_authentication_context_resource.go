@@ -0,0 +1,207 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccessauthenticationcontextclassreference"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func conditionalAccessAuthenticationContextResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: conditionalAccessAuthenticationContextResourceCreate,
+		ReadContext:   conditionalAccessAuthenticationContextResourceRead,
+		UpdateContext: conditionalAccessAuthenticationContextResourceUpdate,
+		DeleteContext: conditionalAccessAuthenticationContextResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			if _, errs := stable.ValidateIdentityConditionalAccessAuthenticationContextClassReferenceID(id, "id"); len(errs) > 0 {
+				out := ""
+				for _, err := range errs {
+					out += err.Error()
+				}
+				return errors.New(out)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"class_reference_id": {
+				Description:  "The identifier of the authentication context class reference, from `c1` to `c99`",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^c([1-9]|[1-9][0-9])$`), "must be in the format `cN`, where N is a number between 1 and 99"),
+			},
+
+			"display_name": {
+				Description:  "The display name of the authentication context",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"description": {
+				Description: "A description of the authentication context",
+				Type:        pluginsdk.TypeString,
+				Optional:    true,
+			},
+
+			"available": {
+				Description: "Whether the authentication context is published, and can be used by applications and tagged resources",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func conditionalAccessAuthenticationContextResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.AuthenticationContextClient
+
+	id := stable.NewIdentityConditionalAccessAuthenticationContextClassReferenceID(d.Get("class_reference_id").(string))
+
+	resp, err := client.GetConditionalAccessAuthenticationContextClassReference(ctx, id, conditionalaccessauthenticationcontextclassreference.DefaultGetConditionalAccessAuthenticationContextClassReferenceOperationOptions())
+	if err != nil {
+		if !response.WasNotFound(resp.HttpResponse) {
+			return tf.ErrorDiagF(err, "Checking for existing %s", id)
+		}
+	} else {
+		return tf.ImportAsExistsDiag("azuread_conditional_access_authentication_context", id.ID())
+	}
+
+	properties := stable.AuthenticationContextClassReference{
+		Id:          pointer.To(id.AuthenticationContextClassReferenceId),
+		Description: nullable.NoZero(d.Get("description").(string)),
+		DisplayName: nullable.Value(d.Get("display_name").(string)),
+		IsAvailable: nullable.Value(d.Get("available").(bool)),
+	}
+
+	if _, err = client.CreateConditionalAccessAuthenticationContextClassReference(ctx, properties, conditionalaccessauthenticationcontextclassreference.DefaultCreateConditionalAccessAuthenticationContextClassReferenceOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Creating %s", id)
+	}
+
+	if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		resp, err := client.GetConditionalAccessAuthenticationContextClassReference(ctx, id, conditionalaccessauthenticationcontextclassreference.DefaultGetConditionalAccessAuthenticationContextClassReferenceOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(resp.Model != nil), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for creation of %s", id)
+	}
+
+	d.SetId(id.ID())
+
+	return conditionalAccessAuthenticationContextResourceRead(ctx, d, meta)
+}
+
+func conditionalAccessAuthenticationContextResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.AuthenticationContextClient
+
+	id, err := stable.ParseIdentityConditionalAccessAuthenticationContextClassReferenceID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Authentication Context ID")
+	}
+
+	properties := stable.AuthenticationContextClassReference{
+		Description: nullable.Value(d.Get("description").(string)),
+		DisplayName: nullable.Value(d.Get("display_name").(string)),
+		IsAvailable: nullable.Value(d.Get("available").(bool)),
+	}
+
+	if _, err = client.UpdateConditionalAccessAuthenticationContextClassReference(ctx, *id, properties, conditionalaccessauthenticationcontextclassreference.DefaultUpdateConditionalAccessAuthenticationContextClassReferenceOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Updating %s", id)
+	}
+
+	return conditionalAccessAuthenticationContextResourceRead(ctx, d, meta)
+}
+
+func conditionalAccessAuthenticationContextResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.AuthenticationContextClient
+
+	id, err := stable.ParseIdentityConditionalAccessAuthenticationContextClassReferenceID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Authentication Context ID")
+	}
+
+	resp, err := client.GetConditionalAccessAuthenticationContextClassReference(ctx, *id, conditionalaccessauthenticationcontextclassreference.DefaultGetConditionalAccessAuthenticationContextClassReferenceOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+
+	authenticationContext := resp.Model
+	if authenticationContext == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+	}
+
+	tf.Set(d, "available", authenticationContext.IsAvailable.GetOrZero())
+	tf.Set(d, "class_reference_id", id.AuthenticationContextClassReferenceId)
+	tf.Set(d, "description", authenticationContext.Description.GetOrZero())
+	tf.Set(d, "display_name", authenticationContext.DisplayName.GetOrZero())
+
+	return nil
+}
+
+func conditionalAccessAuthenticationContextResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.AuthenticationContextClient
+
+	id, err := stable.ParseIdentityConditionalAccessAuthenticationContextClassReferenceID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Authentication Context ID")
+	}
+
+	if resp, err := client.DeleteConditionalAccessAuthenticationContextClassReference(ctx, *id, conditionalaccessauthenticationcontextclassreference.DefaultDeleteConditionalAccessAuthenticationContextClassReferenceOperationOptions()); err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s already deleted", id)
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Deleting %s", id)
+	}
+
+	if err = consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
+		resp, err := client.GetConditionalAccessAuthenticationContextClassReference(ctx, *id, conditionalaccessauthenticationcontextclassreference.DefaultGetConditionalAccessAuthenticationContextClassReferenceOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(true), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for deletion of %s", id)
+	}
+
+	return nil
+}
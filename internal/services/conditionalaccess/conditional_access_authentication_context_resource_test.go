@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccessauthenticationcontextclassreference"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+)
+
+type ConditionalAccessAuthenticationContextResource struct{}
+
+func TestAccConditionalAccessAuthenticationContext_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_conditional_access_authentication_context", "test")
+	r := ConditionalAccessAuthenticationContextResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctest-AUTHCONTEXT-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("available").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccConditionalAccessAuthenticationContext_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_conditional_access_authentication_context", "test")
+	r := ConditionalAccessAuthenticationContextResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("description").HasValue("Step-up authentication for sensitive data"),
+				check.That(data.ResourceName).Key("available").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("description").HasValue(""),
+				check.That(data.ResourceName).Key("available").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r ConditionalAccessAuthenticationContextResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := stable.ParseIdentityConditionalAccessAuthenticationContextClassReferenceID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.ConditionalAccess.AuthenticationContextClient.GetConditionalAccessAuthenticationContextClassReference(ctx, *id, conditionalaccessauthenticationcontextclassreference.DefaultGetConditionalAccessAuthenticationContextClassReferenceOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %v", id, err)
+	}
+
+	return pointer.To(true), nil
+}
+
+// classReferenceId returns an authentication context ID between c1 and c99, which is shared across all tests in the
+// tenant, so tests for this resource should not be run in parallel with each other
+func (ConditionalAccessAuthenticationContextResource) classReferenceId(data acceptance.TestData) string {
+	return fmt.Sprintf("c%d", data.RandomInteger%99+1)
+}
+
+func (r ConditionalAccessAuthenticationContextResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_conditional_access_authentication_context" "test" {
+  class_reference_id = "%[1]s"
+  display_name       = "acctest-AUTHCONTEXT-%[2]d"
+}
+`, r.classReferenceId(data), data.RandomInteger)
+}
+
+func (r ConditionalAccessAuthenticationContextResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_conditional_access_authentication_context" "test" {
+  class_reference_id = "%[1]s"
+  display_name       = "acctest-AUTHCONTEXT-%[2]d"
+  description        = "Step-up authentication for sensitive data"
+  available          = true
+}
+`, r.classReferenceId(data), data.RandomInteger)
+}
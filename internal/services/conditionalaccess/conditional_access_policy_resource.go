@@ -5,17 +5,23 @@ package conditionalaccess
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccesspolicy"
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/conditionalaccess"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/rawrequest"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
@@ -75,251 +81,235 @@ func conditionalAccessPolicyResource() *pluginsdk.Resource {
 				Type:     pluginsdk.TypeList,
 				Required: true,
 				MaxItems: 1,
+				Elem:     conditionalAccessConditionSetSchema("conditions"),
+			},
+
+			"grant_controls": {
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{"grant_controls", "session_controls"},
+				MaxItems:     1,
+				Elem:         conditionalAccessGrantControlsSchema("grant_controls"),
+			},
+
+			"session_controls": {
+				Type:             pluginsdk.TypeList,
+				Optional:         true,
+				AtLeastOneOf:     []string{"grant_controls", "session_controls"},
+				MaxItems:         1,
+				DiffSuppressFunc: conditionalAccessPolicyDiffSuppress,
+				Elem:             conditionalAccessSessionControlsSchema("session_controls"),
+			},
+
+			"lockout_protection": {
+				Description: "Refuse to create or update an enabled policy that would prevent the protected principals from signing in",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				MaxItems:    1,
 				Elem: &pluginsdk.Resource{
 					Schema: map[string]*pluginsdk.Schema{
-						"applications": {
-							Type:     pluginsdk.TypeList,
-							Required: true,
-							MaxItems: 1,
-							Elem: &pluginsdk.Resource{
-								Schema: map[string]*pluginsdk.Schema{
-									"included_applications": {
-										Type:         pluginsdk.TypeList,
-										Optional:     true,
-										ExactlyOneOf: []string{"conditions.0.applications.0.included_applications", "conditions.0.applications.0.included_user_actions"},
-										Elem: &pluginsdk.Schema{
-											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringIsNotEmpty,
-										},
-									},
+						"break_glass_user_ids": {
+							Description: "Object IDs of break-glass accounts which must be excluded from the policy",
+							Type:        pluginsdk.TypeSet,
+							Optional:    true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.IsUUID,
+							},
+						},
 
-									"excluded_applications": {
-										Type:     pluginsdk.TypeList,
-										Optional: true,
-										Elem: &pluginsdk.Schema{
-											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringIsNotEmpty,
-										},
-									},
+						"protect_calling_principal": {
+							Description: "Whether the principal used to authenticate the provider must be excluded from the policy",
+							Type:        pluginsdk.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+					},
+				},
+			},
 
-									"included_user_actions": {
-										Type:         pluginsdk.TypeList,
-										Optional:     true,
-										ExactlyOneOf: []string{"conditions.0.applications.0.included_applications", "conditions.0.applications.0.included_user_actions"},
-										Elem: &pluginsdk.Schema{
-											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringIsNotEmpty,
-										},
-									},
-								},
+			"object_id": {
+				Description: "The object ID of the policy",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// conditionalAccessConditionSetSchema returns the schema for the `conditions` block of a conditional access policy. The
+// root is the address of the block, used for constraints between nested attributes. When the block is nested within a
+// list, such as in a data source, root should be empty and these constraints are omitted.
+func conditionalAccessConditionSetSchema(root string) *pluginsdk.Resource {
+	var applicationsExactlyOneOf, usersAtLeastOneOf []string
+	if root != "" {
+		applicationsExactlyOneOf = []string{root + ".0.applications.0.included_applications", root + ".0.applications.0.included_user_actions"}
+		usersAtLeastOneOf = []string{root + ".0.users.0.included_groups", root + ".0.users.0.included_roles", root + ".0.users.0.included_users", root + ".0.users.0.included_guests_or_external_users"}
+	}
+
+	conditionsSchema := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"applications": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"included_applications": {
+							Type:         pluginsdk.TypeList,
+							Optional:     true,
+							ExactlyOneOf: applicationsExactlyOneOf,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
 							},
 						},
 
-						"client_applications": {
+						"excluded_applications": {
 							Type:     pluginsdk.TypeList,
 							Optional: true,
-							MaxItems: 1,
-							Elem: &pluginsdk.Resource{
-								Schema: map[string]*pluginsdk.Schema{
-									"included_service_principals": {
-										Type:     pluginsdk.TypeList,
-										Optional: true,
-										Elem: &pluginsdk.Schema{
-											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringIsNotEmpty,
-										},
-									},
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
 
-									"excluded_service_principals": {
-										Type:     pluginsdk.TypeList,
-										Optional: true,
-										Elem: &pluginsdk.Schema{
-											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringIsNotEmpty,
-										},
-									},
-								},
+						"included_user_actions": {
+							Type:         pluginsdk.TypeList,
+							Optional:     true,
+							ExactlyOneOf: applicationsExactlyOneOf,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
 							},
 						},
+					},
+				},
+			},
 
-						"users": {
+			"client_applications": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"included_service_principals": {
 							Type:     pluginsdk.TypeList,
-							Required: true,
-							MaxItems: 1,
-							Elem: &pluginsdk.Resource{
-								Schema: map[string]*pluginsdk.Schema{
-									"included_users": {
-										Type:         pluginsdk.TypeList,
-										Optional:     true,
-										AtLeastOneOf: []string{"conditions.0.users.0.included_groups", "conditions.0.users.0.included_roles", "conditions.0.users.0.included_users", "conditions.0.users.0.included_guests_or_external_users"},
-										Elem: &pluginsdk.Schema{
-											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringIsNotEmpty,
-										},
-									},
-
-									"excluded_users": {
-										Type:     pluginsdk.TypeList,
-										Optional: true,
-										Elem: &pluginsdk.Schema{
-											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringIsNotEmpty,
-										},
-									},
-
-									"included_groups": {
-										Type:         pluginsdk.TypeList,
-										Optional:     true,
-										AtLeastOneOf: []string{"conditions.0.users.0.included_groups", "conditions.0.users.0.included_roles", "conditions.0.users.0.included_users", "conditions.0.users.0.included_guests_or_external_users"},
-										Elem: &pluginsdk.Schema{
-											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringIsNotEmpty,
-										},
-									},
-
-									"excluded_groups": {
-										Type:     pluginsdk.TypeList,
-										Optional: true,
-										Elem: &pluginsdk.Schema{
-											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringIsNotEmpty,
-										},
-									},
-
-									"included_roles": {
-										Type:         pluginsdk.TypeList,
-										Optional:     true,
-										AtLeastOneOf: []string{"conditions.0.users.0.included_groups", "conditions.0.users.0.included_roles", "conditions.0.users.0.included_users", "conditions.0.users.0.included_guests_or_external_users"},
-										Elem: &pluginsdk.Schema{
-											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringIsNotEmpty,
-										},
-									},
-
-									"excluded_roles": {
-										Type:     pluginsdk.TypeList,
-										Optional: true,
-										Elem: &pluginsdk.Schema{
-											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringIsNotEmpty,
-										},
-									},
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
 
-									"included_guests_or_external_users": {
-										Type:         pluginsdk.TypeList,
-										Optional:     true,
-										AtLeastOneOf: []string{"conditions.0.users.0.included_groups", "conditions.0.users.0.included_roles", "conditions.0.users.0.included_users", "conditions.0.users.0.included_guests_or_external_users"},
-										Elem: &pluginsdk.Resource{
-											Schema: map[string]*pluginsdk.Schema{
-												"guest_or_external_user_types": {
-													Type:     pluginsdk.TypeList,
-													Required: true,
-													Elem: &pluginsdk.Schema{
-														Type:         pluginsdk.TypeString,
-														ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessGuestOrExternalUserTypes(), false),
-													},
-												},
+						"excluded_service_principals": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+					},
+				},
+			},
 
-												"external_tenants": {
-													Type:     pluginsdk.TypeList,
-													Optional: true,
-													Elem: &pluginsdk.Resource{
-														Schema: map[string]*pluginsdk.Schema{
-															"membership_kind": {
-																Type:         pluginsdk.TypeString,
-																Required:     true,
-																ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessExternalTenantsMembershipKind(), false),
-															},
-
-															"members": {
-																Type:     pluginsdk.TypeList,
-																Optional: true,
-																Elem: &pluginsdk.Schema{
-																	Type:         pluginsdk.TypeString,
-																	ValidateFunc: validation.StringIsNotEmpty,
-																},
-															},
-														},
-													},
-												},
-											},
-										},
-									},
+			"users": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"included_users": {
+							Type:         pluginsdk.TypeList,
+							Optional:     true,
+							AtLeastOneOf: usersAtLeastOneOf,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
 
-									"excluded_guests_or_external_users": {
-										Type:     pluginsdk.TypeList,
-										Optional: true,
-										Elem: &pluginsdk.Resource{
-											Schema: map[string]*pluginsdk.Schema{
-												"guest_or_external_user_types": {
-													Type:     pluginsdk.TypeList,
-													Required: true,
-													Elem: &pluginsdk.Schema{
-														Type:         pluginsdk.TypeString,
-														ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessGuestOrExternalUserTypes(), false),
-													},
-												},
+						"excluded_users": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
 
-												"external_tenants": {
-													Type:     pluginsdk.TypeList,
-													Optional: true,
-													Elem: &pluginsdk.Resource{
-														Schema: map[string]*pluginsdk.Schema{
-															"membership_kind": {
-																Type:         pluginsdk.TypeString,
-																Required:     true,
-																ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessExternalTenantsMembershipKind(), false),
-															},
-
-															"members": {
-																Type:     pluginsdk.TypeList,
-																Optional: true,
-																Elem: &pluginsdk.Schema{
-																	Type:         pluginsdk.TypeString,
-																	ValidateFunc: validation.StringIsNotEmpty,
-																},
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
+						"included_groups": {
+							Type:         pluginsdk.TypeList,
+							Optional:     true,
+							AtLeastOneOf: usersAtLeastOneOf,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
 							},
 						},
 
-						"client_app_types": {
+						"excluded_groups": {
 							Type:     pluginsdk.TypeList,
-							Required: true,
+							Optional: true,
 							Elem: &pluginsdk.Schema{
 								Type:         pluginsdk.TypeString,
-								ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessClientApp(), false),
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+
+						"included_roles": {
+							Type:         pluginsdk.TypeList,
+							Optional:     true,
+							AtLeastOneOf: usersAtLeastOneOf,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
 							},
 						},
 
-						"devices": {
+						"excluded_roles": {
 							Type:     pluginsdk.TypeList,
 							Optional: true,
-							MaxItems: 1,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+
+						"included_guests_or_external_users": {
+							Type:         pluginsdk.TypeList,
+							Optional:     true,
+							AtLeastOneOf: usersAtLeastOneOf,
 							Elem: &pluginsdk.Resource{
 								Schema: map[string]*pluginsdk.Schema{
-									"filter": {
+									"guest_or_external_user_types": {
+										Type:     pluginsdk.TypeList,
+										Required: true,
+										Elem: &pluginsdk.Schema{
+											Type:         pluginsdk.TypeString,
+											ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessGuestOrExternalUserTypes(), false),
+										},
+									},
+
+									"external_tenants": {
 										Type:     pluginsdk.TypeList,
 										Optional: true,
-										MaxItems: 1,
 										Elem: &pluginsdk.Resource{
 											Schema: map[string]*pluginsdk.Schema{
-												"mode": {
+												"membership_kind": {
 													Type:         pluginsdk.TypeString,
 													Required:     true,
-													ValidateFunc: validation.StringInSlice(stable.PossibleValuesForFilterMode(), false),
+													ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessExternalTenantsMembershipKind(), false),
 												},
 
-												"rule": {
-													Type:         pluginsdk.TypeString,
-													Required:     true,
-													ValidateFunc: validation.StringIsNotEmpty,
+												"members": {
+													Type:     pluginsdk.TypeList,
+													Optional: true,
+													Elem: &pluginsdk.Schema{
+														Type:         pluginsdk.TypeString,
+														ValidateFunc: validation.StringIsNotEmpty,
+													},
 												},
 											},
 										},
@@ -328,220 +318,336 @@ func conditionalAccessPolicyResource() *pluginsdk.Resource {
 							},
 						},
 
-						"locations": {
+						"excluded_guests_or_external_users": {
 							Type:     pluginsdk.TypeList,
 							Optional: true,
-							MaxItems: 1,
 							Elem: &pluginsdk.Resource{
 								Schema: map[string]*pluginsdk.Schema{
-									"included_locations": {
+									"guest_or_external_user_types": {
 										Type:     pluginsdk.TypeList,
 										Required: true,
 										Elem: &pluginsdk.Schema{
 											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringIsNotEmpty,
+											ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessGuestOrExternalUserTypes(), false),
 										},
 									},
 
-									"excluded_locations": {
+									"external_tenants": {
 										Type:     pluginsdk.TypeList,
 										Optional: true,
-										Elem: &pluginsdk.Schema{
-											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringIsNotEmpty,
+										Elem: &pluginsdk.Resource{
+											Schema: map[string]*pluginsdk.Schema{
+												"membership_kind": {
+													Type:         pluginsdk.TypeString,
+													Required:     true,
+													ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessExternalTenantsMembershipKind(), false),
+												},
+
+												"members": {
+													Type:     pluginsdk.TypeList,
+													Optional: true,
+													Elem: &pluginsdk.Schema{
+														Type:         pluginsdk.TypeString,
+														ValidateFunc: validation.StringIsNotEmpty,
+													},
+												},
+											},
 										},
 									},
 								},
 							},
 						},
+					},
+				},
+			},
 
-						"platforms": {
+			"client_app_types": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessClientApp(), false),
+				},
+			},
+
+			"devices": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"filter": {
 							Type:     pluginsdk.TypeList,
 							Optional: true,
 							MaxItems: 1,
 							Elem: &pluginsdk.Resource{
 								Schema: map[string]*pluginsdk.Schema{
-									"included_platforms": {
-										Type:     pluginsdk.TypeList,
-										Required: true,
-										Elem: &pluginsdk.Schema{
-											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessDevicePlatform(), false),
-										},
+									"mode": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(stable.PossibleValuesForFilterMode(), false),
 									},
 
-									"excluded_platforms": {
-										Type:     pluginsdk.TypeList,
-										Optional: true,
-										Elem: &pluginsdk.Schema{
-											Type:         pluginsdk.TypeString,
-											ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessDevicePlatform(), false),
-										},
+									"rule": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
 									},
 								},
 							},
 						},
+					},
+				},
+			},
 
-						"service_principal_risk_levels": {
-							Type:     pluginsdk.TypeList,
-							Optional: true,
-							Elem: &pluginsdk.Schema{
-								Type:         pluginsdk.TypeString,
-								ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskLevel(), false),
-							},
-						},
-
-						"sign_in_risk_levels": {
+			"locations": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"included_locations": {
 							Type:     pluginsdk.TypeList,
-							Optional: true,
+							Required: true,
 							Elem: &pluginsdk.Schema{
 								Type:         pluginsdk.TypeString,
-								ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskLevel(), false),
+								ValidateFunc: validation.StringIsNotEmpty,
 							},
 						},
 
-						"user_risk_levels": {
+						"excluded_locations": {
 							Type:     pluginsdk.TypeList,
 							Optional: true,
 							Elem: &pluginsdk.Schema{
 								Type:         pluginsdk.TypeString,
-								ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskLevel(), false),
+								ValidateFunc: validation.StringIsNotEmpty,
 							},
 						},
-
-						"insider_risk_levels": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							Computed:     true,
-							ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessInsiderRiskLevels(), false),
-						},
 					},
 				},
 			},
 
-			"grant_controls": {
-				Type:         pluginsdk.TypeList,
-				Optional:     true,
-				AtLeastOneOf: []string{"grant_controls", "session_controls"},
-				MaxItems:     1,
+			"platforms": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
 				Elem: &pluginsdk.Resource{
 					Schema: map[string]*pluginsdk.Schema{
-						"operator": {
-							Type:         pluginsdk.TypeString,
-							Required:     true,
-							ValidateFunc: validation.StringInSlice([]string{"AND", "OR"}, false),
-						},
-
-						"built_in_controls": {
-							Type:         pluginsdk.TypeList,
-							Optional:     true,
-							AtLeastOneOf: []string{"grant_controls.0.built_in_controls", "grant_controls.0.authentication_strength_policy_id", "grant_controls.0.terms_of_use"},
+						"included_platforms": {
+							Type:     pluginsdk.TypeList,
+							Required: true,
 							Elem: &pluginsdk.Schema{
 								Type:         pluginsdk.TypeString,
-								ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessGrantControl(), false),
+								ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessDevicePlatform(), false),
 							},
 						},
 
-						"authentication_strength_policy_id": {
-							AtLeastOneOf: []string{"grant_controls.0.built_in_controls", "grant_controls.0.authentication_strength_policy_id", "grant_controls.0.terms_of_use"},
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							ValidateFunc: stable.ValidatePolicyAuthenticationStrengthPolicyID,
-						},
-
-						"custom_authentication_factors": {
+						"excluded_platforms": {
 							Type:     pluginsdk.TypeList,
 							Optional: true,
 							Elem: &pluginsdk.Schema{
 								Type:         pluginsdk.TypeString,
-								ValidateFunc: validation.StringIsNotEmpty,
-							},
-						},
-
-						"terms_of_use": {
-							Type:         pluginsdk.TypeList,
-							Optional:     true,
-							AtLeastOneOf: []string{"grant_controls.0.built_in_controls", "grant_controls.0.authentication_strength_policy_id", "grant_controls.0.terms_of_use"},
-							Elem: &pluginsdk.Schema{
-								Type:         pluginsdk.TypeString,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessDevicePlatform(), false),
 							},
 						},
 					},
 				},
 			},
 
-			"session_controls": {
-				Type:             pluginsdk.TypeList,
-				Optional:         true,
-				AtLeastOneOf:     []string{"grant_controls", "session_controls"},
-				MaxItems:         1,
-				DiffSuppressFunc: conditionalAccessPolicyDiffSuppress,
-				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"application_enforced_restrictions_enabled": {
-							Type:     pluginsdk.TypeBool,
-							Optional: true,
-						},
+			"service_principal_risk_levels": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskLevel(), false),
+				},
+			},
 
-						"cloud_app_security_policy": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringInSlice(stable.PossibleValuesForCloudAppSecuritySessionControlType(), false),
-						},
+			"sign_in_risk_levels": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskLevel(), false),
+				},
+			},
 
-						"disable_resilience_defaults": {
-							Type:     pluginsdk.TypeBool,
-							Optional: true,
-						},
+			"user_risk_levels": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskLevel(), false),
+				},
+			},
 
-						"persistent_browser_mode": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringInSlice(stable.PossibleValuesForPersistentBrowserSessionMode(), false),
-						},
+			"insider_risk_levels": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessInsiderRiskLevels(), false),
+			},
+		},
+	}
 
-						"sign_in_frequency": {
-							Type:         pluginsdk.TypeInt,
-							Optional:     true,
-							RequiredWith: []string{"session_controls.0.sign_in_frequency_period"},
-							ValidateFunc: validation.IntAtLeast(0),
-						},
+	// Properties that are only available in the beta API are only supported by the policy resource
+	if root != "" {
+		conditionsSchema.Schema["authentication_flow_transfer_methods"] = &pluginsdk.Schema{
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(beta.ConditionalAccessTransferMethods_AuthenticationTransfer),
+					string(beta.ConditionalAccessTransferMethods_DeviceCodeFlow),
+				}, false),
+			},
+		}
+	}
 
-						"sign_in_frequency_authentication_type": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							Computed:     true,
-							ValidateFunc: validation.StringInSlice(stable.PossibleValuesForSignInFrequencyAuthenticationType(), false),
-						},
+	return conditionsSchema
+}
 
-						"sign_in_frequency_interval": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							Computed:     true,
-							ValidateFunc: validation.StringInSlice(stable.PossibleValuesForSignInFrequencyInterval(), false),
-						},
+// conditionalAccessGrantControlsSchema returns the schema for the `grant_controls` block of a conditional access policy
+func conditionalAccessGrantControlsSchema(root string) *pluginsdk.Resource {
+	var controlsAtLeastOneOf []string
+	if root != "" {
+		controlsAtLeastOneOf = []string{root + ".0.built_in_controls", root + ".0.authentication_strength_policy_id", root + ".0.terms_of_use"}
+	}
 
-						"sign_in_frequency_period": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							RequiredWith: []string{"session_controls.0.sign_in_frequency"},
-							ValidateFunc: validation.StringInSlice(stable.PossibleValuesForSigninFrequencyType(), false),
-						},
-					},
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"operator": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"AND", "OR"}, false),
+			},
+
+			"built_in_controls": {
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				AtLeastOneOf: controlsAtLeastOneOf,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessGrantControl(), false),
 				},
 			},
 
-			"object_id": {
-				Description: "The object ID of the policy",
-				Type:        pluginsdk.TypeString,
-				Computed:    true,
+			"authentication_strength_policy_id": {
+				AtLeastOneOf: controlsAtLeastOneOf,
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: stable.ValidatePolicyAuthenticationStrengthPolicyID,
+			},
+
+			"custom_authentication_factors": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"terms_of_use": {
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				AtLeastOneOf: controlsAtLeastOneOf,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	}
+}
+
+// conditionalAccessSessionControlsSchema returns the schema for the `session_controls` block of a conditional access policy
+func conditionalAccessSessionControlsSchema(root string) *pluginsdk.Resource {
+	var signInFrequencyRequiredWith, signInFrequencyPeriodRequiredWith []string
+	if root != "" {
+		signInFrequencyRequiredWith = []string{root + ".0.sign_in_frequency_period"}
+		signInFrequencyPeriodRequiredWith = []string{root + ".0.sign_in_frequency"}
+	}
+
+	sessionControlsSchema := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"application_enforced_restrictions_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+			},
+
+			"cloud_app_security_policy": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForCloudAppSecuritySessionControlType(), false),
+			},
+
+			"disable_resilience_defaults": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+			},
+
+			"persistent_browser_mode": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForPersistentBrowserSessionMode(), false),
+			},
+
+			"sign_in_frequency": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				RequiredWith: signInFrequencyRequiredWith,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"sign_in_frequency_authentication_type": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForSignInFrequencyAuthenticationType(), false),
+			},
+
+			"sign_in_frequency_interval": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForSignInFrequencyInterval(), false),
+			},
+
+			"sign_in_frequency_period": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				RequiredWith: signInFrequencyPeriodRequiredWith,
+				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForSigninFrequencyType(), false),
 			},
 		},
 	}
+
+	// Properties that are only available in the beta API are only supported by the policy resource
+	if root != "" {
+		sessionControlsSchema.Schema["continuous_access_evaluation_mode"] = &pluginsdk.Schema{
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(beta.PossibleValuesForContinuousAccessEvaluationMode(), false),
+		}
+
+		sessionControlsSchema.Schema["global_secure_access_filtering_profile_id"] = &pluginsdk.Schema{
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsUUID,
+		}
+
+		sessionControlsSchema.Schema["secure_sign_in_session_enabled"] = &pluginsdk.Schema{
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+		}
+	}
+
+	return sessionControlsSchema
 }
 
-func conditionalAccessPolicyCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+func conditionalAccessPolicyCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
 	// The API does not like sessionControls being set with ineffectual properties, so this additional validation complements
 	// AtLeastOneOf: []string{"grant_controls", "session_controls"} by helping to ensure that either `grant_controls` or a
 	// _useful_ `session_controls` block has been set in the configuration.
@@ -550,13 +656,125 @@ func conditionalAccessPolicyCustomizeDiff(_ context.Context, diff *pluginsdk.Res
 		diff.Get("session_controls.0.cloud_app_security_policy").(string) == "" && !diff.Get("session_controls.0.disable_resilience_defaults").(bool) &&
 		diff.Get("session_controls.0.persistent_browser_mode").(string) == "" && diff.Get("session_controls.0.sign_in_frequency").(int) == 0 &&
 		diff.Get("session_controls.0.sign_in_frequency_authentication_type").(string) == string(stable.SignInFrequencyAuthenticationType_PrimaryAndSecondaryAuthentication) &&
-		diff.Get("session_controls.0.sign_in_frequency_interval").(string) == string(stable.SignInFrequencyInterval_TimeBased) {
+		diff.Get("session_controls.0.sign_in_frequency_interval").(string) == string(stable.SignInFrequencyInterval_TimeBased) &&
+		diff.Get("session_controls.0.continuous_access_evaluation_mode").(string) == "" && !diff.Get("session_controls.0.secure_sign_in_session_enabled").(bool) &&
+		diff.Get("session_controls.0.global_secure_access_filtering_profile_id").(string) == "" {
 		sessionControlsSetButIneffective = true
 	}
 	if diff.Get("grant_controls.#").(int) == 0 && sessionControlsSetButIneffective {
 		return fmt.Errorf("when specifying `session_controls` but not `grant_controls`, one of the properties in the `session_controls` block must be set to an effective value in order for session controls to work")
 	}
 
+	// Token protection is only supported for desktop clients, and is rejected by the API for any other client app type
+	if diff.Get("session_controls.0.secure_sign_in_session_enabled").(bool) && diff.NewValueKnown("conditions.0.client_app_types") {
+		clientAppTypes := tf.ExpandStringSlice(diff.Get("conditions.0.client_app_types").([]interface{}))
+		if len(clientAppTypes) != 1 || clientAppTypes[0] != string(stable.ConditionalAccessClientApp_MobileAppsAndDesktopClients) {
+			return fmt.Errorf("when `session_controls.0.secure_sign_in_session_enabled` is true, `conditions.0.client_app_types` must contain only %q", stable.ConditionalAccessClientApp_MobileAppsAndDesktopClients)
+		}
+	}
+
+	// Continuous access evaluation can only be disabled for all applications
+	if diff.Get("session_controls.0.continuous_access_evaluation_mode").(string) == string(beta.ContinuousAccessEvaluationMode_Disabled) && diff.NewValueKnown("conditions.0.applications.0.included_applications") {
+		includedApplications := tf.ExpandStringSlice(diff.Get("conditions.0.applications.0.included_applications").([]interface{}))
+		if len(includedApplications) != 1 || includedApplications[0] != "All" {
+			return fmt.Errorf("when `session_controls.0.continuous_access_evaluation_mode` is %q, `conditions.0.applications.0.included_applications` must contain only \"All\"", beta.ContinuousAccessEvaluationMode_Disabled)
+		}
+	}
+
+	// A policy that has been updated using the beta API can no longer be managed using the stable API, so it must be
+	// replaced when all the beta-only properties are removed
+	if diff.Id() != "" && diff.NewValueKnown("conditions") && diff.NewValueKnown("session_controls") {
+		oldConditions, newConditions := diff.GetChange("conditions")
+		oldSessionControls, newSessionControls := diff.GetChange("session_controls")
+		if expandConditionalAccessPolicyBetaProperties(oldConditions.([]interface{}), oldSessionControls.([]interface{})) != nil &&
+			expandConditionalAccessPolicyBetaProperties(newConditions.([]interface{}), newSessionControls.([]interface{})) == nil {
+			for _, key := range []string{
+				"conditions.0.authentication_flow_transfer_methods",
+				"session_controls.0.continuous_access_evaluation_mode",
+				"session_controls.0.global_secure_access_filtering_profile_id",
+				"session_controls.0.secure_sign_in_session_enabled",
+			} {
+				if diff.HasChange(key) {
+					if err := diff.ForceNew(key); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	// Lockout protection is checked at plan time when the relevant properties are known, otherwise it's deferred until
+	// the policy is created or updated
+	if diff.Get("lockout_protection.#").(int) == 1 {
+		for _, key := range []string{
+			"state",
+			"conditions.0.users.0.included_users",
+			"conditions.0.users.0.excluded_users",
+			"conditions.0.client_applications.0.included_service_principals",
+			"conditions.0.client_applications.0.excluded_service_principals",
+			"grant_controls.0.built_in_controls",
+			"lockout_protection.0.break_glass_user_ids",
+		} {
+			if !diff.NewValueKnown(key) {
+				return nil
+			}
+		}
+
+		grantControls, err := expandConditionalAccessGrantControls(diff.Get("grant_controls").([]interface{}))
+		if err != nil {
+			return fmt.Errorf("parsing `grant_controls`: %v", err)
+		}
+
+		policy := stable.ConditionalAccessPolicy{
+			DisplayName:   pointer.To(diff.Get("display_name").(string)),
+			State:         pointer.To(stable.ConditionalAccessPolicyState(diff.Get("state").(string))),
+			Conditions:    expandConditionalAccessConditionSet(diff.Get("conditions").([]interface{})),
+			GrantControls: grantControls,
+		}
+
+		if err = conditionalAccessPolicyLockoutCheck(policy, diff.Get("lockout_protection").([]interface{}), meta); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// conditionalAccessPolicyLockoutCheck returns an error when the `lockout_protection` block is specified and the policy
+// would prevent the calling principal, or any of the configured break-glass accounts, from signing in. The calling
+// principal is checked as a service principal when the access token was issued to an application.
+func conditionalAccessPolicyLockoutCheck(policy stable.ConditionalAccessPolicy, in []interface{}, meta interface{}) error {
+	if len(in) == 0 {
+		return nil
+	}
+
+	callerId := ""
+	callerIsServicePrincipal := false
+	if client, ok := meta.(*clients.Client); ok {
+		callerId = client.ObjectID
+		callerIsServicePrincipal = client.Claims != nil && strings.EqualFold(client.Claims.IdType, "app")
+	}
+
+	protectCallingPrincipal := true
+	breakGlassUserIds := make([]string, 0)
+	if in[0] != nil {
+		config := in[0].(map[string]interface{})
+		protectCallingPrincipal = config["protect_calling_principal"].(bool)
+		breakGlassUserIds = tf.ExpandStringSlice(config["break_glass_user_ids"].(*pluginsdk.Set).List())
+	}
+
+	if protectCallingPrincipal && callerId != "" {
+		if lockout, reason := conditionalaccess.CheckLockout(policy, callerId, callerIsServicePrincipal); lockout {
+			return fmt.Errorf("lockout protection: conditional access policy %q would lock out the calling principal %q: %s", pointer.From(policy.DisplayName), callerId, reason)
+		}
+	}
+
+	for _, userId := range breakGlassUserIds {
+		if lockout, reason := conditionalaccess.CheckLockout(policy, userId, false); lockout {
+			return fmt.Errorf("lockout protection: conditional access policy %q would lock out the break-glass account %q: %s", pointer.From(policy.DisplayName), userId, reason)
+		}
+	}
+
 	return nil
 }
 
@@ -596,6 +814,15 @@ func conditionalAccessPolicyDiffSuppress(k, old, new string, d *pluginsdk.Resour
 			if v, ok := sessionControls["sign_in_frequency_period"]; ok && v.(string) != "" {
 				suppress = false
 			}
+			if v, ok := sessionControls["continuous_access_evaluation_mode"]; ok && v.(string) != "" {
+				suppress = false
+			}
+			if v, ok := sessionControls["global_secure_access_filtering_profile_id"]; ok && v.(string) != "" {
+				suppress = false
+			}
+			if v, ok := sessionControls["secure_sign_in_session_enabled"]; ok && v.(bool) {
+				suppress = false
+			}
 		}
 	}
 
@@ -628,12 +855,28 @@ func conditionalAccessPolicyResourceCreate(ctx context.Context, d *pluginsdk.Res
 		SessionControls: sessionControls,
 	}
 
-	resp, err := client.CreateConditionalAccessPolicy(ctx, properties, conditionalaccesspolicy.DefaultCreateConditionalAccessPolicyOperationOptions())
-	if err != nil {
-		return tf.ErrorDiagF(err, "Could not create conditional access policy")
+	if err = conditionalAccessPolicyLockoutCheck(properties, d.Get("lockout_protection").([]interface{}), meta); err != nil {
+		return tf.ErrorDiagPathF(err, "lockout_protection", "Lockout protection check failed for conditional access policy %q", d.Get("display_name").(string))
+	}
+
+	// The beta API is only used when beta-only properties are configured, since it irreversibly changes the policy
+	betaProperties := expandConditionalAccessPolicyBetaProperties(d.Get("conditions").([]interface{}), d.Get("session_controls").([]interface{}))
+	useBeta := betaProperties != nil
+
+	var policy *stable.ConditionalAccessPolicy
+	if useBeta {
+		policy, err = conditionalAccessPolicyWriteBeta(ctx, meta, nil, properties, *betaProperties)
+		if err != nil {
+			return tf.ErrorDiagF(err, "Could not create conditional access policy")
+		}
+	} else {
+		resp, err := client.CreateConditionalAccessPolicy(ctx, properties, conditionalaccesspolicy.DefaultCreateConditionalAccessPolicyOperationOptions())
+		if err != nil {
+			return tf.ErrorDiagF(err, "Could not create conditional access policy")
+		}
+		policy = resp.Model
 	}
 
-	policy := resp.Model
 	if policy == nil {
 		return tf.ErrorDiagF(errors.New("model was nil"), "Could not create conditional access policy")
 	}
@@ -646,14 +889,14 @@ func conditionalAccessPolicyResourceCreate(ctx context.Context, d *pluginsdk.Res
 
 	// Consistency check
 	if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
-		resp, err := client.GetConditionalAccessPolicy(ctx, id, conditionalaccesspolicy.DefaultGetConditionalAccessPolicyOperationOptions())
+		policy, _, resp, err := conditionalAccessPolicyGet(ctx, meta, id, useBeta)
 		if err != nil {
-			if response.WasNotFound(resp.HttpResponse) {
+			if response.WasNotFound(resp) {
 				return pointer.To(false), nil
 			}
 			return pointer.To(false), err
 		}
-		return pointer.To(resp.Model != nil), nil
+		return pointer.To(policy != nil), nil
 	}); err != nil {
 		return tf.ErrorDiagF(err, "Waiting for creation of %s", id)
 	}
@@ -692,7 +935,20 @@ func conditionalAccessPolicyResourceUpdate(ctx context.Context, d *pluginsdk.Res
 		SessionControls: sessionControls,
 	}
 
-	if _, err := client.UpdateConditionalAccessPolicy(ctx, *id, properties, conditionalaccesspolicy.DefaultUpdateConditionalAccessPolicyOperationOptions()); err != nil {
+	if err = conditionalAccessPolicyLockoutCheck(properties, d.Get("lockout_protection").([]interface{}), meta); err != nil {
+		return tf.ErrorDiagPathF(err, "lockout_protection", "Lockout protection check failed for conditional access policy %q", d.Get("display_name").(string))
+	}
+
+	// Once a policy has beta-only properties, it's always updated using the beta API, since removing all of them
+	// causes the policy to be replaced
+	betaProperties := expandConditionalAccessPolicyBetaProperties(d.Get("conditions").([]interface{}), d.Get("session_controls").([]interface{}))
+	useBeta := betaProperties != nil
+
+	if useBeta {
+		if _, err := conditionalAccessPolicyWriteBeta(ctx, meta, id, properties, *betaProperties); err != nil {
+			return tf.ErrorDiagF(err, "Could not update conditional access policy with ID: %q", d.Id())
+		}
+	} else if _, err := client.UpdateConditionalAccessPolicy(ctx, *id, properties, conditionalaccesspolicy.DefaultUpdateConditionalAccessPolicyOperationOptions()); err != nil {
 		return tf.ErrorDiagF(err, "Could not update conditional access policy with ID: %q", d.Id())
 	}
 
@@ -707,12 +963,11 @@ func conditionalAccessPolicyResourceUpdate(ctx context.Context, d *pluginsdk.Res
 		MinTimeout:                5 * time.Second,
 		ContinuousTargetOccurence: 5,
 		Refresh: func() (interface{}, string, error) {
-			resp, err := client.GetConditionalAccessPolicy(ctx, *id, conditionalaccesspolicy.DefaultGetConditionalAccessPolicyOperationOptions())
+			policy, _, _, err := conditionalAccessPolicyGet(ctx, meta, *id, useBeta)
 			if err != nil {
 				return nil, "Error", err
 			}
 
-			policy := resp.Model
 			if policy == nil {
 				return "stub", "Pending", nil
 			}
@@ -734,16 +989,22 @@ func conditionalAccessPolicyResourceUpdate(ctx context.Context, d *pluginsdk.Res
 }
 
 func conditionalAccessPolicyResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
-	client := meta.(*clients.Client).ConditionalAccess.PolicyClient
-
 	id, err := stable.ParseIdentityConditionalAccessPolicyID(d.Id())
 	if err != nil {
 		return tf.ErrorDiagPathF(err, "id", "Parsing Conditional Access Policy ID")
 	}
 
-	resp, err := client.GetConditionalAccessPolicy(ctx, *id, conditionalaccesspolicy.DefaultGetConditionalAccessPolicyOperationOptions())
+	useBeta := conditionalAccessPolicyUsesBeta(d)
+
+	policy, betaProperties, resp, err := conditionalAccessPolicyGet(ctx, meta, *id, useBeta)
+	if err != nil && !useBeta && !response.WasNotFound(resp) {
+		// A policy that was updated using the beta API, e.g. when imported, may not be readable using the stable API.
+		// Reading a policy does not modify it, so the beta API can safely be used instead.
+		log.Printf("[DEBUG] Retrieving %s using the beta API: %v", id, err)
+		policy, betaProperties, resp, err = conditionalAccessPolicyGet(ctx, meta, *id, true)
+	}
 	if err != nil {
-		if response.WasNotFound(resp.HttpResponse) {
+		if response.WasNotFound(resp) {
 			log.Printf("[DEBUG] %s not found - removing from state", id)
 			d.SetId("")
 			return nil
@@ -752,17 +1013,18 @@ func conditionalAccessPolicyResourceRead(ctx context.Context, d *pluginsdk.Resou
 		return tf.ErrorDiagPathF(err, "id", "retrieving %s", id)
 	}
 
-	policy := resp.Model
 	if policy == nil {
 		return tf.ErrorDiagF(errors.New("model was nil"), "retrieving %s", id)
 	}
 
+	conditions, sessionControls := flattenConditionalAccessPolicyBetaProperties(betaProperties, flattenConditionalAccessConditionSet(policy.Conditions), flattenConditionalAccessSessionControls(policy.SessionControls))
+
 	tf.Set(d, "object_id", pointer.From(policy.Id))
 	tf.Set(d, "display_name", pointer.From(policy.DisplayName))
 	tf.Set(d, "state", pointer.From(policy.State))
-	tf.Set(d, "conditions", flattenConditionalAccessConditionSet(policy.Conditions))
+	tf.Set(d, "conditions", conditions)
 	tf.Set(d, "grant_controls", flattenConditionalAccessGrantControls(policy.GrantControls))
-	tf.Set(d, "session_controls", flattenConditionalAccessSessionControls(policy.SessionControls))
+	tf.Set(d, "session_controls", sessionControls)
 
 	return nil
 }
@@ -775,9 +1037,10 @@ func conditionalAccessPolicyResourceDelete(ctx context.Context, d *pluginsdk.Res
 		return tf.ErrorDiagPathF(err, "id", "Parsing Conditional Access Policy ID")
 	}
 
-	resp, err := client.GetConditionalAccessPolicy(ctx, *id, conditionalaccesspolicy.DefaultGetConditionalAccessPolicyOperationOptions())
-	if err != nil {
-		if response.WasNotFound(resp.HttpResponse) {
+	useBeta := conditionalAccessPolicyUsesBeta(d)
+
+	if _, _, resp, err := conditionalAccessPolicyGet(ctx, meta, *id, useBeta); err != nil {
+		if response.WasNotFound(resp) {
 			log.Printf("[DEBUG] %s already deleted", id)
 			return nil
 		}
@@ -785,13 +1048,23 @@ func conditionalAccessPolicyResourceDelete(ctx context.Context, d *pluginsdk.Res
 		return tf.ErrorDiagPathF(err, "id", "retrieving %s", id)
 	}
 
-	if _, err = client.DeleteConditionalAccessPolicy(ctx, *id, conditionalaccesspolicy.DefaultDeleteConditionalAccessPolicyOperationOptions()); err != nil {
+	if useBeta {
+		if _, err = rawrequest.Do(ctx, meta.(*clients.Client).ConditionalAccess.PolicyClientBeta, rawrequest.Options{
+			ExpectedStatusCodes: []int{
+				http.StatusNoContent,
+			},
+			HttpMethod: http.MethodDelete,
+			Path:       id.ID(),
+		}, nil, nil); err != nil {
+			return tf.ErrorDiagPathF(err, "id", "Deleting %s", id)
+		}
+	} else if _, err = client.DeleteConditionalAccessPolicy(ctx, *id, conditionalaccesspolicy.DefaultDeleteConditionalAccessPolicyOperationOptions()); err != nil {
 		return tf.ErrorDiagPathF(err, "id", "Deleting %s", id)
 	}
 
 	if err := consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
-		if resp, err := client.GetConditionalAccessPolicy(ctx, *id, conditionalaccesspolicy.DefaultGetConditionalAccessPolicyOperationOptions()); err != nil {
-			if response.WasNotFound(resp.HttpResponse) {
+		if _, _, resp, err := conditionalAccessPolicyGet(ctx, meta, *id, useBeta); err != nil {
+			if response.WasNotFound(resp) {
 				return pointer.To(false), nil
 			}
 			return nil, err
@@ -803,3 +1076,109 @@ func conditionalAccessPolicyResourceDelete(ctx context.Context, d *pluginsdk.Res
 
 	return nil
 }
+
+// conditionalAccessPolicyUsesBeta returns whether the policy is managed using the beta API, which is the case when any
+// beta-only properties are configured
+func conditionalAccessPolicyUsesBeta(d *pluginsdk.ResourceData) bool {
+	return expandConditionalAccessPolicyBetaProperties(d.Get("conditions").([]interface{}), d.Get("session_controls").([]interface{})) != nil
+}
+
+// conditionalAccessPolicyGet retrieves a policy using the stable API, or using the beta API when useBeta is true, in
+// which case the beta-only properties of the policy are also returned
+func conditionalAccessPolicyGet(ctx context.Context, meta interface{}, id stable.IdentityConditionalAccessPolicyId, useBeta bool) (*stable.ConditionalAccessPolicy, *conditionalAccessPolicyBetaProperties, *http.Response, error) {
+	client := meta.(*clients.Client).ConditionalAccess
+
+	if !useBeta {
+		resp, err := client.PolicyClient.GetConditionalAccessPolicy(ctx, id, conditionalaccesspolicy.DefaultGetConditionalAccessPolicyOperationOptions())
+		if err != nil {
+			return nil, nil, resp.HttpResponse, err
+		}
+		return resp.Model, nil, resp.HttpResponse, nil
+	}
+
+	var raw json.RawMessage
+	resp, err := rawrequest.Do(ctx, client.PolicyClientBeta, rawrequest.Options{
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       id.ID(),
+	}, nil, &raw)
+	if err != nil {
+		return nil, nil, resp, err
+	}
+	if len(raw) == 0 {
+		return nil, nil, resp, nil
+	}
+
+	var policy stable.ConditionalAccessPolicy
+	if err = json.Unmarshal(raw, &policy); err != nil {
+		return nil, nil, resp, fmt.Errorf("unmarshaling policy: %+v", err)
+	}
+
+	var betaProperties conditionalAccessPolicyBetaProperties
+	if err = json.Unmarshal(raw, &betaProperties); err != nil {
+		return nil, nil, resp, fmt.Errorf("unmarshaling beta properties: %+v", err)
+	}
+
+	return &policy, &betaProperties, resp, nil
+}
+
+// conditionalAccessPolicyWriteBeta creates a policy when id is nil, otherwise updates the existing policy, using the
+// beta API. The request combines the stable properties of the policy with the beta-only properties.
+func conditionalAccessPolicyWriteBeta(ctx context.Context, meta interface{}, id *stable.IdentityConditionalAccessPolicyId, properties stable.ConditionalAccessPolicy, betaProperties conditionalAccessPolicyBetaProperties) (*stable.ConditionalAccessPolicy, error) {
+	client := meta.(*clients.Client).ConditionalAccess.PolicyClientBeta
+
+	payload := make(map[string]interface{})
+	encoded, err := json.Marshal(properties)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling policy: %+v", err)
+	}
+	if err = json.Unmarshal(encoded, &payload); err != nil {
+		return nil, fmt.Errorf("unmarshaling policy: %+v", err)
+	}
+
+	additional := make(map[string]map[string]interface{})
+	if encoded, err = json.Marshal(betaProperties); err != nil {
+		return nil, fmt.Errorf("marshaling beta properties: %+v", err)
+	}
+	if err = json.Unmarshal(encoded, &additional); err != nil {
+		return nil, fmt.Errorf("unmarshaling beta properties: %+v", err)
+	}
+
+	for key, values := range additional {
+		existing, ok := payload[key].(map[string]interface{})
+		if !ok {
+			existing = make(map[string]interface{})
+		}
+		for k, v := range values {
+			existing[k] = v
+		}
+		payload[key] = existing
+	}
+
+	if id != nil {
+		_, err = rawrequest.Do(ctx, client, rawrequest.Options{
+			ExpectedStatusCodes: []int{
+				http.StatusOK,
+				http.StatusNoContent,
+			},
+			HttpMethod: http.MethodPatch,
+			Path:       id.ID(),
+		}, payload, nil)
+		return nil, err
+	}
+
+	var policy stable.ConditionalAccessPolicy
+	if _, err = rawrequest.Do(ctx, client, rawrequest.Options{
+		ExpectedStatusCodes: []int{
+			http.StatusCreated,
+		},
+		HttpMethod: http.MethodPost,
+		Path:       "/identity/conditionalAccess/policies",
+	}, payload, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
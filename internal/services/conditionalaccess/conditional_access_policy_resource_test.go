@@ -6,6 +6,9 @@ package conditionalaccess_test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
@@ -15,11 +18,14 @@ import (
 	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
 	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/rawrequest"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
 )
 
 type ConditionalAccessPolicyResource struct{}
 
+const conditionalAccessPolicyFilteringProfileEnvVar = "ARM_TEST_GLOBAL_SECURE_ACCESS_FILTERING_PROFILE_ID"
+
 func TestAccConditionalAccessPolicy_basic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_conditional_access_policy", "test")
 	r := ConditionalAccessPolicyResource{}
@@ -369,6 +375,94 @@ func TestAccConditionalAccessPolicy_insiderRisk(t *testing.T) {
 	})
 }
 
+func TestAccConditionalAccessPolicy_betaProperties(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_conditional_access_policy", "test")
+	r := ConditionalAccessPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.authenticationFlows(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("conditions.0.authentication_flow_transfer_methods.#").HasValue("1"),
+				check.That(data.ResourceName).Key("conditions.0.authentication_flow_transfer_methods.0").HasValue("deviceCodeFlow"),
+			),
+		},
+		data.ImportStep("conditions.0.authentication_flow_transfer_methods"),
+		{
+			Config: r.tokenProtection(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("session_controls.0.continuous_access_evaluation_mode").HasValue("strictLocation"),
+				check.That(data.ResourceName).Key("session_controls.0.secure_sign_in_session_enabled").HasValue("true"),
+			),
+		},
+		data.ImportStep("session_controls.0.continuous_access_evaluation_mode", "session_controls.0.secure_sign_in_session_enabled"),
+	})
+}
+
+func TestAccConditionalAccessPolicy_globalSecureAccessFilteringProfile(t *testing.T) {
+	profileId := os.Getenv(conditionalAccessPolicyFilteringProfileEnvVar)
+	if profileId == "" {
+		t.Skipf("`%s` must be set to the ID of a Global Secure Access filtering profile", conditionalAccessPolicyFilteringProfileEnvVar)
+	}
+
+	data := acceptance.BuildTestData(t, "azuread_conditional_access_policy", "test")
+	r := ConditionalAccessPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.globalSecureAccessFilteringProfile(data, profileId),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("session_controls.0.global_secure_access_filtering_profile_id").HasValue(profileId),
+			),
+		},
+		data.ImportStep("session_controls.0.global_secure_access_filtering_profile_id"),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("session_controls.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccConditionalAccessPolicy_tokenProtectionInvalidClientAppTypes(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_conditional_access_policy", "test")
+	r := ConditionalAccessPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.tokenProtectionInvalidClientAppTypes(data),
+			ExpectError: regexp.MustCompile("`conditions.0.client_app_types` must contain only \"mobileAppsAndDesktopClients\""),
+		},
+	})
+}
+
+func TestAccConditionalAccessPolicy_lockoutProtection(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_conditional_access_policy", "test")
+	r := ConditionalAccessPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.lockoutProtection(data, false),
+			ExpectError: regexp.MustCompile("would lock out the calling principal"),
+		},
+		{
+			Config: r.lockoutProtection(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("state").HasValue("enabled"),
+				check.That(data.ResourceName).Key("lockout_protection.0.protect_calling_principal").HasValue("true"),
+			),
+		},
+		data.ImportStep("lockout_protection"),
+	})
+}
+
 func (r ConditionalAccessPolicyResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := stable.ParseIdentityConditionalAccessPolicyID(state.ID)
 	if err != nil {
@@ -380,7 +474,21 @@ func (r ConditionalAccessPolicyResource) Exists(ctx context.Context, clients *cl
 		if response.WasNotFound(resp.HttpResponse) {
 			return pointer.To(false), nil
 		}
-		return nil, fmt.Errorf("failed to retrieve %s: %v", id, err)
+
+		// Policies with beta-only properties may only be readable using the beta API
+		betaResp, betaErr := rawrequest.Do(ctx, clients.ConditionalAccess.PolicyClientBeta, rawrequest.Options{
+			ExpectedStatusCodes: []int{
+				http.StatusOK,
+			},
+			HttpMethod: http.MethodGet,
+			Path:       id.ID(),
+		}, nil, nil)
+		if betaErr != nil {
+			if response.WasNotFound(betaResp) {
+				return pointer.To(false), nil
+			}
+			return nil, fmt.Errorf("failed to retrieve %s: %v", id, err)
+		}
 	}
 
 	return pointer.To(true), nil
@@ -949,3 +1057,161 @@ resource "azuread_conditional_access_policy" "test" {
 }
 `, data.RandomInteger)
 }
+
+func (ConditionalAccessPolicyResource) authenticationFlows(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_conditional_access_policy" "test" {
+  display_name = "acctest-CONPOLICY-%[1]d"
+  state        = "disabled"
+
+  conditions {
+    client_app_types                     = ["all"]
+    authentication_flow_transfer_methods = ["deviceCodeFlow"]
+
+    applications {
+      included_applications = ["All"]
+    }
+
+    users {
+      included_users = ["All"]
+      excluded_users = ["GuestsOrExternalUsers"]
+    }
+  }
+
+  grant_controls {
+    operator          = "OR"
+    built_in_controls = ["block"]
+  }
+}
+`, data.RandomInteger)
+}
+
+func (ConditionalAccessPolicyResource) tokenProtection(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_conditional_access_policy" "test" {
+  display_name = "acctest-CONPOLICY-%[1]d"
+  state        = "disabled"
+
+  conditions {
+    client_app_types = ["mobileAppsAndDesktopClients"]
+
+    applications {
+      included_applications = ["00000003-0000-0ff1-ce00-000000000000"]
+    }
+
+    platforms {
+      included_platforms = ["windows"]
+    }
+
+    users {
+      included_users = ["All"]
+      excluded_users = ["GuestsOrExternalUsers"]
+    }
+  }
+
+  session_controls {
+    continuous_access_evaluation_mode = "strictLocation"
+    secure_sign_in_session_enabled    = true
+  }
+}
+`, data.RandomInteger)
+}
+
+func (ConditionalAccessPolicyResource) globalSecureAccessFilteringProfile(data acceptance.TestData, profileId string) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_conditional_access_policy" "test" {
+  display_name = "acctest-CONPOLICY-%[1]d"
+  state        = "disabled"
+
+  conditions {
+    client_app_types = ["all"]
+
+    applications {
+      included_applications = ["All"]
+    }
+
+    users {
+      included_users = ["All"]
+      excluded_users = ["GuestsOrExternalUsers"]
+    }
+  }
+
+  session_controls {
+    global_secure_access_filtering_profile_id = "%[2]s"
+  }
+}
+`, data.RandomInteger, profileId)
+}
+
+func (ConditionalAccessPolicyResource) tokenProtectionInvalidClientAppTypes(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_conditional_access_policy" "test" {
+  display_name = "acctest-CONPOLICY-%[1]d"
+  state        = "disabled"
+
+  conditions {
+    client_app_types = ["browser", "mobileAppsAndDesktopClients"]
+
+    applications {
+      included_applications = ["00000003-0000-0ff1-ce00-000000000000"]
+    }
+
+    users {
+      included_users = ["All"]
+    }
+  }
+
+  session_controls {
+    secure_sign_in_session_enabled = true
+  }
+}
+`, data.RandomInteger)
+}
+
+func (ConditionalAccessPolicyResource) lockoutProtection(data acceptance.TestData, excludeCaller bool) string {
+	excludedUsers := `["GuestsOrExternalUsers"]`
+	if excludeCaller {
+		excludedUsers = `["GuestsOrExternalUsers", data.azuread_client_config.current.object_id]`
+	}
+
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_client_config" "current" {}
+
+resource "azuread_conditional_access_policy" "test" {
+  display_name = "acctest-CONPOLICY-%[1]d"
+  state        = "enabled"
+
+  conditions {
+    client_app_types = ["browser"]
+
+    applications {
+      included_applications = ["None"]
+    }
+
+    users {
+      included_users = ["All"]
+      excluded_users = %[2]s
+    }
+  }
+
+  grant_controls {
+    operator          = "OR"
+    built_in_controls = ["block"]
+  }
+
+  lockout_protection {
+    protect_calling_principal = true
+  }
+}
+`, data.RandomInteger, excludedUsers)
+}
@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccesstemplate"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func conditionalAccessPolicyTemplateDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		ReadContext: conditionalAccessPolicyTemplateDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"template_id": {
+				Description:  "The ID of the conditional access policy template",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "template_id"},
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"name": {
+				Description:  "The name of the conditional access policy template",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "template_id"},
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"description": {
+				Description: "The description of the conditional access policy template",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"scenarios": {
+				Description: "The scenarios which the conditional access policy template is intended for",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"conditions": {
+				Description: "The conditions of the conditional access policy template, in the same format as the `azuread_conditional_access_policy` resource",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem:        computedResourceSchema(conditionalAccessConditionSetSchema("")),
+			},
+
+			"grant_controls": {
+				Description: "The grant controls of the conditional access policy template, in the same format as the `azuread_conditional_access_policy` resource",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem:        computedResourceSchema(conditionalAccessGrantControlsSchema("")),
+			},
+
+			"session_controls": {
+				Description: "The session controls of the conditional access policy template, in the same format as the `azuread_conditional_access_policy` resource",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem:        computedResourceSchema(conditionalAccessSessionControlsSchema("")),
+			},
+		},
+	}
+}
+
+// computedResourceSchema returns a copy of a nested resource schema in which every attribute is computed, so that the
+// schema for a block in the policy resource can be reused to export the same block from a data source
+func computedResourceSchema(in *pluginsdk.Resource) *pluginsdk.Resource {
+	out := &pluginsdk.Resource{
+		Schema: make(map[string]*pluginsdk.Schema, len(in.Schema)),
+	}
+
+	for name, s := range in.Schema {
+		out.Schema[name] = &pluginsdk.Schema{
+			Type:        s.Type,
+			Description: s.Description,
+			Computed:    true,
+		}
+
+		switch elem := s.Elem.(type) {
+		case *pluginsdk.Resource:
+			out.Schema[name].Elem = computedResourceSchema(elem)
+		case *pluginsdk.Schema:
+			out.Schema[name].Elem = &pluginsdk.Schema{
+				Type: elem.Type,
+			}
+		}
+	}
+
+	return out
+}
+
+func conditionalAccessPolicyTemplateDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.TemplateClient
+
+	var template *stable.ConditionalAccessTemplate
+
+	if templateId := d.Get("template_id").(string); templateId != "" {
+		id := stable.NewIdentityConditionalAccessTemplateID(templateId)
+
+		resp, err := client.GetConditionalAccessTemplate(ctx, id, conditionalaccesstemplate.DefaultGetConditionalAccessTemplateOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return tf.ErrorDiagPathF(nil, "template_id", "No Conditional Access Policy Template was found with ID %q", templateId)
+			}
+			return tf.ErrorDiagF(err, "Retrieving %s", id)
+		}
+
+		template = resp.Model
+		if template == nil {
+			return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+		}
+	} else {
+		// The templates API does not support filtering, so all templates are retrieved and matched by name
+		name := d.Get("name").(string)
+
+		resp, err := client.ListConditionalAccessTemplates(ctx, conditionalaccesstemplate.DefaultListConditionalAccessTemplatesOperationOptions())
+		if err != nil {
+			return tf.ErrorDiagF(err, "Listing Conditional Access Policy Templates")
+		}
+		if resp.Model == nil {
+			return tf.ErrorDiagF(errors.New("model was nil"), "Listing Conditional Access Policy Templates")
+		}
+
+		for _, item := range *resp.Model {
+			if strings.EqualFold(pointer.From(item.Name), name) {
+				if template != nil {
+					return tf.ErrorDiagPathF(nil, "name", "More than one Conditional Access Policy Template was found with name %q", name)
+				}
+				template = pointer.To(item)
+			}
+		}
+
+		if template == nil {
+			return tf.ErrorDiagPathF(nil, "name", "No Conditional Access Policy Template was found with name %q", name)
+		}
+	}
+
+	if template.Id == nil {
+		return tf.ErrorDiagF(errors.New("ID is nil for returned Conditional Access Policy Template"), "Bad API response")
+	}
+
+	id := stable.NewIdentityConditionalAccessTemplateID(*template.Id)
+	d.SetId(id.ID())
+
+	scenarios := make([]string, 0)
+	for _, v := range strings.Split(string(pointer.From(template.Scenarios)), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			scenarios = append(scenarios, v)
+		}
+	}
+
+	tf.Set(d, "description", pointer.From(template.Description))
+	tf.Set(d, "name", pointer.From(template.Name))
+	tf.Set(d, "scenarios", scenarios)
+	tf.Set(d, "template_id", id.ConditionalAccessTemplateId)
+
+	if details := template.Details; details != nil {
+		tf.Set(d, "conditions", flattenConditionalAccessConditionSet(details.Conditions))
+		tf.Set(d, "grant_controls", flattenConditionalAccessGrantControls(details.GrantControls))
+		tf.Set(d, "session_controls", flattenConditionalAccessSessionControls(details.SessionControls))
+	}
+
+	return nil
+}
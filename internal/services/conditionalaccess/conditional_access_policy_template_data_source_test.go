@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ConditionalAccessPolicyTemplateDataSource struct{}
+
+func TestAccConditionalAccessPolicyTemplateDataSource_byName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_policy_template", "test")
+	r := ConditionalAccessPolicyTemplateDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.byName(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("template_id").IsUuid(),
+				check.That(data.ResourceName).Key("description").Exists(),
+				check.That(data.ResourceName).Key("conditions.0.users.#").HasValue("1"),
+				check.That(data.ResourceName).Key("grant_controls.0.built_in_controls.0").HasValue("block"),
+			),
+		},
+	})
+}
+
+func TestAccConditionalAccessPolicyTemplateDataSource_byTemplateId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_policy_template", "test")
+	r := ConditionalAccessPolicyTemplateDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.byTemplateId(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("name").HasValue("Block legacy authentication"),
+				check.That(data.ResourceName).Key("scenarios.#").Exists(),
+			),
+		},
+	})
+}
+
+func (ConditionalAccessPolicyTemplateDataSource) byName() string {
+	return `
+data "azuread_conditional_access_policy_template" "test" {
+  name = "Block legacy authentication"
+}
+`
+}
+
+func (ConditionalAccessPolicyTemplateDataSource) byTemplateId() string {
+	return `
+data "azuread_conditional_access_policy_template" "by_name" {
+  name = "Block legacy authentication"
+}
+
+data "azuread_conditional_access_policy_template" "test" {
+  template_id = data.azuread_conditional_access_policy_template.by_name.template_id
+}
+`
+}
@@ -0,0 +1,626 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccessnamedlocation"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccesspolicy"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/conditionalaccess"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func conditionalAccessWhatIfDataSource() *pluginsdk.Resource {
+	clientAppTypes := make([]string, 0)
+	for _, v := range stable.PossibleValuesForConditionalAccessClientApp() {
+		if v != string(stable.ConditionalAccessClientApp_All) {
+			clientAppTypes = append(clientAppTypes, v)
+		}
+	}
+
+	platforms := make([]string, 0)
+	for _, v := range stable.PossibleValuesForConditionalAccessDevicePlatform() {
+		if v != string(stable.ConditionalAccessDevicePlatform_All) {
+			platforms = append(platforms, v)
+		}
+	}
+
+	return &pluginsdk.Resource{
+		ReadContext: conditionalAccessWhatIfDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"include_report_only": {
+				Description: "Whether policies in report-only mode contribute to the resulting blocked status, grant controls and session controls",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"policy_ids": {
+				Description:   "The object IDs of the conditional access policies to evaluate. Defaults to all policies in the tenant",
+				Type:          pluginsdk.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"policy"},
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.IsUUID,
+				},
+			},
+
+			"policy": {
+				Description:   "One or more policies to evaluate instead of the policies in the tenant",
+				Type:          pluginsdk.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"policy_ids"},
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"display_name": {
+							Description:  "The display name for the policy",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"state": {
+							Description:  "The state of the policy",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      string(stable.ConditionalAccessPolicyState_Enabled),
+							ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessPolicyState(), false),
+						},
+
+						"conditions": {
+							Type:     pluginsdk.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem:     conditionalAccessConditionSetSchema(""),
+						},
+
+						"grant_controls": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     conditionalAccessGrantControlsSchema(""),
+						},
+
+						"session_controls": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     conditionalAccessSessionControlsSchema(""),
+						},
+					},
+				},
+			},
+
+			"sign_in": {
+				Description: "The sign-in scenario to evaluate",
+				Type:        pluginsdk.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"user_object_id": {
+							Description:  "The object ID of the user signing in",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ExactlyOneOf: []string{"sign_in.0.user_object_id", "sign_in.0.service_principal_object_id"},
+							ValidateFunc: validation.IsUUID,
+						},
+
+						"group_object_ids": {
+							Description:   "The object IDs of groups the user is a member of",
+							Type:          pluginsdk.TypeList,
+							Optional:      true,
+							ConflictsWith: []string{"sign_in.0.service_principal_object_id"},
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.IsUUID,
+							},
+						},
+
+						"role_template_ids": {
+							Description:   "The template IDs of directory roles assigned to the user",
+							Type:          pluginsdk.TypeList,
+							Optional:      true,
+							ConflictsWith: []string{"sign_in.0.service_principal_object_id"},
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.IsUUID,
+							},
+						},
+
+						"guest_or_external_user_type": {
+							Description:   "The type of guest or external user, when the user is not a member of the tenant",
+							Type:          pluginsdk.TypeString,
+							Optional:      true,
+							ConflictsWith: []string{"sign_in.0.service_principal_object_id"},
+							ValidateFunc:  validation.StringInSlice(stable.PossibleValuesForConditionalAccessGuestOrExternalUserTypes(), false),
+						},
+
+						"external_tenant_id": {
+							Description:  "The ID of the home tenant of a guest or external user",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							RequiredWith: []string{"sign_in.0.guest_or_external_user_type"},
+							ValidateFunc: validation.IsUUID,
+						},
+
+						"service_principal_object_id": {
+							Description:  "The object ID of the service principal signing in, for a workload identity sign-in",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ExactlyOneOf: []string{"sign_in.0.user_object_id", "sign_in.0.service_principal_object_id"},
+							ValidateFunc: validation.IsUUID,
+						},
+
+						"client_id": {
+							Description:  "The client ID of the application being accessed",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ExactlyOneOf: []string{"sign_in.0.client_id", "sign_in.0.user_action"},
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"user_action": {
+							Description:  "The user action being performed, e.g. `urn:user:registersecurityinfo`",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ExactlyOneOf: []string{"sign_in.0.client_id", "sign_in.0.user_action"},
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"client_app_type": {
+							Description:  "The type of client application used to sign in",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      string(stable.ConditionalAccessClientApp_Browser),
+							ValidateFunc: validation.StringInSlice(clientAppTypes, false),
+						},
+
+						"platform": {
+							Description:  "The platform of the device used to sign in",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice(platforms, false),
+						},
+
+						"ip_address": {
+							Description:  "The IP address from which the sign-in originates, which is matched against IP named locations in the tenant",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsIPAddress,
+						},
+
+						"country_code": {
+							Description:  "The two-letter country code from which the sign-in originates, which is matched against country named locations in the tenant",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringLenBetween(2, 2),
+						},
+
+						"named_location_ids": {
+							Description: "The IDs of named locations from which the sign-in originates",
+							Type:        pluginsdk.TypeList,
+							Optional:    true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+
+						"trusted_location": {
+							Description: "Whether the sign-in originates from a trusted location",
+							Type:        pluginsdk.TypeBool,
+							Optional:    true,
+						},
+
+						"sign_in_risk_level": {
+							Description:  "The risk level of the sign-in",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      string(stable.RiskLevel_None),
+							ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskLevel(), false),
+						},
+
+						"user_risk_level": {
+							Description:  "The risk level of the user",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      string(stable.RiskLevel_None),
+							ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskLevel(), false),
+						},
+
+						"service_principal_risk_level": {
+							Description:  "The risk level of the service principal, for a workload identity sign-in",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      string(stable.RiskLevel_None),
+							ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskLevel(), false),
+						},
+
+						"insider_risk_level": {
+							Description:  "The insider risk level of the user",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessInsiderRiskLevels(), false),
+						},
+
+						"device_attributes": {
+							Description: "A map of properties of the device used to sign in, without the `device.` prefix, used to evaluate device filters",
+							Type:        pluginsdk.TypeMap,
+							Optional:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+					},
+				},
+			},
+
+			"policies": {
+				Description: "The result of evaluating each policy",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"object_id": {
+							Description: "The object ID of the policy, when evaluating policies in the tenant",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"display_name": {
+							Description: "The display name of the policy",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"state": {
+							Description: "The state of the policy",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"applies": {
+							Description: "Whether the policy applies to the sign-in. This is also true when the result is indeterminate",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+
+						"indeterminate": {
+							Description: "Whether the policy could not be fully evaluated, for example due to a device filter rule that cannot be evaluated locally",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+
+						"reason": {
+							Description: "The reason the policy does not apply to the sign-in, or could not be fully evaluated",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+
+			"blocked": {
+				Description: "Whether the sign-in is blocked by an enabled policy, or by a report-only policy when `include_report_only` is true",
+				Type:        pluginsdk.TypeBool,
+				Computed:    true,
+			},
+
+			"grant_controls": {
+				Description: "The grant controls of each enabled policy that applies to the sign-in, as well as each report-only policy when `include_report_only` is true",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"object_id": {
+							Description: "The object ID of the policy, when evaluating policies in the tenant",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"display_name": {
+							Description: "The display name of the policy",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"state": {
+							Description: "The state of the policy",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"operator": {
+							Description: "Whether all or any of the controls must be satisfied",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"built_in_controls": {
+							Description: "The built-in controls required by the policy",
+							Type:        pluginsdk.TypeList,
+							Computed:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"authentication_strength_policy_id": {
+							Description: "The ID of the authentication strength policy required by the policy",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"custom_authentication_factors": {
+							Description: "The custom authentication factors required by the policy",
+							Type:        pluginsdk.TypeList,
+							Computed:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"terms_of_use": {
+							Description: "The terms of use required by the policy",
+							Type:        pluginsdk.TypeList,
+							Computed:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+					},
+				},
+			},
+
+			"session_controls": {
+				Description: "The combined session controls of the enabled policies that apply to the sign-in, as well as report-only policies when `include_report_only` is true",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"application_enforced_restrictions_enabled": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+
+						"cloud_app_security_policy": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"disable_resilience_defaults": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+
+						"persistent_browser_mode": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"sign_in_frequency": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"sign_in_frequency_authentication_type": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"sign_in_frequency_interval": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"sign_in_frequency_period": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func conditionalAccessWhatIfDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.PolicyClient
+	namedLocationClient := meta.(*clients.Client).ConditionalAccess.NamedLocationClient
+
+	policies := make([]stable.ConditionalAccessPolicy, 0)
+
+	if v, ok := d.GetOk("policy"); ok {
+		for i, raw := range v.([]interface{}) {
+			if raw == nil {
+				continue
+			}
+			in := raw.(map[string]interface{})
+
+			grantControls, err := expandConditionalAccessGrantControls(in["grant_controls"].([]interface{}))
+			if err != nil {
+				return tf.ErrorDiagPathF(err, "policy", "Parsing `grant_controls` for policy at index %d", i)
+			}
+
+			var sessionControls *stable.ConditionalAccessSessionControls
+			if sessionControlsRaw := in["session_controls"].([]interface{}); len(sessionControlsRaw) > 0 {
+				sessionControls = expandConditionalAccessSessionControls(sessionControlsRaw)
+			}
+
+			policies = append(policies, stable.ConditionalAccessPolicy{
+				DisplayName:     pointer.To(in["display_name"].(string)),
+				State:           pointer.To(stable.ConditionalAccessPolicyState(in["state"].(string))),
+				Conditions:      expandConditionalAccessConditionSet(in["conditions"].([]interface{})),
+				GrantControls:   grantControls,
+				SessionControls: sessionControls,
+			})
+		}
+	} else {
+		resp, err := client.ListConditionalAccessPolicies(ctx, conditionalaccesspolicy.DefaultListConditionalAccessPoliciesOperationOptions())
+		if err != nil {
+			return tf.ErrorDiagF(err, "Could not list conditional access policies")
+		}
+		if resp.Model == nil {
+			return tf.ErrorDiagF(errors.New("model was nil"), "Could not list conditional access policies")
+		}
+
+		policyIds := tf.ExpandStringSlice(d.Get("policy_ids").([]interface{}))
+		for _, policyId := range policyIds {
+			found := false
+			for _, policy := range *resp.Model {
+				if strings.EqualFold(pointer.From(policy.Id), policyId) {
+					policies = append(policies, policy)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return tf.ErrorDiagPathF(nil, "policy_ids", "Conditional access policy with object ID %q was not found", policyId)
+			}
+		}
+
+		if len(policyIds) == 0 {
+			policies = *resp.Model
+		}
+	}
+
+	signInRaw := d.Get("sign_in").([]interface{})
+	if len(signInRaw) == 0 || signInRaw[0] == nil {
+		return tf.ErrorDiagPathF(nil, "sign_in", "A `sign_in` block must be specified")
+	}
+	in := signInRaw[0].(map[string]interface{})
+
+	signIn := conditionalaccess.SignIn{
+		UserId:                    in["user_object_id"].(string),
+		GroupIds:                  tf.ExpandStringSlice(in["group_object_ids"].([]interface{})),
+		RoleIds:                   tf.ExpandStringSlice(in["role_template_ids"].([]interface{})),
+		GuestOrExternalUserType:   in["guest_or_external_user_type"].(string),
+		ExternalTenantId:          in["external_tenant_id"].(string),
+		ServicePrincipalId:        in["service_principal_object_id"].(string),
+		ApplicationId:             in["client_id"].(string),
+		UserAction:                in["user_action"].(string),
+		ClientAppType:             in["client_app_type"].(string),
+		Platform:                  in["platform"].(string),
+		NamedLocationIds:          tf.ExpandStringSlice(in["named_location_ids"].([]interface{})),
+		TrustedLocation:           in["trusted_location"].(bool),
+		SignInRiskLevel:           in["sign_in_risk_level"].(string),
+		UserRiskLevel:             in["user_risk_level"].(string),
+		ServicePrincipalRiskLevel: in["service_principal_risk_level"].(string),
+		InsiderRiskLevel:          in["insider_risk_level"].(string),
+		DeviceAttributes:          make(map[string]string),
+	}
+	for k, v := range in["device_attributes"].(map[string]interface{}) {
+		signIn.DeviceAttributes[k] = v.(string)
+	}
+
+	// Resolve the IP address and country of the sign-in to the named locations defined in the tenant
+	if ipAddress, countryCode := in["ip_address"].(string), in["country_code"].(string); ipAddress != "" || countryCode != "" {
+		resp, err := namedLocationClient.ListConditionalAccessNamedLocations(ctx, conditionalaccessnamedlocation.DefaultListConditionalAccessNamedLocationsOperationOptions())
+		if err != nil {
+			return tf.ErrorDiagF(err, "Could not list named locations")
+		}
+		if resp.Model == nil {
+			return tf.ErrorDiagF(errors.New("model was nil"), "Could not list named locations")
+		}
+
+		namedLocationIds, trusted := conditionalaccess.MatchNamedLocations(*resp.Model, ipAddress, countryCode)
+		signIn.NamedLocationIds = append(signIn.NamedLocationIds, namedLocationIds...)
+		signIn.TrustedLocation = signIn.TrustedLocation || trusted
+	}
+
+	results := make([]map[string]interface{}, 0)
+	grantControls := make([]interface{}, 0)
+	sessionControls := make([]*stable.ConditionalAccessSessionControls, 0)
+	blocked := false
+	includeReportOnly := d.Get("include_report_only").(bool)
+
+	for _, policy := range policies {
+		result, reason := conditionalaccess.Evaluate(policy, signIn)
+
+		// Policies that cannot be fully evaluated are conservatively treated as applying
+		applies := result != conditionalaccess.EvaluationResultDoesNotApply
+
+		results = append(results, map[string]interface{}{
+			"object_id":     pointer.From(policy.Id),
+			"display_name":  pointer.From(policy.DisplayName),
+			"state":         string(pointer.From(policy.State)),
+			"applies":       applies,
+			"indeterminate": result == conditionalaccess.EvaluationResultIndeterminate,
+			"reason":        reason,
+		})
+
+		// Policies in report-only mode are not enforced, so only contribute to the resulting controls when requested
+		if !applies {
+			continue
+		}
+		if state := pointer.From(policy.State); state != stable.ConditionalAccessPolicyState_Enabled && !(includeReportOnly && state == stable.ConditionalAccessPolicyState_EnabledForReportingButNotEnforced) {
+			continue
+		}
+
+		if policy.GrantControls != nil {
+			for _, v := range pointer.From(policy.GrantControls.BuiltInControls) {
+				if v == stable.ConditionalAccessGrantControl_Block {
+					blocked = true
+				}
+			}
+
+			for _, raw := range flattenConditionalAccessGrantControls(policy.GrantControls) {
+				controls := raw.(map[string]interface{})
+				controls["object_id"] = pointer.From(policy.Id)
+				controls["display_name"] = pointer.From(policy.DisplayName)
+				controls["state"] = string(pointer.From(policy.State))
+				grantControls = append(grantControls, controls)
+			}
+		}
+
+		sessionControls = append(sessionControls, policy.SessionControls)
+	}
+
+	scenario, err := json.Marshal(signIn)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for sign-in scenario")
+	}
+
+	h := sha1.New()
+	if _, err := h.Write(scenario); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for sign-in scenario")
+	}
+	if _, err := h.Write([]byte(fmt.Sprintf("%t", includeReportOnly))); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for sign-in scenario")
+	}
+	for _, policy := range policies {
+		if _, err := h.Write([]byte(fmt.Sprintf("%s/%s", pointer.From(policy.Id), pointer.From(policy.DisplayName)))); err != nil {
+			return tf.ErrorDiagF(err, "Unable to compute hash for policies")
+		}
+	}
+
+	d.SetId("conditionalAccessWhatIf#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+
+	tf.Set(d, "policies", results)
+	tf.Set(d, "blocked", blocked)
+	tf.Set(d, "grant_controls", grantControls)
+	tf.Set(d, "session_controls", flattenConditionalAccessSessionControls(conditionalaccess.CombineSessionControls(sessionControls)))
+
+	return nil
+}
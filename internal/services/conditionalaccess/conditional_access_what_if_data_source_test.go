@@ -0,0 +1,269 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ConditionalAccessWhatIfDataSource struct{}
+
+func TestAccConditionalAccessWhatIfDataSource_policies(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_what_if", "test")
+	r := ConditionalAccessWhatIfDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.policies(false),
+			Check: acceptance.ComposeAggregateTestCheckFunc(
+				check.That(data.ResourceName).Key("policies.#").HasValue("3"),
+				check.That(data.ResourceName).Key("policies.0.applies").HasValue("true"),
+				check.That(data.ResourceName).Key("policies.1.applies").HasValue("false"),
+				check.That(data.ResourceName).Key("policies.1.reason").HasValue("user is excluded"),
+				check.That(data.ResourceName).Key("policies.2.applies").HasValue("true"),
+				check.That(data.ResourceName).Key("policies.2.state").HasValue("enabledForReportingButNotEnforced"),
+				check.That(data.ResourceName).Key("blocked").HasValue("false"),
+				check.That(data.ResourceName).Key("grant_controls.#").HasValue("1"),
+				check.That(data.ResourceName).Key("grant_controls.0.display_name").HasValue("require-mfa"),
+				check.That(data.ResourceName).Key("grant_controls.0.built_in_controls.0").HasValue("mfa"),
+				check.That(data.ResourceName).Key("session_controls.#").HasValue("1"),
+				check.That(data.ResourceName).Key("session_controls.0.sign_in_frequency").HasValue("4"),
+				check.That(data.ResourceName).Key("session_controls.0.sign_in_frequency_period").HasValue("hours"),
+			),
+		},
+	})
+}
+
+func TestAccConditionalAccessWhatIfDataSource_includeReportOnly(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_what_if", "test")
+	r := ConditionalAccessWhatIfDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.policies(true),
+			Check: acceptance.ComposeAggregateTestCheckFunc(
+				check.That(data.ResourceName).Key("policies.#").HasValue("3"),
+				check.That(data.ResourceName).Key("blocked").HasValue("true"),
+				check.That(data.ResourceName).Key("grant_controls.#").HasValue("2"),
+				check.That(data.ResourceName).Key("grant_controls.0.state").HasValue("enabled"),
+				check.That(data.ResourceName).Key("grant_controls.1.display_name").HasValue("report-only-block"),
+				check.That(data.ResourceName).Key("grant_controls.1.state").HasValue("enabledForReportingButNotEnforced"),
+			),
+		},
+	})
+}
+
+func TestAccConditionalAccessWhatIfDataSource_tenantPolicy(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_what_if", "test")
+	r := ConditionalAccessWhatIfDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.tenantPolicy(data),
+			Check: acceptance.ComposeAggregateTestCheckFunc(
+				check.That(data.ResourceName).Key("policies.#").HasValue("1"),
+				check.That(data.ResourceName).Key("policies.0.object_id").IsUuid(),
+				check.That(data.ResourceName).Key("policies.0.applies").HasValue("false"),
+				check.That(data.ResourceName).Key("policies.0.reason").HasValue("policy is disabled"),
+				check.That(data.ResourceName).Key("blocked").HasValue("false"),
+			),
+		},
+	})
+}
+
+func TestAccConditionalAccessWhatIfDataSource_invalidDeviceFilter(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_what_if", "test")
+	r := ConditionalAccessWhatIfDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.invalidDeviceFilter(data),
+			Check: acceptance.ComposeAggregateTestCheckFunc(
+				check.That(data.ResourceName).Key("policies.#").HasValue("1"),
+				check.That(data.ResourceName).Key("policies.0.applies").HasValue("true"),
+				check.That(data.ResourceName).Key("policies.0.indeterminate").HasValue("true"),
+				check.That(data.ResourceName).Key("policies.0.reason").MatchesRegex(regexp.MustCompile("device filter rule could not be parsed")),
+				check.That(data.ResourceName).Key("grant_controls.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func (ConditionalAccessWhatIfDataSource) policies(includeReportOnly bool) string {
+	return fmt.Sprintf(`
+data "azuread_client_config" "current" {}
+
+data "azuread_conditional_access_what_if" "test" {
+  include_report_only = %[1]t
+
+  policy {
+    display_name = "require-mfa"
+
+    conditions {
+      client_app_types = ["all"]
+
+      applications {
+        included_applications = ["All"]
+      }
+
+      users {
+        included_users = ["All"]
+      }
+    }
+
+    grant_controls {
+      operator          = "OR"
+      built_in_controls = ["mfa"]
+    }
+
+    session_controls {
+      sign_in_frequency        = 4
+      sign_in_frequency_period = "hours"
+    }
+  }
+
+  policy {
+    display_name = "exclude-current-user"
+
+    conditions {
+      client_app_types = ["all"]
+
+      applications {
+        included_applications = ["All"]
+      }
+
+      users {
+        included_users = ["All"]
+        excluded_users = [data.azuread_client_config.current.object_id]
+      }
+    }
+
+    grant_controls {
+      operator          = "OR"
+      built_in_controls = ["block"]
+    }
+  }
+
+  policy {
+    display_name = "report-only-block"
+    state        = "enabledForReportingButNotEnforced"
+
+    conditions {
+      client_app_types = ["browser"]
+
+      applications {
+        included_applications = ["All"]
+      }
+
+      devices {
+        filter {
+          mode = "exclude"
+          rule = "device.isCompliant -eq True"
+        }
+      }
+
+      users {
+        included_users = ["All"]
+      }
+    }
+
+    grant_controls {
+      operator          = "OR"
+      built_in_controls = ["block"]
+    }
+  }
+
+  sign_in {
+    user_object_id = data.azuread_client_config.current.object_id
+    client_id      = "00000003-0000-0ff1-ce00-000000000000"
+    platform       = "windows"
+
+    device_attributes = {
+      isCompliant = "false"
+    }
+  }
+}
+`, includeReportOnly)
+}
+
+func (ConditionalAccessWhatIfDataSource) tenantPolicy(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azuread_client_config" "current" {}
+
+resource "azuread_conditional_access_policy" "test" {
+  display_name = "acctest-CONPOLICY-%[1]d"
+  state        = "disabled"
+
+  conditions {
+    client_app_types = ["browser"]
+
+    applications {
+      included_applications = ["All"]
+    }
+
+    users {
+      included_users = ["All"]
+    }
+  }
+
+  grant_controls {
+    operator          = "OR"
+    built_in_controls = ["block"]
+  }
+}
+
+data "azuread_conditional_access_what_if" "test" {
+  policy_ids = [azuread_conditional_access_policy.test.object_id]
+
+  sign_in {
+    user_object_id = data.azuread_client_config.current.object_id
+    client_id      = "00000003-0000-0ff1-ce00-000000000000"
+  }
+}
+`, data.RandomInteger)
+}
+
+func (ConditionalAccessWhatIfDataSource) invalidDeviceFilter(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azuread_conditional_access_what_if" "test" {
+  policy {
+    display_name = "acctest-CONPOLICY-%[1]d"
+
+    conditions {
+      client_app_types = ["all"]
+
+      applications {
+        included_applications = ["All"]
+      }
+
+      devices {
+        filter {
+          mode = "include"
+          rule = "user.department -eq \"Sales\""
+        }
+      }
+
+      users {
+        included_users = ["All"]
+      }
+    }
+
+    grant_controls {
+      operator          = "OR"
+      built_in_controls = ["mfa"]
+    }
+  }
+
+  sign_in {
+    user_object_id = "00000000-0000-0000-0000-000000000000"
+    client_id      = "00000003-0000-0ff1-ce00-000000000000"
+  }
+}
+`, data.RandomInteger)
+}
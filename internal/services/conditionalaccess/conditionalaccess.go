@@ -4,11 +4,14 @@
 package conditionalaccess
 
 import (
+	"encoding/json"
 	"strings"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
 	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/conditionalaccess"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 )
 
@@ -298,6 +301,39 @@ func flattenCountryNamedLocation(in *stable.CountryNamedLocation) []interface{}
 	}
 }
 
+// compliantNetworkNamedLocation decodes a named location that is not modelled by the v1.0 API, returning nil when it
+// is not a compliant network named location. Compliant network named locations are provisioned by Global Secure Access.
+func compliantNetworkNamedLocation(in stable.RawNamedLocationImpl) *beta.CompliantNetworkNamedLocation {
+	if !strings.EqualFold(in.Type, "#microsoft.graph.compliantNetworkNamedLocation") {
+		return nil
+	}
+
+	encoded, err := json.Marshal(in.Values)
+	if err != nil {
+		return nil
+	}
+
+	var result beta.CompliantNetworkNamedLocation
+	if err = json.Unmarshal(encoded, &result); err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+func flattenCompliantNetworkNamedLocation(in *beta.CompliantNetworkNamedLocation) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"compliant_network_type": string(pointer.From(in.CompliantNetworkType)),
+			"trusted":                pointer.From(in.IsTrusted),
+		},
+	}
+}
+
 func flattenIPNamedLocation(in *stable.IPNamedLocation) []interface{} {
 	if in == nil {
 		return []interface{}{}
@@ -335,6 +371,12 @@ func flattenIPNamedLocationIPRange(in []stable.IPRange) []interface{} {
 		}
 	}
 
+	for i, cidr := range result {
+		if normalized, err := conditionalaccess.NormalizeCIDR(cidr); err == nil {
+			result[i] = normalized
+		}
+	}
+
 	return tf.FlattenStringSlice(result)
 }
 
@@ -703,13 +745,155 @@ func expandIPNamedLocation(in []interface{}) *stable.IPNamedLocation {
 	return &result
 }
 
+// expandIPNamedLocationIPRange returns the IP ranges in canonical form, since equivalent ranges (e.g. IPv6 ranges with
+// and without zero compression) are otherwise considered to be distinct
 func expandIPNamedLocationIPRange(in []interface{}) []stable.IPRange {
 	result := make([]stable.IPRange, 0)
-	for _, cidr := range in {
-		result = append(result, stable.IPv4CIDRRange{
-			CIDRAddress: pointer.To(cidr.(string)),
-		})
+
+	for _, raw := range in {
+		cidr := raw.(string)
+		if normalized, err := conditionalaccess.NormalizeCIDR(cidr); err == nil {
+			cidr = normalized
+		}
+
+		if conditionalaccess.IsIPv6CIDR(cidr) {
+			result = append(result, stable.IPv6CIDRRange{
+				CIDRAddress: pointer.To(cidr),
+			})
+		} else {
+			result = append(result, stable.IPv4CIDRRange{
+				CIDRAddress: pointer.To(cidr),
+			})
+		}
 	}
 
 	return result
 }
+
+// conditionalAccessPolicyBetaProperties holds the properties of a conditional access policy that are only available in
+// the beta API. These are sent and received alongside the stable properties of the policy.
+type conditionalAccessPolicyBetaProperties struct {
+	Conditions      *conditionalAccessPolicyBetaConditions      `json:"conditions,omitempty"`
+	SessionControls *conditionalAccessPolicyBetaSessionControls `json:"sessionControls,omitempty"`
+}
+
+type conditionalAccessPolicyBetaConditions struct {
+	AuthenticationFlows *beta.ConditionalAccessAuthenticationFlows `json:"authenticationFlows,omitempty"`
+}
+
+type conditionalAccessPolicyBetaSessionControls struct {
+	ContinuousAccessEvaluation         *beta.ContinuousAccessEvaluationSessionControl                     `json:"continuousAccessEvaluation,omitempty"`
+	GlobalSecureAccessFilteringProfile *conditionalAccessGlobalSecureAccessFilteringProfileSessionControl `json:"globalSecureAccessFilteringProfile,omitempty"`
+	SecureSignInSession                *beta.SecureSignInSessionControl                                   `json:"secureSignInSession,omitempty"`
+}
+
+// conditionalAccessGlobalSecureAccessFilteringProfileSessionControl is not yet modelled by the SDK
+type conditionalAccessGlobalSecureAccessFilteringProfileSessionControl struct {
+	IsEnabled *bool   `json:"isEnabled,omitempty"`
+	ProfileId *string `json:"profileId,omitempty"`
+}
+
+// expandConditionalAccessPolicyBetaProperties returns the beta-only properties configured in the `conditions` and
+// `session_controls` blocks, or nil when none are configured and the policy can be managed using the stable API
+func expandConditionalAccessPolicyBetaProperties(conditions, sessionControls []interface{}) *conditionalAccessPolicyBetaProperties {
+	result := conditionalAccessPolicyBetaProperties{}
+
+	if len(conditions) > 0 && conditions[0] != nil {
+		config := conditions[0].(map[string]interface{})
+
+		transferMethods := make([]string, 0)
+		if v, ok := config["authentication_flow_transfer_methods"]; ok {
+			transferMethods = tf.ExpandStringSlice(v.([]interface{}))
+		}
+		if len(transferMethods) > 0 {
+			result.Conditions = &conditionalAccessPolicyBetaConditions{
+				AuthenticationFlows: &beta.ConditionalAccessAuthenticationFlows{
+					TransferMethods: pointer.To(beta.ConditionalAccessTransferMethods(strings.Join(transferMethods, ","))),
+				},
+			}
+		}
+	}
+
+	if len(sessionControls) > 0 && sessionControls[0] != nil {
+		config := sessionControls[0].(map[string]interface{})
+		controls := conditionalAccessPolicyBetaSessionControls{}
+
+		if v, ok := config["continuous_access_evaluation_mode"]; ok && v.(string) != "" {
+			controls.ContinuousAccessEvaluation = &beta.ContinuousAccessEvaluationSessionControl{
+				Mode: pointer.To(beta.ContinuousAccessEvaluationMode(v.(string))),
+			}
+		}
+
+		if v, ok := config["global_secure_access_filtering_profile_id"]; ok && v.(string) != "" {
+			controls.GlobalSecureAccessFilteringProfile = &conditionalAccessGlobalSecureAccessFilteringProfileSessionControl{
+				IsEnabled: pointer.To(true),
+				ProfileId: pointer.To(v.(string)),
+			}
+		}
+
+		if v, ok := config["secure_sign_in_session_enabled"]; ok && v.(bool) {
+			controls.SecureSignInSession = &beta.SecureSignInSessionControl{
+				IsEnabled: nullable.Value(true),
+			}
+		}
+
+		if controls.ContinuousAccessEvaluation != nil || controls.GlobalSecureAccessFilteringProfile != nil || controls.SecureSignInSession != nil {
+			result.SessionControls = &controls
+		}
+	}
+
+	if result.Conditions == nil && result.SessionControls == nil {
+		return nil
+	}
+
+	return &result
+}
+
+// flattenConditionalAccessPolicyBetaProperties adds the beta-only properties of a policy to the flattened `conditions`
+// and `session_controls` blocks, returning the updated blocks
+func flattenConditionalAccessPolicyBetaProperties(in *conditionalAccessPolicyBetaProperties, conditions, sessionControls []interface{}) ([]interface{}, []interface{}) {
+	transferMethods := make([]string, 0)
+	continuousAccessEvaluationMode := ""
+	globalSecureAccessFilteringProfileId := ""
+	secureSignInSessionEnabled := false
+
+	if in != nil {
+		if in.Conditions != nil && in.Conditions.AuthenticationFlows != nil {
+			for _, v := range strings.Split(string(pointer.From(in.Conditions.AuthenticationFlows.TransferMethods)), ",") {
+				if v = strings.TrimSpace(v); v != "" && v != string(beta.ConditionalAccessTransferMethods_None) {
+					transferMethods = append(transferMethods, v)
+				}
+			}
+		}
+
+		if in.SessionControls != nil {
+			if in.SessionControls.ContinuousAccessEvaluation != nil {
+				continuousAccessEvaluationMode = string(pointer.From(in.SessionControls.ContinuousAccessEvaluation.Mode))
+			}
+			if profile := in.SessionControls.GlobalSecureAccessFilteringProfile; profile != nil && pointer.From(profile.IsEnabled) {
+				globalSecureAccessFilteringProfileId = pointer.From(profile.ProfileId)
+			}
+			if in.SessionControls.SecureSignInSession != nil {
+				secureSignInSessionEnabled = in.SessionControls.SecureSignInSession.IsEnabled.GetOrZero()
+			}
+		}
+	}
+
+	if len(conditions) > 0 && conditions[0] != nil {
+		conditions[0].(map[string]interface{})["authentication_flow_transfer_methods"] = transferMethods
+	}
+
+	if continuousAccessEvaluationMode != "" || globalSecureAccessFilteringProfileId != "" || secureSignInSessionEnabled {
+		if len(sessionControls) == 0 || sessionControls[0] == nil {
+			sessionControls = flattenConditionalAccessSessionControls(&stable.ConditionalAccessSessionControls{})
+		}
+	}
+	if len(sessionControls) > 0 && sessionControls[0] != nil {
+		controls := sessionControls[0].(map[string]interface{})
+		controls["continuous_access_evaluation_mode"] = continuousAccessEvaluationMode
+		controls["global_secure_access_filtering_profile_id"] = globalSecureAccessFilteringProfileId
+		controls["secure_sign_in_session_enabled"] = secureSignInSessionEnabled
+	}
+
+	return conditions, sessionControls
+}
@@ -56,6 +56,24 @@ func namedLocationDataSource() *pluginsdk.Resource {
 				},
 			},
 
+			"compliant_network": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"compliant_network_type": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"trusted": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+
 			"country": {
 				Type:     pluginsdk.TypeList,
 				Computed: true,
@@ -132,6 +150,12 @@ func namedLocationDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData,
 
 		tf.Set(d, "display_name", pointer.From(namedLocation.DisplayName))
 		tf.Set(d, "country", flattenCountryNamedLocation(&namedLocation))
+
+	case stable.RawNamedLocationImpl:
+		if compliantNetwork := compliantNetworkNamedLocation(namedLocation); compliantNetwork != nil {
+			tf.Set(d, "display_name", pointer.From(compliantNetwork.DisplayName))
+			tf.Set(d, "compliant_network", flattenCompliantNetworkNamedLocation(compliantNetwork))
+		}
 	}
 
 	id := stable.NewIdentityConditionalAccessNamedLocationID(pointer.From(item.NamedLocation().Id))
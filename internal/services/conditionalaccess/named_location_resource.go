@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccessnamedlocation"
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/conditionalaccess"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
@@ -74,9 +75,11 @@ func namedLocationResource() *pluginsdk.Resource {
 						"ip_ranges": {
 							Type:     pluginsdk.TypeList,
 							Required: true,
+							MaxItems: 2000,
 							Elem: &pluginsdk.Schema{
-								Type:         pluginsdk.TypeString,
-								ValidateFunc: validation.PrefixLengthAtLeast(8),
+								Type:             pluginsdk.TypeString,
+								ValidateFunc:     validation.PrefixLengthAtLeast(8),
+								DiffSuppressFunc: namedLocationIPRangeDiffSuppress,
 							},
 						},
 
@@ -345,7 +348,16 @@ func ipNamedLocationWait(client *conditionalaccessnamedlocation.ConditionalAcces
 			location := locationRaw[0].(map[string]interface{})
 			ip := v.([]interface{})[0].(map[string]interface{})
 
-			if !reflect.DeepEqual(location["ip_ranges"], ip["ip_ranges"]) {
+			// The configured ranges are normalized, since the API returns ranges in canonical form
+			expected := make([]interface{}, 0)
+			for _, cidr := range ip["ip_ranges"].([]interface{}) {
+				if normalized, err := conditionalaccess.NormalizeCIDR(cidr.(string)); err == nil {
+					expected = append(expected, normalized)
+				} else {
+					expected = append(expected, cidr)
+				}
+			}
+			if !reflect.DeepEqual(location["ip_ranges"], expected) {
 				return pointer.To(false), nil
 			}
 
@@ -390,3 +402,16 @@ func countryNamedLocationWait(client *conditionalaccessnamedlocation.Conditional
 		return pointer.To(true), nil
 	}
 }
+
+// namedLocationIPRangeDiffSuppress suppresses differences between IP ranges that have the same canonical form
+func namedLocationIPRangeDiffSuppress(_, old, new string, _ *pluginsdk.ResourceData) bool {
+	oldCidr, err := conditionalaccess.NormalizeCIDR(old)
+	if err != nil {
+		return false
+	}
+	newCidr, err := conditionalaccess.NormalizeCIDR(new)
+	if err != nil {
+		return false
+	}
+	return oldCidr == newCidr
+}
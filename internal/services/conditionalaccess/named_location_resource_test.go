@@ -79,6 +79,22 @@ func TestAccNamedLocation_updateIP(t *testing.T) {
 	})
 }
 
+func TestAccNamedLocation_normalizedIP(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_named_location", "test")
+	r := NamedLocationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.normalizedIP(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("ip.0.ip_ranges.#").HasValue("3"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccNamedLocation_basicCountry(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_named_location", "test")
 	r := NamedLocationResource{}
@@ -232,6 +248,21 @@ resource "azuread_named_location" "test" {
 `, data.RandomInteger)
 }
 
+func (NamedLocationResource) normalizedIP(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_named_location" "test" {
+  display_name = "acctestNLIP-%[1]d"
+  ip {
+    ip_ranges = [
+      "1.1.1.1/32",
+      "2001:DB8:0:0::/32",
+      "10.1.2.3/8",
+    ]
+  }
+}
+`, data.RandomInteger)
+}
+
 func (NamedLocationResource) basicCountry(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 resource "azuread_named_location" "test" {
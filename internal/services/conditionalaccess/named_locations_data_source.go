@@ -0,0 +1,219 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccessnamedlocation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+const (
+	NamedLocationTypeCompliantNetwork = "compliantNetwork"
+	NamedLocationTypeCountry          = "country"
+	NamedLocationTypeIP               = "ip"
+)
+
+var possibleValuesForNamedLocationType = []string{
+	NamedLocationTypeCompliantNetwork,
+	NamedLocationTypeCountry,
+	NamedLocationTypeIP,
+}
+
+func namedLocationsDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		ReadContext: namedLocationsDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"type": {
+				Description:  "The type of named locations to return",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(possibleValuesForNamedLocationType, false),
+			},
+
+			"trusted": {
+				Description: "Whether to return only trusted, or only untrusted, named locations",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+			},
+
+			"object_ids": {
+				Description: "The object IDs of the named locations",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"named_locations": {
+				Description: "A list of named locations",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"object_id": {
+							Description: "The object ID of the named location",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"display_name": {
+							Description: "The display name of the named location",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"type": {
+							Description: "The type of the named location",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"trusted": {
+							Description: "Whether the named location is trusted",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+
+						"compliant_network_type": {
+							Description: "The type of a compliant network named location",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"ip_ranges": {
+							Description: "The IP ranges of an IP named location, in canonical form",
+							Type:        pluginsdk.TypeList,
+							Computed:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"countries_and_regions": {
+							Description: "The countries and regions of a country named location",
+							Type:        pluginsdk.TypeList,
+							Computed:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"include_unknown_countries_and_regions": {
+							Description: "Whether a country named location includes IP addresses that don't map to a country or region",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func namedLocationsDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.NamedLocationClient
+
+	locationType := d.Get("type").(string)
+
+	var trusted *bool
+	if v, ok := d.GetOkExists("trusted"); ok { //nolint:staticcheck // needed to detect unset booleans
+		trusted = pointer.To(v.(bool))
+	}
+
+	resp, err := client.ListConditionalAccessNamedLocations(ctx, conditionalaccessnamedlocation.DefaultListConditionalAccessNamedLocationsOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing Named Locations")
+	}
+	if resp.Model == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Bad API response")
+	}
+
+	objectIds := make([]string, 0)
+	namedLocations := make([]interface{}, 0)
+
+	for _, item := range *resp.Model {
+		if item == nil {
+			continue
+		}
+
+		base := item.NamedLocation()
+		if base.Id == nil {
+			return tf.ErrorDiagF(errors.New("ID is nil for returned Named Location"), "Bad API response")
+		}
+
+		namedLocation := map[string]interface{}{
+			"object_id":                             *base.Id,
+			"display_name":                          pointer.From(base.DisplayName),
+			"type":                                  "",
+			"trusted":                               false,
+			"compliant_network_type":                "",
+			"ip_ranges":                             []interface{}{},
+			"countries_and_regions":                 []interface{}{},
+			"include_unknown_countries_and_regions": false,
+		}
+
+		switch model := item.(type) {
+		case stable.IPNamedLocation:
+			namedLocation["type"] = NamedLocationTypeIP
+			namedLocation["trusted"] = pointer.From(model.IsTrusted)
+			namedLocation["ip_ranges"] = flattenIPNamedLocationIPRange(model.IPRanges)
+
+		case stable.CountryNamedLocation:
+			namedLocation["type"] = NamedLocationTypeCountry
+			namedLocation["countries_and_regions"] = tf.FlattenStringSlice(model.CountriesAndRegions)
+			namedLocation["include_unknown_countries_and_regions"] = pointer.From(model.IncludeUnknownCountriesAndRegions)
+
+		case stable.RawNamedLocationImpl:
+			// Named locations which are not modelled by the v1.0 API, such as compliant network locations created by
+			// Global Secure Access, are identified using their OData type
+			namedLocation["type"] = strings.TrimSuffix(strings.TrimPrefix(model.Type, "#microsoft.graph."), "NamedLocation")
+			if v, ok := model.Values["isTrusted"].(bool); ok {
+				namedLocation["trusted"] = v
+			}
+			if compliantNetwork := compliantNetworkNamedLocation(model); compliantNetwork != nil {
+				namedLocation["compliant_network_type"] = string(pointer.From(compliantNetwork.CompliantNetworkType))
+			}
+		}
+
+		if locationType != "" && !strings.EqualFold(namedLocation["type"].(string), locationType) {
+			continue
+		}
+		if trusted != nil && namedLocation["trusted"].(bool) != *trusted {
+			continue
+		}
+
+		objectIds = append(objectIds, *base.Id)
+		namedLocations = append(namedLocations, namedLocation)
+	}
+
+	h := sha1.New()
+	if _, err := h.Write([]byte(locationType + "/" + strings.Join(objectIds, "/"))); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for object IDs")
+	}
+
+	d.SetId("namedLocations#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+
+	tf.Set(d, "object_ids", objectIds)
+	tf.Set(d, "named_locations", namedLocations)
+
+	return nil
+}
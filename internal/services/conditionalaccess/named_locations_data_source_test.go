@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type NamedLocationsDataSource struct{}
+
+func TestAccNamedLocationsDataSource_trustedIP(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_named_locations", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: NamedLocationsDataSource{}.trustedIP(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("object_ids.#").Exists(),
+				check.That(data.ResourceName).Key("named_locations.0.type").HasValue("ip"),
+				check.That(data.ResourceName).Key("named_locations.0.trusted").HasValue("true"),
+			),
+		},
+	})
+}
+
+func TestAccNamedLocationsDataSource_country(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_named_locations", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: NamedLocationsDataSource{}.country(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("object_ids.#").Exists(),
+				check.That(data.ResourceName).Key("named_locations.0.type").HasValue("country"),
+				check.That(data.ResourceName).Key("named_locations.0.trusted").HasValue("false"),
+			),
+		},
+	})
+}
+
+func (NamedLocationsDataSource) trustedIP(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_named_locations" "test" {
+  type    = "ip"
+  trusted = true
+
+  depends_on = [azuread_named_location.test]
+}
+`, NamedLocationResource{}.completeIP(data))
+}
+
+func (NamedLocationsDataSource) country(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_named_locations" "test" {
+  type = "country"
+
+  depends_on = [azuread_named_location.test]
+}
+`, NamedLocationResource{}.completeCountry(data))
+}
@@ -29,14 +29,18 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azuread_named_location": namedLocationDataSource(),
+		"azuread_conditional_access_policy_template": conditionalAccessPolicyTemplateDataSource(),
+		"azuread_conditional_access_what_if":         conditionalAccessWhatIfDataSource(),
+		"azuread_named_location":                     namedLocationDataSource(),
+		"azuread_named_locations":                    namedLocationsDataSource(),
 	}
 }
 
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azuread_named_location":            namedLocationResource(),
-		"azuread_conditional_access_policy": conditionalAccessPolicyResource(),
+		"azuread_named_location":                            namedLocationResource(),
+		"azuread_conditional_access_authentication_context": conditionalAccessAuthenticationContextResource(),
+		"azuread_conditional_access_policy":                 conditionalAccessPolicyResource(),
 	}
 }
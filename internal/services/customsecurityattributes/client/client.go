@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directory/stable/attributeset"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directory/stable/customsecurityattributedefinition"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directory/stable/customsecurityattributedefinitionallowedvalue"
+	"github.com/hashicorp/terraform-provider-azuread/internal/common"
+)
+
+type Client struct {
+	AttributeSetClient                                  *attributeset.AttributeSetClient
+	CustomSecurityAttributeDefinitionAllowedValueClient *customsecurityattributedefinitionallowedvalue.CustomSecurityAttributeDefinitionAllowedValueClient
+	CustomSecurityAttributeDefinitionClient             *customsecurityattributedefinition.CustomSecurityAttributeDefinitionClient
+}
+
+func NewClient(o *common.ClientOptions) (*Client, error) {
+	attributeSetClient, err := attributeset.NewAttributeSetClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(attributeSetClient.Client)
+
+	allowedValueClient, err := customsecurityattributedefinitionallowedvalue.NewCustomSecurityAttributeDefinitionAllowedValueClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(allowedValueClient.Client)
+
+	definitionClient, err := customsecurityattributedefinition.NewCustomSecurityAttributeDefinitionClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(definitionClient.Client)
+
+	return &Client{
+		AttributeSetClient: attributeSetClient,
+		CustomSecurityAttributeDefinitionAllowedValueClient: allowedValueClient,
+		CustomSecurityAttributeDefinitionClient:             definitionClient,
+	}, nil
+}
@@ -0,0 +1,19 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package customsecurityattributes
+
+const (
+	CustomSecurityAttributeStatusAvailable  = "Available"
+	CustomSecurityAttributeStatusDeprecated = "Deprecated"
+)
+
+var possibleValuesForCustomSecurityAttributeStatus = []string{CustomSecurityAttributeStatusAvailable, CustomSecurityAttributeStatusDeprecated}
+
+const (
+	CustomSecurityAttributeTypeBoolean = "Boolean"
+	CustomSecurityAttributeTypeInteger = "Integer"
+	CustomSecurityAttributeTypeString  = "String"
+)
+
+var possibleValuesForCustomSecurityAttributeType = []string{CustomSecurityAttributeTypeBoolean, CustomSecurityAttributeTypeInteger, CustomSecurityAttributeTypeString}
@@ -0,0 +1,393 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package customsecurityattributes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directory/stable/customsecurityattributedefinition"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directory/stable/customsecurityattributedefinitionallowedvalue"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func customSecurityAttributeDefinitionResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: customSecurityAttributeDefinitionResourceCreate,
+		ReadContext:   customSecurityAttributeDefinitionResourceRead,
+		UpdateContext: customSecurityAttributeDefinitionResourceUpdate,
+		DeleteContext: customSecurityAttributeDefinitionResourceDelete,
+
+		CustomizeDiff: pluginsdk.CustomDiffInSequence(
+			customSecurityAttributeDefinitionResourceCustomizeDiff,
+			// Predefined values can be relaxed, but cannot later be enforced
+			pluginsdk.ForceNewIfChange("use_predefined_values_only", func(ctx context.Context, old, new, meta interface{}) bool {
+				return !old.(bool) && new.(bool)
+			}),
+		),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			if _, errs := stable.ValidateDirectoryCustomSecurityAttributeDefinitionID(id, "id"); len(errs) > 0 {
+				out := ""
+				for _, err := range errs {
+					out += err.Error()
+				}
+				return errors.New(out)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"attribute_set": {
+				Description:  "The name of the attribute set in which to define the custom security attribute",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9]{1,32}$`), "must be up to 32 characters long, and cannot contain spaces or special characters"),
+			},
+
+			"name": {
+				Description:  "The name of the custom security attribute, which must be unique within the attribute set",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9]{1,32}$`), "must be up to 32 characters long, and cannot contain spaces or special characters"),
+			},
+
+			"type": {
+				Description:  "The data type for the custom security attribute values",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(possibleValuesForCustomSecurityAttributeType, false),
+			},
+
+			"description": {
+				Description:  "The description of the custom security attribute",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 128),
+			},
+
+			"collection_enabled": {
+				Description: "Whether multiple values can be assigned to the custom security attribute",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+
+			"searchable": {
+				Description: "Whether custom security attribute values are indexed for searching on objects that are assigned attribute values",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+
+			"status": {
+				Description:  "Whether the custom security attribute is active or deactivated",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Default:      CustomSecurityAttributeStatusAvailable,
+				ValidateFunc: validation.StringInSlice(possibleValuesForCustomSecurityAttributeStatus, false),
+			},
+
+			"use_predefined_values_only": {
+				Description: "Whether only predefined values can be assigned to the custom security attribute",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"predefined_value": {
+				Description: "A predefined value for the custom security attribute",
+				Type:        pluginsdk.TypeSet,
+				Optional:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"value": {
+							Description:  "The predefined value",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 64),
+						},
+
+						"active": {
+							Description: "Whether the predefined value is active. Inactive values cannot be assigned to any other objects",
+							Type:        pluginsdk.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func customSecurityAttributeDefinitionResourceCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+	attributeType := diff.Get("type").(string)
+	predefinedValues := diff.Get("predefined_value").(*pluginsdk.Set).List()
+
+	if attributeType == CustomSecurityAttributeTypeBoolean {
+		if diff.Get("collection_enabled").(bool) {
+			return fmt.Errorf("`collection_enabled` cannot be true when `type` is %q", CustomSecurityAttributeTypeBoolean)
+		}
+		if diff.Get("use_predefined_values_only").(bool) {
+			return fmt.Errorf("`use_predefined_values_only` cannot be true when `type` is %q", CustomSecurityAttributeTypeBoolean)
+		}
+		if len(predefinedValues) > 0 {
+			return fmt.Errorf("`predefined_value` cannot be specified when `type` is %q", CustomSecurityAttributeTypeBoolean)
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for _, raw := range predefinedValues {
+		value := raw.(map[string]interface{})["value"].(string)
+		if value == "" {
+			// Value is not yet known
+			continue
+		}
+		if _, ok := seen[strings.ToLower(value)]; ok {
+			return fmt.Errorf("duplicate `predefined_value` %q, predefined values are case insensitive", value)
+		}
+		seen[strings.ToLower(value)] = struct{}{}
+	}
+
+	return nil
+}
+
+func customSecurityAttributeDefinitionResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).CustomSecurityAttributes.CustomSecurityAttributeDefinitionClient
+
+	attributeSet := d.Get("attribute_set").(string)
+	name := d.Get("name").(string)
+	id := stable.NewDirectoryCustomSecurityAttributeDefinitionID(fmt.Sprintf("%s_%s", attributeSet, name))
+
+	resp, err := client.GetCustomSecurityAttributeDefinition(ctx, id, customsecurityattributedefinition.DefaultGetCustomSecurityAttributeDefinitionOperationOptions())
+	if err != nil {
+		if !response.WasNotFound(resp.HttpResponse) {
+			return tf.ErrorDiagF(err, "Checking for existing %s", id)
+		}
+	} else {
+		return tf.ImportAsExistsDiag("azuread_custom_security_attribute_definition", id.ID())
+	}
+
+	properties := stable.CustomSecurityAttributeDefinition{
+		AttributeSet:            pointer.To(attributeSet),
+		Description:             nullable.NoZero(d.Get("description").(string)),
+		IsCollection:            pointer.To(d.Get("collection_enabled").(bool)),
+		IsSearchable:            nullable.Value(d.Get("searchable").(bool)),
+		Name:                    pointer.To(name),
+		Status:                  pointer.To(d.Get("status").(string)),
+		Type:                    pointer.To(d.Get("type").(string)),
+		UsePreDefinedValuesOnly: nullable.Value(d.Get("use_predefined_values_only").(bool)),
+	}
+
+	if allowedValues := expandCustomSecurityAttributeAllowedValues(d.Get("predefined_value").(*pluginsdk.Set).List()); len(allowedValues) > 0 {
+		properties.AllowedValues = &allowedValues
+	}
+
+	if _, err = client.CreateCustomSecurityAttributeDefinition(ctx, properties, customsecurityattributedefinition.DefaultCreateCustomSecurityAttributeDefinitionOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Creating %s", id)
+	}
+
+	if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		resp, err := client.GetCustomSecurityAttributeDefinition(ctx, id, customsecurityattributedefinition.DefaultGetCustomSecurityAttributeDefinitionOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(resp.Model != nil), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for creation of %s", id)
+	}
+
+	d.SetId(id.ID())
+
+	return customSecurityAttributeDefinitionResourceRead(ctx, d, meta)
+}
+
+func customSecurityAttributeDefinitionResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).CustomSecurityAttributes.CustomSecurityAttributeDefinitionClient
+	allowedValueClient := meta.(*clients.Client).CustomSecurityAttributes.CustomSecurityAttributeDefinitionAllowedValueClient
+
+	id, err := stable.ParseDirectoryCustomSecurityAttributeDefinitionID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Custom Security Attribute Definition ID")
+	}
+
+	// Predefined values are updated first, so that they exist before the definition is updated
+	if d.HasChange("predefined_value") {
+		existingValues, err := customSecurityAttributeListAllowedValues(ctx, allowedValueClient, *id)
+		if err != nil {
+			return tf.ErrorDiagF(err, "Listing predefined values for %s", id)
+		}
+
+		for _, desired := range expandCustomSecurityAttributeAllowedValues(d.Get("predefined_value").(*pluginsdk.Set).List()) {
+			valueId := stable.NewDirectoryCustomSecurityAttributeDefinitionIdAllowedValueID(id.CustomSecurityAttributeDefinitionId, pointer.From(desired.Id))
+
+			existing, ok := existingValues[strings.ToLower(pointer.From(desired.Id))]
+			if !ok {
+				if _, err = allowedValueClient.CreateCustomSecurityAttributeDefinitionAllowedValue(ctx, *id, desired, customsecurityattributedefinitionallowedvalue.DefaultCreateCustomSecurityAttributeDefinitionAllowedValueOperationOptions()); err != nil {
+					return tf.ErrorDiagPathF(err, "predefined_value", "Creating %s", valueId)
+				}
+				continue
+			}
+
+			delete(existingValues, strings.ToLower(pointer.From(desired.Id)))
+			if existing.IsActive.GetOrZero() != desired.IsActive.GetOrZero() {
+				properties := stable.AllowedValue{
+					IsActive: desired.IsActive,
+				}
+				if _, err = allowedValueClient.UpdateCustomSecurityAttributeDefinitionAllowedValue(ctx, valueId, properties, customsecurityattributedefinitionallowedvalue.DefaultUpdateCustomSecurityAttributeDefinitionAllowedValueOperationOptions()); err != nil {
+					return tf.ErrorDiagPathF(err, "predefined_value", "Updating %s", valueId)
+				}
+			}
+		}
+
+		// Predefined values cannot be deleted, so any values no longer configured are deactivated instead
+		for _, existing := range existingValues {
+			if !existing.IsActive.GetOrZero() {
+				continue
+			}
+
+			valueId := stable.NewDirectoryCustomSecurityAttributeDefinitionIdAllowedValueID(id.CustomSecurityAttributeDefinitionId, pointer.From(existing.Id))
+			properties := stable.AllowedValue{
+				IsActive: nullable.Value(false),
+			}
+			if _, err = allowedValueClient.UpdateCustomSecurityAttributeDefinitionAllowedValue(ctx, valueId, properties, customsecurityattributedefinitionallowedvalue.DefaultUpdateCustomSecurityAttributeDefinitionAllowedValueOperationOptions()); err != nil {
+				return tf.ErrorDiagPathF(err, "predefined_value", "Deactivating %s", valueId)
+			}
+		}
+	}
+
+	properties := stable.CustomSecurityAttributeDefinition{}
+
+	if d.HasChange("description") {
+		properties.Description = nullable.NoZero(d.Get("description").(string))
+	}
+
+	if d.HasChange("status") {
+		properties.Status = pointer.To(d.Get("status").(string))
+	}
+
+	if d.HasChange("use_predefined_values_only") {
+		properties.UsePreDefinedValuesOnly = nullable.Value(d.Get("use_predefined_values_only").(bool))
+	}
+
+	if d.HasChanges("description", "status", "use_predefined_values_only") {
+		if _, err = client.UpdateCustomSecurityAttributeDefinition(ctx, *id, properties, customsecurityattributedefinition.DefaultUpdateCustomSecurityAttributeDefinitionOperationOptions()); err != nil {
+			return tf.ErrorDiagF(err, "Updating %s", id)
+		}
+	}
+
+	return customSecurityAttributeDefinitionResourceRead(ctx, d, meta)
+}
+
+func customSecurityAttributeDefinitionResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).CustomSecurityAttributes.CustomSecurityAttributeDefinitionClient
+	allowedValueClient := meta.(*clients.Client).CustomSecurityAttributes.CustomSecurityAttributeDefinitionAllowedValueClient
+
+	id, err := stable.ParseDirectoryCustomSecurityAttributeDefinitionID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Custom Security Attribute Definition ID")
+	}
+
+	resp, err := client.GetCustomSecurityAttributeDefinition(ctx, *id, customsecurityattributedefinition.DefaultGetCustomSecurityAttributeDefinitionOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+
+	definition := resp.Model
+	if definition == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+	}
+
+	allowedValues, err := customSecurityAttributeListAllowedValues(ctx, allowedValueClient, *id)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing predefined values for %s", id)
+	}
+
+	// Deactivated values cannot be deleted, so they are only tracked when they are still configured
+	configuredValues := make(map[string]struct{})
+	for _, raw := range d.Get("predefined_value").(*pluginsdk.Set).List() {
+		configuredValues[strings.ToLower(raw.(map[string]interface{})["value"].(string))] = struct{}{}
+	}
+
+	predefinedValues := make([]interface{}, 0)
+	for key, allowedValue := range allowedValues {
+		if _, ok := configuredValues[key]; !ok && !allowedValue.IsActive.GetOrZero() {
+			continue
+		}
+		predefinedValues = append(predefinedValues, map[string]interface{}{
+			"value":  pointer.From(allowedValue.Id),
+			"active": allowedValue.IsActive.GetOrZero(),
+		})
+	}
+
+	tf.Set(d, "attribute_set", pointer.From(definition.AttributeSet))
+	tf.Set(d, "collection_enabled", pointer.From(definition.IsCollection))
+	tf.Set(d, "description", definition.Description.GetOrZero())
+	tf.Set(d, "name", pointer.From(definition.Name))
+	tf.Set(d, "predefined_value", predefinedValues)
+	tf.Set(d, "searchable", definition.IsSearchable.GetOrZero())
+	tf.Set(d, "status", pointer.From(definition.Status))
+	tf.Set(d, "type", pointer.From(definition.Type))
+	tf.Set(d, "use_predefined_values_only", definition.UsePreDefinedValuesOnly.GetOrZero())
+
+	return nil
+}
+
+func customSecurityAttributeDefinitionResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).CustomSecurityAttributes.CustomSecurityAttributeDefinitionClient
+
+	id, err := stable.ParseDirectoryCustomSecurityAttributeDefinitionID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Custom Security Attribute Definition ID")
+	}
+
+	// Custom security attribute definitions cannot be deleted, so they are deactivated instead
+	properties := stable.CustomSecurityAttributeDefinition{
+		Status: pointer.To(CustomSecurityAttributeStatusDeprecated),
+	}
+
+	if resp, err := client.UpdateCustomSecurityAttributeDefinition(ctx, *id, properties, customsecurityattributedefinition.DefaultUpdateCustomSecurityAttributeDefinitionOperationOptions()); err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s already deleted", id)
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Deactivating %s", id)
+	}
+
+	return nil
+}
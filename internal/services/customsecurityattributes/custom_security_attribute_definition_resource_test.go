@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package customsecurityattributes_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directory/stable/customsecurityattributedefinition"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type CustomSecurityAttributeDefinitionResource struct{}
+
+func TestAccCustomSecurityAttributeDefinition_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_custom_security_attribute_definition", "test")
+	r := CustomSecurityAttributeDefinitionResource{}
+
+	// Attribute definitions cannot be deleted, only deprecated, so the destroy check is skipped
+	data.ResourceTestSkipCheckDestroyed(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("status").HasValue("Available"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccCustomSecurityAttributeDefinition_predefinedValues(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_custom_security_attribute_definition", "test")
+	r := CustomSecurityAttributeDefinitionResource{}
+
+	data.ResourceTestSkipCheckDestroyed(t, []acceptance.TestStep{
+		{
+			Config: r.predefinedValues(data, `"Alpine", "Baker"`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("predefined_value.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.predefinedValues(data, `"Alpine", "Cascade", "Denali"`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("predefined_value.#").HasValue("3"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccCustomSecurityAttributeDefinition_assignments(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user", "test")
+	r := CustomSecurityAttributeDefinitionResource{}
+
+	data.ResourceTestSkipCheckDestroyed(t, []acceptance.TestStep{
+		{
+			Config: r.assignments(data, "Alpine", 5),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("custom_security_attributes.#").HasValue("2"),
+				check.That("azuread_service_principal.test").Key("custom_security_attributes.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("password"),
+		{
+			Config: r.assignments(data, "Baker", 10),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("custom_security_attributes.#").HasValue("2"),
+			),
+		},
+		data.ImportStep("password"),
+	})
+}
+
+func (r CustomSecurityAttributeDefinitionResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.CustomSecurityAttributes.CustomSecurityAttributeDefinitionClient
+
+	id, err := stable.ParseDirectoryCustomSecurityAttributeDefinitionID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetCustomSecurityAttributeDefinition(ctx, *id, customsecurityattributedefinition.DefaultGetCustomSecurityAttributeDefinitionOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, fmt.Errorf("%s does not exist", id)
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %v", id, err)
+	}
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (CustomSecurityAttributeDefinitionResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_custom_security_attribute_set" "test" {
+  name = "acctest%[1]s"
+}
+`, data.RandomString)
+}
+
+func (r CustomSecurityAttributeDefinitionResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_custom_security_attribute_definition" "test" {
+  attribute_set = azuread_custom_security_attribute_set.test.name
+  name          = "Project"
+  type          = "String"
+}
+`, r.template(data))
+}
+
+func (r CustomSecurityAttributeDefinitionResource) predefinedValues(data acceptance.TestData, values string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_custom_security_attribute_definition" "test" {
+  attribute_set              = azuread_custom_security_attribute_set.test.name
+  name                       = "Project"
+  description                = "Active projects"
+  type                       = "String"
+  collection_enabled         = true
+  use_predefined_values_only = true
+
+  dynamic "predefined_value" {
+    for_each = toset([%[2]s])
+    content {
+      value = predefined_value.value
+    }
+  }
+}
+`, r.template(data), values)
+}
+
+func (r CustomSecurityAttributeDefinitionResource) assignments(data acceptance.TestData, project string, level int) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_custom_security_attribute_definition" "project" {
+  attribute_set = azuread_custom_security_attribute_set.test.name
+  name          = "Project"
+  type          = "String"
+}
+
+resource "azuread_custom_security_attribute_definition" "level" {
+  attribute_set = azuread_custom_security_attribute_set.test.name
+  name          = "Level"
+  type          = "Integer"
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestUser.%[2]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[2]d"
+  password            = "%[3]s"
+
+  custom_security_attributes {
+    attribute_set = azuread_custom_security_attribute_set.test.name
+    name          = azuread_custom_security_attribute_definition.project.name
+    type          = "String"
+    value         = "%[4]s"
+  }
+
+  custom_security_attributes {
+    attribute_set = azuread_custom_security_attribute_set.test.name
+    name          = azuread_custom_security_attribute_definition.level.name
+    type          = "Integer"
+    value         = "%[5]d"
+  }
+}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[2]d"
+}
+
+resource "azuread_service_principal" "test" {
+  client_id = azuread_application.test.client_id
+
+  custom_security_attributes {
+    attribute_set = azuread_custom_security_attribute_set.test.name
+    name          = azuread_custom_security_attribute_definition.project.name
+    type          = "String"
+    value         = "%[4]s"
+  }
+}
+`, r.template(data), data.RandomInteger, data.RandomPassword, project, level)
+}
@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package customsecurityattributes
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directory/stable/attributeset"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func customSecurityAttributeSetResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: customSecurityAttributeSetResourceCreate,
+		ReadContext:   customSecurityAttributeSetResourceRead,
+		UpdateContext: customSecurityAttributeSetResourceUpdate,
+		DeleteContext: customSecurityAttributeSetResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			if _, errs := stable.ValidateDirectoryAttributeSetID(id, "id"); len(errs) > 0 {
+				out := ""
+				for _, err := range errs {
+					out += err.Error()
+				}
+				return errors.New(out)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Description:  "The name of the attribute set",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9]{1,32}$`), "must be up to 32 characters long, and cannot contain spaces or special characters"),
+			},
+
+			"description": {
+				Description:  "The description of the attribute set",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 128),
+			},
+
+			"max_attributes_per_set": {
+				Description:  "The maximum number of custom security attributes that can be defined in this attribute set",
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 500),
+			},
+		},
+	}
+}
+
+func customSecurityAttributeSetResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).CustomSecurityAttributes.AttributeSetClient
+
+	id := stable.NewDirectoryAttributeSetID(d.Get("name").(string))
+
+	resp, err := client.GetAttributeSet(ctx, id, attributeset.DefaultGetAttributeSetOperationOptions())
+	if err != nil {
+		if !response.WasNotFound(resp.HttpResponse) {
+			return tf.ErrorDiagF(err, "Checking for existing %s", id)
+		}
+	} else {
+		return tf.ImportAsExistsDiag("azuread_custom_security_attribute_set", id.ID())
+	}
+
+	properties := stable.AttributeSet{
+		Id:          pointer.To(id.AttributeSetId),
+		Description: nullable.NoZero(d.Get("description").(string)),
+	}
+
+	if v, ok := d.GetOk("max_attributes_per_set"); ok {
+		properties.MaxAttributesPerSet = nullable.Value(int64(v.(int)))
+	}
+
+	if _, err = client.CreateAttributeSet(ctx, properties, attributeset.DefaultCreateAttributeSetOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Creating %s", id)
+	}
+
+	if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		resp, err := client.GetAttributeSet(ctx, id, attributeset.DefaultGetAttributeSetOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(resp.Model != nil), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for creation of %s", id)
+	}
+
+	d.SetId(id.ID())
+
+	return customSecurityAttributeSetResourceRead(ctx, d, meta)
+}
+
+func customSecurityAttributeSetResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).CustomSecurityAttributes.AttributeSetClient
+
+	id, err := stable.ParseDirectoryAttributeSetID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Attribute Set ID")
+	}
+
+	properties := stable.AttributeSet{}
+
+	if d.HasChange("description") {
+		properties.Description = nullable.NoZero(d.Get("description").(string))
+	}
+
+	if d.HasChange("max_attributes_per_set") {
+		properties.MaxAttributesPerSet = nullable.NoZero(int64(d.Get("max_attributes_per_set").(int)))
+	}
+
+	if _, err = client.UpdateAttributeSet(ctx, *id, properties, attributeset.DefaultUpdateAttributeSetOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Updating %s", id)
+	}
+
+	return customSecurityAttributeSetResourceRead(ctx, d, meta)
+}
+
+func customSecurityAttributeSetResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).CustomSecurityAttributes.AttributeSetClient
+
+	id, err := stable.ParseDirectoryAttributeSetID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Attribute Set ID")
+	}
+
+	resp, err := client.GetAttributeSet(ctx, *id, attributeset.DefaultGetAttributeSetOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+
+	attributeSet := resp.Model
+	if attributeSet == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+	}
+
+	tf.Set(d, "name", pointer.From(attributeSet.Id))
+	tf.Set(d, "description", attributeSet.Description.GetOrZero())
+	tf.Set(d, "max_attributes_per_set", int(attributeSet.MaxAttributesPerSet.GetOrZero()))
+
+	return nil
+}
+
+func customSecurityAttributeSetResourceDelete(_ context.Context, d *pluginsdk.ResourceData, _ interface{}) pluginsdk.Diagnostics {
+	id, err := stable.ParseDirectoryAttributeSetID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Attribute Set ID")
+	}
+
+	// Attribute sets cannot be deleted, so we just remove it from state
+	log.Printf("[WARN] %s cannot be deleted and will only be removed from state", id)
+
+	return nil
+}
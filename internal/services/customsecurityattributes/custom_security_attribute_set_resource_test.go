@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package customsecurityattributes_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directory/stable/attributeset"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type CustomSecurityAttributeSetResource struct{}
+
+func TestAccCustomSecurityAttributeSet_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_custom_security_attribute_set", "test")
+	r := CustomSecurityAttributeSetResource{}
+
+	// Attribute sets cannot be deleted, so the destroy check is skipped
+	data.ResourceTestSkipCheckDestroyed(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("name").HasValue(fmt.Sprintf("acctest%s", data.RandomString)),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccCustomSecurityAttributeSet_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_custom_security_attribute_set", "test")
+	r := CustomSecurityAttributeSetResource{}
+
+	data.ResourceTestSkipCheckDestroyed(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("max_attributes_per_set").HasValue("25"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r CustomSecurityAttributeSetResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.CustomSecurityAttributes.AttributeSetClient
+
+	id, err := stable.ParseDirectoryAttributeSetID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetAttributeSet(ctx, *id, attributeset.DefaultGetAttributeSetOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, fmt.Errorf("%s does not exist", id)
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %v", id, err)
+	}
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (CustomSecurityAttributeSetResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_custom_security_attribute_set" "test" {
+  name = "acctest%[1]s"
+}
+`, data.RandomString)
+}
+
+func (CustomSecurityAttributeSetResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_custom_security_attribute_set" "test" {
+  name                   = "acctest%[1]s"
+  description            = "Acceptance test attribute set %[1]s"
+  max_attributes_per_set = 25
+}
+`, data.RandomString)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package customsecurityattributes
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directory/stable/customsecurityattributedefinitionallowedvalue"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+)
+
+func expandCustomSecurityAttributeAllowedValues(in []interface{}) []stable.AllowedValue {
+	result := make([]stable.AllowedValue, 0)
+	for _, raw := range in {
+		if raw == nil {
+			continue
+		}
+		v := raw.(map[string]interface{})
+		result = append(result, stable.AllowedValue{
+			Id:       pointer.To(v["value"].(string)),
+			IsActive: nullable.Value(v["active"].(bool)),
+		})
+	}
+	return result
+}
+
+// customSecurityAttributeListAllowedValues returns all predefined values for a custom security attribute, including
+// deactivated values, keyed by the lowercased value
+func customSecurityAttributeListAllowedValues(ctx context.Context, client *customsecurityattributedefinitionallowedvalue.CustomSecurityAttributeDefinitionAllowedValueClient, id stable.DirectoryCustomSecurityAttributeDefinitionId) (map[string]stable.AllowedValue, error) {
+	resp, err := client.ListCustomSecurityAttributeDefinitionAllowedValuesComplete(ctx, id, customsecurityattributedefinitionallowedvalue.DefaultListCustomSecurityAttributeDefinitionAllowedValuesOperationOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]stable.AllowedValue)
+	for _, v := range resp.Items {
+		if v.Id == nil {
+			continue
+		}
+		result[strings.ToLower(*v.Id)] = v
+	}
+
+	return result, nil
+}
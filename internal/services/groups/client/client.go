@@ -9,8 +9,10 @@ import (
 	groupBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/group"
 	memberBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/member"
 	memberofBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/memberof"
+	memberswithlicenseerrorBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/memberswithlicenseerror"
 	ownerBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/owner"
 	transitivememberBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/transitivemember"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/user"
 	"github.com/hashicorp/terraform-provider-azuread/internal/common"
 )
 
@@ -19,13 +21,15 @@ import (
 // board owing to the complexity of the azuread_group resource, and known bugs when retrieving members with the Stable API.
 
 type Client struct {
-	AdministrativeUnitMemberClientBeta *administrativeunitmemberBeta.AdministrativeUnitMemberClient
-	DirectoryObjectClient              *directoryobject.DirectoryObjectClient
-	GroupClientBeta                    *groupBeta.GroupClient
-	GroupMemberClientBeta              *memberBeta.MemberClient
-	GroupMemberOfClientBeta            *memberofBeta.MemberOfClient
-	GroupOwnerClientBeta               *ownerBeta.OwnerClient
-	GroupTransitiveMemberClientBeta    *transitivememberBeta.TransitiveMemberClient
+	AdministrativeUnitMemberClientBeta     *administrativeunitmemberBeta.AdministrativeUnitMemberClient
+	DirectoryObjectClient                  *directoryobject.DirectoryObjectClient
+	GroupClientBeta                        *groupBeta.GroupClient
+	GroupMemberClientBeta                  *memberBeta.MemberClient
+	GroupMemberOfClientBeta                *memberofBeta.MemberOfClient
+	GroupMembersWithLicenseErrorClientBeta *memberswithlicenseerrorBeta.MembersWithLicenseErrorClient
+	GroupOwnerClientBeta                   *ownerBeta.OwnerClient
+	GroupTransitiveMemberClientBeta        *transitivememberBeta.TransitiveMemberClient
+	UserClient                             *user.UserClient
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
@@ -61,6 +65,12 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(memberOfClientBeta.Client)
 
+	membersWithLicenseErrorClientBeta, err := memberswithlicenseerrorBeta.NewMembersWithLicenseErrorClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(membersWithLicenseErrorClientBeta.Client)
+
 	ownerClientBeta, err := ownerBeta.NewOwnerClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
@@ -74,13 +84,22 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(transitiveMemberClientBeta.Client)
 
+	// Used to retrieve license assignment states for members of groups with license errors
+	userClient, err := user.NewUserClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(userClient.Client)
+
 	return &Client{
-		AdministrativeUnitMemberClientBeta: administrativeUnitMemberClientBeta,
-		DirectoryObjectClient:              directoryObjectClient,
-		GroupClientBeta:                    groupClientBeta,
-		GroupMemberClientBeta:              memberClientBeta,
-		GroupMemberOfClientBeta:            memberOfClientBeta,
-		GroupOwnerClientBeta:               ownerClientBeta,
-		GroupTransitiveMemberClientBeta:    transitiveMemberClientBeta,
+		AdministrativeUnitMemberClientBeta:     administrativeUnitMemberClientBeta,
+		DirectoryObjectClient:                  directoryObjectClient,
+		GroupClientBeta:                        groupClientBeta,
+		GroupMemberClientBeta:                  memberClientBeta,
+		GroupMemberOfClientBeta:                memberOfClientBeta,
+		GroupMembersWithLicenseErrorClientBeta: membersWithLicenseErrorClientBeta,
+		GroupOwnerClientBeta:                   ownerClientBeta,
+		GroupTransitiveMemberClientBeta:        transitiveMemberClientBeta,
+		UserClient:                             userClient,
 	}, nil
 }
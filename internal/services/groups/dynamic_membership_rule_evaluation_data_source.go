@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package groups
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func dynamicMembershipRuleEvaluationDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		ReadContext: dynamicMembershipRuleEvaluationDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"rule": {
+				Description:      "The dynamic membership rule to evaluate",
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.IsDynamicMembershipRule,
+			},
+
+			"subject": {
+				Description: "A list of users or devices to evaluate the rule against",
+				Type:        pluginsdk.TypeList,
+				Required:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"key": {
+							Description:  "A unique key used to identify this subject in the results",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"attributes": {
+							Description: "A map of single-valued properties for the subject, without the `user.` or `device.` prefix",
+							Type:        pluginsdk.TypeMap,
+							Optional:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"multi_valued_attribute": {
+							Description: "A multi-valued property for the subject",
+							Type:        pluginsdk.TypeList,
+							Optional:    true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"name": {
+										Description:  "The name of the property, without the `user.` or `device.` prefix",
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"values": {
+										Description: "The values of the property",
+										Type:        pluginsdk.TypeList,
+										Required:    true,
+										Elem: &pluginsdk.Schema{
+											Type: pluginsdk.TypeString,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"object_type": {
+				Description: "The type of object the rule applies to, either `user` or `device`",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"matching_keys": {
+				Description: "The keys of subjects that match the rule",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"results": {
+				Description: "The result of evaluating the rule for each subject",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"key": {
+							Description: "The key of the subject",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"matches": {
+							Description: "Whether the subject matches the rule",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dynamicMembershipRuleEvaluationDataSourceRead(_ context.Context, d *pluginsdk.ResourceData, _ interface{}) pluginsdk.Diagnostics {
+	ruleString := d.Get("rule").(string)
+
+	rule, err := validation.ParseDynamicMembershipRule(ruleString)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "rule", "Parsing dynamic membership rule")
+	}
+
+	keys := make(map[string]struct{})
+	matchingKeys := make([]string, 0)
+	results := make([]map[string]interface{}, 0)
+
+	for i, raw := range d.Get("subject").([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		in := raw.(map[string]interface{})
+		key := in["key"].(string)
+
+		if _, ok := keys[key]; ok {
+			return tf.ErrorDiagPathF(nil, "subject", "Duplicate subject key %q at index %d", key, i)
+		}
+		keys[key] = struct{}{}
+
+		subject := validation.DynamicMembershipRuleSubject{
+			Attributes:            make(map[string]string),
+			MultiValuedAttributes: make(map[string][]string),
+		}
+		for k, v := range in["attributes"].(map[string]interface{}) {
+			subject.Attributes[k] = v.(string)
+		}
+		for _, mv := range in["multi_valued_attribute"].([]interface{}) {
+			if mv == nil {
+				continue
+			}
+			attr := mv.(map[string]interface{})
+			subject.MultiValuedAttributes[attr["name"].(string)] = tf.ExpandStringSlice(attr["values"].([]interface{}))
+		}
+
+		matches, err := rule.Evaluate(subject)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "rule", "Evaluating dynamic membership rule for subject %q", key)
+		}
+
+		if matches {
+			matchingKeys = append(matchingKeys, key)
+		}
+		results = append(results, map[string]interface{}{
+			"key":     key,
+			"matches": matches,
+		})
+	}
+
+	h := sha1.New()
+	if _, err := h.Write([]byte(ruleString)); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for rule")
+	}
+
+	d.SetId("dynamicMembershipRuleEvaluation#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+
+	tf.Set(d, "object_type", rule.ObjectType)
+	tf.Set(d, "matching_keys", matchingKeys)
+	tf.Set(d, "results", results)
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package groups_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type DynamicMembershipRuleEvaluationDataSource struct{}
+
+func TestAccDynamicMembershipRuleEvaluationDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_dynamic_membership_rule_evaluation", "test")
+	r := DynamicMembershipRuleEvaluationDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(),
+			Check: acceptance.ComposeAggregateTestCheckFunc(
+				check.That(data.ResourceName).Key("object_type").HasValue("user"),
+				check.That(data.ResourceName).Key("matching_keys.#").HasValue("1"),
+				check.That(data.ResourceName).Key("matching_keys.0").HasValue("alice"),
+				check.That(data.ResourceName).Key("results.#").HasValue("2"),
+				check.That(data.ResourceName).Key("results.1.matches").HasValue("false"),
+			),
+		},
+	})
+}
+
+func TestAccDynamicMembershipRuleEvaluationDataSource_multiValued(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_dynamic_membership_rule_evaluation", "test")
+	r := DynamicMembershipRuleEvaluationDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.multiValued(),
+			Check: acceptance.ComposeAggregateTestCheckFunc(
+				check.That(data.ResourceName).Key("matching_keys.#").HasValue("1"),
+				check.That(data.ResourceName).Key("matching_keys.0").HasValue("bob"),
+			),
+		},
+	})
+}
+
+func TestAccDynamicMembershipRuleEvaluationDataSource_invalidRule(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_dynamic_membership_rule_evaluation", "test")
+	r := DynamicMembershipRuleEvaluationDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config:      r.invalidRule(),
+			ExpectError: regexp.MustCompile("unsupported user property"),
+		},
+	})
+}
+
+func (DynamicMembershipRuleEvaluationDataSource) basic() string {
+	return `
+data "azuread_dynamic_membership_rule_evaluation" "test" {
+  rule = "(user.department -eq \"Sales\") -and (user.accountEnabled -eq true)"
+
+  subject {
+    key = "alice"
+    attributes = {
+      department     = "Sales"
+      accountEnabled = "true"
+    }
+  }
+
+  subject {
+    key = "bob"
+    attributes = {
+      department     = "Marketing"
+      accountEnabled = "true"
+    }
+  }
+}
+`
+}
+
+func (DynamicMembershipRuleEvaluationDataSource) multiValued() string {
+	return `
+data "azuread_dynamic_membership_rule_evaluation" "test" {
+  rule = "user.proxyAddresses -any (_ -contains \"fabrikam\")"
+
+  subject {
+    key = "alice"
+
+    multi_valued_attribute {
+      name   = "proxyAddresses"
+      values = ["SMTP:alice@contoso.com"]
+    }
+  }
+
+  subject {
+    key = "bob"
+
+    multi_valued_attribute {
+      name   = "proxyAddresses"
+      values = ["SMTP:bob@contoso.com", "smtp:bob@fabrikam.com"]
+    }
+  }
+}
+`
+}
+
+func (DynamicMembershipRuleEvaluationDataSource) invalidRule() string {
+	return fmt.Sprintf(`
+data "azuread_dynamic_membership_rule_evaluation" "test" {
+  rule = %q
+
+  subject {
+    key = "alice"
+  }
+}
+`, `user.departmnet -eq "Sales"`)
+}
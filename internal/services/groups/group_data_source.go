@@ -19,6 +19,7 @@ import (
 	ownerBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/owner"
 	transitivememberBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/transitivemember"
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/directoryextensions"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
@@ -133,6 +134,15 @@ func groupDataSource() *pluginsdk.Resource {
 				Computed:    true,
 			},
 
+			"extension_attributes": {
+				Description: "A map of directory extension attribute values for the group, keyed by the full name of the extension property in the format `extension_{appId}_{name}`",
+				Type:        pluginsdk.TypeMap,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
 			"hide_from_address_lists": {
 				Description: "Indicates whether the group is displayed in certain parts of the Outlook user interface: in the Address Book, in address lists for selecting message recipients, and in the Browse Groups dialog for searching groups.",
 				Type:        pluginsdk.TypeBool,
@@ -261,6 +271,7 @@ func groupDataSource() *pluginsdk.Resource {
 
 func groupDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).Groups.GroupClientBeta
+	directoryObjectClient := meta.(*clients.Client).Groups.DirectoryObjectClient
 	memberClient := meta.(*clients.Client).Groups.GroupMemberClientBeta
 	ownerClient := meta.(*clients.Client).Groups.GroupOwnerClientBeta
 	transitiveMemberClient := meta.(*clients.Client).Groups.GroupTransitiveMemberClientBeta
@@ -496,5 +507,19 @@ func groupDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta in
 	}
 	tf.Set(d, "owners", owners)
 
+	extensionProperties, err := directoryextensions.ListAvailable(ctx, directoryObjectClient, directoryextensions.TargetObjectGroup)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not retrieve extension properties for group with object ID: %q", d.Id())
+	}
+	extensionValues, err := directoryextensions.Get(ctx, client.Client, id.ID(), extensionProperties)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not retrieve extension attributes for group with object ID: %q", d.Id())
+	}
+	extensionAttributes, err := directoryextensions.Flatten(extensionValues, extensionProperties, nil)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not flatten extension attributes for group with object ID: %q", d.Id())
+	}
+	tf.Set(d, "extension_attributes", extensionAttributes)
+
 	return nil
 }
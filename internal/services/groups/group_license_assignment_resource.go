@@ -0,0 +1,371 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package groups
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	groupBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/group"
+	memberswithlicenseerrorBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/memberswithlicenseerror"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/user"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/licensing"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/groups/parse"
+)
+
+// groupLicenseErrorsInspectLimit is the maximum number of members with license errors for which the license assignment
+// states are retrieved, in order to report the errors for a particular SKU
+const groupLicenseErrorsInspectLimit = 10
+
+func groupLicenseAssignmentResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: groupLicenseAssignmentResourceCreate,
+		ReadContext:   groupLicenseAssignmentResourceRead,
+		UpdateContext: groupLicenseAssignmentResourceUpdate,
+		DeleteContext: groupLicenseAssignmentResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.GroupLicenseAssignmentID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"group_object_id": {
+				Description:  "The object ID of the group to which the license should be assigned",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"sku_id": {
+				Description:  "The unique identifier of the SKU to assign",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"disabled_plans": {
+				Description: "A set of service plan IDs that should be disabled for members of the group",
+				Type:        pluginsdk.TypeSet,
+				Optional:    true,
+				Set:         pluginsdk.HashString,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.IsUUID,
+				},
+			},
+
+			"license_processing_state": {
+				Description: "The state of license processing for all members of the group",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func groupLicenseAssignmentResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Groups.GroupClientBeta
+
+	id := beta.NewGroupID(d.Get("group_object_id").(string))
+	skuId := d.Get("sku_id").(string)
+	resourceId := parse.NewGroupLicenseAssignmentID(id.GroupId, skuId)
+
+	tf.LockByName(groupResourceName, id.GroupId)
+	defer tf.UnlockByName(groupResourceName, id.GroupId)
+
+	group, err := groupGetLicenseProcessingState(ctx, client, id)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "group_object_id", "Retrieving %s", id)
+	}
+	if group == nil {
+		return tf.ErrorDiagPathF(nil, "group_object_id", "%s was not found", id)
+	}
+
+	if groupFindAssignedLicense(group.AssignedLicenses, skuId) != nil {
+		return tf.ImportAsExistsDiag("azuread_group_license_assignment", resourceId.String())
+	}
+
+	disabledPlans := tf.ExpandStringSlice(d.Get("disabled_plans").(*pluginsdk.Set).List())
+
+	if err = groupAssignLicense(ctx, client, id, skuId, disabledPlans); err != nil {
+		return tf.ErrorDiagF(err, "Assigning license for %s", resourceId)
+	}
+
+	d.SetId(resourceId.String())
+
+	if err = groupWaitForLicenseAssignment(ctx, client, id, skuId, disabledPlans); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for license assignment for %s", resourceId)
+	}
+
+	return groupLicenseAssignmentResourceRead(ctx, d, meta)
+}
+
+func groupLicenseAssignmentResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Groups.GroupClientBeta
+
+	resourceId, err := parse.GroupLicenseAssignmentID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Group License Assignment ID %q", d.Id())
+	}
+	id := beta.NewGroupID(resourceId.GroupId)
+
+	tf.LockByName(groupResourceName, id.GroupId)
+	defer tf.UnlockByName(groupResourceName, id.GroupId)
+
+	if d.HasChange("disabled_plans") {
+		disabledPlans := tf.ExpandStringSlice(d.Get("disabled_plans").(*pluginsdk.Set).List())
+
+		// Assigning a SKU that is already assigned replaces the disabled plans for that assignment
+		if err = groupAssignLicense(ctx, client, id, resourceId.SkuId, disabledPlans); err != nil {
+			return tf.ErrorDiagPathF(err, "disabled_plans", "Updating license assignment for %s", resourceId)
+		}
+
+		if err = groupWaitForLicenseAssignment(ctx, client, id, resourceId.SkuId, disabledPlans); err != nil {
+			return tf.ErrorDiagF(err, "Waiting for license assignment for %s", resourceId)
+		}
+	}
+
+	return groupLicenseAssignmentResourceRead(ctx, d, meta)
+}
+
+func groupLicenseAssignmentResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Groups.GroupClientBeta
+	membersWithLicenseErrorClient := meta.(*clients.Client).Groups.GroupMembersWithLicenseErrorClientBeta
+	userClient := meta.(*clients.Client).Groups.UserClient
+
+	resourceId, err := parse.GroupLicenseAssignmentID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Group License Assignment ID %q", d.Id())
+	}
+	id := beta.NewGroupID(resourceId.GroupId)
+
+	group, err := groupGetLicenseProcessingState(ctx, client, id)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+	if group == nil {
+		log.Printf("[DEBUG] %s was not found - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	license := groupFindAssignedLicense(group.AssignedLicenses, resourceId.SkuId)
+	if license == nil {
+		log.Printf("[DEBUG] License assignment for SKU %q was not found for %s - removing from state", resourceId.SkuId, id)
+		d.SetId("")
+		return nil
+	}
+
+	processingState := ""
+	if group.LicenseProcessingState != nil {
+		processingState = group.LicenseProcessingState.State.GetOrZero()
+	}
+
+	tf.Set(d, "group_object_id", id.GroupId)
+	tf.Set(d, "sku_id", resourceId.SkuId)
+	tf.Set(d, "disabled_plans", tf.FlattenStringSlicePtr(license.DisabledPlans))
+	tf.Set(d, "license_processing_state", processingState)
+
+	if !group.HasMembersWithLicenseErrors.GetOrZero() {
+		return nil
+	}
+
+	return groupLicenseErrorDiagnostics(ctx, membersWithLicenseErrorClient, userClient, id, resourceId.SkuId)
+}
+
+func groupLicenseAssignmentResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Groups.GroupClientBeta
+
+	resourceId, err := parse.GroupLicenseAssignmentID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Group License Assignment ID %q", d.Id())
+	}
+	id := beta.NewGroupID(resourceId.GroupId)
+
+	tf.LockByName(groupResourceName, id.GroupId)
+	defer tf.UnlockByName(groupResourceName, id.GroupId)
+
+	properties := groupBeta.AssignLicenseRequest{
+		AddLicenses:    &[]beta.AssignedLicense{},
+		RemoveLicenses: &[]string{resourceId.SkuId},
+	}
+
+	if resp, err := client.AssignLicense(ctx, id, properties, groupBeta.DefaultAssignLicenseOperationOptions()); err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Removing license assignment for %s", resourceId)
+	}
+
+	if err = consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
+		group, err := groupGetLicenseProcessingState(ctx, client, id)
+		if err != nil {
+			return nil, err
+		}
+		if group == nil {
+			return pointer.To(false), nil
+		}
+		return pointer.To(groupFindAssignedLicense(group.AssignedLicenses, resourceId.SkuId) != nil), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for removal of license assignment for %s", resourceId)
+	}
+
+	return nil
+}
+
+// groupGetLicenseProcessingState retrieves the assigned licenses and license processing state for a group, or nil if
+// the group was not found
+func groupGetLicenseProcessingState(ctx context.Context, client *groupBeta.GroupClient, id beta.GroupId) (*beta.Group, error) {
+	options := groupBeta.GetGroupOperationOptions{
+		Select: &[]string{"id", "assignedLicenses", "hasMembersWithLicenseErrors", "licenseProcessingState"},
+	}
+
+	resp, err := client.GetGroup(ctx, id, options)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return resp.Model, nil
+}
+
+func groupFindAssignedLicense(licenses *[]beta.AssignedLicense, skuId string) *beta.AssignedLicense {
+	if licenses == nil {
+		return nil
+	}
+	for _, license := range *licenses {
+		if strings.EqualFold(license.SkuId.GetOrZero(), skuId) {
+			l := license
+			return &l
+		}
+	}
+	return nil
+}
+
+func groupAssignLicense(ctx context.Context, client *groupBeta.GroupClient, id beta.GroupId, skuId string, disabledPlans []string) error {
+	properties := groupBeta.AssignLicenseRequest{
+		AddLicenses: &[]beta.AssignedLicense{
+			{
+				SkuId:         nullable.Value(skuId),
+				DisabledPlans: &disabledPlans,
+			},
+		},
+		RemoveLicenses: &[]string{},
+	}
+
+	_, err := client.AssignLicense(ctx, id, properties, groupBeta.DefaultAssignLicenseOperationOptions())
+	return err
+}
+
+// groupWaitForLicenseAssignment waits for a license assignment to be reported with the expected disabled plans
+func groupWaitForLicenseAssignment(ctx context.Context, client *groupBeta.GroupClient, id beta.GroupId, skuId string, disabledPlans []string) error {
+	return consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		group, err := groupGetLicenseProcessingState(ctx, client, id)
+		if err != nil {
+			return nil, err
+		}
+		if group == nil {
+			return nil, fmt.Errorf("%s was not found", id)
+		}
+
+		license := groupFindAssignedLicense(group.AssignedLicenses, skuId)
+		if license == nil {
+			return pointer.To(false), nil
+		}
+
+		return pointer.To(licensing.DisabledPlansMatch(pointer.From(license.DisabledPlans), disabledPlans)), nil
+	})
+}
+
+// groupLicenseErrorDiagnostics returns a warning describing the license processing errors for members of a group that
+// relate to the specified SKU. Since errors are reported per member, only a limited number of members are inspected.
+func groupLicenseErrorDiagnostics(ctx context.Context, client *memberswithlicenseerrorBeta.MembersWithLicenseErrorClient, userClient *user.UserClient, id beta.GroupId, skuId string) pluginsdk.Diagnostics {
+	options := memberswithlicenseerrorBeta.ListMembersWithLicenseErrorsOperationOptions{
+		Select: &[]string{"id"},
+		Top:    pointer.To(int64(999)),
+	}
+
+	resp, err := client.ListMembersWithLicenseErrors(ctx, id, options)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing members with license errors for %s", id)
+	}
+
+	members := pointer.From(resp.Model)
+	inspected := 0
+	failures := make([]string, 0)
+
+	for _, member := range members {
+		if inspected >= groupLicenseErrorsInspectLimit {
+			break
+		}
+
+		memberId := pointer.From(member.DirectoryObject().Id)
+		if memberId == "" {
+			continue
+		}
+		inspected++
+
+		userResp, err := userClient.GetUser(ctx, stable.NewUserID(memberId), user.GetUserOperationOptions{
+			Select: &[]string{"id", "licenseAssignmentStates"},
+		})
+		if err != nil {
+			if response.WasNotFound(userResp.HttpResponse) {
+				continue
+			}
+			return tf.ErrorDiagF(err, "Retrieving license assignment states for member %q of %s", memberId, id)
+		}
+		if userResp.Model == nil {
+			continue
+		}
+
+		state := licensing.FindAssignmentState(userResp.Model.LicenseAssignmentStates, skuId, id.GroupId)
+		if state == nil || !licensing.HasError(*state) {
+			continue
+		}
+
+		failures = append(failures, fmt.Sprintf("%s: %s", memberId, licensing.DescribeError(state.Error.GetOrZero())))
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	detail := fmt.Sprintf("License processing failed for the following members:\n\n%s", strings.Join(failures, "\n"))
+	if len(members) > inspected {
+		detail += fmt.Sprintf("\n\nOnly the first %d of %d members with license errors were inspected.", inspected, len(members))
+	}
+
+	return pluginsdk.Diagnostics{
+		pluginsdk.Diagnostic{
+			Severity: pluginsdk.DiagWarning,
+			Summary:  fmt.Sprintf("Members of %s have license errors for SKU %q", id, skuId),
+			Detail:   detail,
+		},
+	}
+}
@@ -0,0 +1,429 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package groups
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
+	groupBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/group"
+	memberBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/member"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/batch"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/groups/parse"
+)
+
+// groupMembersBindLimit is the maximum number of references that can be added in a single `members@odata.bind` request
+const groupMembersBindLimit = 20
+
+type GroupMembersModel struct {
+	GroupId string   `tfschema:"group_id"`
+	Members []string `tfschema:"members"`
+}
+
+var (
+	_ sdk.ResourceWithUpdate         = GroupMembersResource{}
+	_ sdk.ResourceWithCustomImporter = GroupMembersResource{}
+)
+
+type GroupMembersResource struct{}
+
+func (r GroupMembersResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return parse.ValidateGroupMembersID
+}
+
+func (r GroupMembersResource) ResourceType() string {
+	return "azuread_group_members"
+}
+
+func (r GroupMembersResource) ModelObject() interface{} {
+	return &GroupMembersModel{}
+}
+
+func (r GroupMembersResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"group_id": {
+			Description:  "The resource ID of the group whose members should be managed",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: beta.ValidateGroupID,
+		},
+
+		"members": {
+			Description: "A set of object IDs of principals that should be members of the group. Supported object types are Users, Groups or Service Principals",
+			Type:        pluginsdk.TypeSet,
+			Required:    true,
+			Set:         pluginsdk.HashString,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.IsUUID,
+			},
+		},
+	}
+}
+
+func (r GroupMembersResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r GroupMembersResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Groups.GroupClientBeta
+			memberClient := metadata.Client.Groups.GroupMemberClientBeta
+
+			var model GroupMembersModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			groupId, err := beta.ParseGroupID(model.GroupId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewGroupMembersID(groupId.GroupId)
+
+			tf.LockByName(groupResourceName, groupId.GroupId)
+			defer tf.UnlockByName(groupResourceName, groupId.GroupId)
+
+			if resp, err := client.GetGroup(ctx, *groupId, groupBeta.DefaultGetGroupOperationOptions()); err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return fmt.Errorf("%s was not found", groupId)
+				}
+				return fmt.Errorf("retrieving %s: %+v", groupId, err)
+			}
+
+			existingMembers, err := groupListMemberIds(ctx, memberClient, *groupId)
+			if err != nil {
+				return fmt.Errorf("listing existing members for %s: %+v", groupId, err)
+			}
+
+			if err = groupApplyMembers(ctx, client, *groupId, existingMembers, model.Members); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			if err = groupWaitForMembers(ctx, memberClient, *groupId, model.Members); err != nil {
+				return fmt.Errorf("waiting for %s to be updated: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r GroupMembersResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			memberClient := metadata.Client.Groups.GroupMemberClientBeta
+
+			id, err := parse.ParseGroupMembersID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			groupId := beta.NewGroupID(id.GroupId)
+
+			members, err := groupListMemberIds(ctx, memberClient, groupId)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if members == nil {
+				return metadata.MarkAsGone(id)
+			}
+
+			state := GroupMembersModel{
+				GroupId: groupId.ID(),
+				Members: members,
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r GroupMembersResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Groups.GroupClientBeta
+			memberClient := metadata.Client.Groups.GroupMemberClientBeta
+
+			id, err := parse.ParseGroupMembersID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model GroupMembersModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			groupId := beta.NewGroupID(id.GroupId)
+
+			tf.LockByName(groupResourceName, id.GroupId)
+			defer tf.UnlockByName(groupResourceName, id.GroupId)
+
+			if metadata.ResourceData.HasChange("members") {
+				existingMembers, err := groupListMemberIds(ctx, memberClient, groupId)
+				if err != nil {
+					return fmt.Errorf("listing existing members for %s: %+v", groupId, err)
+				}
+
+				if err = groupApplyMembers(ctx, client, groupId, existingMembers, model.Members); err != nil {
+					return fmt.Errorf("updating %s: %+v", id, err)
+				}
+
+				if err = groupWaitForMembers(ctx, memberClient, groupId, model.Members); err != nil {
+					return fmt.Errorf("waiting for %s to be updated: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r GroupMembersResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Groups.GroupClientBeta
+			memberClient := metadata.Client.Groups.GroupMemberClientBeta
+
+			id, err := parse.ParseGroupMembersID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model GroupMembersModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			groupId := beta.NewGroupID(id.GroupId)
+
+			tf.LockByName(groupResourceName, id.GroupId)
+			defer tf.UnlockByName(groupResourceName, id.GroupId)
+
+			existingMembers, err := groupListMemberIds(ctx, memberClient, groupId)
+			if err != nil {
+				return fmt.Errorf("listing existing members for %s: %+v", groupId, err)
+			}
+			if existingMembers == nil {
+				return nil
+			}
+
+			// Only remove the members we know about, leaving any that were added after the last refresh
+			desiredMembers := tf.Difference(existingMembers, model.Members)
+
+			if err = groupApplyMembers(ctx, client, groupId, existingMembers, desiredMembers); err != nil {
+				return fmt.Errorf("removing %s: %+v", id, err)
+			}
+
+			if err = groupWaitForMembers(ctx, memberClient, groupId, desiredMembers); err != nil {
+				return fmt.Errorf("waiting for removal of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r GroupMembersResource) CustomImporter() sdk.ResourceRunFunc {
+	return func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+		client := metadata.Client.Groups.GroupClientBeta
+
+		id, err := parse.ParseGroupMembersID(metadata.ResourceData.Id())
+		if err != nil {
+			return err
+		}
+
+		groupId := beta.NewGroupID(id.GroupId)
+
+		if resp, err := client.GetGroup(ctx, groupId, groupBeta.DefaultGetGroupOperationOptions()); err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return fmt.Errorf("importing %s: %s was not found", id, groupId)
+			}
+			return fmt.Errorf("importing %s: retrieving %s: %+v", id, groupId, err)
+		}
+
+		return metadata.ResourceData.Set("group_id", groupId.ID())
+	}
+}
+
+// groupListMemberIds returns the object IDs of all direct members of a group, or nil if the group was not found
+func groupListMemberIds(ctx context.Context, client *memberBeta.MemberClient, id beta.GroupId) ([]string, error) {
+	options := memberBeta.ListMembersOperationOptions{
+		Select: &[]string{"id"},
+		Top:    pointer.To(int64(999)),
+	}
+
+	resp, err := client.ListMembers(ctx, id, options)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	result := make([]string, 0)
+	if resp.Model != nil {
+		for _, member := range *resp.Model {
+			if memberId := pointer.From(member.DirectoryObject().Id); memberId != "" {
+				result = append(result, memberId)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// groupApplyMembers adds and removes members so that the group membership matches `desiredMembers`. Additions are
+// made using `members@odata.bind` in chunks of 20 references, and removals are made individually. All requests are
+// submitted using JSON batching to minimise the number of round trips.
+func groupApplyMembers(ctx context.Context, client *groupBeta.GroupClient, id beta.GroupId, existingMembers, desiredMembers []string) error {
+	membersForRemoval := tf.Difference(existingMembers, desiredMembers)
+	membersToAdd := tf.Difference(desiredMembers, existingMembers)
+
+	if len(membersForRemoval) == 0 && len(membersToAdd) == 0 {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Adding %d and removing %d members for %s", len(membersToAdd), len(membersForRemoval), id)
+
+	requests := make([]batch.Request, 0)
+	addChunks := batch.Chunk(membersToAdd, groupMembersBindLimit)
+
+	for i, chunk := range addChunks {
+		refs := make([]string, 0, len(chunk))
+		for _, v := range chunk {
+			refs = append(refs, client.Client.BaseUri+beta.NewDirectoryObjectID(v).ID())
+		}
+
+		requests = append(requests, batch.Request{
+			Id:     fmt.Sprintf("add-%d", i),
+			Method: http.MethodPatch,
+			Url:    id.ID(),
+			Body: beta.Group{
+				Members_ODataBind: &refs,
+			},
+		})
+	}
+
+	for _, v := range membersForRemoval {
+		requests = append(requests, batch.Request{
+			Id:     fmt.Sprintf("remove-%s", v),
+			Method: http.MethodDelete,
+			Url:    beta.NewGroupIdMemberID(id.GroupId, v).ID() + "/$ref",
+		})
+	}
+
+	responses, err := batch.Execute(ctx, client.Client, requests)
+	if err != nil {
+		return err
+	}
+
+	for _, req := range requests {
+		resp, ok := responses[req.Id]
+		if !ok {
+			return fmt.Errorf("no response received for batch request %q", req.Id)
+		}
+		if resp.Success() {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(req.Id, "remove-"):
+			// The member was already removed
+			if resp.Status == http.StatusNotFound {
+				continue
+			}
+			return fmt.Errorf("removing %s: %+v", beta.NewGroupIdMemberID(id.GroupId, strings.TrimPrefix(req.Id, "remove-")), resp.Error())
+
+		case strings.HasPrefix(req.Id, "add-"):
+			// A single existing reference fails the entire chunk, so fall back to adding these members one at a time
+			if resp.Status != http.StatusBadRequest || !strings.Contains(resp.Error().Error(), odata.ErrorAddedObjectReferencesAlreadyExist) {
+				return fmt.Errorf("adding members to %s: %+v", id, resp.Error())
+			}
+
+			i, err := strconv.Atoi(strings.TrimPrefix(req.Id, "add-"))
+			if err != nil {
+				return fmt.Errorf("parsing batch request ID %q: %+v", req.Id, err)
+			}
+			if err = groupAddMembersIndividually(ctx, client, id, addChunks[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func groupAddMembersIndividually(ctx context.Context, client *groupBeta.GroupClient, id beta.GroupId, members []string) error {
+	requests := make([]batch.Request, 0, len(members))
+	for _, v := range members {
+		requests = append(requests, batch.Request{
+			Id:     v,
+			Method: http.MethodPost,
+			Url:    id.ID() + "/members/$ref",
+			Body: beta.ReferenceCreate{
+				ODataId: pointer.To(client.Client.BaseUri + beta.NewDirectoryObjectID(v).ID()),
+			},
+		})
+	}
+
+	responses, err := batch.Execute(ctx, client.Client, requests)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range members {
+		resp, ok := responses[v]
+		if !ok {
+			return fmt.Errorf("no response received for batch request %q", v)
+		}
+		if resp.Success() {
+			continue
+		}
+		if resp.Status == http.StatusBadRequest && strings.Contains(resp.Error().Error(), odata.ErrorAddedObjectReferencesAlreadyExist) {
+			continue
+		}
+		return fmt.Errorf("adding %s: %+v", beta.NewGroupIdMemberID(id.GroupId, v), resp.Error())
+	}
+
+	return nil
+}
+
+// groupWaitForMembers waits for the listed members of a group to match `desiredMembers`, to account for replication delays
+func groupWaitForMembers(ctx context.Context, client *memberBeta.MemberClient, id beta.GroupId, desiredMembers []string) error {
+	return consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		members, err := groupListMemberIds(ctx, client, id)
+		if err != nil {
+			return nil, err
+		}
+		if members == nil {
+			return nil, fmt.Errorf("%s was not found", id)
+		}
+		return pointer.To(len(tf.Difference(members, desiredMembers)) == 0 && len(tf.Difference(desiredMembers, members)) == 0), nil
+	})
+}
@@ -0,0 +1,234 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package groups_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
+	memberBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/member"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/groups/parse"
+)
+
+type GroupMembersResource struct{}
+
+func TestAccGroupMembers_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group_members", "test")
+	r := GroupMembersResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("members.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccGroupMembers_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group_members", "test")
+	r := GroupMembersResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("members.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.mixed(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("members.#").HasValue("3"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("members.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccGroupMembers_manyUsers(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group_members", "test")
+	r := GroupMembersResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.manyUsers(data, 45),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("members.#").HasValue("45"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.manyUsers(data, 10),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("members.#").HasValue("10"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccGroupMembers_withOwners(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group_members", "test")
+	r := GroupMembersResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.withOwners(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("members.#").HasValue("1"),
+				check.That("azuread_group_owners.test").Key("owners.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			// Neither resource should propose changes to the other
+			Config:   r.withOwners(data),
+			PlanOnly: true,
+		},
+	})
+}
+
+func (r GroupMembersResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Groups.GroupMemberClientBeta
+
+	id, err := parse.ParseGroupMembersID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.ListMembers(ctx, beta.NewGroupID(id.GroupId), memberBeta.DefaultListMembersOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return pointer.To(resp.Model != nil && len(*resp.Model) > 0), nil
+}
+
+func (GroupMembersResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_group_without_members" "test" {
+  display_name     = "acctestGroup-%[1]d"
+  security_enabled = true
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestUser.%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[1]d"
+  password            = "%[2]s"
+}
+`, data.RandomInteger, data.RandomPassword)
+}
+
+func (r GroupMembersResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group_members" "test" {
+  group_id = azuread_group_without_members.test.id
+  members  = [azuread_user.test.object_id]
+}
+`, r.template(data))
+}
+
+func (r GroupMembersResource) mixed(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group" "member" {
+  display_name     = "acctestGroup-%[2]d-Member"
+  security_enabled = true
+}
+
+resource "azuread_application" "test" {
+  display_name = "acctestServicePrincipal-%[2]d"
+}
+
+resource "azuread_service_principal" "test" {
+  client_id = azuread_application.test.client_id
+}
+
+resource "azuread_group_members" "test" {
+  group_id = azuread_group_without_members.test.id
+  members = [
+    azuread_group.member.object_id,
+    azuread_service_principal.test.object_id,
+    azuread_user.test.object_id,
+  ]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r GroupMembersResource) withOwners(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group_members" "test" {
+  group_id = azuread_group_without_members.test.id
+  members  = [azuread_user.test.object_id]
+}
+
+resource "azuread_group_owners" "test" {
+  group_id = azuread_group_without_members.test.id
+  owners   = [azuread_user.test.object_id]
+}
+`, r.template(data))
+}
+
+func (GroupMembersResource) manyUsers(data acceptance.TestData, count int) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_group_without_members" "test" {
+  display_name     = "acctestGroup-%[1]d"
+  security_enabled = true
+}
+
+resource "azuread_user" "test" {
+  count               = 45
+  user_principal_name = "acctestUser.%[1]d.${count.index}@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[1]d-${count.index}"
+  password            = "%[2]s"
+}
+
+resource "azuread_group_members" "test" {
+  group_id = azuread_group_without_members.test.id
+  members  = slice(azuread_user.test[*].object_id, 0, %[3]d)
+}
+`, data.RandomInteger, data.RandomPassword, count)
+}
@@ -0,0 +1,355 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package groups
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
+	groupBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/group"
+	ownerBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/groups/beta/owner"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/groups/parse"
+)
+
+type GroupOwnersModel struct {
+	GroupId string   `tfschema:"group_id"`
+	Owners  []string `tfschema:"owners"`
+}
+
+var (
+	_ sdk.ResourceWithUpdate         = GroupOwnersResource{}
+	_ sdk.ResourceWithCustomImporter = GroupOwnersResource{}
+)
+
+type GroupOwnersResource struct{}
+
+func (r GroupOwnersResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return parse.ValidateGroupOwnersID
+}
+
+func (r GroupOwnersResource) ResourceType() string {
+	return "azuread_group_owners"
+}
+
+func (r GroupOwnersResource) ModelObject() interface{} {
+	return &GroupOwnersModel{}
+}
+
+func (r GroupOwnersResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"group_id": {
+			Description:  "The resource ID of the group whose owners should be managed",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: beta.ValidateGroupID,
+		},
+
+		"owners": {
+			Description: "A set of object IDs of principals that should be owners of the group. Supported object types are Users or Service Principals",
+			Type:        pluginsdk.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			MaxItems:    100,
+			Set:         pluginsdk.HashString,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.IsUUID,
+			},
+		},
+	}
+}
+
+func (r GroupOwnersResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r GroupOwnersResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Groups.GroupClientBeta
+			ownerClient := metadata.Client.Groups.GroupOwnerClientBeta
+
+			var model GroupOwnersModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			groupId, err := beta.ParseGroupID(model.GroupId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewGroupOwnersID(groupId.GroupId)
+
+			tf.LockByName(groupResourceName, groupId.GroupId)
+			defer tf.UnlockByName(groupResourceName, groupId.GroupId)
+
+			if resp, err := client.GetGroup(ctx, *groupId, groupBeta.DefaultGetGroupOperationOptions()); err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return fmt.Errorf("%s was not found", groupId)
+				}
+				return fmt.Errorf("retrieving %s: %+v", groupId, err)
+			}
+
+			existingOwners, err := groupListOwnerIds(ctx, ownerClient, *groupId)
+			if err != nil {
+				return fmt.Errorf("listing existing owners for %s: %+v", groupId, err)
+			}
+
+			if err = groupApplyOwners(ctx, ownerClient, *groupId, existingOwners, model.Owners); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			if err = groupWaitForOwners(ctx, ownerClient, *groupId, model.Owners); err != nil {
+				return fmt.Errorf("waiting for %s to be updated: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r GroupOwnersResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			ownerClient := metadata.Client.Groups.GroupOwnerClientBeta
+
+			id, err := parse.ParseGroupOwnersID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			groupId := beta.NewGroupID(id.GroupId)
+
+			owners, err := groupListOwnerIds(ctx, ownerClient, groupId)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if owners == nil {
+				return metadata.MarkAsGone(id)
+			}
+
+			state := GroupOwnersModel{
+				GroupId: groupId.ID(),
+				Owners:  owners,
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r GroupOwnersResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			ownerClient := metadata.Client.Groups.GroupOwnerClientBeta
+
+			id, err := parse.ParseGroupOwnersID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model GroupOwnersModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			groupId := beta.NewGroupID(id.GroupId)
+
+			tf.LockByName(groupResourceName, id.GroupId)
+			defer tf.UnlockByName(groupResourceName, id.GroupId)
+
+			if metadata.ResourceData.HasChange("owners") {
+				existingOwners, err := groupListOwnerIds(ctx, ownerClient, groupId)
+				if err != nil {
+					return fmt.Errorf("listing existing owners for %s: %+v", groupId, err)
+				}
+
+				if err = groupApplyOwners(ctx, ownerClient, groupId, existingOwners, model.Owners); err != nil {
+					return fmt.Errorf("updating %s: %+v", id, err)
+				}
+
+				if err = groupWaitForOwners(ctx, ownerClient, groupId, model.Owners); err != nil {
+					return fmt.Errorf("waiting for %s to be updated: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r GroupOwnersResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			ownerClient := metadata.Client.Groups.GroupOwnerClientBeta
+
+			id, err := parse.ParseGroupOwnersID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model GroupOwnersModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			groupId := beta.NewGroupID(id.GroupId)
+
+			tf.LockByName(groupResourceName, id.GroupId)
+			defer tf.UnlockByName(groupResourceName, id.GroupId)
+
+			existingOwners, err := groupListOwnerIds(ctx, ownerClient, groupId)
+			if err != nil {
+				return fmt.Errorf("listing existing owners for %s: %+v", groupId, err)
+			}
+			if existingOwners == nil {
+				return nil
+			}
+
+			// Only remove the owners we know about, leaving any that were added after the last refresh. A group cannot
+			// be left without any owners, so the calling principal is restored as the sole owner when necessary, in
+			// order to maintain consistency with the azuread_group resource.
+			desiredOwners := tf.Difference(existingOwners, model.Owners)
+			if len(desiredOwners) == 0 {
+				desiredOwners = []string{metadata.Client.ObjectID}
+			}
+
+			if err = groupApplyOwners(ctx, ownerClient, groupId, existingOwners, desiredOwners); err != nil {
+				return fmt.Errorf("removing %s: %+v", id, err)
+			}
+
+			if err = groupWaitForOwners(ctx, ownerClient, groupId, desiredOwners); err != nil {
+				return fmt.Errorf("waiting for removal of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r GroupOwnersResource) CustomImporter() sdk.ResourceRunFunc {
+	return func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+		client := metadata.Client.Groups.GroupClientBeta
+
+		id, err := parse.ParseGroupOwnersID(metadata.ResourceData.Id())
+		if err != nil {
+			return err
+		}
+
+		groupId := beta.NewGroupID(id.GroupId)
+
+		if resp, err := client.GetGroup(ctx, groupId, groupBeta.DefaultGetGroupOperationOptions()); err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return fmt.Errorf("importing %s: %s was not found", id, groupId)
+			}
+			return fmt.Errorf("importing %s: retrieving %s: %+v", id, groupId, err)
+		}
+
+		return metadata.ResourceData.Set("group_id", groupId.ID())
+	}
+}
+
+// groupListOwnerIds returns the object IDs of all owners of a group, or nil if the group was not found
+func groupListOwnerIds(ctx context.Context, client *ownerBeta.OwnerClient, id beta.GroupId) ([]string, error) {
+	options := ownerBeta.ListOwnersOperationOptions{
+		Select: &[]string{"id"},
+		Top:    pointer.To(int64(999)),
+	}
+
+	resp, err := client.ListOwners(ctx, id, options)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	result := make([]string, 0)
+	if resp.Model != nil {
+		for _, owner := range *resp.Model {
+			if ownerId := pointer.From(owner.DirectoryObject().Id); ownerId != "" {
+				result = append(result, ownerId)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// groupApplyOwners adds and removes owners so that the group ownership matches `desiredOwners`. New owners are added
+// before any are removed, to avoid leaving the group without any owners.
+func groupApplyOwners(ctx context.Context, client *ownerBeta.OwnerClient, id beta.GroupId, existingOwners, desiredOwners []string) error {
+	ownersForRemoval := tf.Difference(existingOwners, desiredOwners)
+	ownersToAdd := tf.Difference(desiredOwners, existingOwners)
+
+	log.Printf("[DEBUG] Adding %d and removing %d owners for %s", len(ownersToAdd), len(ownersForRemoval), id)
+
+	// Newly created principals may not have replicated yet
+	addOptions := ownerBeta.AddOwnerRefOperationOptions{
+		RetryFunc: func(resp *http.Response, _ *odata.OData) (bool, error) {
+			if response.WasNotFound(resp) {
+				return true, nil
+			}
+			return false, nil
+		},
+	}
+
+	for _, v := range ownersToAdd {
+		ref := beta.ReferenceCreate{
+			ODataId: pointer.To(client.Client.BaseUri + beta.NewDirectoryObjectID(v).ID()),
+		}
+		if resp, err := client.AddOwnerRef(ctx, id, ref, addOptions); err != nil {
+			if resp.HttpResponse != nil && resp.HttpResponse.StatusCode == http.StatusBadRequest && resp.OData != nil && resp.OData.Error != nil && resp.OData.Error.Match(odata.ErrorAddedObjectReferencesAlreadyExist) {
+				continue
+			}
+			return fmt.Errorf("adding %s: %+v", beta.NewGroupIdOwnerID(id.GroupId, v), err)
+		}
+	}
+
+	for _, v := range ownersForRemoval {
+		ownerId := beta.NewGroupIdOwnerID(id.GroupId, v)
+		if resp, err := client.RemoveOwnerRef(ctx, ownerId, ownerBeta.DefaultRemoveOwnerRefOperationOptions()); err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				continue
+			}
+			return fmt.Errorf("removing %s: %+v", ownerId, err)
+		}
+	}
+
+	return nil
+}
+
+// groupWaitForOwners waits for the listed owners of a group to match `desiredOwners`, to account for replication delays
+func groupWaitForOwners(ctx context.Context, client *ownerBeta.OwnerClient, id beta.GroupId, desiredOwners []string) error {
+	return consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		owners, err := groupListOwnerIds(ctx, client, id)
+		if err != nil {
+			return nil, err
+		}
+		if owners == nil {
+			return nil, fmt.Errorf("%s was not found", id)
+		}
+		return pointer.To(len(tf.Difference(owners, desiredOwners)) == 0 && len(tf.Difference(desiredOwners, owners)) == 0), nil
+	})
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/directoryextensions"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
@@ -135,10 +136,10 @@ func groupResource() *pluginsdk.Resource {
 						},
 
 						"rule": {
-							Description:  "Rule to determine members for a dynamic group. Required when `group_types` contains 'DynamicMembership'",
-							Type:         pluginsdk.TypeString,
-							Required:     true,
-							ValidateFunc: validation.StringLenBetween(0, 3072),
+							Description:      "Rule to determine members for a dynamic group. Required when `group_types` contains 'DynamicMembership'",
+							Type:             pluginsdk.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.IsDynamicMembershipRule,
 						},
 					},
 				},
@@ -151,6 +152,16 @@ func groupResource() *pluginsdk.Resource {
 				Computed:    true,
 			},
 
+			"extension_attributes": {
+				Description:      "A map of directory extension attribute values for the group, keyed by the full name of the extension property in the format `extension_{appId}_{name}`",
+				Type:             pluginsdk.TypeMap,
+				Optional:         true,
+				ValidateDiagFunc: validation.MapKeyMatch(directoryextensions.NameRegex, "must be the full name of a directory extension property, in the format `extension_{appId}_{name}`"),
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
 			"hide_from_address_lists": {
 				Description: "Indicates whether the group is displayed in certain parts of the Outlook user interface: in the Address Book, in address lists for selecting message recipients, and in the Browse Groups dialog for searching groups.",
 				Type:        pluginsdk.TypeBool,
@@ -492,6 +503,17 @@ func groupResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta in
 
 	description := d.Get("description").(string)
 
+	// Validate extension attributes against their declared data types before creating the group
+	configuredExtensionAttributes := d.Get("extension_attributes").(map[string]interface{})
+	extensionProperties, err := directoryextensions.ListOwned(ctx, directoryObjectClient, directoryextensions.TargetObjectGroup, configuredExtensionAttributes)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "extension_attributes", "Could not retrieve extension properties")
+	}
+	extensionAttributes, err := directoryextensions.Expand(configuredExtensionAttributes, nil, extensionProperties)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "extension_attributes", "Could not set extension attributes")
+	}
+
 	properties := beta.Group{
 		Description:                 nullable.NoZero(description),
 		DisplayName:                 nullable.Value(displayName),
@@ -904,6 +926,10 @@ func groupResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta in
 		}
 	}
 
+	if err = directoryextensions.Update(ctx, client.Client, id.ID(), extensionAttributes); err != nil {
+		return tf.ErrorDiagPathF(err, "extension_attributes", "Could not set extension attributes for %s", id)
+	}
+
 	enableRetries := false
 	if _, ok := d.GetOk("administrative_unit_ids"); ok {
 		// It has been observed that when creating a group within an administrative unit and querying the group with the `/groups` endpoint whilst
@@ -919,6 +945,7 @@ func groupResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta in
 	ownerClient := meta.(*clients.Client).Groups.GroupOwnerClientBeta
 	memberClient := meta.(*clients.Client).Groups.GroupMemberClientBeta
 	memberOfClient := meta.(*clients.Client).Groups.GroupMemberOfClientBeta
+	directoryObjectClient := meta.(*clients.Client).Groups.DirectoryObjectClient
 	administrativeUnitMemberClient := meta.(*clients.Client).Groups.AdministrativeUnitMemberClientBeta
 
 	id, err := beta.ParseGroupID(d.Id())
@@ -1207,6 +1234,21 @@ func groupResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta in
 		}
 	}
 
+	if d.HasChange("extension_attributes") {
+		oldExtensionAttributes, newExtensionAttributes := d.GetChange("extension_attributes")
+		extensionProperties, err := directoryextensions.ListOwned(ctx, directoryObjectClient, directoryextensions.TargetObjectGroup, oldExtensionAttributes.(map[string]interface{}), newExtensionAttributes.(map[string]interface{}))
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "extension_attributes", "Could not retrieve extension properties for %s", id)
+		}
+		payload, err := directoryextensions.Expand(newExtensionAttributes.(map[string]interface{}), oldExtensionAttributes.(map[string]interface{}), extensionProperties)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "extension_attributes", "Could not set extension attributes for %s", id)
+		}
+		if err = directoryextensions.Update(ctx, client.Client, id.ID(), payload); err != nil {
+			return tf.ErrorDiagPathF(err, "extension_attributes", "Could not set extension attributes for %s", id)
+		}
+	}
+
 	return groupResourceReadFunc(false)(ctx, d, meta)
 }
 
@@ -1219,6 +1261,7 @@ func groupResourceReadFunc(enableRetries bool) pluginsdk.ReadContextFunc {
 		ownerClient := meta.(*clients.Client).Groups.GroupOwnerClientBeta
 		memberClient := meta.(*clients.Client).Groups.GroupMemberClientBeta
 		memberOfClient := meta.(*clients.Client).Groups.GroupMemberOfClientBeta
+		directoryObjectClient := meta.(*clients.Client).Groups.DirectoryObjectClient
 
 		id, err := beta.ParseGroupID(d.Id())
 		if err != nil {
@@ -1344,6 +1387,24 @@ func groupResourceReadFunc(enableRetries bool) pluginsdk.ReadContextFunc {
 		}
 		tf.Set(d, "administrative_unit_ids", administrativeUnitIds)
 
+		// Only extension attributes owned by the same applications as those already being managed are tracked, so that
+		// extension attributes belonging to unrelated applications do not cause a diff
+		if configuredExtensionAttributes := d.Get("extension_attributes").(map[string]interface{}); len(configuredExtensionAttributes) > 0 {
+			extensionProperties, err := directoryextensions.ListOwned(ctx, directoryObjectClient, directoryextensions.TargetObjectGroup, configuredExtensionAttributes)
+			if err != nil {
+				return tf.ErrorDiagPathF(err, "extension_attributes", "Could not retrieve extension properties for %s", id)
+			}
+			extensionValues, err := directoryextensions.Get(ctx, client.Client, id.ID(), extensionProperties)
+			if err != nil {
+				return tf.ErrorDiagPathF(err, "extension_attributes", "Could not retrieve extension attributes for %s", id)
+			}
+			extensionAttributes, err := directoryextensions.Flatten(extensionValues, extensionProperties, configuredExtensionAttributes)
+			if err != nil {
+				return tf.ErrorDiagPathF(err, "extension_attributes", "Could not flatten extension attributes for %s", id)
+			}
+			tf.Set(d, "extension_attributes", extensionAttributes)
+		}
+
 		preventDuplicates := false
 		if v := d.Get("prevent_duplicate_names").(bool); v {
 			preventDuplicates = v
@@ -133,6 +133,33 @@ func TestAccGroup_behaviors(t *testing.T) {
 	})
 }
 
+func TestAccGroup_extensionAttributes(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.extensionAttributes(data, `
+    (azuread_application_extension_property.is_restricted.extension_name) = "true"
+    (azuread_application_extension_property.projects.extension_name)      = jsonencode(["Alpine", "Baker"])
+`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("extension_attributes.%").HasValue("2"),
+			),
+		},
+		data.ImportStep("extension_attributes"),
+		{
+			Config: r.extensionAttributes(data, ""),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("extension_attributes.%").HasValue("0"),
+			),
+		},
+		data.ImportStep("extension_attributes"),
+	})
+}
+
 func TestAccGroup_dynamicMembership(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_group", "test")
 	r := GroupResource{}
@@ -778,6 +805,38 @@ resource "azuread_group" "test" {
 `, data.RandomInteger)
 }
 
+func (GroupResource) extensionAttributes(data acceptance.TestData, attributes string) string {
+	return fmt.Sprintf(`
+resource "azuread_application_registration" "test" {
+  display_name = "acctest-GroupExtensions-%[1]d"
+}
+
+resource "azuread_application_extension_property" "is_restricted" {
+  application_id = azuread_application_registration.test.id
+  name           = "isRestricted"
+  data_type      = "Boolean"
+  target_objects = ["Group"]
+}
+
+resource "azuread_application_extension_property" "projects" {
+  application_id = azuread_application_registration.test.id
+  name           = "projects"
+  data_type      = "String"
+  multi_valued   = true
+  target_objects = ["Group"]
+}
+
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[1]d"
+  security_enabled = true
+
+  extension_attributes = {
+%[2]s
+  }
+}
+`, data.RandomInteger, attributes)
+}
+
 func (GroupResource) dynamicMembership(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 resource "azuread_group" "test" {
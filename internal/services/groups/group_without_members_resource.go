@@ -123,10 +123,10 @@ func groupWithoutMembersResource() *pluginsdk.Resource {
 						},
 
 						"rule": {
-							Description:  "Rule to determine members for a dynamic group. Required when `group_types` contains 'DynamicMembership'",
-							Type:         pluginsdk.TypeString,
-							Required:     true,
-							ValidateFunc: validation.StringLenBetween(0, 3072),
+							Description:      "Rule to determine members for a dynamic group. Required when `group_types` contains 'DynamicMembership'",
+							Type:             pluginsdk.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.IsDynamicMembershipRule,
 						},
 					},
 				},
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+type GroupMembersId struct {
+	GroupId string
+}
+
+func NewGroupMembersID(groupId string) *GroupMembersId {
+	return &GroupMembersId{
+		GroupId: groupId,
+	}
+}
+
+// ParseGroupMembersID parses 'input' into a GroupMembersId
+func ParseGroupMembersID(input string) (*GroupMembersId, error) {
+	parser := resourceids.NewParserFromResourceIdType(&GroupMembersId{})
+	parsed, err := parser.Parse(input, false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	id := &GroupMembersId{}
+	if err = id.FromParseResult(*parsed); err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+// ValidateGroupMembersID checks that 'input' can be parsed as a Group Members ID
+func ValidateGroupMembersID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	id, err := ParseGroupMembersID(v)
+	if err != nil {
+		errors = append(errors, err)
+		return
+	}
+
+	return validation.IsUUID(id.GroupId, "ID")
+}
+
+func (id *GroupMembersId) ID() string {
+	fmtString := "/groups/%s/members"
+	return fmt.Sprintf(fmtString, id.GroupId)
+}
+
+// Segments returns a slice of Resource ID Segments which comprise this ID
+func (id *GroupMembersId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("groups", "groups", "groups"),
+		resourceids.UserSpecifiedSegment("groupId", "00000000-0000-0000-0000-000000000000"),
+		resourceids.StaticSegment("members", "members", "members"),
+	}
+}
+
+func (id *GroupMembersId) String() string {
+	return fmt.Sprintf("Group Members (Group ID: %q)", id.GroupId)
+}
+
+func (id *GroupMembersId) FromParseResult(input resourceids.ParseResult) error {
+	var ok bool
+
+	if id.GroupId, ok = input.Parsed["groupId"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "groupId", input)
+	}
+
+	return nil
+}
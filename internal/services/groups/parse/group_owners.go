@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+type GroupOwnersId struct {
+	GroupId string
+}
+
+func NewGroupOwnersID(groupId string) *GroupOwnersId {
+	return &GroupOwnersId{
+		GroupId: groupId,
+	}
+}
+
+// ParseGroupOwnersID parses 'input' into a GroupOwnersId
+func ParseGroupOwnersID(input string) (*GroupOwnersId, error) {
+	parser := resourceids.NewParserFromResourceIdType(&GroupOwnersId{})
+	parsed, err := parser.Parse(input, false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	id := &GroupOwnersId{}
+	if err = id.FromParseResult(*parsed); err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+// ValidateGroupOwnersID checks that 'input' can be parsed as a Group Owners ID
+func ValidateGroupOwnersID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	id, err := ParseGroupOwnersID(v)
+	if err != nil {
+		errors = append(errors, err)
+		return
+	}
+
+	return validation.IsUUID(id.GroupId, "ID")
+}
+
+func (id *GroupOwnersId) ID() string {
+	fmtString := "/groups/%s/owners"
+	return fmt.Sprintf(fmtString, id.GroupId)
+}
+
+// Segments returns a slice of Resource ID Segments which comprise this ID
+func (id *GroupOwnersId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("groups", "groups", "groups"),
+		resourceids.UserSpecifiedSegment("groupId", "00000000-0000-0000-0000-000000000000"),
+		resourceids.StaticSegment("owners", "owners", "owners"),
+	}
+}
+
+func (id *GroupOwnersId) String() string {
+	return fmt.Sprintf("Group Owners (Group ID: %q)", id.GroupId)
+}
+
+func (id *GroupOwnersId) FromParseResult(input resourceids.ParseResult) error {
+	var ok bool
+
+	if id.GroupId, ok = input.Parsed["groupId"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "groupId", input)
+	}
+
+	return nil
+}
@@ -3,7 +3,10 @@
 
 package groups
 
-import "github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+import (
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+)
 
 type Registration struct{}
 
@@ -27,6 +30,7 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
+		"azuread_dynamic_membership_rule_evaluation": dynamicMembershipRuleEvaluationDataSource(),
 		"azuread_group":  groupDataSource(),
 		"azuread_groups": groupsDataSource(),
 	}
@@ -35,8 +39,22 @@ func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azuread_group":                 groupResource(),
-		"azuread_group_without_members": groupWithoutMembersResource(),
-		"azuread_group_member":          groupMemberResource(),
+		"azuread_group":                    groupResource(),
+		"azuread_group_license_assignment": groupLicenseAssignmentResource(),
+		"azuread_group_without_members":    groupWithoutMembersResource(),
+		"azuread_group_member":             groupMemberResource(),
+	}
+}
+
+// DataSources returns the typed DataSources supported by this service
+func (r Registration) DataSources() []sdk.DataSource {
+	return []sdk.DataSource{}
+}
+
+// Resources returns the typed Resources supported by this service
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		GroupMembersResource{},
+		GroupOwnersResource{},
 	}
 }
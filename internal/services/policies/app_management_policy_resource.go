@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/beta/appmanagementpolicy"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func appManagementPolicyResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: appManagementPolicyResourceCreate,
+		ReadContext:   appManagementPolicyResourceRead,
+		UpdateContext: appManagementPolicyResourceUpdate,
+		DeleteContext: appManagementPolicyResourceDelete,
+
+		CustomizeDiff: appManagementPolicyResourceCustomizeDiff,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			if _, errs := beta.ValidatePolicyAppManagementPolicyID(id, "id"); len(errs) > 0 {
+				out := ""
+				for _, err := range errs {
+					out += err.Error()
+				}
+				return errors.New(out)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"display_name": {
+				Description:  "Display name for this policy",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"description": {
+				Description:  "Description for this policy",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"enabled": {
+				Description: "Whether the policy is enforced for the applications and service principals to which it is assigned",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+
+			"restrictions": {
+				Description: "The credential restrictions enforced by this policy",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &pluginsdk.Resource{
+					Schema: appManagementRestrictionsSchema(),
+				},
+			},
+		},
+	}
+}
+
+func appManagementPolicyResourceCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+	return validateAppManagementRestrictions("restrictions", diff.Get("restrictions").([]interface{}))
+}
+
+func appManagementPolicyResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.AppManagementPolicyClientBeta
+
+	keyCredentials, passwordCredentials := expandAppManagementRestrictions(d.Get("restrictions").([]interface{}))
+
+	properties := beta.AppManagementPolicy{
+		Description: d.Get("description").(string),
+		DisplayName: d.Get("display_name").(string),
+		IsEnabled:   pointer.To(d.Get("enabled").(bool)),
+		Restrictions: &beta.CustomAppManagementConfiguration{
+			KeyCredentials:      keyCredentials,
+			PasswordCredentials: passwordCredentials,
+		},
+	}
+
+	resp, err := client.CreateAppManagementPolicy(ctx, properties, appmanagementpolicy.DefaultCreateAppManagementPolicyOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not create App Management Policy")
+	}
+
+	appManagementPolicy := resp.Model
+	if appManagementPolicy == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Could not create App Management Policy")
+	}
+	if appManagementPolicy.Id == nil {
+		return tf.ErrorDiagF(errors.New("model return with nil ID"), "Could not create App Management Policy")
+	}
+
+	id := beta.NewPolicyAppManagementPolicyID(*appManagementPolicy.Id)
+	d.SetId(id.ID())
+
+	return appManagementPolicyResourceRead(ctx, d, meta)
+}
+
+func appManagementPolicyResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.AppManagementPolicyClientBeta
+
+	id, err := beta.ParsePolicyAppManagementPolicyID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing ID")
+	}
+
+	resp, err := client.GetAppManagementPolicy(ctx, *id, appmanagementpolicy.DefaultGetAppManagementPolicyOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s - removing from state!", id)
+			d.SetId("")
+			return nil
+		}
+
+		return tf.ErrorDiagF(err, "retrieving %s", id)
+	}
+
+	appManagementPolicy := resp.Model
+	if appManagementPolicy == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+	}
+
+	restrictions := make([]interface{}, 0)
+	if appManagementPolicy.Restrictions != nil {
+		restrictions = flattenAppManagementRestrictions(appManagementPolicy.Restrictions.KeyCredentials, appManagementPolicy.Restrictions.PasswordCredentials)
+	}
+
+	tf.Set(d, "description", appManagementPolicy.Description)
+	tf.Set(d, "display_name", appManagementPolicy.DisplayName)
+	tf.Set(d, "enabled", pointer.From(appManagementPolicy.IsEnabled))
+	tf.Set(d, "restrictions", restrictions)
+
+	return nil
+}
+
+func appManagementPolicyResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.AppManagementPolicyClientBeta
+
+	id, err := beta.ParsePolicyAppManagementPolicyID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing ID")
+	}
+
+	keyCredentials, passwordCredentials := expandAppManagementRestrictions(d.Get("restrictions").([]interface{}))
+
+	properties := beta.AppManagementPolicy{
+		Description: d.Get("description").(string),
+		DisplayName: d.Get("display_name").(string),
+		IsEnabled:   pointer.To(d.Get("enabled").(bool)),
+		Restrictions: &beta.CustomAppManagementConfiguration{
+			KeyCredentials:      keyCredentials,
+			PasswordCredentials: passwordCredentials,
+		},
+	}
+
+	if _, err := client.UpdateAppManagementPolicy(ctx, *id, properties, appmanagementpolicy.DefaultUpdateAppManagementPolicyOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Could not update %s", id)
+	}
+
+	return appManagementPolicyResourceRead(ctx, d, meta)
+}
+
+func appManagementPolicyResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.AppManagementPolicyClientBeta
+
+	id, err := beta.ParsePolicyAppManagementPolicyID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing ID")
+	}
+
+	if _, err := client.DeleteAppManagementPolicy(ctx, *id, appmanagementpolicy.DefaultDeleteAppManagementPolicyOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Deleting %s", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/appmanagementpolicy"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+)
+
+func applicationAppManagementPolicyAssignmentResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: applicationAppManagementPolicyAssignmentResourceCreate,
+		ReadContext:   applicationAppManagementPolicyAssignmentResourceRead,
+		DeleteContext: applicationAppManagementPolicyAssignmentResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			if _, errs := stable.ValidateApplicationIdAppManagementPolicyID(id, "id"); len(errs) > 0 {
+				out := ""
+				for _, err := range errs {
+					out += err.Error()
+				}
+				return errors.New(out)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"app_management_policy_id": {
+				Description:  "ID of the app management policy to assign",
+				Type:         pluginsdk.TypeString,
+				ForceNew:     true,
+				Required:     true,
+				ValidateFunc: stable.ValidatePolicyAppManagementPolicyID,
+			},
+
+			"application_id": {
+				Description:  "The resource ID of the application to which the policy should be assigned",
+				Type:         pluginsdk.TypeString,
+				ForceNew:     true,
+				Required:     true,
+				ValidateFunc: stable.ValidateApplicationID,
+			},
+		},
+	}
+}
+
+func applicationAppManagementPolicyAssignmentResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.ApplicationAppManagementPolicyClient
+
+	applicationId, err := stable.ParseApplicationID(d.Get("application_id").(string))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "application_id", "Parsing `application_id`")
+	}
+
+	policyId, err := stable.ParsePolicyAppManagementPolicyID(d.Get("app_management_policy_id").(string))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "app_management_policy_id", "Parsing `app_management_policy_id`")
+	}
+
+	ref := stable.ReferenceCreate{
+		ODataId: pointer.To(client.Client.BaseUri + stable.NewDirectoryObjectID(policyId.AppManagementPolicyId).ID()),
+	}
+
+	if _, err := client.AddAppManagementPolicyRef(ctx, *applicationId, ref, appmanagementpolicy.DefaultAddAppManagementPolicyRefOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Creating AppManagementPolicyAssignment for %s", applicationId)
+	}
+
+	id := stable.NewApplicationIdAppManagementPolicyID(applicationId.ApplicationId, policyId.AppManagementPolicyId)
+	d.SetId(id.ID())
+
+	return applicationAppManagementPolicyAssignmentResourceRead(ctx, d, meta)
+}
+
+func applicationAppManagementPolicyAssignmentResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.ApplicationAppManagementPolicyClient
+
+	id, err := stable.ParseApplicationIdAppManagementPolicyID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing App Management Policy Assignment ID %q", d.Id())
+	}
+
+	policyId := stable.NewPolicyAppManagementPolicyID(id.AppManagementPolicyId)
+	applicationId := stable.NewApplicationID(id.ApplicationId)
+
+	resp, err := client.ListAppManagementPolicies(ctx, applicationId, appmanagementpolicy.DefaultListAppManagementPoliciesOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing app management policy assignment from state!", applicationId)
+			d.SetId("")
+			return nil
+		}
+
+		return tf.ErrorDiagF(err, "listing App Management Policy Assignments for %s", applicationId)
+	}
+
+	policies := resp.Model
+	if policies == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "listing App Management Policy Assignments for %s", applicationId)
+	}
+
+	var policy *stable.AppManagementPolicy
+
+	// Check the assignment is found in the currently assigned policies
+	for _, p := range *policies {
+		if pointer.From(p.Id) == id.AppManagementPolicyId {
+			policy = &p
+			break
+		}
+	}
+	if policy == nil {
+		d.SetId("")
+		log.Printf("[DEBUG] App Management Policy with Object ID %q was not found - removing assignment from state!", id.AppManagementPolicyId)
+		return nil
+	}
+
+	tf.Set(d, "app_management_policy_id", policyId.ID())
+	tf.Set(d, "application_id", applicationId.ID())
+
+	return nil
+}
+
+func applicationAppManagementPolicyAssignmentResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.ApplicationAppManagementPolicyClient
+
+	id, err := stable.ParseApplicationIdAppManagementPolicyID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing App Management Policy Assignment ID %q", d.Id())
+	}
+
+	if _, err = client.RemoveAppManagementPolicyRef(ctx, *id, appmanagementpolicy.DefaultRemoveAppManagementPolicyRefOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "removing %s", id)
+	}
+
+	return nil
+}
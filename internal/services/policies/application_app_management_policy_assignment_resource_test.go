@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/appmanagementpolicy"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type ApplicationAppManagementPolicyAssignmentResource struct{}
+
+func TestAccApplicationAppManagementPolicyAssignment_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_app_management_policy_assignment", "test")
+	r := ApplicationAppManagementPolicyAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r ApplicationAppManagementPolicyAssignmentResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Policies.ApplicationAppManagementPolicyClient
+
+	id, err := stable.ParseApplicationIdAppManagementPolicyID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing App Management Policy Assignment ID: %v", err)
+	}
+
+	applicationId := stable.NewApplicationID(id.ApplicationId)
+
+	resp, err := client.ListAppManagementPolicies(ctx, applicationId, appmanagementpolicy.DefaultListAppManagementPoliciesOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, fmt.Errorf("%s does not exist", applicationId)
+		}
+		return nil, fmt.Errorf("failed to retrieve app management policy assignments for %s: %+v", applicationId, err)
+	}
+
+	if resp.Model != nil {
+		for _, p := range *resp.Model {
+			if pointer.From(p.Id) == id.AppManagementPolicyId {
+				return pointer.To(true), nil
+			}
+		}
+	}
+
+	return pointer.To(false), nil
+}
+
+func (ApplicationAppManagementPolicyAssignmentResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+}
+
+resource "azuread_app_management_policy" "test" {
+  display_name = "acctest-%[2]s"
+  description  = "Acceptance test policy"
+
+  restrictions {
+    password_credentials {
+      restriction_type                = "passwordAddition"
+      restrict_for_apps_created_after = "2020-01-01T00:00:00Z"
+    }
+  }
+}
+
+resource "azuread_application_app_management_policy_assignment" "test" {
+  application_id           = azuread_application.test.id
+  app_management_policy_id = azuread_app_management_policy.test.id
+}
+`, data.RandomInteger, data.RandomString)
+}
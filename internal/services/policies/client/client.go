@@ -4,21 +4,44 @@
 package client
 
 import (
+	applicationAppManagementPolicy "github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/appmanagementpolicy"
+	appmanagementpolicyBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/beta/appmanagementpolicy"
+	defaultappmanagementpolicyBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/beta/defaultappmanagementpolicy"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/authenticationstrengthpolicy"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/claimsmappingpolicy"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/homerealmdiscoverypolicy"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/rolemanagementpolicy"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/rolemanagementpolicyassignment"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/tokenlifetimepolicy"
 	"github.com/hashicorp/terraform-provider-azuread/internal/common"
 )
 
 type Client struct {
+	AppManagementPolicyClientBeta        *appmanagementpolicyBeta.AppManagementPolicyClient
+	ApplicationAppManagementPolicyClient *applicationAppManagementPolicy.AppManagementPolicyClient
 	AuthenticationStrengthPolicyClient   *authenticationstrengthpolicy.AuthenticationStrengthPolicyClient
 	ClaimsMappingPolicyClient            *claimsmappingpolicy.ClaimsMappingPolicyClient
+	DefaultAppManagementPolicyClientBeta *defaultappmanagementpolicyBeta.DefaultAppManagementPolicyClient
+	HomeRealmDiscoveryPolicyClient       *homerealmdiscoverypolicy.HomeRealmDiscoveryPolicyClient
 	RoleManagementPolicyAssignmentClient *rolemanagementpolicyassignment.RoleManagementPolicyAssignmentClient
 	RoleManagementPolicyClient           *rolemanagementpolicy.RoleManagementPolicyClient
+	TokenLifetimePolicyClient            *tokenlifetimepolicy.TokenLifetimePolicyClient
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
+	// Needed because v1.0 API doesn't support `trustedCertificateAuthority` key credential restrictions
+	appManagementPolicyClientBeta, err := appmanagementpolicyBeta.NewAppManagementPolicyClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(appManagementPolicyClientBeta.Client)
+
+	applicationAppManagementPolicyClient, err := applicationAppManagementPolicy.NewAppManagementPolicyClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(applicationAppManagementPolicyClient.Client)
+
 	authenticationStrengthpolicyClient, err := authenticationstrengthpolicy.NewAuthenticationStrengthPolicyClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
@@ -31,6 +54,19 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(claimsMappingPolicyClient.Client)
 
+	// Needed because v1.0 API doesn't support `trustedCertificateAuthority` key credential restrictions
+	defaultAppManagementPolicyClientBeta, err := defaultappmanagementpolicyBeta.NewDefaultAppManagementPolicyClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(defaultAppManagementPolicyClientBeta.Client)
+
+	homeRealmDiscoveryPolicyClient, err := homerealmdiscoverypolicy.NewHomeRealmDiscoveryPolicyClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(homeRealmDiscoveryPolicyClient.Client)
+
 	roleManagementPolicyAssignmentClient, err := rolemanagementpolicyassignment.NewRoleManagementPolicyAssignmentClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
@@ -43,10 +79,21 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(roleManagementPolicyClient.Client)
 
+	tokenLifetimePolicyClient, err := tokenlifetimepolicy.NewTokenLifetimePolicyClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(tokenLifetimePolicyClient.Client)
+
 	return &Client{
+		AppManagementPolicyClientBeta:        appManagementPolicyClientBeta,
+		ApplicationAppManagementPolicyClient: applicationAppManagementPolicyClient,
 		AuthenticationStrengthPolicyClient:   authenticationStrengthpolicyClient,
 		ClaimsMappingPolicyClient:            claimsMappingPolicyClient,
+		DefaultAppManagementPolicyClientBeta: defaultAppManagementPolicyClientBeta,
+		HomeRealmDiscoveryPolicyClient:       homeRealmDiscoveryPolicyClient,
 		RoleManagementPolicyAssignmentClient: roleManagementPolicyAssignmentClient,
 		RoleManagementPolicyClient:           roleManagementPolicyClient,
+		TokenLifetimePolicyClient:            tokenLifetimePolicyClient,
 	}, nil
 }
@@ -9,3 +9,6 @@ const (
 )
 
 var possibleValuesForRoleDefinitionId = []string{RoleDefinitionIdMember, RoleDefinitionIdOwner}
+
+// TenantAppManagementPolicyId is the ID of the singleton tenant-wide app management policy
+const TenantAppManagementPolicyId = "/policies/defaultAppManagementPolicy"
@@ -0,0 +1,301 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/homerealmdiscoverypolicy"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func homeRealmDiscoveryPolicyResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: homeRealmDiscoveryPolicyResourceCreate,
+		ReadContext:   homeRealmDiscoveryPolicyResourceRead,
+		UpdateContext: homeRealmDiscoveryPolicyResourceUpdate,
+		DeleteContext: homeRealmDiscoveryPolicyResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			if _, errs := stable.ValidatePolicyHomeRealmDiscoveryPolicyID(id, "id"); len(errs) > 0 {
+				out := ""
+				for _, err := range errs {
+					out += err.Error()
+				}
+				return errors.New(out)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"display_name": {
+				Description:  "Display name for this policy",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"definition": {
+				Description: "The rules and settings for this policy",
+				Type:        pluginsdk.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"accelerate_to_federated_domain": {
+							Description: "Whether users are sent directly to the federated identity provider for sign in, when the tenant has a single federated domain or `preferred_domain` is set",
+							Type:        pluginsdk.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+
+						"allow_cloud_password_validation": {
+							Description: "Whether users from federated domains can authenticate with a password synchronized to Azure Active Directory",
+							Type:        pluginsdk.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+
+						"alternate_id_login_enabled": {
+							Description: "Whether users can sign in with an email address as an alternate login ID",
+							Type:        pluginsdk.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+
+						"preferred_domain": {
+							Description:  "The federated domain to which users are sent when `accelerate_to_federated_domain` is enabled and the tenant has more than one federated domain",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"is_organization_default": {
+				Description: "Whether this policy applies to all service principals in the tenant that do not have a policy assigned",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func homeRealmDiscoveryPolicyResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.HomeRealmDiscoveryPolicyClient
+
+	definition, err := expandHomeRealmDiscoveryPolicyDefinition(d.Get("definition").([]interface{}))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "definition", "Building home realm discovery policy definition")
+	}
+
+	properties := stable.HomeRealmDiscoveryPolicy{
+		Definition:            []string{definition},
+		DisplayName:           nullable.Value(d.Get("display_name").(string)),
+		IsOrganizationDefault: nullable.Value(d.Get("is_organization_default").(bool)),
+	}
+
+	resp, err := client.CreateHomeRealmDiscoveryPolicy(ctx, properties, homerealmdiscoverypolicy.DefaultCreateHomeRealmDiscoveryPolicyOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not create Home Realm Discovery Policy")
+	}
+
+	homeRealmDiscoveryPolicy := resp.Model
+	if homeRealmDiscoveryPolicy == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Could not create Home Realm Discovery Policy")
+	}
+	if homeRealmDiscoveryPolicy.Id == nil {
+		return tf.ErrorDiagF(errors.New("model return with nil ID"), "Could not create Home Realm Discovery Policy")
+	}
+
+	id := stable.NewPolicyHomeRealmDiscoveryPolicyID(*homeRealmDiscoveryPolicy.Id)
+	d.SetId(id.ID())
+
+	return homeRealmDiscoveryPolicyResourceRead(ctx, d, meta)
+}
+
+func homeRealmDiscoveryPolicyResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.HomeRealmDiscoveryPolicyClient
+
+	id, err := stable.ParsePolicyHomeRealmDiscoveryPolicyID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing ID")
+	}
+
+	resp, err := client.GetHomeRealmDiscoveryPolicy(ctx, *id, homerealmdiscoverypolicy.DefaultGetHomeRealmDiscoveryPolicyOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s - removing from state!", id)
+			d.SetId("")
+			return nil
+		}
+
+		return tf.ErrorDiagF(err, "retrieving %s", id)
+	}
+
+	homeRealmDiscoveryPolicy := resp.Model
+	if homeRealmDiscoveryPolicy == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+	}
+
+	definition := make([]interface{}, 0)
+	if len(homeRealmDiscoveryPolicy.Definition) > 0 {
+		if definition, err = flattenHomeRealmDiscoveryPolicyDefinition(homeRealmDiscoveryPolicy.Definition[0]); err != nil {
+			return tf.ErrorDiagF(err, "Parsing definition for %s", id)
+		}
+	}
+
+	tf.Set(d, "definition", definition)
+	tf.Set(d, "display_name", homeRealmDiscoveryPolicy.DisplayName.GetOrZero())
+	tf.Set(d, "is_organization_default", homeRealmDiscoveryPolicy.IsOrganizationDefault.GetOrZero())
+
+	return nil
+}
+
+func homeRealmDiscoveryPolicyResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.HomeRealmDiscoveryPolicyClient
+
+	id, err := stable.ParsePolicyHomeRealmDiscoveryPolicyID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing ID")
+	}
+
+	definition, err := expandHomeRealmDiscoveryPolicyDefinition(d.Get("definition").([]interface{}))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "definition", "Building home realm discovery policy definition")
+	}
+
+	properties := stable.HomeRealmDiscoveryPolicy{
+		Definition:            []string{definition},
+		DisplayName:           nullable.Value(d.Get("display_name").(string)),
+		IsOrganizationDefault: nullable.Value(d.Get("is_organization_default").(bool)),
+	}
+
+	if _, err := client.UpdateHomeRealmDiscoveryPolicy(ctx, *id, properties, homerealmdiscoverypolicy.DefaultUpdateHomeRealmDiscoveryPolicyOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Could not update %s", id)
+	}
+
+	return homeRealmDiscoveryPolicyResourceRead(ctx, d, meta)
+}
+
+func homeRealmDiscoveryPolicyResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.HomeRealmDiscoveryPolicyClient
+
+	id, err := stable.ParsePolicyHomeRealmDiscoveryPolicyID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing ID")
+	}
+
+	if _, err := client.DeleteHomeRealmDiscoveryPolicy(ctx, *id, homerealmdiscoverypolicy.DefaultDeleteHomeRealmDiscoveryPolicyOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Deleting %s", id)
+	}
+
+	return nil
+}
+
+type homeRealmDiscoveryPolicyDefinition struct {
+	HomeRealmDiscoveryPolicy struct {
+		AccelerateToFederatedDomain  policyDefinitionBool                      `json:"AccelerateToFederatedDomain"`
+		AllowCloudPasswordValidation policyDefinitionBool                      `json:"AllowCloudPasswordValidation"`
+		AlternateIdLogin             *homeRealmDiscoveryPolicyAlternateIdLogin `json:"AlternateIdLogin,omitempty"`
+		PreferredDomain              string                                    `json:"PreferredDomain,omitempty"`
+	} `json:"HomeRealmDiscoveryPolicy"`
+}
+
+type homeRealmDiscoveryPolicyAlternateIdLogin struct {
+	Enabled policyDefinitionBool `json:"Enabled"`
+}
+
+// policyDefinitionBool accepts both JSON booleans and quoted booleans, since policies created outside of Terraform
+// sometimes use the latter
+type policyDefinitionBool bool
+
+func (b *policyDefinitionBool) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	switch v := raw.(type) {
+	case bool:
+		*b = policyDefinitionBool(v)
+	case string:
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		*b = policyDefinitionBool(parsed)
+	case nil:
+		*b = false
+	default:
+		return errors.New("expected a boolean value")
+	}
+
+	return nil
+}
+
+func expandHomeRealmDiscoveryPolicyDefinition(input []interface{}) (string, error) {
+	var definition homeRealmDiscoveryPolicyDefinition
+
+	if len(input) > 0 && input[0] != nil {
+		in := input[0].(map[string]interface{})
+		definition.HomeRealmDiscoveryPolicy.AccelerateToFederatedDomain = policyDefinitionBool(in["accelerate_to_federated_domain"].(bool))
+		definition.HomeRealmDiscoveryPolicy.AllowCloudPasswordValidation = policyDefinitionBool(in["allow_cloud_password_validation"].(bool))
+		definition.HomeRealmDiscoveryPolicy.PreferredDomain = in["preferred_domain"].(string)
+
+		if in["alternate_id_login_enabled"].(bool) {
+			definition.HomeRealmDiscoveryPolicy.AlternateIdLogin = &homeRealmDiscoveryPolicyAlternateIdLogin{Enabled: true}
+		}
+	}
+
+	out, err := json.Marshal(definition)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func flattenHomeRealmDiscoveryPolicyDefinition(input string) ([]interface{}, error) {
+	var definition homeRealmDiscoveryPolicyDefinition
+	if err := json.Unmarshal([]byte(input), &definition); err != nil {
+		return nil, err
+	}
+
+	policy := definition.HomeRealmDiscoveryPolicy
+
+	alternateIdLoginEnabled := false
+	if policy.AlternateIdLogin != nil {
+		alternateIdLoginEnabled = bool(policy.AlternateIdLogin.Enabled)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"accelerate_to_federated_domain":  bool(policy.AccelerateToFederatedDomain),
+			"allow_cloud_password_validation": bool(policy.AllowCloudPasswordValidation),
+			"alternate_id_login_enabled":      alternateIdLoginEnabled,
+			"preferred_domain":                policy.PreferredDomain,
+		},
+	}, nil
+}
@@ -0,0 +1,281 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/suppress"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+var appManagementMaxLifetimeRegex = regexp.MustCompile(`^P(?:\d+Y)?(?:\d+M)?(?:\d+W)?(?:\d+D)?(?:T(?:\d+H)?(?:\d+M)?(?:\d+S)?)?$`)
+
+// appManagementRestrictionsSchema returns the schema for the credential restrictions shared by tenant-wide and
+// custom app management policies
+func appManagementRestrictionsSchema() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"key_credentials": {
+			Description: "Restrictions that apply to certificates and other asymmetric key credentials",
+			Type:        pluginsdk.TypeList,
+			Optional:    true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"restriction_type": {
+						Description:  "The type of restriction",
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(possibleValuesForAppKeyCredentialRestrictionType(), false),
+					},
+
+					"certificate_based_application_configuration_ids": {
+						Description: "IDs of the certificate based application configurations containing the trusted certificate authorities, when `restriction_type` is `trustedCertificateAuthority`",
+						Type:        pluginsdk.TypeSet,
+						Optional:    true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+
+					"max_lifetime": {
+						Description:  "The maximum lifetime of the credential as an ISO 8601 duration, e.g. `P90D`, when `restriction_type` is `asymmetricKeyLifetime`",
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringMatch(appManagementMaxLifetimeRegex, "must be an ISO 8601 duration, e.g. `P90D`"),
+					},
+
+					"restrict_for_apps_created_after": {
+						Description:      "The restriction only applies to applications or service principals created after this date, formatted as an RFC3339 date string (e.g. `2018-01-01T01:02:03Z`)",
+						Type:             pluginsdk.TypeString,
+						Required:         true,
+						ValidateFunc:     validation.IsRFC3339Time,
+						DiffSuppressFunc: suppress.RFC3339Time,
+					},
+
+					"enabled": {
+						Description: "Whether the restriction is enforced",
+						Type:        pluginsdk.TypeBool,
+						Optional:    true,
+						Default:     true,
+					},
+				},
+			},
+		},
+
+		"password_credentials": {
+			Description: "Restrictions that apply to client secrets and other symmetric key credentials",
+			Type:        pluginsdk.TypeList,
+			Optional:    true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"restriction_type": {
+						Description:  "The type of restriction",
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(possibleValuesForAppCredentialRestrictionType(), false),
+					},
+
+					"max_lifetime": {
+						Description:  "The maximum lifetime of the credential as an ISO 8601 duration, e.g. `P90D`, when `restriction_type` is `passwordLifetime` or `symmetricKeyLifetime`",
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringMatch(appManagementMaxLifetimeRegex, "must be an ISO 8601 duration, e.g. `P90D`"),
+					},
+
+					"restrict_for_apps_created_after": {
+						Description:      "The restriction only applies to applications or service principals created after this date, formatted as an RFC3339 date string (e.g. `2018-01-01T01:02:03Z`)",
+						Type:             pluginsdk.TypeString,
+						Required:         true,
+						ValidateFunc:     validation.IsRFC3339Time,
+						DiffSuppressFunc: suppress.RFC3339Time,
+					},
+
+					"enabled": {
+						Description: "Whether the restriction is enforced",
+						Type:        pluginsdk.TypeBool,
+						Optional:    true,
+						Default:     true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func possibleValuesForAppCredentialRestrictionType() []string {
+	return []string{
+		string(beta.AppCredentialRestrictionType_CustomPasswordAddition),
+		string(beta.AppCredentialRestrictionType_PasswordAddition),
+		string(beta.AppCredentialRestrictionType_PasswordLifetime),
+		string(beta.AppCredentialRestrictionType_SymmetricKeyAddition),
+		string(beta.AppCredentialRestrictionType_SymmetricKeyLifetime),
+	}
+}
+
+func possibleValuesForAppKeyCredentialRestrictionType() []string {
+	return []string{
+		string(beta.AppKeyCredentialRestrictionType_AsymmetricKeyLifetime),
+		string(beta.AppKeyCredentialRestrictionType_TrustedCertificateAuthority),
+	}
+}
+
+// validateAppManagementRestrictions checks that each restriction specifies the arguments required by its type, since
+// the API only reports these errors when the policy is saved
+func validateAppManagementRestrictions(path string, input []interface{}) error {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+	in := input[0].(map[string]interface{})
+
+	for i, raw := range in["password_credentials"].([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		restriction := raw.(map[string]interface{})
+		restrictionType := beta.AppCredentialRestrictionType(restriction["restriction_type"].(string))
+		isLifetime := restrictionType == beta.AppCredentialRestrictionType_PasswordLifetime || restrictionType == beta.AppCredentialRestrictionType_SymmetricKeyLifetime
+		if err := validateAppManagementMaxLifetime(fmt.Sprintf("%s.0.password_credentials.%d", path, i), string(restrictionType), restriction["max_lifetime"].(string), isLifetime); err != nil {
+			return err
+		}
+	}
+
+	for i, raw := range in["key_credentials"].([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		restriction := raw.(map[string]interface{})
+		restrictionType := beta.AppKeyCredentialRestrictionType(restriction["restriction_type"].(string))
+		blockPath := fmt.Sprintf("%s.0.key_credentials.%d", path, i)
+		isLifetime := restrictionType == beta.AppKeyCredentialRestrictionType_AsymmetricKeyLifetime
+		if err := validateAppManagementMaxLifetime(blockPath, string(restrictionType), restriction["max_lifetime"].(string), isLifetime); err != nil {
+			return err
+		}
+
+		configurationIds := restriction["certificate_based_application_configuration_ids"].(*pluginsdk.Set).Len()
+		if restrictionType == beta.AppKeyCredentialRestrictionType_TrustedCertificateAuthority && configurationIds == 0 {
+			return fmt.Errorf("`%s.certificate_based_application_configuration_ids` must be specified when `restriction_type` is %q", blockPath, restrictionType)
+		}
+		if restrictionType != beta.AppKeyCredentialRestrictionType_TrustedCertificateAuthority && configurationIds > 0 {
+			return fmt.Errorf("`%s.certificate_based_application_configuration_ids` can only be specified when `restriction_type` is %q", blockPath, beta.AppKeyCredentialRestrictionType_TrustedCertificateAuthority)
+		}
+	}
+
+	return nil
+}
+
+func validateAppManagementMaxLifetime(path, restrictionType, maxLifetime string, isLifetime bool) error {
+	if isLifetime && maxLifetime == "" {
+		return fmt.Errorf("`%s.max_lifetime` must be specified when `restriction_type` is %q", path, restrictionType)
+	}
+	if !isLifetime && maxLifetime != "" {
+		return fmt.Errorf("`%s.max_lifetime` cannot be specified when `restriction_type` is %q", path, restrictionType)
+	}
+	return nil
+}
+
+func appManagementRestrictionState(enabled bool) *beta.AppManagementRestrictionState {
+	if enabled {
+		return pointer.To(beta.AppManagementRestrictionState_Enabled)
+	}
+	return pointer.To(beta.AppManagementRestrictionState_Disabled)
+}
+
+// expandAppManagementRestrictions returns the key and password credential restrictions from a restrictions block. Empty
+// slices are returned rather than nil, so that restrictions removed from the configuration are also removed from the
+// policy.
+func expandAppManagementRestrictions(input []interface{}) (*[]beta.KeyCredentialConfiguration, *[]beta.PasswordCredentialConfiguration) {
+	keyCredentials := make([]beta.KeyCredentialConfiguration, 0)
+	passwordCredentials := make([]beta.PasswordCredentialConfiguration, 0)
+
+	if len(input) == 0 || input[0] == nil {
+		return &keyCredentials, &passwordCredentials
+	}
+	in := input[0].(map[string]interface{})
+
+	for _, raw := range in["key_credentials"].([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		restriction := raw.(map[string]interface{})
+
+		keyCredential := beta.KeyCredentialConfiguration{
+			MaxLifetime:                         nullable.NoZero(restriction["max_lifetime"].(string)),
+			RestrictForAppsCreatedAfterDateTime: nullable.NoZero(restriction["restrict_for_apps_created_after"].(string)),
+			RestrictionType:                     pointer.To(beta.AppKeyCredentialRestrictionType(restriction["restriction_type"].(string))),
+			State:                               appManagementRestrictionState(restriction["enabled"].(bool)),
+		}
+
+		if ids := tf.ExpandStringSlice(restriction["certificate_based_application_configuration_ids"].(*pluginsdk.Set).List()); len(ids) > 0 {
+			keyCredential.CertificateBasedApplicationConfigurationIds = &ids
+		}
+
+		keyCredentials = append(keyCredentials, keyCredential)
+	}
+
+	for _, raw := range in["password_credentials"].([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		restriction := raw.(map[string]interface{})
+
+		passwordCredentials = append(passwordCredentials, beta.PasswordCredentialConfiguration{
+			MaxLifetime:                         nullable.NoZero(restriction["max_lifetime"].(string)),
+			RestrictForAppsCreatedAfterDateTime: nullable.NoZero(restriction["restrict_for_apps_created_after"].(string)),
+			RestrictionType:                     pointer.To(beta.AppCredentialRestrictionType(restriction["restriction_type"].(string))),
+			State:                               appManagementRestrictionState(restriction["enabled"].(bool)),
+		})
+	}
+
+	return &keyCredentials, &passwordCredentials
+}
+
+func flattenAppManagementRestrictions(keyCredentials *[]beta.KeyCredentialConfiguration, passwordCredentials *[]beta.PasswordCredentialConfiguration) []interface{} {
+	if (keyCredentials == nil || len(*keyCredentials) == 0) && (passwordCredentials == nil || len(*passwordCredentials) == 0) {
+		return []interface{}{}
+	}
+
+	keys := make([]interface{}, 0)
+	if keyCredentials != nil {
+		for _, k := range *keyCredentials {
+			configurationIds := make([]string, 0)
+			if k.CertificateBasedApplicationConfigurationIds != nil {
+				configurationIds = *k.CertificateBasedApplicationConfigurationIds
+			}
+
+			keys = append(keys, map[string]interface{}{
+				"certificate_based_application_configuration_ids": tf.FlattenStringSlice(configurationIds),
+				"enabled":                         pointer.From(k.State) != beta.AppManagementRestrictionState_Disabled,
+				"max_lifetime":                    k.MaxLifetime.GetOrZero(),
+				"restrict_for_apps_created_after": k.RestrictForAppsCreatedAfterDateTime.GetOrZero(),
+				"restriction_type":                string(pointer.From(k.RestrictionType)),
+			})
+		}
+	}
+
+	passwords := make([]interface{}, 0)
+	if passwordCredentials != nil {
+		for _, p := range *passwordCredentials {
+			passwords = append(passwords, map[string]interface{}{
+				"enabled":                         pointer.From(p.State) != beta.AppManagementRestrictionState_Disabled,
+				"max_lifetime":                    p.MaxLifetime.GetOrZero(),
+				"restrict_for_apps_created_after": p.RestrictForAppsCreatedAfterDateTime.GetOrZero(),
+				"restriction_type":                string(pointer.From(p.RestrictionType)),
+			})
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"key_credentials":      keys,
+			"password_credentials": passwords,
+		},
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/beta/defaultappmanagementpolicy"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func tenantAppManagementPolicyResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: tenantAppManagementPolicyResourceCreateUpdate,
+		ReadContext:   tenantAppManagementPolicyResourceRead,
+		UpdateContext: tenantAppManagementPolicyResourceCreateUpdate,
+		DeleteContext: tenantAppManagementPolicyResourceDelete,
+
+		CustomizeDiff: tenantAppManagementPolicyResourceCustomizeDiff,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			if id != TenantAppManagementPolicyId {
+				return fmt.Errorf("expected ID to be %q, got %q", TenantAppManagementPolicyId, id)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"display_name": {
+				Description:  "Display name for the tenant app management policy",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"description": {
+				Description:  "Description for the tenant app management policy",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"enabled": {
+				Description: "Whether the tenant app management policy is enforced",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+
+			"application_restrictions": {
+				Description: "The credential restrictions enforced for applications in the tenant",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &pluginsdk.Resource{
+					Schema: appManagementRestrictionsSchema(),
+				},
+			},
+
+			"service_principal_restrictions": {
+				Description: "The credential restrictions enforced for service principals in the tenant",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &pluginsdk.Resource{
+					Schema: appManagementRestrictionsSchema(),
+				},
+			},
+		},
+	}
+}
+
+func tenantAppManagementPolicyResourceCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+	if err := validateAppManagementRestrictions("application_restrictions", diff.Get("application_restrictions").([]interface{})); err != nil {
+		return err
+	}
+	return validateAppManagementRestrictions("service_principal_restrictions", diff.Get("service_principal_restrictions").([]interface{}))
+}
+
+func tenantAppManagementPolicyResourceCreateUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.DefaultAppManagementPolicyClientBeta
+
+	// The tenant policy always exists, so the current display name and description are retained when not specified
+	resp, err := client.GetDefaultAppManagementPolicy(ctx, defaultappmanagementpolicy.DefaultGetDefaultAppManagementPolicyOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving tenant app management policy")
+	}
+	if resp.Model == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving tenant app management policy")
+	}
+
+	properties := tenantAppManagementPolicyExpand(d, *resp.Model)
+
+	if _, err := client.UpdateDefaultAppManagementPolicy(ctx, properties, defaultappmanagementpolicy.DefaultUpdateDefaultAppManagementPolicyOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Could not update tenant app management policy")
+	}
+
+	d.SetId(TenantAppManagementPolicyId)
+
+	return tenantAppManagementPolicyResourceRead(ctx, d, meta)
+}
+
+func tenantAppManagementPolicyResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.DefaultAppManagementPolicyClientBeta
+
+	resp, err := client.GetDefaultAppManagementPolicy(ctx, defaultappmanagementpolicy.DefaultGetDefaultAppManagementPolicyOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving tenant app management policy")
+	}
+
+	policy := resp.Model
+	if policy == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving tenant app management policy")
+	}
+
+	applicationRestrictions := make([]interface{}, 0)
+	if policy.ApplicationRestrictions != nil {
+		applicationRestrictions = flattenAppManagementRestrictions(policy.ApplicationRestrictions.KeyCredentials, policy.ApplicationRestrictions.PasswordCredentials)
+	}
+
+	servicePrincipalRestrictions := make([]interface{}, 0)
+	if policy.ServicePrincipalRestrictions != nil {
+		servicePrincipalRestrictions = flattenAppManagementRestrictions(policy.ServicePrincipalRestrictions.KeyCredentials, policy.ServicePrincipalRestrictions.PasswordCredentials)
+	}
+
+	tf.Set(d, "application_restrictions", applicationRestrictions)
+	tf.Set(d, "description", policy.Description)
+	tf.Set(d, "display_name", policy.DisplayName)
+	tf.Set(d, "enabled", pointer.From(policy.IsEnabled))
+	tf.Set(d, "service_principal_restrictions", servicePrincipalRestrictions)
+
+	return nil
+}
+
+func tenantAppManagementPolicyResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.DefaultAppManagementPolicyClientBeta
+
+	resp, err := client.GetDefaultAppManagementPolicy(ctx, defaultappmanagementpolicy.DefaultGetDefaultAppManagementPolicyOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving tenant app management policy")
+	}
+	if resp.Model == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving tenant app management policy")
+	}
+
+	// The tenant policy cannot be deleted, so it is disabled and its restrictions removed instead
+	emptyKeyCredentials, emptyPasswordCredentials := expandAppManagementRestrictions(nil)
+	properties := beta.TenantAppManagementPolicy{
+		Description: resp.Model.Description,
+		DisplayName: resp.Model.DisplayName,
+		IsEnabled:   pointer.To(false),
+		ApplicationRestrictions: &beta.AppManagementApplicationConfiguration{
+			KeyCredentials:      emptyKeyCredentials,
+			PasswordCredentials: emptyPasswordCredentials,
+		},
+		ServicePrincipalRestrictions: &beta.AppManagementServicePrincipalConfiguration{
+			KeyCredentials:      emptyKeyCredentials,
+			PasswordCredentials: emptyPasswordCredentials,
+		},
+	}
+
+	if _, err := client.UpdateDefaultAppManagementPolicy(ctx, properties, defaultappmanagementpolicy.DefaultUpdateDefaultAppManagementPolicyOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Resetting tenant app management policy")
+	}
+
+	return nil
+}
+
+func tenantAppManagementPolicyExpand(d *pluginsdk.ResourceData, existing beta.TenantAppManagementPolicy) beta.TenantAppManagementPolicy {
+	description := existing.Description
+	if v, ok := d.GetOk("description"); ok {
+		description = v.(string)
+	}
+
+	displayName := existing.DisplayName
+	if v, ok := d.GetOk("display_name"); ok {
+		displayName = v.(string)
+	}
+
+	applicationKeyCredentials, applicationPasswordCredentials := expandAppManagementRestrictions(d.Get("application_restrictions").([]interface{}))
+	servicePrincipalKeyCredentials, servicePrincipalPasswordCredentials := expandAppManagementRestrictions(d.Get("service_principal_restrictions").([]interface{}))
+
+	return beta.TenantAppManagementPolicy{
+		Description: description,
+		DisplayName: displayName,
+		IsEnabled:   pointer.To(d.Get("enabled").(bool)),
+		ApplicationRestrictions: &beta.AppManagementApplicationConfiguration{
+			KeyCredentials:      applicationKeyCredentials,
+			PasswordCredentials: applicationPasswordCredentials,
+		},
+		ServicePrincipalRestrictions: &beta.AppManagementServicePrincipalConfiguration{
+			KeyCredentials:      servicePrincipalKeyCredentials,
+			PasswordCredentials: servicePrincipalPasswordCredentials,
+		},
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/beta/defaultappmanagementpolicy"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type TenantAppManagementPolicyResource struct{}
+
+func TestAccTenantAppManagementPolicy_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_tenant_app_management_policy", "test")
+	r := TenantAppManagementPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("display_name").Exists(),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("service_principal_restrictions.0.key_credentials.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+// Exists reports whether the tenant policy is enabled, since the policy itself always exists
+func (r TenantAppManagementPolicyResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Policies.DefaultAppManagementPolicyClientBeta
+
+	resp, err := client.GetDefaultAppManagementPolicy(ctx, defaultappmanagementpolicy.DefaultGetDefaultAppManagementPolicyOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tenant app management policy: %v", err)
+	}
+	if resp.Model == nil {
+		return nil, fmt.Errorf("failed to retrieve tenant app management policy: model was nil")
+	}
+
+	return pointer.To(pointer.From(resp.Model.IsEnabled)), nil
+}
+
+func (TenantAppManagementPolicyResource) basic() string {
+	return `
+resource "azuread_tenant_app_management_policy" "test" {
+  application_restrictions {
+    password_credentials {
+      restriction_type                = "passwordLifetime"
+      max_lifetime                    = "P90D"
+      restrict_for_apps_created_after = "2099-01-01T00:00:00Z"
+    }
+  }
+}
+`
+}
+
+func (TenantAppManagementPolicyResource) complete() string {
+	return `
+resource "azuread_tenant_app_management_policy" "test" {
+  display_name = "Default app management tenant policy"
+  description  = "Managed by Terraform acceptance tests"
+
+  application_restrictions {
+    password_credentials {
+      restriction_type                = "passwordLifetime"
+      max_lifetime                    = "P90D"
+      restrict_for_apps_created_after = "2099-01-01T00:00:00Z"
+    }
+
+    password_credentials {
+      restriction_type                = "passwordAddition"
+      restrict_for_apps_created_after = "2099-01-01T00:00:00Z"
+    }
+  }
+
+  service_principal_restrictions {
+    key_credentials {
+      restriction_type                = "asymmetricKeyLifetime"
+      max_lifetime                    = "P365D"
+      restrict_for_apps_created_after = "2099-01-01T00:00:00Z"
+    }
+  }
+}
+`
+}
@@ -0,0 +1,311 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/tokenlifetimepolicy"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+const (
+	tokenLifetimeMinimum = 10 * time.Minute
+	tokenLifetimeMaximum = 24 * time.Hour
+)
+
+func tokenLifetimePolicyResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: tokenLifetimePolicyResourceCreate,
+		ReadContext:   tokenLifetimePolicyResourceRead,
+		UpdateContext: tokenLifetimePolicyResourceUpdate,
+		DeleteContext: tokenLifetimePolicyResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			if _, errs := stable.ValidatePolicyTokenLifetimePolicyID(id, "id"); len(errs) > 0 {
+				out := ""
+				for _, err := range errs {
+					out += err.Error()
+				}
+				return errors.New(out)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"display_name": {
+				Description:  "Display name for this policy",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"access_token_lifetime": {
+				Description:      "The lifetime of access and ID tokens, and SAML tokens, in the format `hh:mm:ss` or `d.hh:mm:ss`. Must be between 10 minutes and 1 day",
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ValidateFunc:     validateTokenLifetime,
+				DiffSuppressFunc: tokenLifetimeDiffSuppress,
+			},
+
+			"is_organization_default": {
+				Description: "Whether this policy applies to all applications and service principals in the tenant that do not have a policy assigned",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"definition": {
+				Description: "The JSON definition of the policy, as generated from the other arguments",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func tokenLifetimePolicyResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.TokenLifetimePolicyClient
+
+	definition, err := expandTokenLifetimePolicyDefinition(d.Get("access_token_lifetime").(string))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "access_token_lifetime", "Building token lifetime policy definition")
+	}
+
+	properties := stable.TokenLifetimePolicy{
+		Definition:            []string{definition},
+		DisplayName:           nullable.Value(d.Get("display_name").(string)),
+		IsOrganizationDefault: nullable.Value(d.Get("is_organization_default").(bool)),
+	}
+
+	resp, err := client.CreateTokenLifetimePolicy(ctx, properties, tokenlifetimepolicy.DefaultCreateTokenLifetimePolicyOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not create Token Lifetime Policy")
+	}
+
+	tokenLifetimePolicy := resp.Model
+	if tokenLifetimePolicy == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Could not create Token Lifetime Policy")
+	}
+	if tokenLifetimePolicy.Id == nil {
+		return tf.ErrorDiagF(errors.New("model return with nil ID"), "Could not create Token Lifetime Policy")
+	}
+
+	id := stable.NewPolicyTokenLifetimePolicyID(*tokenLifetimePolicy.Id)
+	d.SetId(id.ID())
+
+	return tokenLifetimePolicyResourceRead(ctx, d, meta)
+}
+
+func tokenLifetimePolicyResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.TokenLifetimePolicyClient
+
+	id, err := stable.ParsePolicyTokenLifetimePolicyID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing ID")
+	}
+
+	resp, err := client.GetTokenLifetimePolicy(ctx, *id, tokenlifetimepolicy.DefaultGetTokenLifetimePolicyOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s - removing from state!", id)
+			d.SetId("")
+			return nil
+		}
+
+		return tf.ErrorDiagF(err, "retrieving %s", id)
+	}
+
+	tokenLifetimePolicy := resp.Model
+	if tokenLifetimePolicy == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+	}
+
+	definition := ""
+	accessTokenLifetime := ""
+	if len(tokenLifetimePolicy.Definition) > 0 {
+		definition = tokenLifetimePolicy.Definition[0]
+		if accessTokenLifetime, err = flattenTokenLifetimePolicyDefinition(definition); err != nil {
+			return tf.ErrorDiagF(err, "Parsing definition for %s", id)
+		}
+	}
+
+	tf.Set(d, "access_token_lifetime", accessTokenLifetime)
+	tf.Set(d, "definition", definition)
+	tf.Set(d, "display_name", tokenLifetimePolicy.DisplayName.GetOrZero())
+	tf.Set(d, "is_organization_default", tokenLifetimePolicy.IsOrganizationDefault.GetOrZero())
+
+	return nil
+}
+
+func tokenLifetimePolicyResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.TokenLifetimePolicyClient
+
+	id, err := stable.ParsePolicyTokenLifetimePolicyID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing ID")
+	}
+
+	definition, err := expandTokenLifetimePolicyDefinition(d.Get("access_token_lifetime").(string))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "access_token_lifetime", "Building token lifetime policy definition")
+	}
+
+	properties := stable.TokenLifetimePolicy{
+		Definition:            []string{definition},
+		DisplayName:           nullable.Value(d.Get("display_name").(string)),
+		IsOrganizationDefault: nullable.Value(d.Get("is_organization_default").(bool)),
+	}
+
+	if _, err := client.UpdateTokenLifetimePolicy(ctx, *id, properties, tokenlifetimepolicy.DefaultUpdateTokenLifetimePolicyOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Could not update %s", id)
+	}
+
+	return tokenLifetimePolicyResourceRead(ctx, d, meta)
+}
+
+func tokenLifetimePolicyResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Policies.TokenLifetimePolicyClient
+
+	id, err := stable.ParsePolicyTokenLifetimePolicyID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing ID")
+	}
+
+	if _, err := client.DeleteTokenLifetimePolicy(ctx, *id, tokenlifetimepolicy.DefaultDeleteTokenLifetimePolicyOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Deleting %s", id)
+	}
+
+	return nil
+}
+
+type tokenLifetimePolicyDefinition struct {
+	TokenLifetimePolicy struct {
+		Version             int    `json:"Version"`
+		AccessTokenLifetime string `json:"AccessTokenLifetime,omitempty"`
+	} `json:"TokenLifetimePolicy"`
+}
+
+func expandTokenLifetimePolicyDefinition(accessTokenLifetime string) (string, error) {
+	lifetime, err := parseTokenLifetime(accessTokenLifetime)
+	if err != nil {
+		return "", err
+	}
+
+	var definition tokenLifetimePolicyDefinition
+	definition.TokenLifetimePolicy.Version = 1
+	definition.TokenLifetimePolicy.AccessTokenLifetime = formatTokenLifetime(lifetime)
+
+	out, err := json.Marshal(definition)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func flattenTokenLifetimePolicyDefinition(input string) (string, error) {
+	var definition tokenLifetimePolicyDefinition
+	if err := json.Unmarshal([]byte(input), &definition); err != nil {
+		return "", err
+	}
+
+	return definition.TokenLifetimePolicy.AccessTokenLifetime, nil
+}
+
+var tokenLifetimeRegex = regexp.MustCompile(`^(?:(\d+)\.)?(\d{1,2}):(\d{2}):(\d{2})$`)
+
+// parseTokenLifetime parses a lifetime in the TimeSpan format used in policy definitions, i.e. `[d.]hh:mm:ss`
+func parseTokenLifetime(input string) (time.Duration, error) {
+	m := tokenLifetimeRegex.FindStringSubmatch(input)
+	if m == nil {
+		return 0, fmt.Errorf("expected a lifetime in the format `hh:mm:ss` or `d.hh:mm:ss`, got %q", input)
+	}
+
+	parts := make([]int, 4)
+	for i, v := range m[1:] {
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("parsing %q: %v", input, err)
+		}
+		parts[i] = n
+	}
+
+	if parts[1] > 23 || parts[2] > 59 || parts[3] > 59 {
+		return 0, fmt.Errorf("hours must be less than 24, and minutes and seconds less than 60, got %q", input)
+	}
+
+	return time.Duration(parts[0])*24*time.Hour + time.Duration(parts[1])*time.Hour + time.Duration(parts[2])*time.Minute + time.Duration(parts[3])*time.Second, nil
+}
+
+func formatTokenLifetime(input time.Duration) string {
+	days := int(input / (24 * time.Hour))
+	input -= time.Duration(days) * 24 * time.Hour
+	hours := int(input / time.Hour)
+	input -= time.Duration(hours) * time.Hour
+	minutes := int(input / time.Minute)
+	input -= time.Duration(minutes) * time.Minute
+	seconds := int(input / time.Second)
+
+	if days > 0 {
+		return fmt.Sprintf("%d.%02d:%02d:%02d", days, hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+}
+
+func validateTokenLifetime(i interface{}, k string) (warnings []string, errs []error) {
+	v, ok := i.(string)
+	if !ok {
+		errs = append(errs, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	lifetime, err := parseTokenLifetime(v)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q: %v", k, err))
+		return
+	}
+
+	if lifetime < tokenLifetimeMinimum || lifetime > tokenLifetimeMaximum {
+		errs = append(errs, fmt.Errorf("%q must be between %s and %s, got %q", k, formatTokenLifetime(tokenLifetimeMinimum), formatTokenLifetime(tokenLifetimeMaximum), v))
+	}
+
+	return
+}
+
+// tokenLifetimeDiffSuppress suppresses differences between equivalent lifetimes, such as `1:00:00` and `01:00:00`
+func tokenLifetimeDiffSuppress(_, old, new string, _ *pluginsdk.ResourceData) bool {
+	oldLifetime, err := parseTokenLifetime(old)
+	if err != nil {
+		return false
+	}
+	newLifetime, err := parseTokenLifetime(new)
+	if err != nil {
+		return false
+	}
+	return oldLifetime == newLifetime
+}
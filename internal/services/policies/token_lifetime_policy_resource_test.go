@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/tokenlifetimepolicy"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type TokenLifetimePolicyResource struct{}
+
+func TestTokenLifetimePolicy_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_token_lifetime_policy", "test")
+	r := TokenLifetimePolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("access_token_lifetime").HasValue("02:00:00"),
+				check.That(data.ResourceName).Key("is_organization_default").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.update(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("access_token_lifetime").HasValue("1.00:00:00"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r TokenLifetimePolicyResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Policies.TokenLifetimePolicyClient
+
+	id, err := stable.ParsePolicyTokenLifetimePolicyID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetTokenLifetimePolicy(ctx, *id, tokenlifetimepolicy.DefaultGetTokenLifetimePolicyOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %v", id, err)
+	}
+
+	return pointer.To(true), nil
+}
+
+func (TokenLifetimePolicyResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_token_lifetime_policy" "test" {
+  display_name          = "acctest-%[1]s"
+  access_token_lifetime = "2:00:00"
+}
+`, data.RandomString)
+}
+
+func (TokenLifetimePolicyResource) update(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_token_lifetime_policy" "test" {
+  display_name          = "acctest-%[1]s-updated"
+  access_token_lifetime = "1.00:00:00"
+}
+`, data.RandomString)
+}
@@ -4,10 +4,13 @@
 package client
 
 import (
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directoryobjects/stable/directoryobject"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/oauth2permissiongrants/stable/oauth2permissiongrant"
+	federatedidentitycredentialBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/beta/federatedidentitycredential"
 	serviceprincipalBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/beta/serviceprincipal"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/claimsmappingpolicy"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/homerealmdiscoverypolicy"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/owner"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/synchronizationjob"
@@ -15,16 +18,25 @@ import (
 )
 
 type Client struct {
-	ClaimsMappingPolicyClient   *claimsmappingpolicy.ClaimsMappingPolicyClient
-	DirectoryObjectClient       *directoryobject.DirectoryObjectClient
-	OAuth2PermissionGrantClient *oauth2permissiongrant.OAuth2PermissionGrantClient
-	ServicePrincipalClient      *serviceprincipal.ServicePrincipalClient
-	ServicePrincipalClientBeta  *serviceprincipalBeta.ServicePrincipalClient
-	ServicePrincipalOwnerClient *owner.OwnerClient
-	SynchronizationJobClient    *synchronizationjob.SynchronizationJobClient
+	ApplicationClient                     *application.ApplicationClient
+	ClaimsMappingPolicyClient             *claimsmappingpolicy.ClaimsMappingPolicyClient
+	DirectoryObjectClient                 *directoryobject.DirectoryObjectClient
+	FederatedIdentityCredentialClientBeta *federatedidentitycredentialBeta.FederatedIdentityCredentialClient
+	HomeRealmDiscoveryPolicyClient        *homerealmdiscoverypolicy.HomeRealmDiscoveryPolicyClient
+	OAuth2PermissionGrantClient           *oauth2permissiongrant.OAuth2PermissionGrantClient
+	ServicePrincipalClient                *serviceprincipal.ServicePrincipalClient
+	ServicePrincipalClientBeta            *serviceprincipalBeta.ServicePrincipalClient
+	ServicePrincipalOwnerClient           *owner.OwnerClient
+	SynchronizationJobClient              *synchronizationjob.SynchronizationJobClient
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
+	applicationClient, err := application.NewApplicationClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(applicationClient.Client)
+
 	claimsMappingPolicyClient, err := claimsmappingpolicy.NewClaimsMappingPolicyClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
@@ -37,6 +49,19 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(directoryObjectClient.Client)
 
+	// Federated identity credentials for service principals are only available in the beta API
+	federatedIdentityCredentialClientBeta, err := federatedidentitycredentialBeta.NewFederatedIdentityCredentialClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(federatedIdentityCredentialClientBeta.Client)
+
+	homeRealmDiscoveryPolicyClient, err := homerealmdiscoverypolicy.NewHomeRealmDiscoveryPolicyClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(homeRealmDiscoveryPolicyClient.Client)
+
 	oAuth2PermissionGrantClient, err := oauth2permissiongrant.NewOAuth2PermissionGrantClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
@@ -69,12 +94,15 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	o.Configure(synchronizationJobClient.Client)
 
 	return &Client{
-		ClaimsMappingPolicyClient:   claimsMappingPolicyClient,
-		DirectoryObjectClient:       directoryObjectClient,
-		OAuth2PermissionGrantClient: oAuth2PermissionGrantClient,
-		ServicePrincipalClient:      servicePrincipalClient,
-		ServicePrincipalClientBeta:  servicePrincipalClientBeta,
-		ServicePrincipalOwnerClient: servicePrincipalOwnerClient,
-		SynchronizationJobClient:    synchronizationJobClient,
+		ApplicationClient:                     applicationClient,
+		ClaimsMappingPolicyClient:             claimsMappingPolicyClient,
+		DirectoryObjectClient:                 directoryObjectClient,
+		FederatedIdentityCredentialClientBeta: federatedIdentityCredentialClientBeta,
+		HomeRealmDiscoveryPolicyClient:        homeRealmDiscoveryPolicyClient,
+		OAuth2PermissionGrantClient:           oAuth2PermissionGrantClient,
+		ServicePrincipalClient:                servicePrincipalClient,
+		ServicePrincipalClientBeta:            servicePrincipalClientBeta,
+		ServicePrincipalOwnerClient:           servicePrincipalOwnerClient,
+		SynchronizationJobClient:              synchronizationJobClient,
 	}, nil
 }
@@ -29,6 +29,8 @@ func servicePrincipalCertificateResource() *pluginsdk.Resource {
 		ReadContext:   servicePrincipalCertificateResourceRead,
 		DeleteContext: servicePrincipalCertificateResourceDelete,
 
+		CustomizeDiff: credentials.GeneratedCertificateRenewalCustomizeDiff,
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -109,12 +111,36 @@ func servicePrincipalCertificateResource() *pluginsdk.Resource {
 			},
 
 			"value": {
-				Description: "The certificate data, which can be PEM encoded, base64 encoded DER or hexadecimal encoded DER",
+				Description:  "The certificate data, which can be PEM encoded, base64 encoded DER or hexadecimal encoded DER",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"generate", "value"},
+			},
+
+			"generate": credentials.GenerateCertificateSchema(),
+
+			"private_key_pem": {
+				Description: "The PEM encoded private key of the generated certificate",
 				Type:        pluginsdk.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Computed:    true,
+				Sensitive:   true,
+			},
+
+			"pkcs12_base64": {
+				Description: "A base64 encoded PKCS#12 bundle containing the generated certificate and its private key",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
 				Sensitive:   true,
 			},
+
+			"ready_for_renewal": {
+				Description: "Whether the generated certificate is due to be replaced",
+				Type:        pluginsdk.TypeBool,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -127,6 +153,15 @@ func servicePrincipalCertificateResourceCreate(ctx context.Context, d *pluginsdk
 		return tf.ErrorDiagPathF(err, "service_principal_id", "Parsing `service_principal_id`")
 	}
 
+	generated, err := credentials.GenerateCertificateForResource(d)
+	if err != nil {
+		attr := ""
+		if kerr, ok := err.(credentials.CredentialError); ok {
+			attr = kerr.Attr()
+		}
+		return tf.ErrorDiagPathF(err, attr, "Generating certificate for %s", servicePrincipalId)
+	}
+
 	credential, err := credentials.KeyCredentialForResource(d)
 	if err != nil {
 		attr := ""
@@ -197,6 +232,12 @@ func servicePrincipalCertificateResourceCreate(ctx context.Context, d *pluginsdk
 
 	d.SetId(id.String())
 
+	if generated != nil {
+		tf.Set(d, "private_key_pem", generated.PrivateKeyPem)
+		tf.Set(d, "pkcs12_base64", generated.PKCS12Base64)
+	}
+	tf.Set(d, "ready_for_renewal", false)
+
 	return servicePrincipalCertificateResourceRead(ctx, d, meta)
 }
 
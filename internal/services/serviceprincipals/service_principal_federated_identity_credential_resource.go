@@ -0,0 +1,299 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceprincipals
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/beta/federatedidentitycredential"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/federatedidentity"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func servicePrincipalFederatedIdentityCredentialResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: servicePrincipalFederatedIdentityCredentialResourceCreate,
+		ReadContext:   servicePrincipalFederatedIdentityCredentialResourceRead,
+		UpdateContext: servicePrincipalFederatedIdentityCredentialResourceUpdate,
+		DeleteContext: servicePrincipalFederatedIdentityCredentialResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(15 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := stable.ParseServicePrincipalIdFederatedIdentityCredentialID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"service_principal_id": {
+				Description:  "The resource ID of the managed identity service principal for which this federated identity credential should be created",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: stable.ValidateServicePrincipalID,
+			},
+
+			"audiences": {
+				Description: "List of audiences that can appear in the external token. This specifies what should be accepted in the `aud` claim of incoming tokens.",
+				Type:        pluginsdk.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"display_name": {
+				Description:  "A unique display name for the federated identity credential",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 120),
+			},
+
+			"issuer": {
+				Description: "The URL of the external identity provider, which must match the issuer claim of the external token being exchanged",
+				Type:        pluginsdk.TypeString,
+				Required:    true,
+			},
+
+			"subject": {
+				Description:  "The identifier of the external software workload within the external identity provider. The combination of issuer and subject must be unique on the service principal.",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"claims_matching_expression", "subject"},
+			},
+
+			"claims_matching_expression": {
+				Description:  "An expression that is evaluated against the claims of incoming tokens, for use in place of `subject`",
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"claims_matching_expression", "subject"},
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"value": {
+							Description:      "The expression to evaluate, e.g. `claims['sub'] matches 'repo:my-organization/my-repo:*'`",
+							Type:             pluginsdk.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.IsClaimsMatchingExpression,
+						},
+
+						"language_version": {
+							Description:  "The version of the expression language",
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							Default:      validation.ClaimsMatchingExpressionLanguageVersion,
+							ValidateFunc: validation.IntInSlice([]int{validation.ClaimsMatchingExpressionLanguageVersion}),
+						},
+					},
+				},
+			},
+
+			"description": {
+				Description: "A description for the federated identity credential",
+				Type:        pluginsdk.TypeString,
+				Optional:    true,
+			},
+
+			"credential_id": {
+				Description: "A UUID used to uniquely identify this federated identity credential",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func servicePrincipalFederatedIdentityCredentialResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalClient
+	federatedIdentityCredentialClient := meta.(*clients.Client).ServicePrincipals.FederatedIdentityCredentialClientBeta
+
+	servicePrincipalId, err := stable.ParseServicePrincipalID(d.Get("service_principal_id").(string))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "service_principal_id", "Parsing `service_principal_id`")
+	}
+
+	tf.LockByName(servicePrincipalResourceName, servicePrincipalId.ServicePrincipalId)
+	defer tf.UnlockByName(servicePrincipalResourceName, servicePrincipalId.ServicePrincipalId)
+
+	resp, err := client.GetServicePrincipal(ctx, *servicePrincipalId, serviceprincipal.DefaultGetServicePrincipalOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return tf.ErrorDiagPathF(nil, "service_principal_id", "%s was not found", servicePrincipalId)
+		}
+		return tf.ErrorDiagPathF(err, "service_principal_id", "Retrieving %s", servicePrincipalId)
+	}
+
+	servicePrincipal := resp.Model
+	if servicePrincipal == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", servicePrincipalId)
+	}
+
+	// Federated identity credentials can only be added to service principals for managed identities, credentials for
+	// applications should be added to the application instead
+	if servicePrincipalType := servicePrincipal.ServicePrincipalType.GetOrZero(); !strings.EqualFold(servicePrincipalType, "ManagedIdentity") {
+		return tf.ErrorDiagPathF(nil, "service_principal_id", "%s has type %q, federated identity credentials can only be added to managed identity service principals", servicePrincipalId, servicePrincipalType)
+	}
+
+	credential := federatedidentity.Credential{
+		Audiences:   tf.ExpandStringSlice(d.Get("audiences").([]interface{})),
+		Description: nullable.Value(d.Get("description").(string)),
+		Issuer:      d.Get("issuer").(string),
+		Name:        d.Get("display_name").(string),
+	}
+
+	if v, ok := d.GetOk("claims_matching_expression"); ok {
+		credential.ClaimsMatchingExpression = federatedidentity.ExpandClaimsMatchingExpression(v.([]interface{}))
+	} else {
+		credential.Subject = nullable.Value(d.Get("subject").(string))
+	}
+
+	newCredential, _, err := federatedidentity.Create(ctx, federatedIdentityCredentialClient.Client, servicePrincipalId.ID(), credential)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Adding federated identity credential for %s", servicePrincipalId)
+	}
+
+	if newCredential == nil || pointer.From(newCredential.Id) == "" {
+		return tf.ErrorDiagF(errors.New("nil or empty ID received"), "API error adding federated identity credential for %s", servicePrincipalId)
+	}
+
+	id := stable.NewServicePrincipalIdFederatedIdentityCredentialID(servicePrincipalId.ServicePrincipalId, *newCredential.Id)
+	d.SetId(id.ID())
+
+	if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		credential, resp, err := federatedidentity.Get(ctx, federatedIdentityCredentialClient.Client, id.ID())
+		if err != nil {
+			if response.WasNotFound(resp) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(credential != nil), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for creation of %s", id)
+	}
+
+	return servicePrincipalFederatedIdentityCredentialResourceRead(ctx, d, meta)
+}
+
+func servicePrincipalFederatedIdentityCredentialResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	federatedIdentityCredentialClient := meta.(*clients.Client).ServicePrincipals.FederatedIdentityCredentialClientBeta
+
+	id, err := stable.ParseServicePrincipalIdFederatedIdentityCredentialID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing federated identity credential ID %q", d.Id())
+	}
+
+	tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+	defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+	credential := federatedidentity.Credential{
+		Id:          pointer.To(id.FederatedIdentityCredentialId),
+		Audiences:   tf.ExpandStringSlice(d.Get("audiences").([]interface{})),
+		Description: nullable.Value(d.Get("description").(string)),
+		Issuer:      d.Get("issuer").(string),
+
+		// Exactly one of these is specified, the other must be nulled when switching between them
+		ClaimsMatchingExpression: federatedidentity.ExpandClaimsMatchingExpression(d.Get("claims_matching_expression").([]interface{})),
+		Subject:                  nullable.NoZero(d.Get("subject").(string)),
+
+		// Name is immutable but must be specified as it is a required field
+		Name: d.Get("display_name").(string),
+	}
+
+	if _, err = federatedidentity.Update(ctx, federatedIdentityCredentialClient.Client, id.ID(), credential); err != nil {
+		return tf.ErrorDiagF(err, "Updating %s", id)
+	}
+
+	return servicePrincipalFederatedIdentityCredentialResourceRead(ctx, d, meta)
+}
+
+func servicePrincipalFederatedIdentityCredentialResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	federatedIdentityCredentialClient := meta.(*clients.Client).ServicePrincipals.FederatedIdentityCredentialClientBeta
+
+	id, err := stable.ParseServicePrincipalIdFederatedIdentityCredentialID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing federated identity credential ID %q", d.Id())
+	}
+
+	credential, resp, err := federatedidentity.Get(ctx, federatedIdentityCredentialClient.Client, id.ID())
+	if err != nil {
+		if response.WasNotFound(resp) {
+			log.Printf("[DEBUG] %s was not found - removing from state!", id)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagPathF(err, "id", "Retrieving %s", id)
+	}
+
+	if credential == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+	}
+
+	tf.Set(d, "service_principal_id", stable.NewServicePrincipalID(id.ServicePrincipalId).ID())
+	tf.Set(d, "credential_id", id.FederatedIdentityCredentialId)
+
+	tf.Set(d, "audiences", tf.FlattenStringSlice(credential.Audiences))
+	tf.Set(d, "claims_matching_expression", federatedidentity.FlattenClaimsMatchingExpression(credential.ClaimsMatchingExpression))
+	tf.Set(d, "description", credential.Description.GetOrZero())
+	tf.Set(d, "display_name", credential.Name)
+	tf.Set(d, "issuer", credential.Issuer)
+	tf.Set(d, "subject", credential.Subject.GetOrZero())
+
+	return nil
+}
+
+func servicePrincipalFederatedIdentityCredentialResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	federatedIdentityCredentialClient := meta.(*clients.Client).ServicePrincipals.FederatedIdentityCredentialClientBeta
+
+	id, err := beta.ParseServicePrincipalIdFederatedIdentityCredentialID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing federated identity credential ID %q", d.Id())
+	}
+
+	tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+	defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+	if resp, err := federatedIdentityCredentialClient.DeleteFederatedIdentityCredential(ctx, *id, federatedidentitycredential.DefaultDeleteFederatedIdentityCredentialOperationOptions()); err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Removing %s", id)
+	}
+
+	if err = consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
+		if _, resp, err := federatedidentity.Get(ctx, federatedIdentityCredentialClient.Client, id.ID()); err != nil {
+			if response.WasNotFound(resp) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(true), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for deletion of %s", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceprincipals_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/beta/federatedidentitycredential"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+// Managed identities cannot be created with this provider, so tests that create credentials require the object ID of an
+// existing user-assigned managed identity to be specified with ARM_TEST_MANAGED_IDENTITY_OBJECT_ID
+const servicePrincipalFederatedIdentityCredentialManagedIdentityEnvVar = "ARM_TEST_MANAGED_IDENTITY_OBJECT_ID"
+
+type ServicePrincipalFederatedIdentityCredentialResource struct{}
+
+func TestAccServicePrincipalFederatedIdentityCredential_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_federated_identity_credential", "test")
+	r := ServicePrincipalFederatedIdentityCredentialResource{}
+	objectId := r.managedIdentityObjectId(t)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, objectId),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("credential_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccServicePrincipalFederatedIdentityCredential_claimsMatchingExpression(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_federated_identity_credential", "test")
+	r := ServicePrincipalFederatedIdentityCredentialResource{}
+	objectId := r.managedIdentityObjectId(t)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.claimsMatchingExpression(data, objectId),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("claims_matching_expression.0.language_version").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, objectId),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("claims_matching_expression.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccServicePrincipalFederatedIdentityCredential_notManagedIdentity(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_federated_identity_credential", "test")
+	r := ServicePrincipalFederatedIdentityCredentialResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.notManagedIdentity(data),
+			ExpectError: regexp.MustCompile("can only be added to managed identity service principals"),
+		},
+	})
+}
+
+func (ServicePrincipalFederatedIdentityCredentialResource) managedIdentityObjectId(t *testing.T) string {
+	objectId := os.Getenv(servicePrincipalFederatedIdentityCredentialManagedIdentityEnvVar)
+	if objectId == "" {
+		t.Skipf("`%s` must be set to the object ID of a user-assigned managed identity", servicePrincipalFederatedIdentityCredentialManagedIdentityEnvVar)
+	}
+	return objectId
+}
+
+func (r ServicePrincipalFederatedIdentityCredentialResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.ServicePrincipals.FederatedIdentityCredentialClientBeta
+
+	id, err := beta.ParseServicePrincipalIdFederatedIdentityCredentialID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetFederatedIdentityCredential(ctx, *id, federatedidentitycredential.DefaultGetFederatedIdentityCredentialOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %+v", id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (ServicePrincipalFederatedIdentityCredentialResource) basic(data acceptance.TestData, objectId string) string {
+	return fmt.Sprintf(`
+resource "azuread_service_principal_federated_identity_credential" "test" {
+  service_principal_id = "/servicePrincipals/%[1]s"
+  display_name         = "hashitown.example.com-%[2]s"
+  audiences            = ["api://AzureADTokenExchange"]
+  issuer               = "https://tokens.hashitown.example.com.net"
+  subject              = "%[3]s"
+}
+`, objectId, data.RandomString, data.RandomID)
+}
+
+func (ServicePrincipalFederatedIdentityCredentialResource) claimsMatchingExpression(data acceptance.TestData, objectId string) string {
+	return fmt.Sprintf(`
+resource "azuread_service_principal_federated_identity_credential" "test" {
+  service_principal_id = "/servicePrincipals/%[1]s"
+  display_name         = "hashitown.example.com-%[2]s"
+  description          = "Funtime tokens for HashiTown"
+  audiences            = ["api://AzureADTokenExchange"]
+  issuer               = "https://token.actions.githubusercontent.com"
+
+  claims_matching_expression {
+    value = "claims['sub'] matches 'repo:hashitown/acctest-%[2]s:*'"
+  }
+}
+`, objectId, data.RandomString)
+}
+
+func (ServicePrincipalFederatedIdentityCredentialResource) notManagedIdentity(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctestServicePrincipal-%[1]d"
+}
+
+resource "azuread_service_principal" "test" {
+  client_id = azuread_application.test.client_id
+}
+
+resource "azuread_service_principal_federated_identity_credential" "test" {
+  service_principal_id = azuread_service_principal.test.id
+  display_name         = "hashitown.example.com-%[2]s"
+  audiences            = ["api://AzureADTokenExchange"]
+  issuer               = "https://tokens.hashitown.example.com.net"
+  subject              = "%[3]s"
+}
+`, data.RandomInteger, data.RandomString, data.RandomID)
+}
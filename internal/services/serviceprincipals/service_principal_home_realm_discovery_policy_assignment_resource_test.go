@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceprincipals_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/homerealmdiscoverypolicy"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type ServicePrincipalHomeRealmDiscoveryPolicyAssignmentResource struct{}
+
+func TestHomeRealmDiscoveryPolicyAssignment_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_home_realm_discovery_policy_assignment", "test")
+	r := ServicePrincipalHomeRealmDiscoveryPolicyAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basicHomeRealmDiscoveryPolicyAssignment(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r ServicePrincipalHomeRealmDiscoveryPolicyAssignmentResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.ServicePrincipals.HomeRealmDiscoveryPolicyClient
+
+	id, err := stable.ParseServicePrincipalIdHomeRealmDiscoveryPolicyID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Home Realm Discovery Policy Assignment ID: %v", err)
+	}
+
+	servicePrincipalId := stable.NewServicePrincipalID(id.ServicePrincipalId)
+
+	resp, err := client.ListHomeRealmDiscoveryPolicies(ctx, servicePrincipalId, homerealmdiscoverypolicy.DefaultListHomeRealmDiscoveryPoliciesOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, fmt.Errorf("%s does not exist", servicePrincipalId)
+		}
+		return nil, fmt.Errorf("failed to retrieve home realm discovery policy assignments for %s: %+v", servicePrincipalId, err)
+	}
+
+	if resp.Model != nil {
+		for _, p := range *resp.Model {
+			if pointer.From(p.Id) == id.HomeRealmDiscoveryPolicyId {
+				return pointer.To(true), nil
+			}
+		}
+	}
+
+	return pointer.To(false), nil
+}
+
+func (ServicePrincipalHomeRealmDiscoveryPolicyAssignmentResource) basicHomeRealmDiscoveryPolicyAssignment(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctestServicePrincipal-%[1]d"
+}
+
+resource "azuread_service_principal" "test" {
+  client_id = azuread_application.test.client_id
+}
+
+resource "azuread_home_realm_discovery_policy" "test" {
+  display_name = "acctest-%[2]s"
+
+  definition {
+    allow_cloud_password_validation = true
+  }
+}
+
+resource "azuread_service_principal_home_realm_discovery_policy_assignment" "test" {
+  home_realm_discovery_policy_id = azuread_home_realm_discovery_policy.test.id
+  service_principal_id           = azuread_service_principal.test.id
+}
+`, data.RandomInteger, data.RandomString)
+}
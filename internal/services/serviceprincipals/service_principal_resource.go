@@ -25,6 +25,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/applications"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/customsecurityattributes"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
@@ -99,6 +100,52 @@ func servicePrincipalResource() *pluginsdk.Resource {
 				Optional:    true,
 			},
 
+			"custom_security_attributes": {
+				Description: "Custom security attributes assigned to the service principal",
+				Type:        pluginsdk.TypeSet,
+				Optional:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"attribute_set": {
+							Description:  "The name of the attribute set containing the custom security attribute",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"name": {
+							Description:  "The name of the custom security attribute",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"type": {
+							Description:  "The data type of the custom security attribute",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(customsecurityattributes.PossibleValuesForType, false),
+						},
+
+						"value": {
+							Description: "The value to assign, for custom security attributes that accept a single value",
+							Type:        pluginsdk.TypeString,
+							Optional:    true,
+						},
+
+						"values": {
+							Description: "The values to assign, for custom security attributes that accept multiple values",
+							Type:        pluginsdk.TypeList,
+							Optional:    true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+					},
+				},
+			},
+
 			"description": {
 				Description:  "Description of the service principal provided for internal end-users",
 				Type:         pluginsdk.TypeString,
@@ -524,6 +571,16 @@ func servicePrincipalResourceCreate(ctx context.Context, d *pluginsdk.ResourceDa
 		}
 	}
 
+	if v := d.Get("custom_security_attributes").(*pluginsdk.Set).List(); len(v) > 0 {
+		payload, err := customsecurityattributes.Expand(v, nil)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "custom_security_attributes", "Could not assign custom security attributes for %s", id)
+		}
+		if err = customsecurityattributes.Update(ctx, client.Client, id.ID(), payload); err != nil {
+			return tf.ErrorDiagPathF(err, "custom_security_attributes", "Could not assign custom security attributes for %s", id)
+		}
+	}
+
 	return servicePrincipalResourceRead(ctx, d, meta)
 }
 
@@ -595,6 +652,17 @@ func servicePrincipalResourceUpdate(ctx context.Context, d *pluginsdk.ResourceDa
 		}
 	}
 
+	if d.HasChange("custom_security_attributes") {
+		oldSecurityAttributes, newSecurityAttributes := d.GetChange("custom_security_attributes")
+		payload, err := customsecurityattributes.Expand(newSecurityAttributes.(*pluginsdk.Set).List(), oldSecurityAttributes.(*pluginsdk.Set).List())
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "custom_security_attributes", "Could not assign custom security attributes for %s", id)
+		}
+		if err = customsecurityattributes.Update(ctx, client.Client, id.ID(), payload); err != nil {
+			return tf.ErrorDiagPathF(err, "custom_security_attributes", "Could not assign custom security attributes for %s", id)
+		}
+	}
+
 	return servicePrincipalResourceRead(ctx, d, meta)
 }
 
@@ -686,6 +754,20 @@ func servicePrincipalResourceRead(ctx context.Context, d *pluginsdk.ResourceData
 	}
 	tf.Set(d, "owners", owners)
 
+	// Reading custom security attributes requires additional permissions, and attributes may be assigned outside of
+	// Terraform, so only those attributes already being managed are tracked
+	if configuredSecurityAttributes := d.Get("custom_security_attributes").(*pluginsdk.Set).List(); len(configuredSecurityAttributes) > 0 {
+		securityAttributes, err := customsecurityattributes.Get(ctx, client.Client, id.ID())
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "custom_security_attributes", "Could not retrieve custom security attributes for %s", id)
+		}
+		flattenedAttributes, err := customsecurityattributes.Flatten(securityAttributes, configuredSecurityAttributes)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "custom_security_attributes", "Could not flatten custom security attributes for %s", id)
+		}
+		tf.Set(d, "custom_security_attributes", flattenedAttributes)
+	}
+
 	return nil
 }
 
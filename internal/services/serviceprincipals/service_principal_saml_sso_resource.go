@@ -0,0 +1,390 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceprincipals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/claimsmappingpolicy"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/credentials"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+const samlSingleSignOnMode = "saml"
+
+func servicePrincipalSamlSsoResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: servicePrincipalSamlSsoResourceCreateUpdate,
+		ReadContext:   servicePrincipalSamlSsoResourceRead,
+		UpdateContext: servicePrincipalSamlSsoResourceCreateUpdate,
+		DeleteContext: servicePrincipalSamlSsoResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			if _, errs := stable.ValidateServicePrincipalID(id, "id"); len(errs) > 0 {
+				out := ""
+				for _, err := range errs {
+					out += err.Error()
+				}
+				return errors.New(out)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"service_principal_id": {
+				Description:  "The ID of the service principal for which to configure SAML single sign-on",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: stable.ValidateServicePrincipalID,
+			},
+
+			"identifier_uris": {
+				Description: "The identifier URIs (entity IDs) which uniquely identify the application within the tenant",
+				Type:        pluginsdk.TypeSet,
+				Required:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"reply_urls": {
+				Description: "The reply URLs (assertion consumer service URLs) to which SAML tokens are sent",
+				Type:        pluginsdk.TypeSet,
+				Required:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.IsRedirectUriFunc(true, false),
+				},
+			},
+
+			"preferred_token_signing_key_thumbprint": {
+				Description:  "The thumbprint of the token signing certificate which should be used to sign SAML tokens",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				StateFunc: func(v interface{}) string {
+					return strings.ToUpper(v.(string))
+				},
+			},
+
+			"claims_mapping_policy_id": {
+				Description:  "The ID of a claims mapping policy to assign to the service principal, used to customize claims and the NameID format",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: stable.ValidatePolicyClaimsMappingPolicyID,
+			},
+
+			"application_id": {
+				Description: "The resource ID of the application backing the service principal",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func servicePrincipalSamlSsoResourceCreateUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalClient
+	applicationClient := meta.(*clients.Client).ServicePrincipals.ApplicationClient
+	claimsMappingPolicyClient := meta.(*clients.Client).ServicePrincipals.ClaimsMappingPolicyClient
+
+	servicePrincipalId, err := stable.ParseServicePrincipalID(d.Get("service_principal_id").(string))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "service_principal_id", "Parsing `service_principal_id`")
+	}
+
+	tf.LockByName(servicePrincipalResourceName, servicePrincipalId.ServicePrincipalId)
+	defer tf.UnlockByName(servicePrincipalResourceName, servicePrincipalId.ServicePrincipalId)
+
+	options := serviceprincipal.GetServicePrincipalOperationOptions{
+		Select: pointer.To([]string{"appId", "keyCredentials"}),
+	}
+	resp, err := client.GetServicePrincipal(ctx, *servicePrincipalId, options)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return tf.ErrorDiagPathF(errors.New("service principal was not found"), "service_principal_id", "Retrieving %s", servicePrincipalId)
+		}
+		return tf.ErrorDiagPathF(err, "service_principal_id", "Retrieving %s", servicePrincipalId)
+	}
+
+	servicePrincipal := resp.Model
+	if servicePrincipal == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", servicePrincipalId)
+	}
+
+	thumbprint := strings.ToUpper(d.Get("preferred_token_signing_key_thumbprint").(string))
+	if thumbprint != "" && (d.IsNewResource() || d.HasChange("preferred_token_signing_key_thumbprint")) {
+		found := false
+		for _, t := range servicePrincipalCertificateThumbprints(servicePrincipal.KeyCredentials) {
+			if strings.EqualFold(t, thumbprint) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return tf.ErrorDiagPathF(fmt.Errorf("thumbprint %q does not match any certificate belonging to %s", thumbprint, servicePrincipalId), "preferred_token_signing_key_thumbprint", "Validating preferred token signing key thumbprint")
+		}
+	}
+
+	applicationId, err := servicePrincipalSamlSsoFindApplication(ctx, applicationClient, servicePrincipal.AppId.GetOrZero())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Finding application for %s", servicePrincipalId)
+	}
+
+	applicationProperties := stable.Application{
+		IdentifierUris: tf.ExpandStringSlicePtr(d.Get("identifier_uris").(*pluginsdk.Set).List()),
+		Web: &stable.WebApplication{
+			RedirectUris: tf.ExpandStringSlicePtr(d.Get("reply_urls").(*pluginsdk.Set).List()),
+		},
+	}
+	if _, err = applicationClient.UpdateApplication(ctx, *applicationId, applicationProperties, application.DefaultUpdateApplicationOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Updating identifier URIs and reply URLs for %s", applicationId)
+	}
+
+	properties := stable.ServicePrincipal{
+		PreferredSingleSignOnMode: nullable.Value(samlSingleSignOnMode),
+	}
+	if thumbprint != "" {
+		properties.PreferredTokenSigningKeyThumbprint = nullable.Value(thumbprint)
+	}
+	if _, err = client.UpdateServicePrincipal(ctx, *servicePrincipalId, properties, serviceprincipal.DefaultUpdateServicePrincipalOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Configuring SAML single sign-on for %s", servicePrincipalId)
+	}
+
+	if d.IsNewResource() || d.HasChange("claims_mapping_policy_id") {
+		oldValue, newValue := d.GetChange("claims_mapping_policy_id")
+
+		if oldPolicyId := oldValue.(string); oldPolicyId != "" && !d.IsNewResource() {
+			policyId, err := stable.ParsePolicyClaimsMappingPolicyID(oldPolicyId)
+			if err != nil {
+				return tf.ErrorDiagPathF(err, "claims_mapping_policy_id", "Parsing `claims_mapping_policy_id`")
+			}
+
+			assignmentId := stable.NewServicePrincipalIdClaimsMappingPolicyID(servicePrincipalId.ServicePrincipalId, policyId.ClaimsMappingPolicyId)
+			if resp, err := claimsMappingPolicyClient.RemoveClaimsMappingPolicyRef(ctx, assignmentId, claimsmappingpolicy.DefaultRemoveClaimsMappingPolicyRefOperationOptions()); err != nil && !response.WasNotFound(resp.HttpResponse) {
+				return tf.ErrorDiagF(err, "Removing %s", assignmentId)
+			}
+		}
+
+		if newPolicyId := newValue.(string); newPolicyId != "" {
+			policyId, err := stable.ParsePolicyClaimsMappingPolicyID(newPolicyId)
+			if err != nil {
+				return tf.ErrorDiagPathF(err, "claims_mapping_policy_id", "Parsing `claims_mapping_policy_id`")
+			}
+
+			ref := stable.ReferenceCreate{
+				ODataId: pointer.To(claimsMappingPolicyClient.Client.BaseUri + stable.NewDirectoryObjectID(policyId.ClaimsMappingPolicyId).ID()),
+			}
+			if _, err = claimsMappingPolicyClient.AddClaimsMappingPolicyRef(ctx, *servicePrincipalId, ref, claimsmappingpolicy.DefaultAddClaimsMappingPolicyRefOperationOptions()); err != nil {
+				return tf.ErrorDiagF(err, "Assigning %s to %s", policyId, servicePrincipalId)
+			}
+		}
+	}
+
+	d.SetId(servicePrincipalId.ID())
+
+	return servicePrincipalSamlSsoResourceRead(ctx, d, meta)
+}
+
+func servicePrincipalSamlSsoResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalClient
+	applicationClient := meta.(*clients.Client).ServicePrincipals.ApplicationClient
+	claimsMappingPolicyClient := meta.(*clients.Client).ServicePrincipals.ClaimsMappingPolicyClient
+
+	id, err := stable.ParseServicePrincipalID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing ID")
+	}
+
+	options := serviceprincipal.GetServicePrincipalOperationOptions{
+		Select: pointer.To([]string{"appId", "preferredSingleSignOnMode", "preferredTokenSigningKeyThumbprint"}),
+	}
+	resp, err := client.GetServicePrincipal(ctx, *id, options)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing from state!", id)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+
+	servicePrincipal := resp.Model
+	if servicePrincipal == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+	}
+
+	if !strings.EqualFold(servicePrincipal.PreferredSingleSignOnMode.GetOrZero(), samlSingleSignOnMode) {
+		log.Printf("[DEBUG] SAML single sign-on is no longer configured for %s - removing from state!", id)
+		d.SetId("")
+		return nil
+	}
+
+	applicationId, err := servicePrincipalSamlSsoFindApplication(ctx, applicationClient, servicePrincipal.AppId.GetOrZero())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Finding application for %s", id)
+	}
+
+	applicationResp, err := applicationClient.GetApplication(ctx, *applicationId, application.DefaultGetApplicationOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving %s", applicationId)
+	}
+
+	app := applicationResp.Model
+	if app == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", applicationId)
+	}
+
+	var replyUrls []string
+	if app.Web != nil {
+		replyUrls = pointer.From(app.Web.RedirectUris)
+	}
+
+	policiesResp, err := claimsMappingPolicyClient.ListClaimsMappingPolicies(ctx, *id, claimsmappingpolicy.DefaultListClaimsMappingPoliciesOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing Claims Mapping Policy Assignments for %s", id)
+	}
+
+	// Only one claims mapping policy can be assigned to a service principal
+	claimsMappingPolicyId := ""
+	if policiesResp.Model != nil {
+		for _, policy := range *policiesResp.Model {
+			if policy.Id != nil {
+				claimsMappingPolicyId = stable.NewPolicyClaimsMappingPolicyID(*policy.Id).ID()
+				break
+			}
+		}
+	}
+
+	tf.Set(d, "application_id", applicationId.ID())
+	tf.Set(d, "claims_mapping_policy_id", claimsMappingPolicyId)
+	tf.Set(d, "identifier_uris", tf.FlattenStringSlicePtr(app.IdentifierUris))
+	tf.Set(d, "preferred_token_signing_key_thumbprint", strings.ToUpper(servicePrincipal.PreferredTokenSigningKeyThumbprint.GetOrZero()))
+	tf.Set(d, "reply_urls", replyUrls)
+	tf.Set(d, "service_principal_id", id.ID())
+
+	return nil
+}
+
+func servicePrincipalSamlSsoResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalClient
+	claimsMappingPolicyClient := meta.(*clients.Client).ServicePrincipals.ClaimsMappingPolicyClient
+
+	id, err := stable.ParseServicePrincipalID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing ID")
+	}
+
+	tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+	defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+	if v := d.Get("claims_mapping_policy_id").(string); v != "" {
+		policyId, err := stable.ParsePolicyClaimsMappingPolicyID(v)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "claims_mapping_policy_id", "Parsing `claims_mapping_policy_id`")
+		}
+
+		assignmentId := stable.NewServicePrincipalIdClaimsMappingPolicyID(id.ServicePrincipalId, policyId.ClaimsMappingPolicyId)
+		if resp, err := claimsMappingPolicyClient.RemoveClaimsMappingPolicyRef(ctx, assignmentId, claimsmappingpolicy.DefaultRemoveClaimsMappingPolicyRefOperationOptions()); err != nil && !response.WasNotFound(resp.HttpResponse) {
+			return tf.ErrorDiagF(err, "Removing %s", assignmentId)
+		}
+	}
+
+	// Identifier URIs and reply URLs are left in place on the application, since they may be shared with other
+	// configuration such as OIDC sign-in, so we only reset the preferred single sign-on mode here
+	properties := stable.ServicePrincipal{
+		PreferredSingleSignOnMode: nullable.NoZero(""),
+	}
+
+	if resp, err := client.UpdateServicePrincipal(ctx, *id, properties, serviceprincipal.DefaultUpdateServicePrincipalOperationOptions()); err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Disabling SAML single sign-on for %s", id)
+	}
+
+	return nil
+}
+
+// servicePrincipalSamlSsoFindApplication returns the ID of the application having the specified client ID
+func servicePrincipalSamlSsoFindApplication(ctx context.Context, client *application.ApplicationClient, clientId string) (*stable.ApplicationId, error) {
+	if clientId == "" {
+		return nil, errors.New("service principal returned with empty client ID")
+	}
+
+	options := application.ListApplicationsOperationOptions{
+		Filter: pointer.To(fmt.Sprintf("appId eq '%s'", odata.EscapeSingleQuote(clientId))),
+	}
+	resp, err := client.ListApplications(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("listing applications: %+v", err)
+	}
+
+	if resp.Model == nil {
+		return nil, errors.New("model was nil")
+	}
+	if len(*resp.Model) != 1 {
+		return nil, fmt.Errorf("unexpected number of applications returned for client ID %q (expected: 1, received: %d)", clientId, len(*resp.Model))
+	}
+
+	app := (*resp.Model)[0]
+	if app.Id == nil || *app.Id == "" {
+		return nil, errors.New("application returned with nil or empty object ID")
+	}
+
+	return pointer.To(stable.NewApplicationID(*app.Id)), nil
+}
+
+// servicePrincipalCertificateThumbprints returns the thumbprints of all certificates found in the provided key credentials
+func servicePrincipalCertificateThumbprints(keyCredentials *[]stable.KeyCredential) []string {
+	result := make([]string, 0)
+	if keyCredentials == nil {
+		return result
+	}
+
+	for _, cred := range *keyCredentials {
+		// For token signing certificates, the customKeyIdentifier holds the thumbprint
+		if v := cred.CustomKeyIdentifier.GetOrZero(); v != "" {
+			result = append(result, v)
+		}
+
+		if key := cred.Key.GetOrZero(); key != "" {
+			thumbprint, err := credentials.GetTokenSigningCertificateThumbprint(
+				[]byte("-----BEGIN CERTIFICATE-----\n" + key + "\n-----END CERTIFICATE-----"))
+			if err != nil {
+				// Not every key credential holds an X.509 certificate, so skip any that cannot be parsed
+				log.Printf("[DEBUG] Skipping key credential %q which could not be parsed as a certificate: %+v", cred.KeyId.GetOrZero(), err)
+				continue
+			}
+			result = append(result, thumbprint)
+		}
+	}
+
+	return result
+}
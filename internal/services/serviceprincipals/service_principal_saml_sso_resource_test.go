@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceprincipals_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type ServicePrincipalSamlSsoResource struct{}
+
+func TestAccServicePrincipalSamlSso_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_saml_sso", "test")
+	r := ServicePrincipalSamlSsoResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("application_id").Exists(),
+				check.That(data.ResourceName).Key("identifier_uris.#").HasValue("1"),
+				check.That(data.ResourceName).Key("reply_urls.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccServicePrincipalSamlSso_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_saml_sso", "test")
+	r := ServicePrincipalSamlSsoResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("claims_mapping_policy_id").Exists(),
+				check.That(data.ResourceName).Key("identifier_uris.#").HasValue("2"),
+				check.That(data.ResourceName).Key("preferred_token_signing_key_thumbprint").Exists(),
+				check.That(data.ResourceName).Key("reply_urls.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("claims_mapping_policy_id").HasValue(""),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r ServicePrincipalSamlSsoResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.ServicePrincipals.ServicePrincipalClient
+
+	id, err := stable.ParseServicePrincipalID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetServicePrincipal(ctx, *id, serviceprincipal.DefaultGetServicePrincipalOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %v", id, err)
+	}
+
+	if resp.Model == nil {
+		return nil, fmt.Errorf("retrieving %s: model was nil", id)
+	}
+
+	return pointer.To(strings.EqualFold(resp.Model.PreferredSingleSignOnMode.GetOrZero(), "saml")), nil
+}
+
+func (ServicePrincipalSamlSsoResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application_from_template" "test" {
+  display_name = "acctest-SamlSso-%[1]d"
+  template_id  = "%[2]s"
+}
+`, data.RandomInteger, testApplicationTemplateId)
+}
+
+func (r ServicePrincipalSamlSsoResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal_saml_sso" "test" {
+  service_principal_id = azuread_application_from_template.test.service_principal_id
+  identifier_uris      = ["https://acctest-%[2]d.example.com/saml"]
+  reply_urls           = ["https://acctest-%[2]d.example.com/saml/acs"]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ServicePrincipalSamlSsoResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal_token_signing_certificate" "test" {
+  service_principal_id = azuread_application_from_template.test.service_principal_id
+  display_name         = "CN=acctest-SamlSso-%[2]d"
+}
+
+resource "azuread_claims_mapping_policy" "test" {
+  definition = [
+    jsonencode({
+      ClaimsMappingPolicy = {
+        Version              = 1
+        IncludeBasicClaimSet = "true"
+        ClaimsSchema = [
+          {
+            Source           = "user"
+            ID               = "userprincipalname"
+            SamlClaimType    = "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/nameidentifier"
+            SamlNameIdFormat = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+          },
+        ]
+      }
+    }),
+  ]
+  display_name = "acctest-SamlSso-%[2]d"
+}
+
+resource "azuread_service_principal_saml_sso" "test" {
+  service_principal_id = azuread_application_from_template.test.service_principal_id
+
+  identifier_uris = [
+    "https://acctest-%[2]d.example.com/saml",
+    "https://acctest-%[2]d.example.net/saml",
+  ]
+
+  reply_urls = [
+    "https://acctest-%[2]d.example.com/saml/acs",
+    "https://acctest-%[2]d.example.net/saml/acs",
+  ]
+
+  preferred_token_signing_key_thumbprint = azuread_service_principal_token_signing_certificate.test.thumbprint
+  claims_mapping_policy_id               = azuread_claims_mapping_policy.test.id
+}
+`, r.template(data), data.RandomInteger)
+}
@@ -4,21 +4,77 @@
 package client
 
 import (
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directoryobjects/stable/directoryobject"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/me/stable/me"
 	userBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/users/beta/user"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/authenticationemailmethod"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/authenticationfido2method"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/authenticationmethod"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/authenticationphonemethod"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/authenticationtemporaryaccesspassmethod"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/directreport"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/manager"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/user"
 	"github.com/hashicorp/terraform-provider-azuread/internal/common"
 )
 
 type Client struct {
-	ManagerClient  *manager.ManagerClient
-	MeClient       *me.MeClient
-	UserClient     *user.UserClient
-	UserClientBeta *userBeta.UserClient
+	AuthenticationEmailMethodClient               *authenticationemailmethod.AuthenticationEmailMethodClient
+	AuthenticationFido2MethodClient               *authenticationfido2method.AuthenticationFido2MethodClient
+	AuthenticationMethodClient                    *authenticationmethod.AuthenticationMethodClient
+	AuthenticationPhoneMethodClient               *authenticationphonemethod.AuthenticationPhoneMethodClient
+	AuthenticationTemporaryAccessPassMethodClient *authenticationtemporaryaccesspassmethod.AuthenticationTemporaryAccessPassMethodClient
+	DirectReportClient                            *directreport.DirectReportClient
+	DirectoryObjectClient                         *directoryobject.DirectoryObjectClient
+	ManagerClient                                 *manager.ManagerClient
+	MeClient                                      *me.MeClient
+	UserClient                                    *user.UserClient
+	UserClientBeta                                *userBeta.UserClient
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
+	authenticationEmailMethodClient, err := authenticationemailmethod.NewAuthenticationEmailMethodClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(authenticationEmailMethodClient.Client)
+
+	authenticationFido2MethodClient, err := authenticationfido2method.NewAuthenticationFido2MethodClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(authenticationFido2MethodClient.Client)
+
+	authenticationMethodClient, err := authenticationmethod.NewAuthenticationMethodClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(authenticationMethodClient.Client)
+
+	authenticationPhoneMethodClient, err := authenticationphonemethod.NewAuthenticationPhoneMethodClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(authenticationPhoneMethodClient.Client)
+
+	authenticationTemporaryAccessPassMethodClient, err := authenticationtemporaryaccesspassmethod.NewAuthenticationTemporaryAccessPassMethodClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(authenticationTemporaryAccessPassMethodClient.Client)
+
+	directReportClient, err := directreport.NewDirectReportClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(directReportClient.Client)
+
+	directoryObjectClient, err := directoryobject.NewDirectoryObjectClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(directoryObjectClient.Client)
+
 	managerClient, err := manager.NewManagerClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
@@ -45,9 +101,16 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	o.Configure(userClientBeta.Client)
 
 	return &Client{
-		ManagerClient:  managerClient,
-		MeClient:       meClient,
-		UserClient:     userClient,
-		UserClientBeta: userClientBeta,
+		AuthenticationEmailMethodClient:               authenticationEmailMethodClient,
+		AuthenticationFido2MethodClient:               authenticationFido2MethodClient,
+		AuthenticationMethodClient:                    authenticationMethodClient,
+		AuthenticationPhoneMethodClient:               authenticationPhoneMethodClient,
+		AuthenticationTemporaryAccessPassMethodClient: authenticationTemporaryAccessPassMethodClient,
+		DirectReportClient:                            directReportClient,
+		DirectoryObjectClient:                         directoryObjectClient,
+		ManagerClient:                                 managerClient,
+		MeClient:                                      meClient,
+		UserClient:                                    userClient,
+		UserClientBeta:                                userClientBeta,
 	}, nil
 }
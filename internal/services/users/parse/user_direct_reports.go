@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+type UserDirectReportsId struct {
+	ManagerId string
+}
+
+func NewUserDirectReportsID(managerId string) UserDirectReportsId {
+	return UserDirectReportsId{
+		ManagerId: managerId,
+	}
+}
+
+func (id UserDirectReportsId) String() string {
+	return fmt.Sprintf("%s/directReports", id.ManagerId)
+}
+
+func UserDirectReportsID(idString string) (*UserDirectReportsId, error) {
+	parts := strings.Split(idString, "/")
+	if len(parts) != 2 || parts[1] != "directReports" {
+		return nil, fmt.Errorf("User Direct Reports ID should be in the format {managerId}/directReports - but got %q", idString)
+	}
+
+	if _, err := uuid.ParseUUID(parts[0]); err != nil {
+		return nil, fmt.Errorf("Manager ID isn't a valid UUID (%q): %+v", parts[0], err)
+	}
+
+	return &UserDirectReportsId{
+		ManagerId: parts[0],
+	}, nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+type UserLicenseAssignmentId struct {
+	UserId string
+	SkuId  string
+}
+
+func NewUserLicenseAssignmentID(userId, skuId string) UserLicenseAssignmentId {
+	return UserLicenseAssignmentId{
+		UserId: userId,
+		SkuId:  skuId,
+	}
+}
+
+func (id UserLicenseAssignmentId) String() string {
+	return fmt.Sprintf("%s/license/%s", id.UserId, id.SkuId)
+}
+
+func UserLicenseAssignmentID(idString string) (*UserLicenseAssignmentId, error) {
+	parts := strings.Split(idString, "/")
+	if len(parts) != 3 || parts[1] != "license" {
+		return nil, fmt.Errorf("User License Assignment ID should be in the format {userId}/license/{skuId} - but got %q", idString)
+	}
+
+	if _, err := uuid.ParseUUID(parts[0]); err != nil {
+		return nil, fmt.Errorf("User ID isn't a valid UUID (%q): %+v", parts[0], err)
+	}
+
+	if _, err := uuid.ParseUUID(parts[2]); err != nil {
+		return nil, fmt.Errorf("SKU ID isn't a valid UUID (%q): %+v", parts[2], err)
+	}
+
+	return &UserLicenseAssignmentId{
+		UserId: parts[0],
+		SkuId:  parts[2],
+	}, nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+type UserManagerId struct {
+	UserId string
+}
+
+func NewUserManagerID(userId string) UserManagerId {
+	return UserManagerId{
+		UserId: userId,
+	}
+}
+
+func (id UserManagerId) String() string {
+	return fmt.Sprintf("%s/manager", id.UserId)
+}
+
+func UserManagerID(idString string) (*UserManagerId, error) {
+	parts := strings.Split(idString, "/")
+	if len(parts) != 2 || parts[1] != "manager" {
+		return nil, fmt.Errorf("User Manager ID should be in the format {userId}/manager - but got %q", idString)
+	}
+
+	if _, err := uuid.ParseUUID(parts[0]); err != nil {
+		return nil, fmt.Errorf("User ID isn't a valid UUID (%q): %+v", parts[0], err)
+	}
+
+	return &UserManagerId{
+		UserId: parts[0],
+	}, nil
+}
@@ -27,14 +27,24 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azuread_user":  userDataSource(),
-		"azuread_users": usersData(),
+		"azuread_subscribed_skus":             subscribedSkusDataSource(),
+		"azuread_user":                        userDataSource(),
+		"azuread_user_authentication_methods": userAuthenticationMethodsDataSource(),
+		"azuread_users":                       usersData(),
 	}
 }
 
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azuread_user": userResource(),
+		"azuread_user":                             userResource(),
+		"azuread_user_direct_reports":              userDirectReportsResource(),
+		"azuread_user_email_authentication_method": userEmailAuthenticationMethodResource(),
+		"azuread_user_fido2_key_removal":           userFido2KeyRemovalResource(),
+		"azuread_user_license_assignment":          userLicenseAssignmentResource(),
+		"azuread_user_manager":                     userManagerResource(),
+		"azuread_user_phone_authentication_method": userPhoneAuthenticationMethodResource(),
+		"azuread_user_temporary_access_pass":       userTemporaryAccessPassResource(),
+		"azuread_users_bulk":                       usersBulkResource(),
 	}
 }
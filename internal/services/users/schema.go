@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+// schemaUserProperties returns the schema for user properties that map directly to the user object. These are shared
+// by the `azuread_user` and `azuread_users_bulk` resources, so that users are mapped and validated identically.
+func schemaUserProperties() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"account_enabled": {
+			Description: "Whether or not the account should be enabled",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+
+		"age_group": {
+			Description:  "The age group of the user",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(possibleValuesForAgeGroup, false),
+		},
+
+		"city": {
+			Description: "The city in which the user is located",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"company_name": {
+			Description: "The company name which the user is associated. This property can be useful for describing the company that an external user comes from",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"consent_provided_for_minor": {
+			Description:  "Whether consent has been obtained for minors",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(possibleValuesForConsentProvidedForMinor, false),
+		},
+
+		"cost_center": {
+			Description: "The cost center associated with the user.",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"country": {
+			Description: "The country/region in which the user is located, e.g. `US` or `UK`",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"department": {
+			Description: "The name for the department in which the user works",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"disable_password_expiration": {
+			Description: "Whether the users password is exempt from expiring",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+
+		"disable_strong_password": {
+			Description: "Whether the user is allowed weaker passwords than the default policy to be specified.",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+
+		"display_name": {
+			Description:  "The name to display in the address book for the user",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"division": {
+			Description: "The name of the division in which the user works.",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"employee_hire_date": {
+			Description:  "The hire date of the user, formatted as an RFC3339 date string (e.g. `2018-01-01T01:02:03Z`).",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsRFC3339Time,
+		},
+
+		"employee_id": {
+			Description:  "The employee identifier assigned to the user by the organisation",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringLenBetween(0, 16),
+		},
+
+		"employee_type": {
+			Description:  "Captures enterprise worker type. For example, Employee, Contractor, Consultant, or Vendor.",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringLenBetween(0, 64),
+		},
+
+		"fax_number": {
+			Description: "The fax number of the user",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"force_password_change": {
+			Description: "Whether the user is forced to change the password during the next sign-in. Only takes effect when also changing the password",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+
+		"given_name": {
+			Description: "The given name (first name) of the user",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"job_title": {
+			Description: "The user’s job title",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"mobile_phone": {
+			Description: "The primary cellular telephone number for the user",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"office_location": {
+			Description: "The office location in the user's place of business",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"password": {
+			Description:  "The password for the user. The password must satisfy minimum requirements as specified by the password policy. The maximum length is 256 characters. This property is required when creating a new user",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringLenBetween(1, 256), // Currently the max length for AAD passwords is 256
+		},
+
+		"postal_code": {
+			Description: "The postal code for the user's postal address. The postal code is specific to the user's country/region. In the United States of America, this attribute contains the ZIP code",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"preferred_language": {
+			Description:  "The user's preferred language, in ISO 639-1 notation",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.ISO639Language,
+		},
+
+		"state": {
+			Description: "The state or province in the user's address",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"street_address": {
+			Description: "The street address of the user's place of business",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"surname": {
+			Description: "The user's surname (family name or last name)",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"usage_location": {
+			Description: "The usage location of the user. Required for users that will be assigned licenses due to legal requirement to check for availability of services in countries. The usage location is a two letter country code (ISO standard 3166). Examples include: `NO`, `JP`, and `GB`. Cannot be reset to null once set",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+		},
+
+		"user_principal_name": {
+			Description:  "The user principal name (UPN) of the user",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsEmailAddress,
+		},
+	}
+}
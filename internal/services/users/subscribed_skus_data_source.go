@@ -0,0 +1,226 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/licensing"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func subscribedSkusDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		ReadContext: subscribedSkusDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"sku_part_numbers": {
+				Description: "The part numbers of the SKUs to return, e.g. `ENTERPRISEPACK`. When omitted, all SKUs are returned",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"skus": {
+				Description: "A list of commercial subscriptions that the tenant has acquired",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"sku_id": {
+							Description: "The unique identifier of the SKU",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"sku_part_number": {
+							Description: "The part number of the SKU, e.g. `AAD_PREMIUM`",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"applies_to": {
+							Description: "The type of object to which the SKU can be assigned, either `User` or `Company`",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"capability_status": {
+							Description: "The status of the subscription, e.g. `Enabled`, `Warning`, `Suspended`, `Deleted` or `LockedOut`",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"consumed_units": {
+							Description: "The number of licenses that have been assigned",
+							Type:        pluginsdk.TypeInt,
+							Computed:    true,
+						},
+
+						"enabled_units": {
+							Description: "The number of units that are enabled for the active subscription",
+							Type:        pluginsdk.TypeInt,
+							Computed:    true,
+						},
+
+						"suspended_units": {
+							Description: "The number of units that are suspended because the subscription has been cancelled",
+							Type:        pluginsdk.TypeInt,
+							Computed:    true,
+						},
+
+						"warning_units": {
+							Description: "The number of units that are in a warning state because the subscription has expired",
+							Type:        pluginsdk.TypeInt,
+							Computed:    true,
+						},
+
+						"locked_out_units": {
+							Description: "The number of units that are locked out because the subscription has been cancelled",
+							Type:        pluginsdk.TypeInt,
+							Computed:    true,
+						},
+
+						"available_units": {
+							Description: "The number of enabled units that have not yet been assigned",
+							Type:        pluginsdk.TypeInt,
+							Computed:    true,
+						},
+
+						"service_plans": {
+							Description: "A list of service plans included in the SKU",
+							Type:        pluginsdk.TypeList,
+							Computed:    true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"service_plan_id": {
+										Description: "The unique identifier of the service plan",
+										Type:        pluginsdk.TypeString,
+										Computed:    true,
+									},
+
+									"service_plan_name": {
+										Description: "The name of the service plan",
+										Type:        pluginsdk.TypeString,
+										Computed:    true,
+									},
+
+									"provisioning_status": {
+										Description: "The provisioning status of the service plan",
+										Type:        pluginsdk.TypeString,
+										Computed:    true,
+									},
+
+									"applies_to": {
+										Description: "The type of object to which the service plan can be assigned, either `User` or `Company`",
+										Type:        pluginsdk.TypeString,
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func subscribedSkusDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.UserClient
+
+	result, err := licensing.ListSubscribedSkus(ctx, client.Client)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not retrieve subscribed SKUs")
+	}
+
+	partNumbers := tf.ExpandStringSlice(d.Get("sku_part_numbers").([]interface{}))
+	wanted := make(map[string]bool, len(partNumbers))
+	for _, v := range partNumbers {
+		wanted[strings.ToUpper(v)] = false
+	}
+
+	skuIds := make([]string, 0)
+	skus := make([]map[string]interface{}, 0)
+	for _, sku := range result {
+		partNumber := sku.SkuPartNumber.GetOrZero()
+		if len(wanted) > 0 {
+			if _, ok := wanted[strings.ToUpper(partNumber)]; !ok {
+				continue
+			}
+			wanted[strings.ToUpper(partNumber)] = true
+		}
+
+		servicePlans := make([]map[string]interface{}, 0)
+		for _, plan := range pointer.From(sku.ServicePlans) {
+			servicePlans = append(servicePlans, map[string]interface{}{
+				"service_plan_id":     plan.ServicePlanId.GetOrZero(),
+				"service_plan_name":   plan.ServicePlanName.GetOrZero(),
+				"provisioning_status": plan.ProvisioningStatus.GetOrZero(),
+				"applies_to":          plan.AppliesTo.GetOrZero(),
+			})
+		}
+
+		var enabled, suspended, warning, lockedOut int64
+		if units := sku.PrepaidUnits; units != nil {
+			enabled = units.Enabled.GetOrZero()
+			suspended = units.Suspended.GetOrZero()
+			warning = units.Warning.GetOrZero()
+			lockedOut = units.LockedOut.GetOrZero()
+		}
+		consumed := sku.ConsumedUnits.GetOrZero()
+
+		available := enabled - consumed
+		if available < 0 {
+			available = 0
+		}
+
+		skuIds = append(skuIds, sku.SkuId.GetOrZero())
+		skus = append(skus, map[string]interface{}{
+			"sku_id":            sku.SkuId.GetOrZero(),
+			"sku_part_number":   partNumber,
+			"applies_to":        sku.AppliesTo.GetOrZero(),
+			"capability_status": sku.CapabilityStatus.GetOrZero(),
+			"consumed_units":    int(consumed),
+			"enabled_units":     int(enabled),
+			"suspended_units":   int(suspended),
+			"warning_units":     int(warning),
+			"locked_out_units":  int(lockedOut),
+			"available_units":   int(available),
+			"service_plans":     servicePlans,
+		})
+	}
+
+	for _, v := range partNumbers {
+		if !wanted[strings.ToUpper(v)] {
+			return tf.ErrorDiagPathF(nil, "sku_part_numbers", "No subscribed SKU found with part number: %q", v)
+		}
+	}
+
+	// Generate a unique ID based on result
+	h := sha1.New()
+	if _, err := h.Write([]byte(strings.Join(skuIds, "/"))); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for SKU IDs")
+	}
+
+	d.SetId("subscribedSkus#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+	tf.Set(d, "skus", skus)
+
+	return nil
+}
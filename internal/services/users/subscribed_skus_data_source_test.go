@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type SubscribedSkusDataSource struct{}
+
+func TestAccSubscribedSkusDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_subscribed_skus", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{{
+		Config: SubscribedSkusDataSource{}.basic(),
+		Check: acceptance.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("skus.0.sku_id").IsUuid(),
+			check.That(data.ResourceName).Key("skus.0.sku_part_number").Exists(),
+			check.That(data.ResourceName).Key("skus.0.service_plans.#").Exists(),
+		),
+	}})
+}
+
+func TestAccSubscribedSkusDataSource_bySkuPartNumbers(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_subscribed_skus", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{{
+		Config: SubscribedSkusDataSource{}.bySkuPartNumbers(),
+		Check: acceptance.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("skus.#").HasValue("1"),
+			check.That(data.ResourceName).Key("skus.0.sku_part_number").MatchesOtherKey(check.That("data.azuread_subscribed_skus.all").Key("skus.0.sku_part_number")),
+		),
+	}})
+}
+
+func (SubscribedSkusDataSource) basic() string {
+	return `
+data "azuread_subscribed_skus" "test" {}
+`
+}
+
+func (SubscribedSkusDataSource) bySkuPartNumbers() string {
+	return `
+data "azuread_subscribed_skus" "all" {}
+
+data "azuread_subscribed_skus" "test" {
+  sku_part_numbers = [data.azuread_subscribed_skus.all.skus.0.sku_part_number]
+}
+`
+}
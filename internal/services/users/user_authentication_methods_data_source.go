@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/authenticationmethod"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func userAuthenticationMethodsDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		ReadContext: userAuthenticationMethodsDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"user_object_id": {
+				Description:  "The object ID of the user",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"method_types": {
+				Description: "A list of the distinct types of authentication method registered for the user",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"methods": {
+				Description: "A list of authentication methods registered for the user",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Description: "The ID of the authentication method",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"type": {
+							Description: "The type of authentication method, e.g. `email`, `fido2`, `microsoftAuthenticator`, `password`, `phone`, `softwareOath`, `temporaryAccessPass` or `windowsHelloForBusiness`",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"display_name": {
+							Description: "The display name of the authentication method, where applicable",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"created_date_time": {
+							Description: "The date and time when the authentication method was registered, where available",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"email_address": {
+							Description: "The email address, for email authentication methods",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"phone_number": {
+							Description: "The phone number, for phone authentication methods",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"phone_type": {
+							Description: "The type of phone, for phone authentication methods",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func userAuthenticationMethodsDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.AuthenticationMethodClient
+
+	userId := stable.NewUserID(d.Get("user_object_id").(string))
+
+	resp, err := client.ListAuthenticationMethodsComplete(ctx, userId, authenticationmethod.DefaultListAuthenticationMethodsOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.LatestHttpResponse) {
+			return tf.ErrorDiagPathF(nil, "user_object_id", "%s was not found", userId)
+		}
+		return tf.ErrorDiagF(err, "Listing authentication methods for %s", userId)
+	}
+
+	methods := make([]map[string]interface{}, 0)
+	methodTypes := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, item := range resp.Items {
+		method := flattenUserAuthenticationMethod(item)
+		methods = append(methods, method)
+
+		if methodType := method["type"].(string); !seen[methodType] {
+			seen[methodType] = true
+			methodTypes = append(methodTypes, methodType)
+		}
+	}
+
+	sort.Strings(methodTypes)
+
+	d.SetId(fmt.Sprintf("%s/authenticationMethods", userId.UserId))
+
+	tf.Set(d, "method_types", methodTypes)
+	tf.Set(d, "methods", methods)
+
+	return nil
+}
+
+func flattenUserAuthenticationMethod(input stable.AuthenticationMethod) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":                pointer.From(input.AuthenticationMethod().Id),
+		"type":              "unknown",
+		"display_name":      "",
+		"created_date_time": "",
+		"email_address":     "",
+		"phone_number":      "",
+		"phone_type":        "",
+	}
+
+	switch method := input.(type) {
+	case stable.EmailAuthenticationMethod:
+		result["type"] = "email"
+		result["email_address"] = method.EmailAddress.GetOrZero()
+
+	case stable.Fido2AuthenticationMethod:
+		result["type"] = "fido2"
+		result["display_name"] = method.DisplayName.GetOrZero()
+		result["created_date_time"] = method.CreatedDateTime.GetOrZero()
+
+	case stable.MicrosoftAuthenticatorAuthenticationMethod:
+		result["type"] = "microsoftAuthenticator"
+		result["display_name"] = method.DisplayName.GetOrZero()
+		result["created_date_time"] = method.CreatedDateTime.GetOrZero()
+
+	case stable.PasswordAuthenticationMethod:
+		result["type"] = "password"
+		result["created_date_time"] = method.CreatedDateTime.GetOrZero()
+
+	case stable.PhoneAuthenticationMethod:
+		result["type"] = "phone"
+		result["phone_number"] = method.PhoneNumber.GetOrZero()
+		result["phone_type"] = string(pointer.From(method.PhoneType))
+
+	case stable.SoftwareOathAuthenticationMethod:
+		result["type"] = "softwareOath"
+
+	case stable.TemporaryAccessPassAuthenticationMethod:
+		result["type"] = "temporaryAccessPass"
+		result["created_date_time"] = method.CreatedDateTime.GetOrZero()
+
+	case stable.WindowsHelloForBusinessAuthenticationMethod:
+		result["type"] = "windowsHelloForBusiness"
+		result["display_name"] = method.DisplayName.GetOrZero()
+		result["created_date_time"] = method.CreatedDateTime.GetOrZero()
+	}
+
+	return result
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type UserAuthenticationMethodsDataSource struct{}
+
+func TestAccUserAuthenticationMethodsDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_user_authentication_methods", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{{
+		Config: UserAuthenticationMethodsDataSource{}.basic(data),
+		Check: acceptance.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("methods.#").Exists(),
+			check.That(data.ResourceName).Key("method_types.#").Exists(),
+		),
+	}})
+}
+
+func (UserAuthenticationMethodsDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_user_authentication_methods" "test" {
+  user_object_id = azuread_user_email_authentication_method.test.user_object_id
+}
+`, UserEmailAuthenticationMethodResource{}.basic(data, "first"))
+}
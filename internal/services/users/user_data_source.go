@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/user"
 	"github.com/hashicorp/go-azure-sdk/sdk/odata"
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/directoryextensions"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
@@ -162,6 +163,15 @@ func userDataSource() *pluginsdk.Resource {
 				Computed:    true,
 			},
 
+			"extension_attributes": {
+				Description: "A map of directory extension attribute values for the user, keyed by the full name of the extension property in the format `extension_{appId}_{name}`",
+				Type:        pluginsdk.TypeMap,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
 			"external_user_state": {
 				Description: "For an external user invited to the tenant, this property represents the invited user's invitation status",
 				Type:        pluginsdk.TypeString,
@@ -326,6 +336,7 @@ func userDataSource() *pluginsdk.Resource {
 
 func userDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).Users.UserClient
+	directoryObjectClient := meta.(*clients.Client).Users.DirectoryObjectClient
 	managerClient := meta.(*clients.Client).Users.ManagerClient
 
 	var foundObjectId *string
@@ -565,5 +576,19 @@ func userDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta int
 	}
 	tf.Set(d, "manager_id", managerId)
 
+	extensionProperties, err := directoryextensions.ListAvailable(ctx, directoryObjectClient, directoryextensions.TargetObjectUser)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not retrieve extension properties for %s", id)
+	}
+	extensionValues, err := directoryextensions.Get(ctx, client.Client, id.ID(), extensionProperties)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not retrieve extension attributes for %s", id)
+	}
+	extensionAttributes, err := directoryextensions.Flatten(extensionValues, extensionProperties, nil)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not flatten extension attributes for %s", id)
+	}
+	tf.Set(d, "extension_attributes", extensionAttributes)
+
 	return nil
 }
@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/directreport"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/manager"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/users/parse"
+)
+
+func userDirectReportsResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: userDirectReportsResourceCreate,
+		ReadContext:   userDirectReportsResourceRead,
+		UpdateContext: userDirectReportsResourceUpdate,
+		DeleteContext: userDirectReportsResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(15 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(15 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(15 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.UserDirectReportsID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"manager_object_id": {
+				Description:  "The object ID of the manager whose direct reports should be managed",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"direct_report_object_ids": {
+				Description: "A set of object IDs of users who should report directly to the manager",
+				Type:        pluginsdk.TypeSet,
+				Required:    true,
+				Set:         pluginsdk.HashString,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.IsUUID,
+				},
+			},
+		},
+	}
+}
+
+func userDirectReportsResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.UserClient
+	directReportClient := meta.(*clients.Client).Users.DirectReportClient
+	managerClient := meta.(*clients.Client).Users.ManagerClient
+
+	id := stable.NewUserID(d.Get("manager_object_id").(string))
+	resourceId := parse.NewUserDirectReportsID(id.UserId)
+
+	if exists, err := userExists(ctx, client, id); err != nil {
+		return tf.ErrorDiagPathF(err, "manager_object_id", "Retrieving %s", id)
+	} else if !exists {
+		return tf.ErrorDiagPathF(nil, "manager_object_id", "%s was not found", id)
+	}
+
+	desiredReports := tf.ExpandStringSlice(d.Get("direct_report_object_ids").(*pluginsdk.Set).List())
+	if diags := userApplyDirectReports(ctx, directReportClient, managerClient, id, desiredReports); diags != nil {
+		return diags
+	}
+
+	d.SetId(resourceId.String())
+
+	return userDirectReportsResourceRead(ctx, d, meta)
+}
+
+func userDirectReportsResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	directReportClient := meta.(*clients.Client).Users.DirectReportClient
+	managerClient := meta.(*clients.Client).Users.ManagerClient
+
+	resourceId, err := parse.UserDirectReportsID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing User Direct Reports ID %q", d.Id())
+	}
+	id := stable.NewUserID(resourceId.ManagerId)
+
+	if d.HasChange("direct_report_object_ids") {
+		desiredReports := tf.ExpandStringSlice(d.Get("direct_report_object_ids").(*pluginsdk.Set).List())
+		if diags := userApplyDirectReports(ctx, directReportClient, managerClient, id, desiredReports); diags != nil {
+			return diags
+		}
+	}
+
+	return userDirectReportsResourceRead(ctx, d, meta)
+}
+
+func userDirectReportsResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.UserClient
+	directReportClient := meta.(*clients.Client).Users.DirectReportClient
+
+	resourceId, err := parse.UserDirectReportsID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing User Direct Reports ID %q", d.Id())
+	}
+	id := stable.NewUserID(resourceId.ManagerId)
+
+	if exists, err := userExists(ctx, client, id); err != nil {
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	} else if !exists {
+		log.Printf("[DEBUG] %s was not found - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	reports, err := userListDirectReportIds(ctx, directReportClient, id)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing direct reports for %s", id)
+	}
+
+	tf.Set(d, "manager_object_id", id.UserId)
+	tf.Set(d, "direct_report_object_ids", reports)
+
+	return nil
+}
+
+func userDirectReportsResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	directReportClient := meta.(*clients.Client).Users.DirectReportClient
+	managerClient := meta.(*clients.Client).Users.ManagerClient
+
+	resourceId, err := parse.UserDirectReportsID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing User Direct Reports ID %q", d.Id())
+	}
+	id := stable.NewUserID(resourceId.ManagerId)
+
+	existingReports, err := userListDirectReportIds(ctx, directReportClient, id)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing direct reports for %s", id)
+	}
+
+	// Only remove the direct reports we know about, leaving any that were assigned after the last refresh
+	managedReports := tf.ExpandStringSlice(d.Get("direct_report_object_ids").(*pluginsdk.Set).List())
+	desiredReports := tf.Difference(existingReports, managedReports)
+
+	return userApplyDirectReports(ctx, directReportClient, managerClient, id, desiredReports)
+}
+
+// userApplyDirectReports assigns or removes the manager for users so that the direct reports of the manager match
+// `desiredReports`. Users are never created or deleted, only their manager reference is changed.
+func userApplyDirectReports(ctx context.Context, directReportClient *directreport.DirectReportClient, managerClient *manager.ManagerClient, id stable.UserId, desiredReports []string) pluginsdk.Diagnostics {
+	existingReports, err := userListDirectReportIds(ctx, directReportClient, id)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing existing direct reports for %s", id)
+	}
+
+	reportsForRemoval := tf.Difference(existingReports, desiredReports)
+	reportsToAdd := tf.Difference(desiredReports, existingReports)
+
+	log.Printf("[DEBUG] Assigning %d and removing %d direct reports for %s", len(reportsToAdd), len(reportsForRemoval), id)
+
+	for _, reportId := range reportsToAdd {
+		if err = userSetManager(ctx, managerClient, stable.NewUserID(reportId), id.UserId); err != nil {
+			return tf.ErrorDiagPathF(err, "direct_report_object_ids", "Could not assign %s as a direct report of %s", stable.NewUserID(reportId), id)
+		}
+	}
+
+	for _, reportId := range reportsForRemoval {
+		if err = userRemoveManager(ctx, managerClient, stable.NewUserID(reportId), id.UserId); err != nil {
+			return tf.ErrorDiagPathF(err, "direct_report_object_ids", "Could not remove %s as a direct report of %s", stable.NewUserID(reportId), id)
+		}
+	}
+
+	// Direct reports are computed from the manager reference of each user, so wait for the reverse relationship
+	if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		reports, err := userListDirectReportIds(ctx, directReportClient, id)
+		if err != nil {
+			return nil, err
+		}
+		return pointer.To(len(tf.Difference(reports, desiredReports)) == 0 && len(tf.Difference(desiredReports, reports)) == 0), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for direct reports of %s to be updated", id)
+	}
+
+	return nil
+}
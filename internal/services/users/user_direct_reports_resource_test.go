@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/user"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/users/parse"
+)
+
+type UserDirectReportsResource struct{}
+
+func TestAccUserDirectReports_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_direct_reports", "test")
+	r := UserDirectReportsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, 2),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("manager_object_id").IsUuid(),
+				check.That(data.ResourceName).Key("direct_report_object_ids.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccUserDirectReports_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_direct_reports", "test")
+	r := UserDirectReportsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, 1),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("direct_report_object_ids.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, 3),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("direct_report_object_ids.#").HasValue("3"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, 0),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("direct_report_object_ids.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r UserDirectReportsResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Users.UserClient
+
+	id, err := parse.UserDirectReportsID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing User Direct Reports ID: %v", err)
+	}
+
+	resp, err := client.GetUser(ctx, stable.NewUserID(id.ManagerId), user.DefaultGetUserOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve manager with object ID %q: %+v", id.ManagerId, err)
+	}
+
+	return pointer.To(true), nil
+}
+
+func (UserDirectReportsResource) basic(data acceptance.TestData, count int) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_user" "manager" {
+  user_principal_name = "acctestManager.%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestManager-%[1]d"
+  password            = "%[2]s"
+}
+
+resource "azuread_user" "test" {
+  count = 3
+
+  user_principal_name = "acctestUser.%[1]d.${count.index}@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[1]d-${count.index}"
+  password            = "%[2]s"
+}
+
+resource "azuread_user_direct_reports" "test" {
+  manager_object_id        = azuread_user.manager.object_id
+  direct_report_object_ids = slice(azuread_user.test.*.object_id, 0, %[3]d)
+}
+`, data.RandomInteger, data.RandomPassword, count)
+}
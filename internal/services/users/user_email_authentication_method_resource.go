@@ -0,0 +1,210 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/authenticationemailmethod"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func userEmailAuthenticationMethodResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: userEmailAuthenticationMethodResourceCreate,
+		ReadContext:   userEmailAuthenticationMethodResourceRead,
+		UpdateContext: userEmailAuthenticationMethodResourceUpdate,
+		DeleteContext: userEmailAuthenticationMethodResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := stable.ParseUserIdAuthenticationEmailMethodID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"user_object_id": {
+				Description:  "The object ID of the user for whom the email authentication method should be registered",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"email_address": {
+				Description:  "The email address to be used for self-service password reset",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func userEmailAuthenticationMethodResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.AuthenticationEmailMethodClient
+	userClient := meta.(*clients.Client).Users.UserClient
+
+	userId := stable.NewUserID(d.Get("user_object_id").(string))
+
+	tf.LockByName(userResourceName, userId.UserId)
+	defer tf.UnlockByName(userResourceName, userId.UserId)
+
+	if exists, err := userExists(ctx, userClient, userId); err != nil {
+		return tf.ErrorDiagPathF(err, "user_object_id", "Retrieving %s", userId)
+	} else if !exists {
+		return tf.ErrorDiagPathF(nil, "user_object_id", "%s was not found", userId)
+	}
+
+	// A user can only have a single email authentication method
+	existing, err := client.ListAuthenticationEmailMethodsComplete(ctx, userId, authenticationemailmethod.DefaultListAuthenticationEmailMethodsOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing email authentication methods for %s", userId)
+	}
+	for _, method := range existing.Items {
+		if pointer.From(method.Id) != "" {
+			return tf.ImportAsExistsDiag("azuread_user_email_authentication_method", stable.NewUserIdAuthenticationEmailMethodID(userId.UserId, *method.Id).ID())
+		}
+	}
+
+	properties := stable.EmailAuthenticationMethod{
+		EmailAddress: nullable.Value(d.Get("email_address").(string)),
+	}
+
+	options := authenticationemailmethod.CreateAuthenticationEmailMethodOperationOptions{
+		RetryFunc: func(resp *http.Response, o *odata.OData) (bool, error) {
+			return response.WasNotFound(resp), nil
+		},
+	}
+
+	resp, err := client.CreateAuthenticationEmailMethod(ctx, userId, properties, options)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Registering email authentication method for %s", userId)
+	}
+
+	if resp.Model == nil || pointer.From(resp.Model.Id) == "" {
+		return tf.ErrorDiagF(errors.New("API returned email authentication method with nil ID"), "Bad API Response")
+	}
+
+	id := stable.NewUserIdAuthenticationEmailMethodID(userId.UserId, *resp.Model.Id)
+	d.SetId(id.ID())
+
+	if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		resp, err := client.GetAuthenticationEmailMethod(ctx, id, authenticationemailmethod.DefaultGetAuthenticationEmailMethodOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(resp.Model != nil), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for creation of %s", id)
+	}
+
+	return userEmailAuthenticationMethodResourceRead(ctx, d, meta)
+}
+
+func userEmailAuthenticationMethodResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.AuthenticationEmailMethodClient
+
+	id, err := stable.ParseUserIdAuthenticationEmailMethodID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing email authentication method ID %q", d.Id())
+	}
+
+	tf.LockByName(userResourceName, id.UserId)
+	defer tf.UnlockByName(userResourceName, id.UserId)
+
+	properties := stable.EmailAuthenticationMethod{
+		EmailAddress: nullable.Value(d.Get("email_address").(string)),
+	}
+
+	if _, err = client.UpdateAuthenticationEmailMethod(ctx, *id, properties, authenticationemailmethod.DefaultUpdateAuthenticationEmailMethodOperationOptions()); err != nil {
+		return tf.ErrorDiagPathF(err, "email_address", "Updating %s", id)
+	}
+
+	return userEmailAuthenticationMethodResourceRead(ctx, d, meta)
+}
+
+func userEmailAuthenticationMethodResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.AuthenticationEmailMethodClient
+
+	id, err := stable.ParseUserIdAuthenticationEmailMethodID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing email authentication method ID %q", d.Id())
+	}
+
+	resp, err := client.GetAuthenticationEmailMethod(ctx, *id, authenticationemailmethod.DefaultGetAuthenticationEmailMethodOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+
+	method := resp.Model
+	if method == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+	}
+
+	tf.Set(d, "user_object_id", id.UserId)
+	tf.Set(d, "email_address", method.EmailAddress.GetOrZero())
+
+	return nil
+}
+
+func userEmailAuthenticationMethodResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.AuthenticationEmailMethodClient
+
+	id, err := stable.ParseUserIdAuthenticationEmailMethodID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing email authentication method ID %q", d.Id())
+	}
+
+	tf.LockByName(userResourceName, id.UserId)
+	defer tf.UnlockByName(userResourceName, id.UserId)
+
+	if resp, err := client.DeleteAuthenticationEmailMethod(ctx, *id, authenticationemailmethod.DefaultDeleteAuthenticationEmailMethodOperationOptions()); err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Deleting %s", id)
+	}
+
+	if err = consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
+		if resp, err := client.GetAuthenticationEmailMethod(ctx, *id, authenticationemailmethod.DefaultGetAuthenticationEmailMethodOperationOptions()); err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(true), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for deletion of %s", id)
+	}
+
+	return nil
+}
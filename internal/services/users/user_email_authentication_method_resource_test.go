@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/authenticationemailmethod"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type UserEmailAuthenticationMethodResource struct{}
+
+func TestAccUserEmailAuthenticationMethod_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_email_authentication_method", "test")
+	r := UserEmailAuthenticationMethodResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("email_address").HasValue(fmt.Sprintf("acctest.%d.second@example.com", data.RandomInteger)),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccUserEmailAuthenticationMethod_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_email_authentication_method", "test")
+	r := UserEmailAuthenticationMethodResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport(data)),
+	})
+}
+
+func (r UserEmailAuthenticationMethodResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Users.AuthenticationEmailMethodClient
+
+	id, err := stable.ParseUserIdAuthenticationEmailMethodID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetAuthenticationEmailMethod(ctx, *id, authenticationemailmethod.DefaultGetAuthenticationEmailMethodOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %+v", id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r UserEmailAuthenticationMethodResource) basic(data acceptance.TestData, suffix string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user_email_authentication_method" "test" {
+  user_object_id = azuread_user.test.object_id
+  email_address  = "acctest.%[2]d.%[3]s@example.com"
+}
+`, UserTemporaryAccessPassResource{}.template(data), data.RandomInteger, suffix)
+}
+
+func (r UserEmailAuthenticationMethodResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user_email_authentication_method" "import" {
+  user_object_id = azuread_user_email_authentication_method.test.user_object_id
+  email_address  = azuread_user_email_authentication_method.test.email_address
+}
+`, r.basic(data, "first"))
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/authenticationfido2method"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+// FIDO2 security keys can only be registered interactively by the user, so this resource manages the removal of an
+// existing key. Creating the resource removes the key, and destroying the resource only removes it from state.
+func userFido2KeyRemovalResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: userFido2KeyRemovalResourceCreate,
+		ReadContext:   userFido2KeyRemovalResourceRead,
+		DeleteContext: userFido2KeyRemovalResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"user_object_id": {
+				Description:  "The object ID of the user from whom the FIDO2 security key should be removed",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"key_id": {
+				Description:  "The ID of the FIDO2 security key authentication method to remove",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"aaguid": {
+				Description: "The Authenticator Attestation GUID of the removed FIDO2 security key",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"display_name": {
+				Description: "The display name of the removed FIDO2 security key",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"model": {
+				Description: "The manufacturer-assigned model of the removed FIDO2 security key",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func userFido2KeyRemovalResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.AuthenticationFido2MethodClient
+
+	id := stable.NewUserIdAuthenticationFido2MethodID(d.Get("user_object_id").(string), d.Get("key_id").(string))
+
+	tf.LockByName(userResourceName, id.UserId)
+	defer tf.UnlockByName(userResourceName, id.UserId)
+
+	resp, err := client.GetAuthenticationFido2Method(ctx, id, authenticationfido2method.DefaultGetAuthenticationFido2MethodOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return tf.ErrorDiagPathF(nil, "key_id", "%s was not found", id)
+		}
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+
+	key := resp.Model
+	if key == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+	}
+
+	if _, err = client.DeleteAuthenticationFido2Method(ctx, id, authenticationfido2method.DefaultDeleteAuthenticationFido2MethodOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Removing %s", id)
+	}
+
+	if err = consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
+		if resp, err := client.GetAuthenticationFido2Method(ctx, id, authenticationfido2method.DefaultGetAuthenticationFido2MethodOperationOptions()); err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(true), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for removal of %s", id)
+	}
+
+	d.SetId(id.ID())
+
+	// The key no longer exists, so record its details now
+	tf.Set(d, "aaguid", key.AaGuid.GetOrZero())
+	tf.Set(d, "display_name", key.DisplayName.GetOrZero())
+	tf.Set(d, "model", key.Model.GetOrZero())
+
+	return userFido2KeyRemovalResourceRead(ctx, d, meta)
+}
+
+func userFido2KeyRemovalResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.AuthenticationFido2MethodClient
+	userClient := meta.(*clients.Client).Users.UserClient
+
+	id, err := stable.ParseUserIdAuthenticationFido2MethodID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing FIDO2 authentication method ID %q", d.Id())
+	}
+
+	if exists, err := userExists(ctx, userClient, stable.NewUserID(id.UserId)); err != nil {
+		return tf.ErrorDiagF(err, "Retrieving user with object ID %q", id.UserId)
+	} else if !exists {
+		log.Printf("[DEBUG] User with object ID %q was not found - removing FIDO2 key removal from state", id.UserId)
+		d.SetId("")
+		return nil
+	}
+
+	resp, err := client.GetAuthenticationFido2Method(ctx, *id, authenticationfido2method.DefaultGetAuthenticationFido2MethodOperationOptions())
+	if err != nil {
+		if !response.WasNotFound(resp.HttpResponse) {
+			return tf.ErrorDiagF(err, "Retrieving %s", id)
+		}
+	} else {
+		log.Printf("[DEBUG] %s is still registered - removing FIDO2 key removal from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	tf.Set(d, "user_object_id", id.UserId)
+	tf.Set(d, "key_id", id.Fido2AuthenticationMethodId)
+
+	return nil
+}
+
+func userFido2KeyRemovalResourceDelete(_ context.Context, d *pluginsdk.ResourceData, _ interface{}) pluginsdk.Diagnostics {
+	log.Printf("[DEBUG] FIDO2 security keys cannot be re-registered, removing %q from state only", d.Id())
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+)
+
+type UserFido2KeyRemovalResource struct{}
+
+// FIDO2 security keys cannot be registered via the API, so only the error case can be tested
+func TestAccUserFido2KeyRemoval_notFound(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_fido2_key_removal", "test")
+	r := UserFido2KeyRemovalResource{}
+
+	data.ResourceTestSkipCheckDestroyed(t, []acceptance.TestStep{
+		{
+			Config:      r.notFound(data),
+			ExpectError: regexp.MustCompile("was not found"),
+		},
+	})
+}
+
+func (r UserFido2KeyRemovalResource) notFound(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user_fido2_key_removal" "test" {
+  user_object_id = azuread_user.test.object_id
+  key_id         = "bm9uLWV4aXN0ZW50LWtleQ"
+}
+`, UserTemporaryAccessPassResource{}.template(data))
+}
@@ -0,0 +1,281 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/user"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/licensing"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/users/parse"
+)
+
+func userLicenseAssignmentResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: userLicenseAssignmentResourceCreate,
+		ReadContext:   userLicenseAssignmentResourceRead,
+		UpdateContext: userLicenseAssignmentResourceUpdate,
+		DeleteContext: userLicenseAssignmentResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.UserLicenseAssignmentID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"user_object_id": {
+				Description:  "The object ID of the user to which the license should be assigned",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"sku_id": {
+				Description:  "The unique identifier of the SKU to assign",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"disabled_plans": {
+				Description: "A set of service plan IDs that should be disabled for this license assignment",
+				Type:        pluginsdk.TypeSet,
+				Optional:    true,
+				Set:         pluginsdk.HashString,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.IsUUID,
+				},
+			},
+
+			"state": {
+				Description: "The state of the license assignment",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func userLicenseAssignmentResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.UserClient
+
+	id := stable.NewUserID(d.Get("user_object_id").(string))
+	skuId := d.Get("sku_id").(string)
+	resourceId := parse.NewUserLicenseAssignmentID(id.UserId, skuId)
+
+	tf.LockByName(userResourceName, id.UserId)
+	defer tf.UnlockByName(userResourceName, id.UserId)
+
+	u, err := userGetLicenseAssignments(ctx, client, id)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "user_object_id", "Retrieving %s", id)
+	}
+	if u == nil {
+		return tf.ErrorDiagPathF(nil, "user_object_id", "%s was not found", id)
+	}
+
+	// Only direct assignments are considered, since the user might also inherit the same SKU from a group
+	if licensing.FindAssignmentState(u.LicenseAssignmentStates, skuId, "") != nil {
+		return tf.ImportAsExistsDiag("azuread_user_license_assignment", resourceId.String())
+	}
+
+	disabledPlans := tf.ExpandStringSlice(d.Get("disabled_plans").(*pluginsdk.Set).List())
+
+	if err = userAssignLicense(ctx, client, id, skuId, disabledPlans); err != nil {
+		return tf.ErrorDiagF(err, "Assigning license for %s", resourceId)
+	}
+
+	d.SetId(resourceId.String())
+
+	if err = userWaitForLicenseAssignment(ctx, client, id, skuId, disabledPlans); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for license assignment for %s", resourceId)
+	}
+
+	return userLicenseAssignmentResourceRead(ctx, d, meta)
+}
+
+func userLicenseAssignmentResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.UserClient
+
+	resourceId, err := parse.UserLicenseAssignmentID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing User License Assignment ID %q", d.Id())
+	}
+	id := stable.NewUserID(resourceId.UserId)
+
+	tf.LockByName(userResourceName, id.UserId)
+	defer tf.UnlockByName(userResourceName, id.UserId)
+
+	if d.HasChange("disabled_plans") {
+		disabledPlans := tf.ExpandStringSlice(d.Get("disabled_plans").(*pluginsdk.Set).List())
+
+		// Assigning a SKU that is already assigned replaces the disabled plans for that assignment
+		if err = userAssignLicense(ctx, client, id, resourceId.SkuId, disabledPlans); err != nil {
+			return tf.ErrorDiagPathF(err, "disabled_plans", "Updating license assignment for %s", resourceId)
+		}
+
+		if err = userWaitForLicenseAssignment(ctx, client, id, resourceId.SkuId, disabledPlans); err != nil {
+			return tf.ErrorDiagF(err, "Waiting for license assignment for %s", resourceId)
+		}
+	}
+
+	return userLicenseAssignmentResourceRead(ctx, d, meta)
+}
+
+func userLicenseAssignmentResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.UserClient
+
+	resourceId, err := parse.UserLicenseAssignmentID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing User License Assignment ID %q", d.Id())
+	}
+	id := stable.NewUserID(resourceId.UserId)
+
+	u, err := userGetLicenseAssignments(ctx, client, id)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+	if u == nil {
+		log.Printf("[DEBUG] %s was not found - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	state := licensing.FindAssignmentState(u.LicenseAssignmentStates, resourceId.SkuId, "")
+	if state == nil {
+		log.Printf("[DEBUG] License assignment for SKU %q was not found for %s - removing from state", resourceId.SkuId, id)
+		d.SetId("")
+		return nil
+	}
+
+	tf.Set(d, "user_object_id", id.UserId)
+	tf.Set(d, "sku_id", resourceId.SkuId)
+	tf.Set(d, "disabled_plans", tf.FlattenStringSlicePtr(state.DisabledPlans))
+	tf.Set(d, "state", state.State.GetOrZero())
+
+	var diags pluginsdk.Diagnostics
+	if licensing.HasError(*state) {
+		diags = append(diags, pluginsdk.Diagnostic{
+			Severity: pluginsdk.DiagWarning,
+			Summary:  fmt.Sprintf("License assignment for SKU %q on %s has errors", resourceId.SkuId, id),
+			Detail:   fmt.Sprintf("The license assignment is in the %q state with the error: %s", state.State.GetOrZero(), licensing.DescribeError(state.Error.GetOrZero())),
+		})
+	}
+
+	return diags
+}
+
+func userLicenseAssignmentResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.UserClient
+
+	resourceId, err := parse.UserLicenseAssignmentID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing User License Assignment ID %q", d.Id())
+	}
+	id := stable.NewUserID(resourceId.UserId)
+
+	tf.LockByName(userResourceName, id.UserId)
+	defer tf.UnlockByName(userResourceName, id.UserId)
+
+	properties := user.AssignLicenseRequest{
+		AddLicenses:    &[]stable.AssignedLicense{},
+		RemoveLicenses: &[]string{resourceId.SkuId},
+	}
+
+	if resp, err := client.AssignLicense(ctx, id, properties, user.DefaultAssignLicenseOperationOptions()); err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Removing license assignment for %s", resourceId)
+	}
+
+	if err = consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
+		u, err := userGetLicenseAssignments(ctx, client, id)
+		if err != nil {
+			return nil, err
+		}
+		if u == nil {
+			return pointer.To(false), nil
+		}
+		return pointer.To(licensing.FindAssignmentState(u.LicenseAssignmentStates, resourceId.SkuId, "") != nil), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for removal of license assignment for %s", resourceId)
+	}
+
+	return nil
+}
+
+// userGetLicenseAssignments retrieves the license assignment states for a user, or nil if the user was not found
+func userGetLicenseAssignments(ctx context.Context, client *user.UserClient, id stable.UserId) (*stable.User, error) {
+	options := user.GetUserOperationOptions{
+		Select: &[]string{"id", "assignedLicenses", "licenseAssignmentStates"},
+	}
+
+	resp, err := client.GetUser(ctx, id, options)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return resp.Model, nil
+}
+
+func userAssignLicense(ctx context.Context, client *user.UserClient, id stable.UserId, skuId string, disabledPlans []string) error {
+	properties := user.AssignLicenseRequest{
+		AddLicenses: &[]stable.AssignedLicense{
+			{
+				SkuId:         nullable.Value(skuId),
+				DisabledPlans: &disabledPlans,
+			},
+		},
+		RemoveLicenses: &[]string{},
+	}
+
+	_, err := client.AssignLicense(ctx, id, properties, user.DefaultAssignLicenseOperationOptions())
+	return err
+}
+
+// userWaitForLicenseAssignment waits for a direct license assignment to be reported with the expected disabled plans
+func userWaitForLicenseAssignment(ctx context.Context, client *user.UserClient, id stable.UserId, skuId string, disabledPlans []string) error {
+	return consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		u, err := userGetLicenseAssignments(ctx, client, id)
+		if err != nil {
+			return nil, err
+		}
+		if u == nil {
+			return nil, fmt.Errorf("%s was not found", id)
+		}
+
+		state := licensing.FindAssignmentState(u.LicenseAssignmentStates, skuId, "")
+		if state == nil {
+			return pointer.To(false), nil
+		}
+
+		return pointer.To(licensing.DisabledPlansMatch(pointer.From(state.DisabledPlans), disabledPlans)), nil
+	})
+}
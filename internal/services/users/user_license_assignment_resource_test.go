@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/user"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/licensing"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/users/parse"
+)
+
+type UserLicenseAssignmentResource struct{}
+
+func TestAccUserLicenseAssignment_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_license_assignment", "test")
+	r := UserLicenseAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("sku_id").IsUuid(),
+				check.That(data.ResourceName).Key("state").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccUserLicenseAssignment_disabledPlans(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_license_assignment", "test")
+	r := UserLicenseAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("disabled_plans.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.disabledPlans(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("disabled_plans.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("disabled_plans.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccUserLicenseAssignment_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_license_assignment", "test")
+	r := UserLicenseAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport(data)),
+	})
+}
+
+func (r UserLicenseAssignmentResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Users.UserClient
+
+	id, err := parse.UserLicenseAssignmentID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing User License Assignment ID: %v", err)
+	}
+
+	resp, err := client.GetUser(ctx, stable.NewUserID(id.UserId), user.GetUserOperationOptions{
+		Select: &[]string{"id", "licenseAssignmentStates"},
+	})
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve user with object ID %q: %+v", id.UserId, err)
+	}
+	if resp.Model == nil {
+		return pointer.To(false), nil
+	}
+
+	return pointer.To(licensing.FindAssignmentState(resp.Model.LicenseAssignmentStates, id.SkuId, "") != nil), nil
+}
+
+func (UserLicenseAssignmentResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+data "azuread_subscribed_skus" "test" {}
+
+locals {
+  sku = [for s in data.azuread_subscribed_skus.test.skus : s if s.applies_to == "User" && s.available_units > 0 && length(s.service_plans) > 1][0]
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestUser.%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[1]d"
+  password            = "%[2]s"
+  usage_location      = "US"
+}
+`, data.RandomInteger, data.RandomPassword)
+}
+
+func (r UserLicenseAssignmentResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user_license_assignment" "test" {
+  user_object_id = azuread_user.test.object_id
+  sku_id         = local.sku.sku_id
+}
+`, r.template(data))
+}
+
+func (r UserLicenseAssignmentResource) disabledPlans(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user_license_assignment" "test" {
+  user_object_id = azuread_user.test.object_id
+  sku_id         = local.sku.sku_id
+  disabled_plans = [local.sku.service_plans.0.service_plan_id]
+}
+`, r.template(data))
+}
+
+func (r UserLicenseAssignmentResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user_license_assignment" "import" {
+  user_object_id = azuread_user_license_assignment.test.user_object_id
+  sku_id         = azuread_user_license_assignment.test.sku_id
+}
+`, r.basic(data))
+}
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/users/parse"
+)
+
+func userManagerResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: userManagerResourceCreate,
+		ReadContext:   userManagerResourceRead,
+		UpdateContext: userManagerResourceUpdate,
+		DeleteContext: userManagerResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.UserManagerID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"user_object_id": {
+				Description:  "The object ID of the user whose manager should be managed",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"manager_object_id": {
+				Description:  "The object ID of the user's manager",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+		},
+	}
+}
+
+func userManagerResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.UserClient
+	managerClient := meta.(*clients.Client).Users.ManagerClient
+
+	id := stable.NewUserID(d.Get("user_object_id").(string))
+	resourceId := parse.NewUserManagerID(id.UserId)
+	managerId := d.Get("manager_object_id").(string)
+
+	if exists, err := userExists(ctx, client, id); err != nil {
+		return tf.ErrorDiagPathF(err, "user_object_id", "Retrieving %s", id)
+	} else if !exists {
+		return tf.ErrorDiagPathF(nil, "user_object_id", "%s was not found", id)
+	}
+
+	existingManagerId, err := userGetManagerId(ctx, managerClient, id)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving manager for %s", id)
+	}
+	if existingManagerId != nil {
+		return tf.ImportAsExistsDiag("azuread_user_manager", resourceId.String())
+	}
+
+	if err = userSetManager(ctx, managerClient, id, managerId); err != nil {
+		return tf.ErrorDiagPathF(err, "manager_object_id", "Could not assign manager for %s", id)
+	}
+
+	d.SetId(resourceId.String())
+
+	return userManagerResourceRead(ctx, d, meta)
+}
+
+func userManagerResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	managerClient := meta.(*clients.Client).Users.ManagerClient
+
+	resourceId, err := parse.UserManagerID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing User Manager ID %q", d.Id())
+	}
+	id := stable.NewUserID(resourceId.UserId)
+
+	if d.HasChange("manager_object_id") {
+		if err = userSetManager(ctx, managerClient, id, d.Get("manager_object_id").(string)); err != nil {
+			return tf.ErrorDiagPathF(err, "manager_object_id", "Could not assign manager for %s", id)
+		}
+	}
+
+	return userManagerResourceRead(ctx, d, meta)
+}
+
+func userManagerResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.UserClient
+	managerClient := meta.(*clients.Client).Users.ManagerClient
+
+	resourceId, err := parse.UserManagerID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing User Manager ID %q", d.Id())
+	}
+	id := stable.NewUserID(resourceId.UserId)
+
+	if exists, err := userExists(ctx, client, id); err != nil {
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	} else if !exists {
+		log.Printf("[DEBUG] %s was not found - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	managerId, err := userGetManagerId(ctx, managerClient, id)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving manager for %s", id)
+	}
+	if managerId == nil {
+		log.Printf("[DEBUG] Manager for %s was not found - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	tf.Set(d, "user_object_id", id.UserId)
+	tf.Set(d, "manager_object_id", pointer.From(managerId))
+
+	return nil
+}
+
+func userManagerResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	managerClient := meta.(*clients.Client).Users.ManagerClient
+
+	resourceId, err := parse.UserManagerID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing User Manager ID %q", d.Id())
+	}
+	id := stable.NewUserID(resourceId.UserId)
+
+	if err = userRemoveManager(ctx, managerClient, id, d.Get("manager_object_id").(string)); err != nil {
+		return tf.ErrorDiagF(err, "Removing manager for %s", id)
+	}
+
+	return nil
+}
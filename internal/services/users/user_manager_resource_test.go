@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/manager"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/users/parse"
+)
+
+type UserManagerResource struct{}
+
+func TestAccUserManager_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_manager", "test")
+	r := UserManagerResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("user_object_id").IsUuid(),
+				check.That(data.ResourceName).Key("manager_object_id").IsUuid(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccUserManager_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_manager", "test")
+	r := UserManagerResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("manager_object_id").MatchesOtherKey(check.That("azuread_user.second").Key("object_id")),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccUserManager_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_manager", "test")
+	r := UserManagerResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport(data)),
+	})
+}
+
+func (r UserManagerResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Users.ManagerClient
+
+	id, err := parse.UserManagerID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing User Manager ID: %v", err)
+	}
+
+	resp, err := client.GetManager(ctx, stable.NewUserID(id.UserId), manager.DefaultGetManagerOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve manager for user with object ID %q: %+v", id.UserId, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (UserManagerResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestUser.%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[1]d"
+  password            = "%[2]s"
+}
+
+resource "azuread_user" "first" {
+  user_principal_name = "acctestManager.%[1]d.A@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestManager-%[1]d-A"
+  password            = "%[2]s"
+}
+
+resource "azuread_user" "second" {
+  user_principal_name = "acctestManager.%[1]d.B@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestManager-%[1]d-B"
+  password            = "%[2]s"
+}
+`, data.RandomInteger, data.RandomPassword)
+}
+
+func (r UserManagerResource) basic(data acceptance.TestData, managerName string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user_manager" "test" {
+  user_object_id    = azuread_user.test.object_id
+  manager_object_id = azuread_user.%[2]s.object_id
+}
+`, r.template(data), managerName)
+}
+
+func (r UserManagerResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuread_user_manager" "import" {
+  user_object_id    = azuread_user_manager.test.user_object_id
+  manager_object_id = azuread_user_manager.test.manager_object_id
+}
+`, r.basic(data, "first"))
+}
@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/authenticationphonemethod"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func userPhoneAuthenticationMethodResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: userPhoneAuthenticationMethodResourceCreate,
+		ReadContext:   userPhoneAuthenticationMethodResourceRead,
+		UpdateContext: userPhoneAuthenticationMethodResourceUpdate,
+		DeleteContext: userPhoneAuthenticationMethodResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := stable.ParseUserIdAuthenticationPhoneMethodID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"user_object_id": {
+				Description:  "The object ID of the user for whom the phone authentication method should be registered",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"phone_type": {
+				Description:  "The type of phone. A user can have one phone of each type",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForAuthenticationPhoneType(), false),
+			},
+
+			"phone_number": {
+				Description:  "The phone number to text or call for authentication, in the format `+{country code} {number}x{extension}`, with the extension being optional",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"sms_sign_in_state": {
+				Description: "Whether the phone is ready to be used for SMS sign-in",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func userPhoneAuthenticationMethodResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.AuthenticationPhoneMethodClient
+	userClient := meta.(*clients.Client).Users.UserClient
+
+	userId := stable.NewUserID(d.Get("user_object_id").(string))
+	phoneType := stable.AuthenticationPhoneType(d.Get("phone_type").(string))
+
+	tf.LockByName(userResourceName, userId.UserId)
+	defer tf.UnlockByName(userResourceName, userId.UserId)
+
+	if exists, err := userExists(ctx, userClient, userId); err != nil {
+		return tf.ErrorDiagPathF(err, "user_object_id", "Retrieving %s", userId)
+	} else if !exists {
+		return tf.ErrorDiagPathF(nil, "user_object_id", "%s was not found", userId)
+	}
+
+	existing, err := client.ListAuthenticationPhoneMethodsComplete(ctx, userId, authenticationphonemethod.DefaultListAuthenticationPhoneMethodsOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing phone authentication methods for %s", userId)
+	}
+	for _, method := range existing.Items {
+		if pointer.From(method.PhoneType) == phoneType && pointer.From(method.Id) != "" {
+			return tf.ImportAsExistsDiag("azuread_user_phone_authentication_method", stable.NewUserIdAuthenticationPhoneMethodID(userId.UserId, *method.Id).ID())
+		}
+	}
+
+	properties := stable.PhoneAuthenticationMethod{
+		PhoneNumber: nullable.Value(d.Get("phone_number").(string)),
+		PhoneType:   pointer.To(phoneType),
+	}
+
+	options := authenticationphonemethod.CreateAuthenticationPhoneMethodOperationOptions{
+		RetryFunc: func(resp *http.Response, o *odata.OData) (bool, error) {
+			return response.WasNotFound(resp), nil
+		},
+	}
+
+	resp, err := client.CreateAuthenticationPhoneMethod(ctx, userId, properties, options)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Registering %s phone authentication method for %s", phoneType, userId)
+	}
+
+	if resp.Model == nil || pointer.From(resp.Model.Id) == "" {
+		return tf.ErrorDiagF(errors.New("API returned phone authentication method with nil ID"), "Bad API Response")
+	}
+
+	id := stable.NewUserIdAuthenticationPhoneMethodID(userId.UserId, *resp.Model.Id)
+	d.SetId(id.ID())
+
+	if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		resp, err := client.GetAuthenticationPhoneMethod(ctx, id, authenticationphonemethod.DefaultGetAuthenticationPhoneMethodOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(resp.Model != nil), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for creation of %s", id)
+	}
+
+	return userPhoneAuthenticationMethodResourceRead(ctx, d, meta)
+}
+
+func userPhoneAuthenticationMethodResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.AuthenticationPhoneMethodClient
+
+	id, err := stable.ParseUserIdAuthenticationPhoneMethodID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing phone authentication method ID %q", d.Id())
+	}
+
+	tf.LockByName(userResourceName, id.UserId)
+	defer tf.UnlockByName(userResourceName, id.UserId)
+
+	// Both the phone number and the phone type must be specified when updating a phone authentication method
+	properties := stable.PhoneAuthenticationMethod{
+		PhoneNumber: nullable.Value(d.Get("phone_number").(string)),
+		PhoneType:   pointer.To(stable.AuthenticationPhoneType(d.Get("phone_type").(string))),
+	}
+
+	if _, err = client.UpdateAuthenticationPhoneMethod(ctx, *id, properties, authenticationphonemethod.DefaultUpdateAuthenticationPhoneMethodOperationOptions()); err != nil {
+		return tf.ErrorDiagPathF(err, "phone_number", "Updating %s", id)
+	}
+
+	return userPhoneAuthenticationMethodResourceRead(ctx, d, meta)
+}
+
+func userPhoneAuthenticationMethodResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.AuthenticationPhoneMethodClient
+
+	id, err := stable.ParseUserIdAuthenticationPhoneMethodID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing phone authentication method ID %q", d.Id())
+	}
+
+	resp, err := client.GetAuthenticationPhoneMethod(ctx, *id, authenticationphonemethod.DefaultGetAuthenticationPhoneMethodOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+
+	method := resp.Model
+	if method == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+	}
+
+	tf.Set(d, "user_object_id", id.UserId)
+	tf.Set(d, "phone_number", method.PhoneNumber.GetOrZero())
+	tf.Set(d, "phone_type", string(pointer.From(method.PhoneType)))
+	tf.Set(d, "sms_sign_in_state", string(pointer.From(method.SmsSignInState)))
+
+	return nil
+}
+
+func userPhoneAuthenticationMethodResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.AuthenticationPhoneMethodClient
+
+	id, err := stable.ParseUserIdAuthenticationPhoneMethodID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing phone authentication method ID %q", d.Id())
+	}
+
+	tf.LockByName(userResourceName, id.UserId)
+	defer tf.UnlockByName(userResourceName, id.UserId)
+
+	if resp, err := client.DeleteAuthenticationPhoneMethod(ctx, *id, authenticationphonemethod.DefaultDeleteAuthenticationPhoneMethodOperationOptions()); err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Deleting %s", id)
+	}
+
+	if err = consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
+		if resp, err := client.GetAuthenticationPhoneMethod(ctx, *id, authenticationphonemethod.DefaultGetAuthenticationPhoneMethodOperationOptions()); err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(true), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for deletion of %s", id)
+	}
+
+	return nil
+}
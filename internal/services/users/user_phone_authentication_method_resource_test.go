@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/authenticationphonemethod"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type UserPhoneAuthenticationMethodResource struct{}
+
+func TestAccUserPhoneAuthenticationMethod_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_phone_authentication_method", "test")
+	r := UserPhoneAuthenticationMethodResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "+1 5555550100"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("phone_type").HasValue("mobile"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccUserPhoneAuthenticationMethod_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_phone_authentication_method", "test")
+	r := UserPhoneAuthenticationMethodResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "+1 5555550100"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, "+1 5555550199"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("phone_number").HasValue("+1 5555550199"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccUserPhoneAuthenticationMethod_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_phone_authentication_method", "test")
+	r := UserPhoneAuthenticationMethodResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "+1 5555550100"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport(data)),
+	})
+}
+
+func (r UserPhoneAuthenticationMethodResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Users.AuthenticationPhoneMethodClient
+
+	id, err := stable.ParseUserIdAuthenticationPhoneMethodID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetAuthenticationPhoneMethod(ctx, *id, authenticationphonemethod.DefaultGetAuthenticationPhoneMethodOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %+v", id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r UserPhoneAuthenticationMethodResource) basic(data acceptance.TestData, phoneNumber string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user_phone_authentication_method" "test" {
+  user_object_id = azuread_user.test.object_id
+  phone_type     = "mobile"
+  phone_number   = "%[2]s"
+}
+`, UserTemporaryAccessPassResource{}.template(data), phoneNumber)
+}
+
+func (r UserPhoneAuthenticationMethodResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user_phone_authentication_method" "import" {
+  user_object_id = azuread_user_phone_authentication_method.test.user_object_id
+  phone_type     = azuread_user_phone_authentication_method.test.phone_type
+  phone_number   = azuread_user_phone_authentication_method.test.phone_number
+}
+`, r.basic(data, "+1 5555550100"))
+}
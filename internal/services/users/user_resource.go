@@ -6,7 +6,6 @@ package users
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
 	"net/http"
 	"strings"
@@ -23,6 +22,8 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/odata"
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/customsecurityattributes"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/directoryextensions"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
@@ -30,7 +31,7 @@ import (
 )
 
 func userResource() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
+	resource := &pluginsdk.Resource{
 		CreateContext: userResourceCreate,
 		ReadContext:   userResourceRead,
 		UpdateContext: userResourceUpdate,
@@ -66,34 +67,6 @@ func userResource() *pluginsdk.Resource {
 		},
 
 		Schema: map[string]*pluginsdk.Schema{
-			"user_principal_name": {
-				Description:  "The user principal name (UPN) of the user",
-				Type:         pluginsdk.TypeString,
-				Required:     true,
-				ValidateFunc: validation.StringIsEmailAddress,
-			},
-
-			"display_name": {
-				Description:  "The name to display in the address book for the user",
-				Type:         pluginsdk.TypeString,
-				Required:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
-			},
-
-			"account_enabled": {
-				Description: "Whether or not the account should be enabled",
-				Type:        pluginsdk.TypeBool,
-				Optional:    true,
-				Default:     true,
-			},
-
-			"age_group": {
-				Description:  "The age group of the user",
-				Type:         pluginsdk.TypeString,
-				Optional:     true,
-				ValidateFunc: validation.StringInSlice(possibleValuesForAgeGroup, false),
-			},
-
 			"business_phones": {
 				Description: "The telephone numbers for the user. Only one number can be set for this property. Read-only for users synced with Azure AD Connect",
 				Type:        pluginsdk.TypeList,
@@ -104,93 +77,60 @@ func userResource() *pluginsdk.Resource {
 				},
 			},
 
-			"city": {
-				Description: "The city in which the user is located",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
-			},
-
-			"company_name": {
-				Description: "The company name which the user is associated. This property can be useful for describing the company that an external user comes from",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
-			},
-
-			"consent_provided_for_minor": {
-				Description:  "Whether consent has been obtained for minors",
-				Type:         pluginsdk.TypeString,
-				Optional:     true,
-				ValidateFunc: validation.StringInSlice(possibleValuesForConsentProvidedForMinor, false),
-			},
-
-			"cost_center": {
-				Description: "The cost center associated with the user.",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
-			},
-
-			"country": {
-				Description: "The country/region in which the user is located, e.g. `US` or `UK`",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
-			},
-
-			"department": {
-				Description: "The name for the department in which the user works",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
-			},
-
-			"division": {
-				Description: "The name of the division in which the user works.",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
-			},
-
-			"employee_hire_date": {
-				Description:  "The hire date of the user, formatted as an RFC3339 date string (e.g. `2018-01-01T01:02:03Z`).",
-				Type:         pluginsdk.TypeString,
-				Optional:     true,
-				ValidateFunc: validation.IsRFC3339Time,
-			},
-
-			"employee_id": {
-				Description:  "The employee identifier assigned to the user by the organisation",
-				Type:         pluginsdk.TypeString,
-				Optional:     true,
-				ValidateFunc: validation.StringLenBetween(0, 16),
-			},
-
-			"employee_type": {
-				Description:  "Captures enterprise worker type. For example, Employee, Contractor, Consultant, or Vendor.",
-				Type:         pluginsdk.TypeString,
-				Optional:     true,
-				ValidateFunc: validation.StringLenBetween(0, 64),
-			},
-
-			"force_password_change": {
-				Description: "Whether the user is forced to change the password during the next sign-in. Only takes effect when also changing the password",
-				Type:        pluginsdk.TypeBool,
-				Optional:    true,
-				Default:     false,
-			},
-
-			"given_name": {
-				Description: "The given name (first name) of the user",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
-			},
-
-			"fax_number": {
-				Description: "The fax number of the user",
-				Type:        pluginsdk.TypeString,
+			"custom_security_attributes": {
+				Description: "Custom security attributes assigned to the user",
+				Type:        pluginsdk.TypeSet,
 				Optional:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"attribute_set": {
+							Description:  "The name of the attribute set containing the custom security attribute",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"name": {
+							Description:  "The name of the custom security attribute",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"type": {
+							Description:  "The data type of the custom security attribute",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(customsecurityattributes.PossibleValuesForType, false),
+						},
+
+						"value": {
+							Description: "The value to assign, for custom security attributes that accept a single value",
+							Type:        pluginsdk.TypeString,
+							Optional:    true,
+						},
+
+						"values": {
+							Description: "The values to assign, for custom security attributes that accept multiple values",
+							Type:        pluginsdk.TypeList,
+							Optional:    true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+					},
+				},
 			},
 
-			"job_title": {
-				Description: "The user’s job title",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
+			"extension_attributes": {
+				Description:      "A map of directory extension attribute values for the user, keyed by the full name of the extension property in the format `extension_{appId}_{name}`",
+				Type:             pluginsdk.TypeMap,
+				Optional:         true,
+				ValidateDiagFunc: validation.MapKeyMatch(directoryextensions.NameRegex, "must be the full name of a directory extension property, in the format `extension_{appId}_{name}`"),
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
 			},
 
 			"mail": {
@@ -211,18 +151,7 @@ func userResource() *pluginsdk.Resource {
 				Description: "The object ID of the user's manager",
 				Type:        pluginsdk.TypeString,
 				Optional:    true,
-			},
-
-			"mobile_phone": {
-				Description: "The primary cellular telephone number for the user",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
-			},
-
-			"office_location": {
-				Description: "The office location in the user's place of business",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
+				Computed:    true,
 			},
 
 			"onpremises_immutable_id": {
@@ -241,41 +170,6 @@ func userResource() *pluginsdk.Resource {
 				},
 			},
 
-			"password": {
-				Description:  "The password for the user. The password must satisfy minimum requirements as specified by the password policy. The maximum length is 256 characters. This property is required when creating a new user",
-				Type:         pluginsdk.TypeString,
-				Optional:     true,
-				Computed:     true,
-				Sensitive:    true,
-				ValidateFunc: validation.StringLenBetween(1, 256), // Currently the max length for AAD passwords is 256
-			},
-
-			"disable_strong_password": {
-				Description: "Whether the user is allowed weaker passwords than the default policy to be specified.",
-				Type:        pluginsdk.TypeBool,
-				Optional:    true,
-				Default:     false,
-			},
-			"disable_password_expiration": {
-				Description: "Whether the users password is exempt from expiring",
-				Type:        pluginsdk.TypeBool,
-				Optional:    true,
-				Default:     false,
-			},
-
-			"postal_code": {
-				Description: "The postal code for the user's postal address. The postal code is specific to the user's country/region. In the United States of America, this attribute contains the ZIP code",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
-			},
-
-			"preferred_language": {
-				Description:  "The user's preferred language, in ISO 639-1 notation",
-				Type:         pluginsdk.TypeString,
-				Optional:     true,
-				ValidateFunc: validation.ISO639Language,
-			},
-
 			"show_in_address_list": {
 				Description: "Whether or not the Outlook global address list should include this user",
 				Type:        pluginsdk.TypeBool,
@@ -283,30 +177,6 @@ func userResource() *pluginsdk.Resource {
 				Default:     true,
 			},
 
-			"state": {
-				Description: "The state or province in the user's address",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
-			},
-
-			"street_address": {
-				Description: "The street address of the user's place of business",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
-			},
-
-			"surname": {
-				Description: "The user's surname (family name or last name)",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
-			},
-
-			"usage_location": {
-				Description: "The usage location of the user. Required for users that will be assigned licenses due to legal requirement to check for availability of services in countries. The usage location is a two letter country code (ISO standard 3166). Examples include: `NO`, `JP`, and `GB`. Cannot be reset to null once set",
-				Type:        pluginsdk.TypeString,
-				Optional:    true,
-			},
-
 			"about_me": {
 				Description: "A freeform field for the user to describe themselves",
 				Type:        pluginsdk.TypeString,
@@ -392,23 +262,23 @@ func userResource() *pluginsdk.Resource {
 			},
 		},
 	}
+
+	for k, v := range schemaUserProperties() {
+		resource.Schema[k] = v
+	}
+
+	return resource
 }
 
 func userResourceCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
-	ageGroup := diff.Get("age_group").(string)
-	consentRequired := diff.Get("consent_provided_for_minor").(string)
-
-	if ageGroup != AgeGroupMinor && consentRequired != "" && consentRequired != ConsentProvidedForMinorNotRequired {
-		return fmt.Errorf("`consent_provided_for_minor` can only be set to %q or %q when `age_group` is %q or %q",
-			ConsentProvidedForMinorGranted, ConsentProvidedForMinorDenied, AgeGroupAdult, AgeGroupNotAdult)
-	}
-	return nil
+	return validateUserConsentForMinor(diff.Get("age_group").(string), diff.Get("consent_provided_for_minor").(string))
 }
 
 func userResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).Users.UserClient
 	clientBeta := meta.(*clients.Client).Users.UserClientBeta
 	managerClient := meta.(*clients.Client).Users.ManagerClient
+	directoryObjectClient := meta.(*clients.Client).Users.DirectoryObjectClient
 
 	password := d.Get("password").(string)
 	if password == "" {
@@ -423,55 +293,13 @@ func userResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta int
 		mailNickName = strings.Split(upn, "@")[0]
 	}
 
-	var passwordPolicies string
-	disableStrongPassword := d.Get("disable_strong_password").(bool)
-	disablePasswordExpiration := d.Get("disable_password_expiration").(bool)
-
-	switch {
-	case disableStrongPassword && (!disablePasswordExpiration):
-		passwordPolicies = "DisableStrongPassword"
-	case (!disableStrongPassword) && disablePasswordExpiration:
-		passwordPolicies = "DisablePasswordExpiration"
-	case disableStrongPassword && disablePasswordExpiration:
-		passwordPolicies = "DisablePasswordExpiration, DisableStrongPassword"
-	}
-
-	properties := stable.User{
-		AccountEnabled:          nullable.Value(d.Get("account_enabled").(bool)),
-		AgeGroup:                nullable.NoZero(d.Get("age_group").(string)),
-		City:                    nullable.NoZero(d.Get("city").(string)),
-		ConsentProvidedForMinor: nullable.NoZero(d.Get("consent_provided_for_minor").(string)),
-		CompanyName:             nullable.NoZero(d.Get("company_name").(string)),
-		Country:                 nullable.NoZero(d.Get("country").(string)),
-		Department:              nullable.NoZero(d.Get("department").(string)),
-		DisplayName:             nullable.NoZero(d.Get("display_name").(string)),
-		EmployeeId:              nullable.NoZero(d.Get("employee_id").(string)),
-		EmployeeOrgData: &stable.EmployeeOrgData{
-			CostCenter: nullable.NoZero(d.Get("cost_center").(string)),
-			Division:   nullable.NoZero(d.Get("division").(string)),
-		},
-		EmployeeType:      nullable.NoZero(d.Get("employee_type").(string)),
-		FaxNumber:         nullable.NoZero(d.Get("fax_number").(string)),
-		GivenName:         nullable.NoZero(d.Get("given_name").(string)),
-		JobTitle:          nullable.NoZero(d.Get("job_title").(string)),
-		Mail:              nullable.NoZero(d.Get("mail").(string)),
-		MailNickname:      nullable.NoZero(mailNickName),
-		MobilePhone:       nullable.NoZero(d.Get("mobile_phone").(string)),
-		OfficeLocation:    nullable.NoZero(d.Get("office_location").(string)),
-		OtherMails:        tf.ExpandStringSlicePtr(d.Get("other_mails").(*pluginsdk.Set).List()),
-		PasswordPolicies:  nullable.NoZero(passwordPolicies),
-		PostalCode:        nullable.NoZero(d.Get("postal_code").(string)),
-		PreferredLanguage: nullable.NoZero(d.Get("preferred_language").(string)),
-		State:             nullable.NoZero(d.Get("state").(string)),
-		StreetAddress:     nullable.NoZero(d.Get("street_address").(string)),
-		Surname:           nullable.NoZero(d.Get("surname").(string)),
-		UsageLocation:     nullable.NoZero(d.Get("usage_location").(string)),
-		UserPrincipalName: nullable.NoZero(upn),
-
-		PasswordProfile: &stable.PasswordProfile{
-			ForceChangePasswordNextSignIn: nullable.Value(d.Get("force_password_change").(bool)),
-			Password:                      nullable.NoZero(password),
-		},
+	properties := expandUserProperties(d.Get)
+	properties.Mail = nullable.NoZero(d.Get("mail").(string))
+	properties.MailNickname = nullable.NoZero(mailNickName)
+	properties.OtherMails = tf.ExpandStringSlicePtr(d.Get("other_mails").(*pluginsdk.Set).List())
+	properties.PasswordProfile = &stable.PasswordProfile{
+		ForceChangePasswordNextSignIn: nullable.Value(d.Get("force_password_change").(bool)),
+		Password:                      nullable.NoZero(password),
 	}
 
 	if v, ok := d.GetOk("business_phones"); ok {
@@ -482,14 +310,6 @@ func userResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta int
 		properties.OnPremisesImmutableId = nullable.NoZero(v.(string))
 	}
 
-	if v, ok := d.GetOk("employee_hire_date"); ok {
-		_, err := time.Parse(time.RFC3339, v.(string))
-		if err != nil {
-			tf.ErrorDiagF(err, "Unable to parse the provided employee_hire_date %q: %+v", v, err)
-		}
-		properties.EmployeeHireDate = nullable.NoZero(v.(string))
-	}
-
 	options := user.CreateUserOperationOptions{
 		RetryFunc: func(resp *http.Response, o *odata.OData) (bool, error) {
 			if response.WasBadRequest(resp) && o != nil && o.Error != nil {
@@ -499,6 +319,17 @@ func userResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta int
 		},
 	}
 
+	// Validate extension attributes against their declared data types before creating the user
+	configuredExtensionAttributes := d.Get("extension_attributes").(map[string]interface{})
+	extensionProperties, err := directoryextensions.ListOwned(ctx, directoryObjectClient, directoryextensions.TargetObjectUser, configuredExtensionAttributes)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "extension_attributes", "Could not retrieve extension properties")
+	}
+	extensionAttributes, err := directoryextensions.Expand(configuredExtensionAttributes, nil, extensionProperties)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "extension_attributes", "Could not set extension attributes")
+	}
+
 	resp, err := client.CreateUser(ctx, properties, options)
 	if err != nil {
 		return tf.ErrorDiagF(err, "Creating user %q", upn)
@@ -548,6 +379,20 @@ func userResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta int
 		return tf.ErrorDiagF(err, "Waiting for creation of %s", id)
 	}
 
+	if v := d.Get("custom_security_attributes").(*pluginsdk.Set).List(); len(v) > 0 {
+		payload, err := customsecurityattributes.Expand(v, nil)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "custom_security_attributes", "Could not assign custom security attributes for %s", id)
+		}
+		if err = customsecurityattributes.Update(ctx, client.Client, id.ID(), payload); err != nil {
+			return tf.ErrorDiagPathF(err, "custom_security_attributes", "Could not assign custom security attributes for %s", id)
+		}
+	}
+
+	if err = directoryextensions.Update(ctx, client.Client, id.ID(), extensionAttributes); err != nil {
+		return tf.ErrorDiagPathF(err, "extension_attributes", "Could not set extension attributes for %s", id)
+	}
+
 	return userResourceRead(ctx, d, meta)
 }
 
@@ -555,51 +400,16 @@ func userResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta int
 	client := meta.(*clients.Client).Users.UserClient
 	clientBeta := meta.(*clients.Client).Users.UserClientBeta
 	managerClient := meta.(*clients.Client).Users.ManagerClient
+	directoryObjectClient := meta.(*clients.Client).Users.DirectoryObjectClient
 
 	id, err := stable.ParseUserID(d.Id())
 	if err != nil {
 		return tf.ErrorDiagPathF(err, "id", "Parsing ID")
 	}
 
-	var passwordPolicies []string
-	if d.Get("disable_strong_password").(bool) {
-		passwordPolicies = append(passwordPolicies, "DisableStrongPassword")
-	}
-	if d.Get("disable_password_expiration").(bool) {
-		passwordPolicies = append(passwordPolicies, "DisablePasswordExpiration")
-	}
-
-	properties := stable.User{
-		AccountEnabled:          nullable.Value(d.Get("account_enabled").(bool)),
-		AgeGroup:                nullable.NoZero(d.Get("age_group").(string)),
-		City:                    nullable.NoZero(d.Get("city").(string)),
-		CompanyName:             nullable.NoZero(d.Get("company_name").(string)),
-		ConsentProvidedForMinor: nullable.NoZero(d.Get("consent_provided_for_minor").(string)),
-		Country:                 nullable.NoZero(d.Get("country").(string)),
-		Department:              nullable.NoZero(d.Get("department").(string)),
-		DisplayName:             nullable.Value(d.Get("display_name").(string)),
-		EmployeeId:              nullable.NoZero(d.Get("employee_id").(string)),
-		EmployeeOrgData: &stable.EmployeeOrgData{
-			CostCenter: nullable.NoZero(d.Get("cost_center").(string)),
-			Division:   nullable.NoZero(d.Get("division").(string)),
-		},
-		EmployeeType:      nullable.NoZero(d.Get("employee_type").(string)),
-		FaxNumber:         nullable.NoZero(d.Get("fax_number").(string)),
-		GivenName:         nullable.NoZero(d.Get("given_name").(string)),
-		JobTitle:          nullable.NoZero(d.Get("job_title").(string)),
-		MailNickname:      nullable.NoZero(d.Get("mail_nickname").(string)),
-		MobilePhone:       nullable.NoZero(d.Get("mobile_phone").(string)),
-		OfficeLocation:    nullable.NoZero(d.Get("office_location").(string)),
-		OtherMails:        tf.ExpandStringSlicePtr(d.Get("other_mails").(*pluginsdk.Set).List()),
-		PasswordPolicies:  nullable.NoZero(strings.Join(passwordPolicies, ", ")),
-		PostalCode:        nullable.NoZero(d.Get("postal_code").(string)),
-		PreferredLanguage: nullable.NoZero(d.Get("preferred_language").(string)),
-		State:             nullable.NoZero(d.Get("state").(string)),
-		StreetAddress:     nullable.NoZero(d.Get("street_address").(string)),
-		Surname:           nullable.NoZero(d.Get("surname").(string)),
-		UsageLocation:     nullable.NoZero(d.Get("usage_location").(string)),
-		UserPrincipalName: nullable.NoZero(d.Get("user_principal_name").(string)),
-	}
+	properties := expandUserProperties(d.Get)
+	properties.MailNickname = nullable.NoZero(d.Get("mail_nickname").(string))
+	properties.OtherMails = tf.ExpandStringSlicePtr(d.Get("other_mails").(*pluginsdk.Set).List())
 
 	if password := d.Get("password").(string); d.HasChange("password") && password != "" {
 		properties.PasswordProfile = &stable.PasswordProfile{
@@ -626,14 +436,6 @@ func userResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta int
 		properties.ShowInAddressList = nullable.NoZero(d.Get("show_in_address_list").(bool))
 	}
 
-	if d.HasChange("employee_hire_date") {
-		_, err := time.Parse(time.RFC3339, d.Get("employee_hire_date").(string))
-		if err != nil {
-			tf.ErrorDiagF(err, "Unable to parse the provided employee_hire_date %q: %+v", d.Get("employee_hire_date"), err)
-		}
-		properties.EmployeeHireDate = nullable.NoZero(d.Get("employee_hire_date").(string))
-	}
-
 	if _, err = client.UpdateUser(ctx, *id, properties, user.DefaultUpdateUserOperationOptions()); err != nil {
 		// Flag the state as 'partial' to avoid setting `password` from the current config. Since the config is the
 		// only source for this property, if the update fails due to a bad password, the current password will be forgotten
@@ -662,13 +464,41 @@ func userResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta int
 			if _, err = managerClient.SetManagerRef(ctx, *id, managerRef, manager.DefaultSetManagerRefOperationOptions()); err != nil {
 				return tf.ErrorDiagPathF(err, "manager_id", "Could not assign manager for %s", id)
 			}
-		} else {
+		} else if !d.GetRawConfig().GetAttr("manager_id").IsNull() {
+			// The manager is only removed when explicitly unset in configuration, so that a manager assigned by the
+			// `azuread_user_manager` or `azuread_user_direct_reports` resources is left untouched
 			if _, err = managerClient.RemoveManagerRef(ctx, *id, manager.DefaultRemoveManagerRefOperationOptions()); err != nil {
 				return tf.ErrorDiagPathF(err, "manager_id", "Could not remove manager for %s", id)
 			}
 		}
 	}
 
+	if d.HasChange("extension_attributes") {
+		oldExtensionAttributes, newExtensionAttributes := d.GetChange("extension_attributes")
+		extensionProperties, err := directoryextensions.ListOwned(ctx, directoryObjectClient, directoryextensions.TargetObjectUser, oldExtensionAttributes.(map[string]interface{}), newExtensionAttributes.(map[string]interface{}))
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "extension_attributes", "Could not retrieve extension properties for %s", id)
+		}
+		payload, err := directoryextensions.Expand(newExtensionAttributes.(map[string]interface{}), oldExtensionAttributes.(map[string]interface{}), extensionProperties)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "extension_attributes", "Could not set extension attributes for %s", id)
+		}
+		if err = directoryextensions.Update(ctx, client.Client, id.ID(), payload); err != nil {
+			return tf.ErrorDiagPathF(err, "extension_attributes", "Could not set extension attributes for %s", id)
+		}
+	}
+
+	if d.HasChange("custom_security_attributes") {
+		oldSecurityAttributes, newSecurityAttributes := d.GetChange("custom_security_attributes")
+		payload, err := customsecurityattributes.Expand(newSecurityAttributes.(*pluginsdk.Set).List(), oldSecurityAttributes.(*pluginsdk.Set).List())
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "custom_security_attributes", "Could not assign custom security attributes for %s", id)
+		}
+		if err = customsecurityattributes.Update(ctx, client.Client, id.ID(), payload); err != nil {
+			return tf.ErrorDiagPathF(err, "custom_security_attributes", "Could not assign custom security attributes for %s", id)
+		}
+	}
+
 	return userResourceRead(ctx, d, meta)
 }
 
@@ -676,6 +506,7 @@ func userResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta inter
 	client := meta.(*clients.Client).Users.UserClient
 	clientBeta := meta.(*clients.Client).Users.UserClientBeta
 	managerClient := meta.(*clients.Client).Users.ManagerClient
+	directoryObjectClient := meta.(*clients.Client).Users.DirectoryObjectClient
 
 	id, err := stable.ParseUserID(d.Id())
 	if err != nil {
@@ -700,50 +531,25 @@ func userResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta inter
 	tf.Set(d, "about_me", u.AboutMe.GetOrZero())
 	tf.Set(d, "business_phones", tf.FlattenStringSlicePtr(u.BusinessPhones))
 	tf.Set(d, "creation_type", u.CreationType.GetOrZero())
-	tf.Set(d, "display_name", u.DisplayName.GetOrZero())
-	tf.Set(d, "given_name", u.GivenName.GetOrZero())
 	tf.Set(d, "im_addresses", tf.FlattenStringSlicePtr(u.ImAddresses))
-	tf.Set(d, "job_title", u.JobTitle.GetOrZero())
 	tf.Set(d, "mail", u.Mail.GetOrZero())
-	tf.Set(d, "mobile_phone", u.MobilePhone.GetOrZero())
 	tf.Set(d, "object_id", pointer.From(u.Id))
-	tf.Set(d, "office_location", u.OfficeLocation.GetOrZero())
 	tf.Set(d, "onpremises_distinguished_name", u.OnPremisesDistinguishedName.GetOrZero())
 	tf.Set(d, "onpremises_domain_name", u.OnPremisesDomainName.GetOrZero())
 	tf.Set(d, "onpremises_sam_account_name", u.OnPremisesSamAccountName.GetOrZero())
 	tf.Set(d, "onpremises_security_identifier", u.OnPremisesSecurityIdentifier.GetOrZero())
 	tf.Set(d, "onpremises_sync_enabled", u.OnPremisesSyncEnabled.GetOrZero())
 	tf.Set(d, "onpremises_user_principal_name", u.OnPremisesUserPrincipalName.GetOrZero())
-	tf.Set(d, "preferred_language", u.PreferredLanguage.GetOrZero())
 	tf.Set(d, "proxy_addresses", tf.FlattenStringSlicePtr(u.ProxyAddresses))
-	tf.Set(d, "surname", u.Surname.GetOrZero())
-	tf.Set(d, "user_principal_name", u.UserPrincipalName.GetOrZero())
 	tf.Set(d, "user_type", u.UserType.GetOrZero())
 
 	// Retrieve additional fields
 	optionsExtra := user.GetUserOperationOptions{
-		Select: &[]string{
-			"accountEnabled",
-			"ageGroup",
-			"city",
-			"companyName",
-			"consentProvidedForMinor",
-			"country",
-			"department",
-			"employeeHireDate",
-			"employeeId",
-			"employeeOrgData",
-			"employeeType",
-			"faxNumber",
+		Select: pointer.To(append([]string{
 			"mailNickname",
 			"onPremisesImmutableId",
 			"otherMails",
-			"passwordPolicies",
-			"postalCode",
-			"state",
-			"streetAddress",
-			"usageLocation",
-		},
+		}, userPropertiesSelect...)),
 	}
 	respExtra, err := client.GetUser(ctx, *id, optionsExtra)
 	if err != nil {
@@ -755,48 +561,14 @@ func userResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta inter
 		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving additional fields for %s", id)
 	}
 
-	tf.Set(d, "account_enabled", uExtra.AccountEnabled.GetOrZero())
-	tf.Set(d, "age_group", uExtra.AgeGroup.GetOrZero())
-	tf.Set(d, "city", uExtra.City.GetOrZero())
-	tf.Set(d, "company_name", uExtra.CompanyName.GetOrZero())
-	tf.Set(d, "consent_provided_for_minor", uExtra.ConsentProvidedForMinor.GetOrZero())
-	tf.Set(d, "country", uExtra.Country.GetOrZero())
-	tf.Set(d, "department", uExtra.Department.GetOrZero())
-	tf.Set(d, "employee_hire_date", uExtra.EmployeeHireDate.GetOrZero())
-	tf.Set(d, "employee_id", uExtra.EmployeeId.GetOrZero())
-	tf.Set(d, "employee_type", uExtra.EmployeeType.GetOrZero())
+	for k, v := range flattenUserProperties(*uExtra) {
+		tf.Set(d, k, v)
+	}
+
 	tf.Set(d, "external_user_state", uExtra.ExternalUserState.GetOrZero())
-	tf.Set(d, "fax_number", uExtra.FaxNumber.GetOrZero())
 	tf.Set(d, "mail_nickname", uExtra.MailNickname.GetOrZero())
 	tf.Set(d, "onpremises_immutable_id", uExtra.OnPremisesImmutableId.GetOrZero())
 	tf.Set(d, "other_mails", tf.FlattenStringSlicePtr(uExtra.OtherMails))
-	tf.Set(d, "postal_code", uExtra.PostalCode.GetOrZero())
-	tf.Set(d, "state", uExtra.State.GetOrZero())
-	tf.Set(d, "street_address", uExtra.StreetAddress.GetOrZero())
-	tf.Set(d, "usage_location", uExtra.UsageLocation.GetOrZero())
-
-	if orgData := uExtra.EmployeeOrgData; orgData != nil {
-		tf.Set(d, "cost_center", orgData.CostCenter.GetOrZero())
-		tf.Set(d, "division", orgData.Division.GetOrZero())
-	}
-
-	disableStrongPassword := false
-	disablePasswordExpiration := false
-
-	if passwordPolicies := uExtra.PasswordPolicies; passwordPolicies != nil {
-		policies := strings.Split(passwordPolicies.GetOrZero(), ",")
-		for _, p := range policies {
-			if strings.EqualFold(strings.TrimSpace(p), "DisableStrongPassword") {
-				disableStrongPassword = true
-			}
-			if strings.EqualFold(strings.TrimSpace(p), "DisablePasswordExpiration") {
-				disablePasswordExpiration = true
-			}
-		}
-	}
-
-	tf.Set(d, "disable_strong_password", disableStrongPassword)
-	tf.Set(d, "disable_password_expiration", disablePasswordExpiration)
 
 	// Retrieve the `accountEnabled` and `showInAddressList` fields using the beta API, see https://developer.microsoft.com/en-us/graph/known-issues/?search=14972
 	optionsBeta := userBeta.GetUserOperationOptions{
@@ -828,6 +600,38 @@ func userResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta inter
 
 	tf.Set(d, "manager_id", managerId)
 
+	// Reading custom security attributes requires additional permissions, and attributes may be assigned outside of
+	// Terraform, so only those attributes already being managed are tracked
+	if configuredSecurityAttributes := d.Get("custom_security_attributes").(*pluginsdk.Set).List(); len(configuredSecurityAttributes) > 0 {
+		securityAttributes, err := customsecurityattributes.Get(ctx, client.Client, id.ID())
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "custom_security_attributes", "Could not retrieve custom security attributes for %s", id)
+		}
+		flattenedAttributes, err := customsecurityattributes.Flatten(securityAttributes, configuredSecurityAttributes)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "custom_security_attributes", "Could not flatten custom security attributes for %s", id)
+		}
+		tf.Set(d, "custom_security_attributes", flattenedAttributes)
+	}
+
+	// Only extension attributes owned by the same applications as those already being managed are tracked, so that
+	// extension attributes belonging to unrelated applications do not cause a diff
+	if configuredExtensionAttributes := d.Get("extension_attributes").(map[string]interface{}); len(configuredExtensionAttributes) > 0 {
+		extensionProperties, err := directoryextensions.ListOwned(ctx, directoryObjectClient, directoryextensions.TargetObjectUser, configuredExtensionAttributes)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "extension_attributes", "Could not retrieve extension properties for %s", id)
+		}
+		extensionValues, err := directoryextensions.Get(ctx, client.Client, id.ID(), extensionProperties)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "extension_attributes", "Could not retrieve extension attributes for %s", id)
+		}
+		extensionAttributes, err := directoryextensions.Flatten(extensionValues, extensionProperties, configuredExtensionAttributes)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "extension_attributes", "Could not flatten extension attributes for %s", id)
+		}
+		tf.Set(d, "extension_attributes", extensionAttributes)
+	}
+
 	return nil
 }
 
@@ -156,6 +156,41 @@ func TestAccUser_passwordInvalid(t *testing.T) {
 	})
 }
 
+func TestAccUser_extensionAttributes(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user", "test")
+	r := UserResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.extensionAttributes(data, `
+    (azuread_application_extension_property.cost_center.extension_name) = "1234"
+    (azuread_application_extension_property.level.extension_name)       = "5"
+`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("extension_attributes.%").HasValue("2"),
+			),
+		},
+		data.ImportStep("extension_attributes", "force_password_change", "password"),
+		{
+			Config: r.extensionAttributes(data, `
+    (azuread_application_extension_property.level.extension_name) = "7"
+`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("extension_attributes.%").HasValue("1"),
+			),
+		},
+		data.ImportStep("extension_attributes", "force_password_change", "password"),
+		{
+			Config: r.extensionAttributes(data, `
+    (azuread_application_extension_property.level.extension_name) = "seven"
+`),
+			ExpectError: regexp.MustCompile("expected a 32-bit integer value"),
+		},
+	})
+}
+
 func (r UserResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	client := clients.Users.UserClient
 
@@ -333,3 +368,41 @@ resource "azuread_user" "test" {
 }
 `, data.RandomInteger, password)
 }
+
+func (UserResource) extensionAttributes(data acceptance.TestData, attributes string) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_application_registration" "test" {
+  display_name = "acctest-UserExtensions-%[1]d"
+}
+
+resource "azuread_application_extension_property" "cost_center" {
+  application_id = azuread_application_registration.test.id
+  name           = "costCenter"
+  data_type      = "String"
+  target_objects = ["User"]
+}
+
+resource "azuread_application_extension_property" "level" {
+  application_id = azuread_application_registration.test.id
+  name           = "level"
+  data_type      = "Integer"
+  target_objects = ["User"]
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestUser.%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[1]d"
+  password            = "%[2]s"
+
+  extension_attributes = {
+%[3]s
+  }
+}
+`, data.RandomInteger, data.RandomPassword, attributes)
+}
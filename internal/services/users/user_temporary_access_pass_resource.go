@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/authenticationtemporaryaccesspassmethod"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func userTemporaryAccessPassResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: userTemporaryAccessPassResourceCreate,
+		ReadContext:   userTemporaryAccessPassResourceRead,
+		DeleteContext: userTemporaryAccessPassResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := stable.ParseUserIdAuthenticationTemporaryAccessPassMethodID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"user_object_id": {
+				Description:  "The object ID of the user for whom the Temporary Access Pass should be created",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"lifetime_in_minutes": {
+				Description:  "The lifetime of the Temporary Access Pass in minutes, starting at `start_date_time`. Must be between 10 and 43200 (30 days)",
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(10, 43200),
+			},
+
+			"is_usable_once": {
+				Description: "Whether the Temporary Access Pass can only be used once",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+
+			"start_date_time": {
+				Description:  "The date and time when the Temporary Access Pass becomes available to use, formatted as an RFC3339 date string (e.g. `2018-01-01T01:02:03Z`)",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+
+			"pass": {
+				Description: "The Temporary Access Pass, which is only available when the pass is created",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+
+			"created_date_time": {
+				Description: "The date and time when the Temporary Access Pass was created",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"is_usable": {
+				Description: "Whether the Temporary Access Pass is currently usable by the user",
+				Type:        pluginsdk.TypeBool,
+				Computed:    true,
+			},
+
+			"method_usability_reason": {
+				Description: "The reason for the current usability state of the Temporary Access Pass, e.g. `EnabledByPolicy`, `DisabledByPolicy`, `Expired`, `NotYetValid` or `OneTimeUsed`",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func userTemporaryAccessPassResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.AuthenticationTemporaryAccessPassMethodClient
+	userClient := meta.(*clients.Client).Users.UserClient
+
+	userId := stable.NewUserID(d.Get("user_object_id").(string))
+
+	tf.LockByName(userResourceName, userId.UserId)
+	defer tf.UnlockByName(userResourceName, userId.UserId)
+
+	if exists, err := userExists(ctx, userClient, userId); err != nil {
+		return tf.ErrorDiagPathF(err, "user_object_id", "Retrieving %s", userId)
+	} else if !exists {
+		return tf.ErrorDiagPathF(nil, "user_object_id", "%s was not found", userId)
+	}
+
+	properties := stable.TemporaryAccessPassAuthenticationMethod{}
+
+	if v, ok := d.GetOk("lifetime_in_minutes"); ok {
+		properties.LifetimeInMinutes = nullable.Value(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOkExists("is_usable_once"); ok { //nolint:staticcheck // needed to detect unset booleans
+		properties.IsUsableOnce = nullable.Value(v.(bool))
+	}
+
+	if v, ok := d.GetOk("start_date_time"); ok {
+		properties.StartDateTime = nullable.Value(v.(string))
+	}
+
+	options := authenticationtemporaryaccesspassmethod.CreateAuthenticationTemporaryAccessPassMethodOperationOptions{
+		RetryFunc: func(resp *http.Response, o *odata.OData) (bool, error) {
+			return response.WasNotFound(resp), nil
+		},
+	}
+
+	resp, err := client.CreateAuthenticationTemporaryAccessPassMethod(ctx, userId, properties, options)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Creating Temporary Access Pass for %s", userId)
+	}
+
+	if resp.Model == nil || pointer.From(resp.Model.Id) == "" {
+		return tf.ErrorDiagF(errors.New("API returned Temporary Access Pass with nil ID"), "Bad API Response")
+	}
+
+	id := stable.NewUserIdAuthenticationTemporaryAccessPassMethodID(userId.UserId, *resp.Model.Id)
+	d.SetId(id.ID())
+
+	// The pass is only returned when it is created
+	tf.Set(d, "pass", resp.Model.TemporaryAccessPass.GetOrZero())
+
+	if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		resp, err := client.GetAuthenticationTemporaryAccessPassMethod(ctx, id, authenticationtemporaryaccesspassmethod.DefaultGetAuthenticationTemporaryAccessPassMethodOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(resp.Model != nil), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for creation of %s", id)
+	}
+
+	return userTemporaryAccessPassResourceRead(ctx, d, meta)
+}
+
+func userTemporaryAccessPassResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.AuthenticationTemporaryAccessPassMethodClient
+
+	id, err := stable.ParseUserIdAuthenticationTemporaryAccessPassMethodID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Temporary Access Pass ID %q", d.Id())
+	}
+
+	resp, err := client.GetAuthenticationTemporaryAccessPassMethod(ctx, *id, authenticationtemporaryaccesspassmethod.DefaultGetAuthenticationTemporaryAccessPassMethodOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+
+	method := resp.Model
+	if method == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+	}
+
+	tf.Set(d, "user_object_id", id.UserId)
+	tf.Set(d, "created_date_time", method.CreatedDateTime.GetOrZero())
+	tf.Set(d, "is_usable", method.IsUsable.GetOrZero())
+	tf.Set(d, "is_usable_once", method.IsUsableOnce.GetOrZero())
+	tf.Set(d, "lifetime_in_minutes", int(method.LifetimeInMinutes.GetOrZero()))
+	tf.Set(d, "method_usability_reason", method.MethodUsabilityReason.GetOrZero())
+	tf.Set(d, "start_date_time", method.StartDateTime.GetOrZero())
+
+	return nil
+}
+
+func userTemporaryAccessPassResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.AuthenticationTemporaryAccessPassMethodClient
+
+	id, err := stable.ParseUserIdAuthenticationTemporaryAccessPassMethodID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Temporary Access Pass ID %q", d.Id())
+	}
+
+	tf.LockByName(userResourceName, id.UserId)
+	defer tf.UnlockByName(userResourceName, id.UserId)
+
+	if resp, err := client.DeleteAuthenticationTemporaryAccessPassMethod(ctx, *id, authenticationtemporaryaccesspassmethod.DefaultDeleteAuthenticationTemporaryAccessPassMethodOperationOptions()); err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Deleting %s", id)
+	}
+
+	if err = consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
+		if resp, err := client.GetAuthenticationTemporaryAccessPassMethod(ctx, *id, authenticationtemporaryaccesspassmethod.DefaultGetAuthenticationTemporaryAccessPassMethodOperationOptions()); err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(true), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for deletion of %s", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/authenticationtemporaryaccesspassmethod"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type UserTemporaryAccessPassResource struct{}
+
+func TestAccUserTemporaryAccessPass_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_temporary_access_pass", "test")
+	r := UserTemporaryAccessPassResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("pass").Exists(),
+				check.That(data.ResourceName).Key("lifetime_in_minutes").Exists(),
+				check.That(data.ResourceName).Key("created_date_time").Exists(),
+			),
+		},
+		data.ImportStep("pass"),
+	})
+}
+
+func TestAccUserTemporaryAccessPass_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user_temporary_access_pass", "test")
+	r := UserTemporaryAccessPassResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("pass").Exists(),
+				check.That(data.ResourceName).Key("lifetime_in_minutes").HasValue("60"),
+				check.That(data.ResourceName).Key("is_usable_once").HasValue("true"),
+			),
+		},
+		data.ImportStep("pass"),
+	})
+}
+
+func (r UserTemporaryAccessPassResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Users.AuthenticationTemporaryAccessPassMethodClient
+
+	id, err := stable.ParseUserIdAuthenticationTemporaryAccessPassMethodID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetAuthenticationTemporaryAccessPassMethod(ctx, *id, authenticationtemporaryaccesspassmethod.DefaultGetAuthenticationTemporaryAccessPassMethodOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %+v", id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (UserTemporaryAccessPassResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestUser.%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[1]d"
+  password            = "%[2]s"
+}
+`, data.RandomInteger, data.RandomPassword)
+}
+
+func (r UserTemporaryAccessPassResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user_temporary_access_pass" "test" {
+  user_object_id = azuread_user.test.object_id
+}
+`, r.template(data))
+}
+
+func (r UserTemporaryAccessPassResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user_temporary_access_pass" "test" {
+  user_object_id      = azuread_user.test.object_id
+  lifetime_in_minutes = 60
+  is_usable_once      = true
+}
+`, r.template(data))
+}
@@ -0,0 +1,283 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/directreport"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/manager"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/user"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+)
+
+// userPropertiesSelect lists the fields of the user object that are mapped by expandUserProperties and
+// flattenUserProperties. Several of these are only returned when explicitly selected.
+var userPropertiesSelect = []string{
+	"accountEnabled",
+	"ageGroup",
+	"city",
+	"companyName",
+	"consentProvidedForMinor",
+	"country",
+	"department",
+	"displayName",
+	"employeeHireDate",
+	"employeeId",
+	"employeeOrgData",
+	"employeeType",
+	"faxNumber",
+	"givenName",
+	"jobTitle",
+	"mobilePhone",
+	"officeLocation",
+	"passwordPolicies",
+	"postalCode",
+	"preferredLanguage",
+	"state",
+	"streetAddress",
+	"surname",
+	"usageLocation",
+	"userPrincipalName",
+}
+
+// expandUserProperties maps the attributes described by schemaUserProperties to a user object. Values are looked up
+// with the provided function, which allows the mapping to be used both with resource data and with nested blocks. The
+// password profile is not included, since callers only send it when the password is being set.
+func expandUserProperties(get func(string) interface{}) stable.User {
+	return stable.User{
+		AccountEnabled:          nullable.Value(get("account_enabled").(bool)),
+		AgeGroup:                nullable.NoZero(get("age_group").(string)),
+		City:                    nullable.NoZero(get("city").(string)),
+		CompanyName:             nullable.NoZero(get("company_name").(string)),
+		ConsentProvidedForMinor: nullable.NoZero(get("consent_provided_for_minor").(string)),
+		Country:                 nullable.NoZero(get("country").(string)),
+		Department:              nullable.NoZero(get("department").(string)),
+		DisplayName:             nullable.Value(get("display_name").(string)),
+		EmployeeHireDate:        nullable.NoZero(get("employee_hire_date").(string)),
+		EmployeeId:              nullable.NoZero(get("employee_id").(string)),
+		EmployeeOrgData: &stable.EmployeeOrgData{
+			CostCenter: nullable.NoZero(get("cost_center").(string)),
+			Division:   nullable.NoZero(get("division").(string)),
+		},
+		EmployeeType:      nullable.NoZero(get("employee_type").(string)),
+		FaxNumber:         nullable.NoZero(get("fax_number").(string)),
+		GivenName:         nullable.NoZero(get("given_name").(string)),
+		JobTitle:          nullable.NoZero(get("job_title").(string)),
+		MobilePhone:       nullable.NoZero(get("mobile_phone").(string)),
+		OfficeLocation:    nullable.NoZero(get("office_location").(string)),
+		PasswordPolicies:  nullable.NoZero(expandUserPasswordPolicies(get("disable_strong_password").(bool), get("disable_password_expiration").(bool))),
+		PostalCode:        nullable.NoZero(get("postal_code").(string)),
+		PreferredLanguage: nullable.NoZero(get("preferred_language").(string)),
+		State:             nullable.NoZero(get("state").(string)),
+		StreetAddress:     nullable.NoZero(get("street_address").(string)),
+		Surname:           nullable.NoZero(get("surname").(string)),
+		UsageLocation:     nullable.NoZero(get("usage_location").(string)),
+		UserPrincipalName: nullable.NoZero(get("user_principal_name").(string)),
+	}
+}
+
+// flattenUserProperties maps a user object to the attributes described by schemaUserProperties, except for the
+// password and whether a password change is forced, which cannot be read back
+func flattenUserProperties(u stable.User) map[string]interface{} {
+	disableStrongPassword, disablePasswordExpiration := flattenUserPasswordPolicies(u.PasswordPolicies.GetOrZero())
+
+	result := map[string]interface{}{
+		"account_enabled":             u.AccountEnabled.GetOrZero(),
+		"age_group":                   u.AgeGroup.GetOrZero(),
+		"city":                        u.City.GetOrZero(),
+		"company_name":                u.CompanyName.GetOrZero(),
+		"consent_provided_for_minor":  u.ConsentProvidedForMinor.GetOrZero(),
+		"cost_center":                 "",
+		"country":                     u.Country.GetOrZero(),
+		"department":                  u.Department.GetOrZero(),
+		"disable_password_expiration": disablePasswordExpiration,
+		"disable_strong_password":     disableStrongPassword,
+		"display_name":                u.DisplayName.GetOrZero(),
+		"division":                    "",
+		"employee_hire_date":          u.EmployeeHireDate.GetOrZero(),
+		"employee_id":                 u.EmployeeId.GetOrZero(),
+		"employee_type":               u.EmployeeType.GetOrZero(),
+		"fax_number":                  u.FaxNumber.GetOrZero(),
+		"given_name":                  u.GivenName.GetOrZero(),
+		"job_title":                   u.JobTitle.GetOrZero(),
+		"mobile_phone":                u.MobilePhone.GetOrZero(),
+		"office_location":             u.OfficeLocation.GetOrZero(),
+		"postal_code":                 u.PostalCode.GetOrZero(),
+		"preferred_language":          u.PreferredLanguage.GetOrZero(),
+		"state":                       u.State.GetOrZero(),
+		"street_address":              u.StreetAddress.GetOrZero(),
+		"surname":                     u.Surname.GetOrZero(),
+		"usage_location":              u.UsageLocation.GetOrZero(),
+		"user_principal_name":         u.UserPrincipalName.GetOrZero(),
+	}
+
+	if orgData := u.EmployeeOrgData; orgData != nil {
+		result["cost_center"] = orgData.CostCenter.GetOrZero()
+		result["division"] = orgData.Division.GetOrZero()
+	}
+
+	return result
+}
+
+func expandUserPasswordPolicies(disableStrongPassword, disablePasswordExpiration bool) string {
+	passwordPolicies := make([]string, 0)
+	if disableStrongPassword {
+		passwordPolicies = append(passwordPolicies, "DisableStrongPassword")
+	}
+	if disablePasswordExpiration {
+		passwordPolicies = append(passwordPolicies, "DisablePasswordExpiration")
+	}
+	return strings.Join(passwordPolicies, ", ")
+}
+
+func flattenUserPasswordPolicies(in string) (disableStrongPassword bool, disablePasswordExpiration bool) {
+	for _, p := range strings.Split(in, ",") {
+		if strings.EqualFold(strings.TrimSpace(p), "DisableStrongPassword") {
+			disableStrongPassword = true
+		}
+		if strings.EqualFold(strings.TrimSpace(p), "DisablePasswordExpiration") {
+			disablePasswordExpiration = true
+		}
+	}
+	return
+}
+
+// validateUserConsentForMinor checks that consent for a minor is only specified for users in the `Minor` age group
+func validateUserConsentForMinor(ageGroup, consentProvidedForMinor string) error {
+	if ageGroup != AgeGroupMinor && consentProvidedForMinor != "" && consentProvidedForMinor != ConsentProvidedForMinorNotRequired {
+		return fmt.Errorf("`consent_provided_for_minor` can only be set to %q or %q when `age_group` is %q or %q",
+			ConsentProvidedForMinorGranted, ConsentProvidedForMinorDenied, AgeGroupAdult, AgeGroupNotAdult)
+	}
+	return nil
+}
+
+// userExists returns whether the user with the specified ID exists
+func userExists(ctx context.Context, client *user.UserClient, id stable.UserId) (bool, error) {
+	options := user.GetUserOperationOptions{
+		Select: &[]string{"id"},
+	}
+
+	resp, err := client.GetUser(ctx, id, options)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// userGetManagerId returns the object ID of the user's manager, or nil if the user does not have a manager. Since the
+// API also returns a 404 response when the user does not exist, callers should first check that the user exists.
+func userGetManagerId(ctx context.Context, client *manager.ManagerClient, id stable.UserId) (*string, error) {
+	resp, err := client.GetManager(ctx, id, manager.DefaultGetManagerOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if resp.Model == nil {
+		return nil, nil
+	}
+
+	return resp.Model.DirectoryObject().Id, nil
+}
+
+// userSetManager assigns the specified manager to a user and waits for the change to be reflected
+func userSetManager(ctx context.Context, client *manager.ManagerClient, id stable.UserId, managerId string) error {
+	tf.LockByName(userResourceName, id.UserId)
+	defer tf.UnlockByName(userResourceName, id.UserId)
+
+	managerRef := stable.ReferenceUpdate{
+		ODataId: pointer.To(client.Client.BaseUri + stable.NewDirectoryObjectID(managerId).ID()),
+	}
+
+	if _, err := client.SetManagerRef(ctx, id, managerRef, manager.DefaultSetManagerRefOperationOptions()); err != nil {
+		return fmt.Errorf("assigning manager for %s: %v", id, err)
+	}
+
+	if err := consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		currentManagerId, err := userGetManagerId(ctx, client, id)
+		if err != nil {
+			return nil, err
+		}
+		return pointer.To(strings.EqualFold(pointer.From(currentManagerId), managerId)), nil
+	}); err != nil {
+		return fmt.Errorf("waiting for manager of %s to be updated: %v", id, err)
+	}
+
+	return nil
+}
+
+// userRemoveManager removes the manager from a user, only if the user's manager is still `expectedManagerId`. This
+// ensures that a manager assigned by another process after the last refresh is not removed.
+func userRemoveManager(ctx context.Context, client *manager.ManagerClient, id stable.UserId, expectedManagerId string) error {
+	tf.LockByName(userResourceName, id.UserId)
+	defer tf.UnlockByName(userResourceName, id.UserId)
+
+	currentManagerId, err := userGetManagerId(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("retrieving manager for %s: %v", id, err)
+	}
+	if !strings.EqualFold(pointer.From(currentManagerId), expectedManagerId) {
+		log.Printf("[DEBUG] Manager for %s is no longer %q, skipping removal", id, expectedManagerId)
+		return nil
+	}
+
+	if resp, err := client.RemoveManagerRef(ctx, id, manager.DefaultRemoveManagerRefOperationOptions()); err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil
+		}
+		return fmt.Errorf("removing manager for %s: %v", id, err)
+	}
+
+	if err = consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
+		currentManagerId, err := userGetManagerId(ctx, client, id)
+		if err != nil {
+			return nil, err
+		}
+		return pointer.To(currentManagerId != nil), nil
+	}); err != nil {
+		return fmt.Errorf("waiting for removal of manager for %s: %v", id, err)
+	}
+
+	return nil
+}
+
+// userListDirectReportIds returns the object IDs of users who report directly to the specified manager. Other types
+// of direct reports, such as organizational contacts, are not returned since their manager cannot be managed.
+func userListDirectReportIds(ctx context.Context, client *directreport.DirectReportClient, id stable.UserId) ([]string, error) {
+	options := directreport.ListDirectReportsOperationOptions{
+		Select: &[]string{"id"},
+	}
+
+	resp, err := client.ListDirectReportsComplete(ctx, id, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0)
+	for _, report := range resp.Items {
+		if _, ok := report.(stable.User); !ok {
+			continue
+		}
+		if reportId := pointer.From(report.DirectoryObject().Id); reportId != "" {
+			result = append(result, reportId)
+		}
+	}
+
+	return result, nil
+}
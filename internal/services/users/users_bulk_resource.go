@@ -0,0 +1,839 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/msgraph"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/batch"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+)
+
+func usersBulkResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: usersBulkResourceCreate,
+		ReadContext:   usersBulkResourceRead,
+		UpdateContext: usersBulkResourceUpdate,
+		DeleteContext: usersBulkResourceDelete,
+
+		CustomizeDiff: usersBulkResourceCustomizeDiff,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(10 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"user": {
+				Description:  "A set of users to manage, keyed by user principal name",
+				Type:         pluginsdk.TypeSet,
+				Optional:     true,
+				ExactlyOneOf: []string{"user", "csv"},
+				Elem: &pluginsdk.Resource{
+					Schema: schemaUsersBulkUser(),
+				},
+			},
+
+			"csv": {
+				Description:      "CSV-encoded users to manage, keyed by user principal name. The header row must contain the names of the user attributes in each column",
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				ExactlyOneOf:     []string{"user", "csv"},
+				ValidateDiagFunc: validateUsersBulkCsv,
+			},
+
+			"object_ids": {
+				Description: "A mapping of user principal names to the object IDs of the managed users",
+				Type:        pluginsdk.TypeMap,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// schemaUsersBulkUser returns the schema for an individual user managed in bulk, which uses the same properties and
+// validation as the `azuread_user` resource
+func schemaUsersBulkUser() map[string]*pluginsdk.Schema {
+	s := schemaUserProperties()
+
+	// Passwords cannot be read back, so they must always be specified for users managed in bulk
+	s["password"].Required = true
+	s["password"].Optional = false
+	s["password"].Computed = false
+
+	return s
+}
+
+// usersBulkFailure describes an operation that failed for an individual user
+type usersBulkFailure struct {
+	UserPrincipalName string
+	Err               error
+}
+
+func usersBulkDiagnostics(action string, failures []usersBulkFailure) pluginsdk.Diagnostics {
+	diags := make(pluginsdk.Diagnostics, 0, len(failures))
+	for _, failure := range failures {
+		diags = append(diags, pluginsdk.Diagnostic{
+			Severity: pluginsdk.DiagError,
+			Summary:  fmt.Sprintf("Could not %s user %q", action, failure.UserPrincipalName),
+			Detail:   failure.Err.Error(),
+		})
+	}
+	return diags
+}
+
+func usersBulkResourceCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("user") || !diff.NewValueKnown("csv") {
+		return nil
+	}
+
+	rows, err := usersBulkRows(diff.Get("user").(*pluginsdk.Set), diff.Get("csv").(string))
+	if err != nil {
+		return err
+	}
+
+	upns := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		upn := row["user_principal_name"].(string)
+		if upns[strings.ToLower(upn)] {
+			return fmt.Errorf("user %q is specified more than once", upn)
+		}
+		upns[strings.ToLower(upn)] = true
+
+		if err = validateUserConsentForMinor(row["age_group"].(string), row["consent_provided_for_minor"].(string)); err != nil {
+			return fmt.Errorf("user %q: %v", upn, err)
+		}
+	}
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	// Users that have been added to the configuration, or that were deleted outside of Terraform, must be created
+	objectIds := diff.Get("object_ids").(map[string]interface{})
+	changed := len(objectIds) != len(upns)
+	for upn := range objectIds {
+		if !upns[strings.ToLower(upn)] {
+			changed = true
+		}
+	}
+	if changed {
+		return diff.SetNewComputed("object_ids")
+	}
+
+	return nil
+}
+
+func usersBulkResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.UserClient
+
+	rows, err := usersBulkRows(d.Get("user").(*pluginsdk.Set), d.Get("csv").(string))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "csv", "Could not parse users")
+	}
+
+	requests := make([]batch.Request, 0, len(rows))
+	for i, row := range rows {
+		requests = append(requests, usersBulkCreateRequest(i, row))
+	}
+
+	responses, err := batch.Execute(ctx, client.Client, requests)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Creating users")
+	}
+
+	objectIds := make(map[string]string, len(rows))
+	failures := make([]usersBulkFailure, 0)
+	for i, row := range rows {
+		upn := row["user_principal_name"].(string)
+		objectId, err := usersBulkCreateResult(requests[i], responses)
+		if err != nil {
+			failures = append(failures, usersBulkFailure{UserPrincipalName: upn, Err: err})
+			continue
+		}
+		objectIds[upn] = objectId
+	}
+
+	if len(failures) > 0 {
+		// Remove any users that were created, so that the resource is not left tainted with only some of its users
+		diags := usersBulkDiagnostics("create", failures)
+		if _, rollbackFailures := usersBulkDeleteUsers(ctx, client.Client, objectIds); len(rollbackFailures) > 0 {
+			diags = append(diags, usersBulkDiagnostics("remove partially created", rollbackFailures)...)
+		}
+		return diags
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return tf.ErrorDiagF(err, "Generating ID for users")
+	}
+	d.SetId(id)
+
+	if err = usersBulkWaitForUsers(ctx, client.Client, objectIds, true); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for creation of users")
+	}
+
+	tf.Set(d, "object_ids", objectIds)
+
+	return usersBulkResourceRead(ctx, d, meta)
+}
+
+func usersBulkResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.UserClient
+
+	oldUsers, newUsers := d.GetChange("user")
+	oldCsv, newCsv := d.GetChange("csv")
+
+	oldRows, err := usersBulkRows(oldUsers.(*pluginsdk.Set), oldCsv.(string))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "csv", "Could not parse existing users")
+	}
+	newRows, err := usersBulkRows(newUsers.(*pluginsdk.Set), newCsv.(string))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "csv", "Could not parse users")
+	}
+
+	oldRowsByUpn := usersBulkRowsByUpn(oldRows)
+	newRowsByUpn := usersBulkRowsByUpn(newRows)
+
+	// The new value of `object_ids` is unknown when users are being added, so use the prior value
+	oldObjectIds, _ := d.GetChange("object_ids")
+	objectIds := make(map[string]string)
+	for upn, v := range oldObjectIds.(map[string]interface{}) {
+		objectIds[strings.ToLower(upn)] = v.(string)
+	}
+
+	requests := make([]batch.Request, 0)
+	requestRows := make(map[string]map[string]interface{})
+	for i, row := range newRows {
+		upn := strings.ToLower(row["user_principal_name"].(string))
+		objectId, exists := objectIds[upn]
+		if !exists {
+			req := usersBulkCreateRequest(i, row)
+			requests = append(requests, req)
+			requestRows[req.Id] = row
+			continue
+		}
+
+		oldRow := oldRowsByUpn[upn]
+		if reflect.DeepEqual(oldRow, row) {
+			continue
+		}
+
+		properties := expandUserProperties(func(k string) interface{} { return row[k] })
+		if oldRow == nil || oldRow["password"] != row["password"] {
+			properties.PasswordProfile = &stable.PasswordProfile{
+				ForceChangePasswordNextSignIn: nullable.Value(row["force_password_change"].(bool)),
+				Password:                      nullable.NoZero(row["password"].(string)),
+			}
+		}
+
+		req := batch.Request{
+			Id:     fmt.Sprintf("update-%d", i),
+			Method: http.MethodPatch,
+			Url:    stable.NewUserID(objectId).ID(),
+			Body:   properties,
+		}
+		requests = append(requests, req)
+		requestRows[req.Id] = row
+	}
+
+	usersForRemoval := make(map[string]string)
+	for upn, objectId := range objectIds {
+		if _, ok := newRowsByUpn[upn]; !ok {
+			usersForRemoval[upn] = objectId
+		}
+	}
+
+	log.Printf("[DEBUG] Applying %d changes and removing %d users for %s", len(requests), len(usersForRemoval), d.Id())
+
+	responses, err := batch.Execute(ctx, client.Client, requests)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Updating users")
+	}
+
+	createdObjectIds := make(map[string]string)
+	failures := make([]usersBulkFailure, 0)
+	failedUpns := make(map[string]bool)
+	for _, req := range requests {
+		row := requestRows[req.Id]
+		upn := row["user_principal_name"].(string)
+
+		if strings.HasPrefix(req.Id, "create-") {
+			objectId, err := usersBulkCreateResult(req, responses)
+			if err != nil {
+				failures = append(failures, usersBulkFailure{UserPrincipalName: upn, Err: err})
+				failedUpns[strings.ToLower(upn)] = true
+				continue
+			}
+			createdObjectIds[upn] = objectId
+			objectIds[strings.ToLower(upn)] = objectId
+			continue
+		}
+
+		resp, ok := responses[req.Id]
+		if !ok {
+			failures = append(failures, usersBulkFailure{UserPrincipalName: upn, Err: fmt.Errorf("no response received for batch request %q", req.Id)})
+			failedUpns[strings.ToLower(upn)] = true
+		} else if !resp.Success() {
+			failures = append(failures, usersBulkFailure{UserPrincipalName: upn, Err: resp.Error()})
+			failedUpns[strings.ToLower(upn)] = true
+		}
+	}
+
+	removedObjectIds, removalFailures := usersBulkDeleteUsers(ctx, client.Client, usersForRemoval)
+	for upn := range removedObjectIds {
+		delete(objectIds, upn)
+	}
+
+	// Record the object IDs using the user principal names as specified in the configuration
+	result := make(map[string]string, len(objectIds))
+	for upn, objectId := range objectIds {
+		if row, ok := newRowsByUpn[upn]; ok {
+			result[row["user_principal_name"].(string)] = objectId
+		} else if row, ok := oldRowsByUpn[upn]; ok {
+			result[row["user_principal_name"].(string)] = objectId
+		} else {
+			result[upn] = objectId
+		}
+	}
+	tf.Set(d, "object_ids", result)
+
+	if len(failures) > 0 || len(removalFailures) > 0 {
+		// Record the users as they were before any failed changes, so that the failed changes are planned again
+		if newCsv.(string) != "" {
+			tf.Set(d, "csv", oldCsv.(string))
+		} else {
+			users := make([]interface{}, 0)
+			for upn := range result {
+				upn = strings.ToLower(upn)
+				if row, ok := newRowsByUpn[upn]; ok && !failedUpns[upn] {
+					users = append(users, row)
+				} else if row, ok := oldRowsByUpn[upn]; ok {
+					users = append(users, row)
+				}
+			}
+			tf.Set(d, "user", users)
+		}
+
+		diags := usersBulkDiagnostics("update", failures)
+		return append(diags, usersBulkDiagnostics("remove", removalFailures)...)
+	}
+
+	if err = usersBulkWaitForUsers(ctx, client.Client, createdObjectIds, true); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for creation of users")
+	}
+
+	return usersBulkResourceRead(ctx, d, meta)
+}
+
+func usersBulkResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.UserClient
+
+	objectIds := make(map[string]string)
+	for upn, v := range d.Get("object_ids").(map[string]interface{}) {
+		objectIds[upn] = v.(string)
+	}
+
+	users, err := usersBulkGetUsers(ctx, client.Client, objectIds)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving users")
+	}
+
+	result := make(map[string]string, len(users))
+	for upn := range users {
+		result[upn] = objectIds[upn]
+	}
+	for upn := range objectIds {
+		if _, ok := users[upn]; !ok {
+			log.Printf("[DEBUG] User %q was not found - removing from state", upn)
+		}
+	}
+
+	if v := d.Get("csv").(string); v != "" {
+		header, rows, errs := usersBulkParseCsv(v)
+		if len(errs) > 0 {
+			return tf.ErrorDiagPathF(errors.Join(errs...), "csv", "Could not parse users")
+		}
+
+		// Changes made outside of Terraform are surfaced by updating the CSV in state, which is only re-encoded when
+		// users have drifted so that the formatting of the configuration is otherwise preserved
+		drifted := false
+		for _, row := range rows {
+			u, ok := users[row["user_principal_name"].(string)]
+			if !ok {
+				continue
+			}
+			for k, v := range flattenUserProperties(u) {
+				if _, ok = row[k]; ok && row[k] != v {
+					row[k] = v
+					drifted = true
+				}
+			}
+		}
+
+		if drifted {
+			encoded, err := usersBulkEncodeCsv(header, rows)
+			if err != nil {
+				return tf.ErrorDiagPathF(err, "csv", "Could not encode users")
+			}
+			tf.Set(d, "csv", encoded)
+		}
+	} else {
+		existingRows := usersBulkRowsByUpn(usersBulkExpandUsers(d.Get("user").(*pluginsdk.Set)))
+
+		rows := make([]interface{}, 0, len(users))
+		for upn, u := range users {
+			row := flattenUserProperties(u)
+			row["force_password_change"] = false
+			row["password"] = ""
+			if existing, ok := existingRows[strings.ToLower(upn)]; ok {
+				row["force_password_change"] = existing["force_password_change"]
+				row["password"] = existing["password"]
+			}
+			rows = append(rows, row)
+		}
+
+		tf.Set(d, "user", rows)
+	}
+
+	tf.Set(d, "object_ids", result)
+
+	return nil
+}
+
+func usersBulkResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Users.UserClient
+
+	objectIds := make(map[string]string)
+	for upn, v := range d.Get("object_ids").(map[string]interface{}) {
+		objectIds[upn] = v.(string)
+	}
+
+	if _, failures := usersBulkDeleteUsers(ctx, client.Client, objectIds); len(failures) > 0 {
+		return usersBulkDiagnostics("delete", failures)
+	}
+
+	return nil
+}
+
+// usersBulkRows returns the users specified either as a set of `user` blocks, or as CSV-encoded rows, in a consistent
+// format containing all attributes of the `user` block
+func usersBulkRows(users *pluginsdk.Set, csvInput string) ([]map[string]interface{}, error) {
+	if csvInput != "" {
+		_, rows, errs := usersBulkParseCsv(csvInput)
+		if len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
+		return rows, nil
+	}
+
+	return usersBulkExpandUsers(users), nil
+}
+
+func usersBulkExpandUsers(users *pluginsdk.Set) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+	if users == nil {
+		return result
+	}
+	for _, v := range users.List() {
+		if row, ok := v.(map[string]interface{}); ok {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+func usersBulkRowsByUpn(rows []map[string]interface{}) map[string]map[string]interface{} {
+	result := make(map[string]map[string]interface{}, len(rows))
+	for _, row := range rows {
+		result[strings.ToLower(row["user_principal_name"].(string))] = row
+	}
+	return result
+}
+
+func usersBulkCreateRequest(i int, row map[string]interface{}) batch.Request {
+	upn := row["user_principal_name"].(string)
+
+	properties := expandUserProperties(func(k string) interface{} { return row[k] })
+
+	// Default mail nickname to the first part of the UPN (matches the portal)
+	properties.MailNickname = nullable.NoZero(strings.Split(upn, "@")[0])
+
+	properties.PasswordProfile = &stable.PasswordProfile{
+		ForceChangePasswordNextSignIn: nullable.Value(row["force_password_change"].(bool)),
+		Password:                      nullable.NoZero(row["password"].(string)),
+	}
+
+	return batch.Request{
+		Id:     fmt.Sprintf("create-%d", i),
+		Method: http.MethodPost,
+		Url:    "/users",
+		Body:   properties,
+	}
+}
+
+// usersBulkCreateResult returns the object ID of a user created by the specified request
+func usersBulkCreateResult(req batch.Request, responses map[string]batch.Response) (string, error) {
+	resp, ok := responses[req.Id]
+	if !ok {
+		return "", fmt.Errorf("no response received for batch request %q", req.Id)
+	}
+	if !resp.Success() {
+		return "", resp.Error()
+	}
+
+	var u stable.User
+	if err := resp.Unmarshal(&u); err != nil {
+		return "", fmt.Errorf("unmarshaling response: %+v", err)
+	}
+	if pointer.From(u.Id) == "" {
+		return "", errors.New("API returned user with nil object ID")
+	}
+
+	return *u.Id, nil
+}
+
+// usersBulkGetUsers retrieves the specified users, keyed by the same user principal names as `objectIds`. Users that
+// were not found are omitted from the result.
+func usersBulkGetUsers(ctx context.Context, c *msgraph.Client, objectIds map[string]string) (map[string]stable.User, error) {
+	selectFields := strings.Join(append([]string{"id"}, userPropertiesSelect...), ",")
+
+	requests := make([]batch.Request, 0, len(objectIds))
+	for _, objectId := range objectIds {
+		requests = append(requests, batch.Request{
+			Id:     objectId,
+			Method: http.MethodGet,
+			Url:    stable.NewUserID(objectId).ID() + "?$select=" + selectFields,
+		})
+	}
+
+	responses, err := batch.Execute(ctx, c, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]stable.User, len(objectIds))
+	for upn, objectId := range objectIds {
+		resp, ok := responses[objectId]
+		if !ok {
+			return nil, fmt.Errorf("no response received for batch request %q", objectId)
+		}
+		if resp.Status == http.StatusNotFound {
+			continue
+		}
+		if !resp.Success() {
+			return nil, fmt.Errorf("retrieving user %q: %+v", upn, resp.Error())
+		}
+
+		var u stable.User
+		if err = resp.Unmarshal(&u); err != nil {
+			return nil, fmt.Errorf("unmarshaling user %q: %+v", upn, err)
+		}
+		result[upn] = u
+	}
+
+	return result, nil
+}
+
+// usersBulkDeleteUsers deletes the specified users and waits for them to be removed, returning those that were
+// deleted along with any failures
+func usersBulkDeleteUsers(ctx context.Context, c *msgraph.Client, objectIds map[string]string) (map[string]string, []usersBulkFailure) {
+	requests := make([]batch.Request, 0, len(objectIds))
+	for _, objectId := range objectIds {
+		requests = append(requests, batch.Request{
+			Id:     objectId,
+			Method: http.MethodDelete,
+			Url:    stable.NewUserID(objectId).ID(),
+		})
+	}
+
+	responses, err := batch.Execute(ctx, c, requests)
+	if err != nil {
+		failures := make([]usersBulkFailure, 0, len(objectIds))
+		for upn := range objectIds {
+			failures = append(failures, usersBulkFailure{UserPrincipalName: upn, Err: err})
+		}
+		return nil, failures
+	}
+
+	deleted := make(map[string]string, len(objectIds))
+	failures := make([]usersBulkFailure, 0)
+	for upn, objectId := range objectIds {
+		resp, ok := responses[objectId]
+		switch {
+		case !ok:
+			failures = append(failures, usersBulkFailure{UserPrincipalName: upn, Err: fmt.Errorf("no response received for batch request %q", objectId)})
+		case resp.Success() || resp.Status == http.StatusNotFound:
+			deleted[upn] = objectId
+		default:
+			failures = append(failures, usersBulkFailure{UserPrincipalName: upn, Err: resp.Error()})
+		}
+	}
+
+	if err = usersBulkWaitForUsers(ctx, c, deleted, false); err != nil {
+		for upn := range deleted {
+			failures = append(failures, usersBulkFailure{UserPrincipalName: upn, Err: fmt.Errorf("waiting for deletion: %+v", err)})
+		}
+		return nil, failures
+	}
+
+	return deleted, failures
+}
+
+// usersBulkWaitForUsers waits for all the specified users to either exist, or to be deleted
+func usersBulkWaitForUsers(ctx context.Context, c *msgraph.Client, objectIds map[string]string, exist bool) error {
+	if len(objectIds) == 0 {
+		return nil
+	}
+
+	f := func(ctx context.Context) (*bool, error) {
+		requests := make([]batch.Request, 0, len(objectIds))
+		for _, objectId := range objectIds {
+			requests = append(requests, batch.Request{
+				Id:     objectId,
+				Method: http.MethodGet,
+				Url:    stable.NewUserID(objectId).ID() + "?$select=id",
+			})
+		}
+
+		responses, err := batch.Execute(ctx, c, requests)
+		if err != nil {
+			return nil, err
+		}
+
+		found := 0
+		for upn, objectId := range objectIds {
+			resp, ok := responses[objectId]
+			if !ok {
+				return nil, fmt.Errorf("no response received for batch request %q", objectId)
+			}
+			if resp.Success() {
+				found++
+			} else if resp.Status != http.StatusNotFound {
+				return nil, fmt.Errorf("retrieving user %q: %+v", upn, resp.Error())
+			}
+		}
+
+		if exist {
+			return pointer.To(found == len(objectIds)), nil
+		}
+		return pointer.To(found > 0), nil
+	}
+
+	if exist {
+		return consistency.WaitForUpdate(ctx, f)
+	}
+	return consistency.WaitForDeletion(ctx, f)
+}
+
+func validateUsersBulkCsv(i interface{}, path cty.Path) pluginsdk.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return pluginsdk.Diagnostics{{Severity: pluginsdk.DiagError, Summary: "Expected a string value", AttributePath: path}}
+	}
+
+	_, _, errs := usersBulkParseCsv(v)
+
+	diags := make(pluginsdk.Diagnostics, 0, len(errs))
+	for _, err := range errs {
+		diags = append(diags, pluginsdk.Diagnostic{
+			Severity:      pluginsdk.DiagError,
+			Summary:       "Invalid CSV-encoded users",
+			Detail:        err.Error(),
+			AttributePath: path,
+		})
+	}
+
+	return diags
+}
+
+// usersBulkParseCsv parses CSV-encoded users, whose header row contains the names of the attributes in each column.
+// Attributes that are omitted or empty take their default values, and each value is validated in the same way as the
+// corresponding attribute of a `user` block. Errors are returned for each invalid row, identified by its user
+// principal name when known.
+func usersBulkParseCsv(input string) ([]string, []map[string]interface{}, []error) {
+	s := schemaUsersBulkUser()
+
+	reader := csv.NewReader(strings.NewReader(strings.TrimSpace(input)))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, []error{fmt.Errorf("reading header row: %+v", err)}
+	}
+
+	columns := make(map[string]bool, len(header))
+	errs := make([]error, 0)
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		header[i] = column
+		if _, ok := s[column]; !ok {
+			errs = append(errs, fmt.Errorf("unsupported column %q in header row", column))
+		}
+		if columns[column] {
+			errs = append(errs, fmt.Errorf("column %q is specified more than once in header row", column))
+		}
+		columns[column] = true
+	}
+
+	for k, v := range s {
+		if v.Required && !columns[k] {
+			errs = append(errs, fmt.Errorf("required column %q is missing from header row", k))
+		}
+	}
+
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+		return nil, nil, errs
+	}
+
+	rows := make([]map[string]interface{}, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %+v", line, err))
+			if errors.Is(err, csv.ErrFieldCount) {
+				continue
+			}
+			break
+		}
+
+		values := make(map[string]string, len(header))
+		for i, column := range header {
+			values[column] = strings.TrimSpace(record[i])
+		}
+
+		key := fmt.Sprintf("line %d", line)
+		if upn := values["user_principal_name"]; upn != "" {
+			key = fmt.Sprintf("user %q", upn)
+		}
+
+		row, rowErrs := usersBulkParseCsvRow(s, values)
+		for _, err := range rowErrs {
+			errs = append(errs, fmt.Errorf("%s: %v", key, err))
+		}
+		if len(rowErrs) == 0 {
+			rows = append(rows, row)
+		}
+	}
+
+	return header, rows, errs
+}
+
+func usersBulkParseCsvRow(s map[string]*pluginsdk.Schema, values map[string]string) (map[string]interface{}, []error) {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	row := make(map[string]interface{}, len(s))
+	errs := make([]error, 0)
+	for _, k := range keys {
+		v := values[k]
+		if v == "" {
+			if s[k].Required {
+				errs = append(errs, fmt.Errorf("%q is required", k))
+				continue
+			}
+			switch {
+			case s[k].Default != nil:
+				row[k] = s[k].Default
+			case s[k].Type == pluginsdk.TypeBool:
+				row[k] = false
+			default:
+				row[k] = ""
+			}
+			continue
+		}
+
+		if s[k].Type == pluginsdk.TypeBool {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%q must be a boolean value, got %q", k, v))
+				continue
+			}
+			row[k] = b
+			continue
+		}
+
+		if s[k].ValidateFunc != nil {
+			if _, validationErrs := s[k].ValidateFunc(v, k); len(validationErrs) > 0 {
+				errs = append(errs, validationErrs...)
+				continue
+			}
+		}
+		row[k] = v
+	}
+
+	if len(errs) == 0 {
+		if err := validateUserConsentForMinor(row["age_group"].(string), row["consent_provided_for_minor"].(string)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return row, errs
+}
+
+// usersBulkEncodeCsv encodes users in CSV format, using the provided columns
+func usersBulkEncodeCsv(header []string, rows []map[string]interface{}) (string, error) {
+	buf := new(bytes.Buffer)
+	writer := csv.NewWriter(buf)
+
+	if err := writer.Write(header); err != nil {
+		return "", err
+	}
+
+	for _, row := range rows {
+		record := make([]string, 0, len(header))
+		for _, column := range header {
+			switch v := row[column].(type) {
+			case bool:
+				record = append(record, strconv.FormatBool(v))
+			case string:
+				record = append(record, v)
+			default:
+				record = append(record, "")
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return buf.String(), writer.Error()
+}
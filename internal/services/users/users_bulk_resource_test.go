@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/users/stable/user"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type UsersBulkResource struct{}
+
+func TestAccUsersBulk_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_users_bulk", "test")
+	r := UsersBulkResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("user.#").HasValue("2"),
+				check.That(data.ResourceName).Key("object_ids.%").HasValue("2"),
+			),
+		},
+	})
+}
+
+func TestAccUsersBulk_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_users_bulk", "test")
+	r := UsersBulkResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("object_ids.%").HasValue("2"),
+			),
+		},
+		{
+			Config: r.updated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("user.#").HasValue("2"),
+				check.That(data.ResourceName).Key("object_ids.%").HasValue("2"),
+			),
+		},
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("object_ids.%").HasValue("2"),
+			),
+		},
+	})
+}
+
+func TestAccUsersBulk_csv(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_users_bulk", "test")
+	r := UsersBulkResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.csv(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("object_ids.%").HasValue("3"),
+			),
+		},
+	})
+}
+
+func TestAccUsersBulk_csvInvalid(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_users_bulk", "test")
+	r := UsersBulkResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.csvInvalid(data),
+			ExpectError: regexp.MustCompile(`"account_enabled" must be a boolean value`),
+		},
+	})
+}
+
+func TestAccUsersBulk_duplicateUser(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_users_bulk", "test")
+	r := UsersBulkResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.duplicateUser(data),
+			ExpectError: regexp.MustCompile("is specified more than once"),
+		},
+	})
+}
+
+func (r UsersBulkResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Users.UserClient
+
+	for k, objectId := range state.Attributes {
+		if !strings.HasPrefix(k, "object_ids.") || k == "object_ids.%" {
+			continue
+		}
+
+		id := stable.NewUserID(objectId)
+		resp, err := client.GetUser(ctx, id, user.DefaultGetUserOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, fmt.Errorf("failed to retrieve %s: %+v", id, err)
+		}
+	}
+
+	return pointer.To(true), nil
+}
+
+func (UsersBulkResource) template() string {
+	return `
+provider "azuread" {}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+`
+}
+
+func (r UsersBulkResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_users_bulk" "test" {
+  user {
+    user_principal_name = "acctestUser.%[2]d.A@${data.azuread_domains.test.domains.0.domain_name}"
+    display_name        = "acctestUser-%[2]d-A"
+    password            = "%[3]s"
+  }
+
+  user {
+    user_principal_name = "acctestUser.%[2]d.B@${data.azuread_domains.test.domains.0.domain_name}"
+    display_name        = "acctestUser-%[2]d-B"
+    password            = "%[3]s"
+    department          = "acctestUser-%[2]d-Dept"
+  }
+}
+`, r.template(), data.RandomInteger, data.RandomPassword)
+}
+
+func (r UsersBulkResource) updated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_users_bulk" "test" {
+  user {
+    user_principal_name = "acctestUser.%[2]d.B@${data.azuread_domains.test.domains.0.domain_name}"
+    display_name        = "acctestUser-%[2]d-B-Updated"
+    password            = "%[3]s"
+    account_enabled     = false
+    job_title           = "acctestUser-%[2]d-Job"
+    usage_location      = "NO"
+  }
+
+  user {
+    user_principal_name = "acctestUser.%[2]d.C@${data.azuread_domains.test.domains.0.domain_name}"
+    display_name        = "acctestUser-%[2]d-C"
+    password            = "%[3]s"
+  }
+}
+`, r.template(), data.RandomInteger, data.RandomPassword)
+}
+
+func (r UsersBulkResource) csv(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+locals {
+  domain = data.azuread_domains.test.domains.0.domain_name
+}
+
+resource "azuread_users_bulk" "test" {
+  csv = <<EOT
+user_principal_name,display_name,password,account_enabled,department
+acctestUser.%[2]d.A@${local.domain},acctestUser-%[2]d-A,%[3]s,true,
+acctestUser.%[2]d.B@${local.domain},acctestUser-%[2]d-B,%[3]s,false,acctestUser-%[2]d-Dept
+acctestUser.%[2]d.C@${local.domain},acctestUser-%[2]d-C,%[3]s,,
+EOT
+}
+`, r.template(), data.RandomInteger, data.RandomPassword)
+}
+
+func (r UsersBulkResource) csvInvalid(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_users_bulk" "test" {
+  csv = <<EOT
+user_principal_name,display_name,password,account_enabled
+acctestUser.%[1]d.A@example.com,acctestUser-%[1]d-A,%[2]s,maybe
+EOT
+}
+`, data.RandomInteger, data.RandomPassword)
+}
+
+func (r UsersBulkResource) duplicateUser(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_users_bulk" "test" {
+  user {
+    user_principal_name = "acctestUser.%[1]d.A@example.com"
+    display_name        = "acctestUser-%[1]d-A"
+    password            = "%[2]s"
+  }
+
+  user {
+    user_principal_name = "ACCTESTUSER.%[1]d.A@example.com"
+    display_name        = "acctestUser-%[1]d-A-Duplicate"
+    password            = "%[2]s"
+  }
+}
+`, data.RandomInteger, data.RandomPassword)
+}